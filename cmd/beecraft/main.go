@@ -8,6 +8,7 @@ import (
 	"syscall"
 	"time"
 
+	email_sender "platform/internal/notification/services/emailSender"
 	"platform/pkg/services/database"
 	"platform/pkg/services/eventbus"
 	"platform/pkg/services/logging"
@@ -58,7 +59,9 @@ func main() {
 	app.Use(recover.New())
 	app.Use(pprof.New()) // Enable pprof middleware for performance profiling and debugging
 
-	SetupRouter(app, dbPool, bus)
+	// Background workers are stopped before the server shuts down
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	SetupRouter(workerCtx, app, dbPool, bus)
 
 	go func() {
 		zap.L().Info("Server is running on port 3000")
@@ -67,16 +70,17 @@ func main() {
 		}
 	}()
 
-	gracefulShutdown(app)
+	gracefulShutdown(app, stopWorkers)
 }
 
-func gracefulShutdown(app *fiber.App) {
+func gracefulShutdown(app *fiber.App, stopWorkers context.CancelFunc) {
 	// Create a buffered channel
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
 	<-quit // Block until signal is received
 	zap.L().Info("Shutting down server")
+	stopWorkers()
 
 	// Create a context with timeout for graceful shutdown (5 seconds)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -87,6 +91,9 @@ func gracefulShutdown(app *fiber.App) {
 		zap.L().Error("Server forced to shutdown", zap.Error(err))
 	}
 
+	// Close the pooled smtp connections
+	email_sender.DefaultPool.Close()
+
 	zap.L().Info("Server exited gracefully")
 }
 
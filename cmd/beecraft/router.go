@@ -1,6 +1,11 @@
 package main
 
 import (
+	"context"
+	"os"
+	"strings"
+
+	"platform/internal/shared"
 	baseHandler "platform/internal/shared/handlers"
 	"platform/internal/shared/middlewares"
 	"platform/pkg/services/cache"
@@ -8,35 +13,137 @@ import (
 	mediator "platform/pkg/services/mediator"
 
 	iamHandlers "platform/internal/iam/handlers"
+	phone_verification "platform/internal/iam/services/phoneVerification"
+	"platform/internal/notification/domain"
 	notificationHandlers "platform/internal/notification/handlers"
 	"platform/internal/notification/mediatr/commands"
 	event_notification "platform/internal/notification/mediatr/notifications"
 	"platform/internal/notification/mediatr/queries"
+	bounce_handler "platform/internal/notification/services/bounceHandler"
+	campaign_scheduler "platform/internal/notification/services/campaignScheduler"
+	delivery_tracker "platform/internal/notification/services/deliveryTracker"
+	"platform/internal/notification/services/dispatcher"
+	email_sandbox "platform/internal/notification/services/emailSandbox"
+	email_sender "platform/internal/notification/services/emailSender"
 	"platform/internal/notification/services/encryption"
+	inbound_mail "platform/internal/notification/services/inboundMail"
+	oauth2_state "platform/internal/notification/services/oauth2State"
+	sms_sender "platform/internal/notification/services/smsSender"
+	subscription_token "platform/internal/notification/services/subscriptionToken"
+	token_manager "platform/internal/notification/services/tokenManager"
 
 	iamRepositories "platform/internal/iam/repositories"
 	notificationRepositories "platform/internal/notification/repositories"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
 )
 
 // SetupRouter configures the Fiber app with Zap logging, recovery, routes, and handlers.
-func SetupRouter(app *fiber.App, dbPool *pgxpool.Pool, bus event_bus.EventBus) {
+// Background workers run until ctx is cancelled.
+func SetupRouter(ctx context.Context, app *fiber.App, dbPool *pgxpool.Pool, bus event_bus.EventBus) {
 	// Services
 	cacheService := cache.NewMemcacheManager("localhost:11211")
 	encryptionService, _ := encryption.NewAESEncryptionService([]byte("1234567890123456"))
+	phoneVerificationService := phone_verification.NewPhoneVerificationService(cacheService)
+	subscriptionTokenSigner, err := subscription_token.NewSigner([]byte(os.Getenv("SUBSCRIPTION_TOKEN_SECRET")), os.Getenv("PUBLIC_BASE_URL"))
+	if err != nil {
+		zap.L().Fatal("Failed to create the subscription token signer", zap.Error(err))
+	}
+	oauth2StateStore, err := oauth2_state.NewStore([]byte(os.Getenv("OAUTH2_STATE_SECRET")), cacheService)
+	if err != nil {
+		zap.L().Fatal("Failed to create the oauth2 state store", zap.Error(err))
+	}
+	oauth2RedirectURL := strings.TrimSuffix(os.Getenv("PUBLIC_BASE_URL"), "/") + "/v1/notification/email-accounts/oauth2-callback"
 
 	// Repositories
 	userRepository := iamRepositories.NewUserRepository(dbPool)
 	roleRepository := iamRepositories.NewRoleRepository(dbPool)
 	emailAccountRepository := notificationRepositories.NewPgEmailAccountRepository(dbPool, cacheService)
+	smsAccountRepository := notificationRepositories.NewPgSmsAccountRepository(dbPool, cacheService)
+	notificationRepository := notificationRepositories.NewPgNotificationRepository(dbPool)
+	recipientPreferenceRepository := notificationRepositories.NewPgRecipientPreferenceRepository(dbPool, cacheService)
+	subscriptionRepository := notificationRepositories.NewPgSubscriptionRepository(dbPool)
+	campaignRepository := notificationRepositories.NewPgCampaignRepository(dbPool)
+	queuedEmailRepository := notificationRepositories.NewPgQueuedEmailRepository(dbPool)
+	suppressionRepository := notificationRepositories.NewPgSuppressionRepository(dbPool)
+	inboundMailboxRepository := notificationRepositories.NewPgInboundMailboxRepository(dbPool)
+	emailDeliveryRepository := notificationRepositories.NewPgEmailDeliveryRepository(dbPool)
+	bounceHandler := bounce_handler.NewHandler(suppressionRepository)
+
+	// The tracking pixel and links of the sent messages point to the public endpoints below
+	deliveryTracker, err := delivery_tracker.NewTracker([]byte(os.Getenv("DELIVERY_TRACKING_SECRET")), os.Getenv("PUBLIC_BASE_URL"), emailDeliveryRepository)
+	if err != nil {
+		zap.L().Fatal("Failed to create the delivery tracker", zap.Error(err))
+	}
+	deliveryWebhooks := delivery_tracker.NewWebhooks(os.Getenv("MAILGUN_WEBHOOK_SIGNING_KEY"))
+
+	// Local and staging environments capture or redirect the messages instead of delivering them, the
+	// captured messages are kept in memory when no table should be used for them
+	emailSandboxRepository := notificationRepositories.NewPgEmailSandboxRepository(dbPool)
+	capturedEmailRepository := notificationRepositories.NewPgCapturedEmailRepository(dbPool)
+	if os.Getenv("EMAIL_CAPTURE_STORE") == "memory" {
+		capturedEmailRepository = notificationRepositories.NewMemoryCapturedEmailRepository(1000)
+	}
+	var sandboxAllowList []string
+	if list := os.Getenv("EMAIL_SANDBOX_ALLOW_LIST"); list != "" {
+		sandboxAllowList = strings.Split(list, ",")
+	}
+	emailSandbox := email_sandbox.NewService(domain.SandboxMode(os.Getenv("EMAIL_SANDBOX_MODE")), sandboxAllowList, os.Getenv("EMAIL_SANDBOX_REDIRECT_TO"), emailSandboxRepository, capturedEmailRepository)
+	email_sender.DefaultPool.SetSandbox(emailSandbox)
+
+	// Fake sms accounts keep their messages in memory instead of sending them, they are refused unless enabled
+	if os.Getenv("SMS_FAKE_PROVIDER") == "enabled" {
+		sms_sender.EnableFakeProvider()
+	}
+
+	// Notification channels
+	notificationDispatcher := dispatcher.NewDispatcher(map[domain.Channel]dispatcher.ChannelSender{
+		domain.EmailChannel:   dispatcher.NewEmailChannel(encryptionService, emailAccountRepository, bounceHandler, deliveryTracker),
+		domain.SmsChannel:     dispatcher.NewSmsChannel(encryptionService, smsAccountRepository),
+		domain.WebhookChannel: dispatcher.NewWebhookChannel(),
+	})
 
 	// Mediator Queries
 	getAllEmailAccountQueryHandler := queries.NewGetAllEmailAccountQueryHandler(emailAccountRepository)
 	getEmailAccountByEmailQueryHandler := queries.NewGetEmailAccountByEmailQueryHandler(emailAccountRepository)
 	mediator.RegisterRequestHandler(getAllEmailAccountQueryHandler)
 	mediator.RegisterRequestHandler(getEmailAccountByEmailQueryHandler)
+	getAllSmsAccountQueryHandler := queries.NewGetAllSmsAccountQueryHandler(smsAccountRepository)
+	getAllFakeSmsQueryHandler := queries.NewGetAllFakeSmsQueryHandler()
+	mediator.RegisterRequestHandler(getAllSmsAccountQueryHandler)
+	mediator.RegisterRequestHandler(getAllFakeSmsQueryHandler)
+	getNotificationQueryHandler := queries.NewGetNotificationQueryHandler(notificationRepository)
+	getAllSubscriptionQueryHandler := queries.NewGetAllSubscriptionQueryHandler(subscriptionRepository)
+	getUnsubscribeTokenQueryHandler := queries.NewGetUnsubscribeTokenQueryHandler(subscriptionTokenSigner)
+	mediator.RegisterRequestHandler(getNotificationQueryHandler)
+	mediator.RegisterRequestHandler(getAllSubscriptionQueryHandler)
+	mediator.RegisterRequestHandler(getUnsubscribeTokenQueryHandler)
+	getAllCampaignQueryHandler := queries.NewGetAllCampaignQueryHandler(campaignRepository)
+	getCampaignQueryHandler := queries.NewGetCampaignQueryHandler(campaignRepository)
+	mediator.RegisterRequestHandler(getAllCampaignQueryHandler)
+	mediator.RegisterRequestHandler(getCampaignQueryHandler)
+	getAllSuppressionQueryHandler := queries.NewGetAllSuppressionQueryHandler(suppressionRepository)
+	mediator.RegisterRequestHandler(getAllSuppressionQueryHandler)
+	getAllEmailProviderQueryHandler := queries.NewGetAllEmailProviderQueryHandler()
+	mediator.RegisterRequestHandler(getAllEmailProviderQueryHandler)
+	getDkimRecordQueryHandler := queries.NewGetDkimRecordQueryHandler(encryptionService, emailAccountRepository)
+	mediator.RegisterRequestHandler(getDkimRecordQueryHandler)
+	getInboundMailboxQueryHandler := queries.NewGetInboundMailboxQueryHandler(inboundMailboxRepository, emailAccountRepository)
+	mediator.RegisterRequestHandler(getInboundMailboxQueryHandler)
+	getAllEmailDeliveryQueryHandler := queries.NewGetAllEmailDeliveryQueryHandler(emailDeliveryRepository)
+	getEmailDeliveryQueryHandler := queries.NewGetEmailDeliveryQueryHandler(emailDeliveryRepository)
+	mediator.RegisterRequestHandler(getAllEmailDeliveryQueryHandler)
+	mediator.RegisterRequestHandler(getEmailDeliveryQueryHandler)
+	getEmailSandboxQueryHandler := queries.NewGetEmailSandboxQueryHandler(emailSandbox, emailSandboxRepository)
+	getAllCapturedEmailQueryHandler := queries.NewGetAllCapturedEmailQueryHandler(capturedEmailRepository)
+	getCapturedEmailQueryHandler := queries.NewGetCapturedEmailQueryHandler(capturedEmailRepository)
+	mediator.RegisterRequestHandler(getEmailSandboxQueryHandler)
+	mediator.RegisterRequestHandler(getAllCapturedEmailQueryHandler)
+	mediator.RegisterRequestHandler(getCapturedEmailQueryHandler)
+	previewTemplateQueryHandler := queries.NewPreviewTemplateQueryHandler(emailAccountRepository)
+	mediator.RegisterRequestHandler(previewTemplateQueryHandler)
 
 	// Mediator Commands
 	createEmailAccountCommandHandler := commands.NewCreateEmailAccountCommandHandler(encryptionService, emailAccountRepository)
@@ -47,46 +154,292 @@ func SetupRouter(app *fiber.App, dbPool *pgxpool.Pool, bus event_bus.EventBus) {
 	mediator.RegisterRequestHandler(deleteEmailAccountCommandHandler)
 	mediator.RegisterRequestHandler(sendTestEmailCommandHandler)
 	mediator.RegisterRequestHandler(updateEmailAccountCommandHandler)
+	configureDkimCommandHandler := commands.NewConfigureDkimCommandHandler(encryptionService, emailAccountRepository)
+	deleteDkimCommandHandler := commands.NewDeleteDkimCommandHandler(emailAccountRepository)
+	mediator.RegisterRequestHandler(configureDkimCommandHandler)
+	mediator.RegisterRequestHandler(deleteDkimCommandHandler)
+	configureInboundMailboxCommandHandler := commands.NewConfigureInboundMailboxCommandHandler(inboundMailboxRepository, emailAccountRepository)
+	deleteInboundMailboxCommandHandler := commands.NewDeleteInboundMailboxCommandHandler(inboundMailboxRepository, emailAccountRepository)
+	mediator.RegisterRequestHandler(configureInboundMailboxCommandHandler)
+	mediator.RegisterRequestHandler(deleteInboundMailboxCommandHandler)
+	configureEmailTrackingCommandHandler := commands.NewConfigureEmailTrackingCommandHandler(emailAccountRepository)
+	mediator.RegisterRequestHandler(configureEmailTrackingCommandHandler)
+	authorizeEmailAccountCommandHandler := commands.NewAuthorizeEmailAccountCommandHandler(oauth2StateStore, emailAccountRepository, oauth2RedirectURL)
+	completeOAuth2CommandHandler := commands.NewCompleteOAuth2CommandHandler(oauth2StateStore, emailAccountRepository, oauth2RedirectURL)
+	mediator.RegisterRequestHandler(authorizeEmailAccountCommandHandler)
+	mediator.RegisterRequestHandler(completeOAuth2CommandHandler)
+	createSmsAccountCommandHandler := commands.NewCreateSmsAccountCommandHandler(encryptionService, smsAccountRepository)
+	deleteSmsAccountCommandHandler := commands.NewDeleteSmsAccountCommandHandler(smsAccountRepository)
+	sendSmsCommandHandler := commands.NewSendSmsCommandHandler(encryptionService, smsAccountRepository)
+	mediator.RegisterRequestHandler(createSmsAccountCommandHandler)
+	mediator.RegisterRequestHandler(deleteSmsAccountCommandHandler)
+	mediator.RegisterRequestHandler(sendSmsCommandHandler)
+	sendNotificationCommandHandler := commands.NewSendNotificationCommandHandler(notificationDispatcher, subscriptionTokenSigner, notificationRepository, recipientPreferenceRepository, subscriptionRepository, suppressionRepository)
+	saveRecipientPreferenceCommandHandler := commands.NewSaveRecipientPreferenceCommandHandler(recipientPreferenceRepository)
+	mediator.RegisterRequestHandler(sendNotificationCommandHandler)
+	mediator.RegisterRequestHandler(saveRecipientPreferenceCommandHandler)
+	subscribeCommandHandler := commands.NewSubscribeCommandHandler(subscriptionTokenSigner, subscriptionRepository)
+	confirmSubscriptionCommandHandler := commands.NewConfirmSubscriptionCommandHandler(subscriptionTokenSigner, subscriptionRepository)
+	unsubscribeCommandHandler := commands.NewUnsubscribeCommandHandler(subscriptionTokenSigner, subscriptionRepository)
+	mediator.RegisterRequestHandler(subscribeCommandHandler)
+	mediator.RegisterRequestHandler(confirmSubscriptionCommandHandler)
+	mediator.RegisterRequestHandler(unsubscribeCommandHandler)
+	createCampaignCommandHandler := commands.NewCreateCampaignCommandHandler(campaignRepository, emailAccountRepository)
+	changeCampaignStatusCommandHandler := commands.NewChangeCampaignStatusCommandHandler(campaignRepository, queuedEmailRepository)
+	mediator.RegisterRequestHandler(createCampaignCommandHandler)
+	mediator.RegisterRequestHandler(changeCampaignStatusCommandHandler)
+	processBounceCommandHandler := commands.NewProcessBounceCommandHandler(bounceHandler, deliveryTracker)
+	addSuppressionCommandHandler := commands.NewAddSuppressionCommandHandler(suppressionRepository)
+	deleteSuppressionCommandHandler := commands.NewDeleteSuppressionCommandHandler(suppressionRepository)
+	mediator.RegisterRequestHandler(processBounceCommandHandler)
+	mediator.RegisterRequestHandler(addSuppressionCommandHandler)
+	mediator.RegisterRequestHandler(deleteSuppressionCommandHandler)
+	trackEmailOpenCommandHandler := commands.NewTrackEmailOpenCommandHandler(deliveryTracker)
+	trackEmailClickCommandHandler := commands.NewTrackEmailClickCommandHandler(deliveryTracker)
+	ingestDeliveryWebhookCommandHandler := commands.NewIngestDeliveryWebhookCommandHandler(os.Getenv("DELIVERY_WEBHOOK_SECRET"), deliveryWebhooks, deliveryTracker)
+	mediator.RegisterRequestHandler(trackEmailOpenCommandHandler)
+	mediator.RegisterRequestHandler(trackEmailClickCommandHandler)
+	mediator.RegisterRequestHandler(ingestDeliveryWebhookCommandHandler)
+	configureEmailSandboxCommandHandler := commands.NewConfigureEmailSandboxCommandHandler(emailSandbox, emailSandboxRepository)
+	deleteEmailSandboxCommandHandler := commands.NewDeleteEmailSandboxCommandHandler(emailSandboxRepository)
+	deleteCapturedEmailCommandHandler := commands.NewDeleteCapturedEmailCommandHandler(capturedEmailRepository)
+	mediator.RegisterRequestHandler(configureEmailSandboxCommandHandler)
+	mediator.RegisterRequestHandler(deleteEmailSandboxCommandHandler)
+	mediator.RegisterRequestHandler(deleteCapturedEmailCommandHandler)
+
+	// Background workers
+	campaignScheduler := campaign_scheduler.NewScheduler(encryptionService, subscriptionTokenSigner, bounceHandler, deliveryTracker, campaignRepository, queuedEmailRepository, subscriptionRepository, suppressionRepository, emailAccountRepository)
+	go campaignScheduler.Run(ctx)
+
+	// OAuth2 tokens are refreshed before they expire and saved, so that rotated refresh tokens are kept
+	tokenManager := token_manager.NewManager(emailAccountRepository)
+	email_sender.DefaultPool.SetTokenSource(tokenManager)
+	go tokenManager.Run(ctx)
+
+	// Received messages are published on the event bus, the IMAP mailboxes log in with the tokens above
+	inboundProcessor := inbound_mail.NewProcessor(bus, bounceHandler, deliveryTracker)
+	imapReceiver := inbound_mail.NewIMAPReceiver(encryptionService, tokenManager, inboundProcessor, inboundMailboxRepository, emailAccountRepository)
+	go imapReceiver.Run(ctx)
+	if addr := os.Getenv("INBOUND_SMTP_ADDR"); addr != "" {
+		domains := strings.Split(os.Getenv("INBOUND_SMTP_DOMAINS"), ",")
+		smtpReceiver := inbound_mail.NewSMTPReceiver(addr, os.Getenv("INBOUND_SMTP_HOSTNAME"), domains, emailAccountRepository, inboundProcessor)
+		go smtpReceiver.Run(ctx)
+	}
 
 	// Notification Handlers
 	emailAccountCreatedHandler := event_notification.EmailAccountCreatedEventHandler{}
 	mediator.RegisterNotificationHandler(&emailAccountCreatedHandler)
+	emailAccountReconsentRequiredHandler := event_notification.EmailAccountReconsentRequiredEventHandler{}
+	mediator.RegisterNotificationHandler(&emailAccountReconsentRequiredHandler)
 
 	// Health-check
 	app.Get("/health", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
 
+	// Route policies
+	routePolicies, err := middlewares.LoadRoutePolicies()
+	if err != nil {
+		zap.L().Fatal("Failed to load route policies", zap.Error(err))
+	}
+	routePolicyEnforcer, err := middlewares.RoutePolicyEnforcer(routePolicies)
+	if err != nil {
+		zap.L().Fatal("Invalid route policies", zap.Error(err))
+	}
+
+	// The links of the responses are resolved from the named routes and left out when the caller's roles
+	// cannot follow them. The relations of the API are documented under API_DOCS_URL when it is set.
+	routeAuthorizer, err := middlewares.RouteAuthorizer(routePolicies)
+	if err != nil {
+		zap.L().Fatal("Invalid route policies", zap.Error(err))
+	}
+	baseHandler.UseRoutes(app, routeAuthorizer)
+	if docsURL := strings.TrimSuffix(os.Getenv("API_DOCS_URL"), "/"); docsURL != "" {
+		shared.RegisterCurie("iam", docsURL+"/rels/{rel}")
+		shared.RegisterCurie("nf", docsURL+"/rels/{rel}")
+	}
+
 	// API Versioning
-	version1 := app.Group("/v1")
+	version1 := app.Group("/v1", routePolicyEnforcer)
 
 	// IAM Service Routes
-	iamGroup := version1.Group("/iam")
+	iamGroup := version1.Group("/iam").Name("iam.")
 	{
 		registerHandler := iamHandlers.NewRegisterHandler(bus, &userRepository, &roleRepository)
-		iamGroup.Post("/register", baseHandler.Serve(registerHandler))
+		iamGroup.Post("/register", middlewares.ProjectIDInjector(), baseHandler.Serve(registerHandler)).Name("register")
+
+		// The code is sent with the sms account of the project, the phone is verified on the caller's account
+		verifyPhoneHandler := iamHandlers.NewVerifyPhoneHandler(&userRepository, phoneVerificationService)
+		iamGroup.Post("/verify-phone", middlewares.RequireAuthentication(), middlewares.RequireProjectID(), baseHandler.Serve(verifyPhoneHandler)).Name("verify-phone")
+
+		confirmPhoneHandler := iamHandlers.NewConfirmPhoneHandler(&userRepository, phoneVerificationService)
+		iamGroup.Post("/verify-phone/confirm", middlewares.RequireAuthentication(), baseHandler.Serve(confirmPhoneHandler)).Name("verify-phone.confirm")
 	}
 
 	// Notification Service Routes
-	notificationGroup := version1.Group("/notification", middlewares.ProjectIDInjector())
+	notificationGroup := version1.Group("/notification", middlewares.ProjectIDInjector()).Name("notification.")
 	{
 		testEmailHandler := notificationHandlers.SendTestEmailHandler{}
-		notificationGroup.Post("/email-accounts/:from", baseHandler.Serve(&testEmailHandler))
+		notificationGroup.Post("/email-accounts/:from", baseHandler.Serve(&testEmailHandler)).Name("email-accounts.send-test")
 
 		createHandler := notificationHandlers.CreateEmailAccountHandler{}
-		notificationGroup.Post("/email-accounts", baseHandler.Serve(&createHandler))
+		notificationGroup.Post("/email-accounts", baseHandler.Serve(&createHandler)).Name("email-accounts.create")
 
 		deleteHandler := notificationHandlers.DeleteEmailAccountHandler{}
-		notificationGroup.Delete("/email-accounts/:email", baseHandler.Serve(&deleteHandler))
+		notificationGroup.Delete("/email-accounts/:email", baseHandler.Serve(&deleteHandler)).Name("email-accounts.delete")
 
 		getAllHandler := notificationHandlers.GetAllEmailAccountHandler{}
-		notificationGroup.Get("/email-accounts", baseHandler.Serve(&getAllHandler))
+		notificationGroup.Get("/email-accounts", baseHandler.Serve(&getAllHandler)).Name("email-accounts.list")
+
+		getAllEmailProviderHandler := notificationHandlers.GetAllEmailProviderHandler{}
+		notificationGroup.Get("/email-providers", baseHandler.Serve(&getAllEmailProviderHandler)).Name("email-providers.list")
 
 		oauth2CallbackHandler := notificationHandlers.OAuth2CallbackHandler{}
-		notificationGroup.Get("/email-accounts/oauth2-callback", baseHandler.Serve(&oauth2CallbackHandler))
+		notificationGroup.Get("/email-accounts/oauth2-callback", baseHandler.Serve(&oauth2CallbackHandler)).Name("email-accounts.oauth2-callback")
+
+		authorizeHandler := notificationHandlers.AuthorizeEmailAccountHandler{}
+		notificationGroup.Post("/email-accounts/:email/oauth2/authorize", baseHandler.Serve(&authorizeHandler)).Name("email-accounts.authorize")
 
 		getHandler := notificationHandlers.GetEmailAccountHandler{}
-		notificationGroup.Get("/email-accounts/:email", baseHandler.Serve(&getHandler))
+		notificationGroup.Get("/email-accounts/:email", baseHandler.Serve(&getHandler)).Name("email-accounts.get")
 
 		updateHandler := notificationHandlers.UpdateEmailAccountHandler{}
-		notificationGroup.Put("/email-accounts/:email", baseHandler.Serve(&updateHandler))
+		notificationGroup.Put("/email-accounts/:email", baseHandler.Serve(&updateHandler)).Name("email-accounts.update")
+
+		getDkimRecordHandler := notificationHandlers.GetDkimRecordHandler{}
+		notificationGroup.Get("/email-accounts/:email/dkim", baseHandler.Serve(&getDkimRecordHandler)).Name("email-accounts.dkim.get")
+
+		configureDkimHandler := notificationHandlers.ConfigureDkimHandler{}
+		notificationGroup.Put("/email-accounts/:email/dkim", baseHandler.Serve(&configureDkimHandler)).Name("email-accounts.dkim.configure")
+
+		deleteDkimHandler := notificationHandlers.DeleteDkimHandler{}
+		notificationGroup.Delete("/email-accounts/:email/dkim", baseHandler.Serve(&deleteDkimHandler)).Name("email-accounts.dkim.delete")
+
+		getInboundMailboxHandler := notificationHandlers.GetInboundMailboxHandler{}
+		notificationGroup.Get("/email-accounts/:email/inbound", baseHandler.Serve(&getInboundMailboxHandler)).Name("email-accounts.inbound.get")
+
+		configureInboundMailboxHandler := notificationHandlers.ConfigureInboundMailboxHandler{}
+		notificationGroup.Put("/email-accounts/:email/inbound", baseHandler.Serve(&configureInboundMailboxHandler)).Name("email-accounts.inbound.configure")
+
+		deleteInboundMailboxHandler := notificationHandlers.DeleteInboundMailboxHandler{}
+		notificationGroup.Delete("/email-accounts/:email/inbound", baseHandler.Serve(&deleteInboundMailboxHandler)).Name("email-accounts.inbound.delete")
+
+		configureEmailTrackingHandler := notificationHandlers.ConfigureEmailTrackingHandler{}
+		notificationGroup.Put("/email-accounts/:email/tracking", baseHandler.Serve(&configureEmailTrackingHandler)).Name("email-accounts.tracking.configure")
+
+		createSmsAccountHandler := notificationHandlers.CreateSmsAccountHandler{}
+		notificationGroup.Post("/sms-accounts", baseHandler.Serve(&createSmsAccountHandler)).Name("sms-accounts.create")
+
+		getAllSmsAccountHandler := notificationHandlers.GetAllSmsAccountHandler{}
+		notificationGroup.Get("/sms-accounts", baseHandler.Serve(&getAllSmsAccountHandler)).Name("sms-accounts.list")
+
+		deleteSmsAccountHandler := notificationHandlers.DeleteSmsAccountHandler{}
+		notificationGroup.Delete("/sms-accounts/:id", baseHandler.Serve(&deleteSmsAccountHandler)).Name("sms-accounts.delete")
+
+		if sms_sender.FakeProviderEnabled() {
+			getAllFakeSmsHandler := notificationHandlers.GetAllFakeSmsHandler{}
+			notificationGroup.Get("/fake-sms", baseHandler.Serve(&getAllFakeSmsHandler)).Name("fake-sms.list")
+		}
+
+		sendNotificationHandler := notificationHandlers.SendNotificationHandler{}
+		notificationGroup.Post("/notifications", baseHandler.Serve(&sendNotificationHandler)).Name("notifications.send")
+
+		getNotificationHandler := notificationHandlers.GetNotificationHandler{}
+		notificationGroup.Get("/notifications/:id", baseHandler.Serve(&getNotificationHandler)).Name("notifications.get")
+
+		saveRecipientPreferenceHandler := notificationHandlers.SaveRecipientPreferenceHandler{}
+		notificationGroup.Put("/preferences/:email", baseHandler.Serve(&saveRecipientPreferenceHandler)).Name("preferences.save")
+
+		subscribeHandler := notificationHandlers.SubscribeHandler{}
+		notificationGroup.Post("/subscriptions", baseHandler.Serve(&subscribeHandler)).Name("subscriptions.create")
+
+		getAllSubscriptionHandler := notificationHandlers.GetAllSubscriptionHandler{}
+		notificationGroup.Get("/subscriptions", baseHandler.Serve(&getAllSubscriptionHandler)).Name("subscriptions.list")
+
+		// Confirmation and one-click unsubscribe links are opened from a mailbox, the project is in their token
+		confirmSubscriptionHandler := notificationHandlers.ConfirmSubscriptionHandler{}
+		notificationGroup.Get("/subscriptions/confirm", baseHandler.Serve(&confirmSubscriptionHandler)).Name("subscriptions.confirm")
+
+		// Opening the link only asks for confirmation, mail scanners follow links (RFC 8058)
+		unsubscribeConfirmationHandler := notificationHandlers.UnsubscribeConfirmationHandler{}
+		notificationGroup.Get("/subscriptions/unsubscribe", baseHandler.Serve(&unsubscribeConfirmationHandler)).Name("subscriptions.unsubscribe-confirmation")
+
+		oneClickUnsubscribeHandler := notificationHandlers.OneClickUnsubscribeHandler{}
+		notificationGroup.Post("/subscriptions/unsubscribe", baseHandler.Serve(&oneClickUnsubscribeHandler)).Name("subscriptions.one-click-unsubscribe")
+
+		unsubscribeHandler := notificationHandlers.UnsubscribeHandler{}
+		notificationGroup.Delete("/subscriptions/:email", baseHandler.Serve(&unsubscribeHandler)).Name("subscriptions.delete")
+
+		createCampaignHandler := notificationHandlers.CreateCampaignHandler{}
+		notificationGroup.Post("/campaigns", baseHandler.Serve(&createCampaignHandler)).Name("campaigns.create")
+
+		getAllCampaignHandler := notificationHandlers.GetAllCampaignHandler{}
+		notificationGroup.Get("/campaigns", baseHandler.Serve(&getAllCampaignHandler)).Name("campaigns.list")
+
+		getCampaignHandler := notificationHandlers.GetCampaignHandler{}
+		notificationGroup.Get("/campaigns/:id", baseHandler.Serve(&getCampaignHandler)).Name("campaigns.get")
+
+		pauseCampaignHandler := notificationHandlers.ChangeCampaignStatusHandler{Action: commands.PauseCampaign}
+		notificationGroup.Post("/campaigns/:id/pause", baseHandler.Serve(&pauseCampaignHandler)).Name("campaigns.pause")
+
+		resumeCampaignHandler := notificationHandlers.ChangeCampaignStatusHandler{Action: commands.ResumeCampaign}
+		notificationGroup.Post("/campaigns/:id/resume", baseHandler.Serve(&resumeCampaignHandler)).Name("campaigns.resume")
+
+		cancelCampaignHandler := notificationHandlers.ChangeCampaignStatusHandler{Action: commands.CancelCampaign}
+		notificationGroup.Post("/campaigns/:id/cancel", baseHandler.Serve(&cancelCampaignHandler)).Name("campaigns.cancel")
+
+		processBounceHandler := notificationHandlers.ProcessBounceHandler{}
+		notificationGroup.Post("/bounces", baseHandler.Serve(&processBounceHandler)).Name("bounces.process")
+
+		getAllSuppressionHandler := notificationHandlers.GetAllSuppressionHandler{}
+		notificationGroup.Get("/suppressions", baseHandler.Serve(&getAllSuppressionHandler)).Name("suppressions.list")
+
+		addSuppressionHandler := notificationHandlers.AddSuppressionHandler{}
+		notificationGroup.Post("/suppressions", baseHandler.Serve(&addSuppressionHandler)).Name("suppressions.create")
+
+		deleteSuppressionHandler := notificationHandlers.DeleteSuppressionHandler{}
+		notificationGroup.Delete("/suppressions/:email", baseHandler.Serve(&deleteSuppressionHandler)).Name("suppressions.delete")
+
+		getAllEmailDeliveryHandler := notificationHandlers.GetAllEmailDeliveryHandler{}
+		notificationGroup.Get("/deliveries", baseHandler.Serve(&getAllEmailDeliveryHandler)).Name("deliveries.list")
+
+		getEmailDeliveryHandler := notificationHandlers.GetEmailDeliveryHandler{}
+		notificationGroup.Get("/deliveries/:id", baseHandler.Serve(&getEmailDeliveryHandler)).Name("deliveries.get")
+
+		previewTemplateHandler := notificationHandlers.PreviewTemplateHandler{}
+		notificationGroup.Post("/templates/:name/preview", baseHandler.Serve(&previewTemplateHandler)).Name("templates.preview")
+
+		getEmailSandboxHandler := notificationHandlers.GetEmailSandboxHandler{}
+		notificationGroup.Get("/email-sandbox", baseHandler.Serve(&getEmailSandboxHandler)).Name("email-sandbox.get")
+
+		configureEmailSandboxHandler := notificationHandlers.ConfigureEmailSandboxHandler{}
+		notificationGroup.Put("/email-sandbox", baseHandler.Serve(&configureEmailSandboxHandler)).Name("email-sandbox.configure")
+
+		deleteEmailSandboxHandler := notificationHandlers.DeleteEmailSandboxHandler{}
+		notificationGroup.Delete("/email-sandbox", baseHandler.Serve(&deleteEmailSandboxHandler)).Name("email-sandbox.delete")
+
+		getAllCapturedEmailHandler := notificationHandlers.GetAllCapturedEmailHandler{}
+		notificationGroup.Get("/captured-emails", baseHandler.Serve(&getAllCapturedEmailHandler)).Name("captured-emails.list")
+
+		getCapturedEmailHandler := notificationHandlers.GetCapturedEmailHandler{}
+		notificationGroup.Get("/captured-emails/:id", baseHandler.Serve(&getCapturedEmailHandler)).Name("captured-emails.get")
+
+		getCapturedEmailRawHandler := notificationHandlers.GetCapturedEmailRawHandler{}
+		notificationGroup.Get("/captured-emails/:id/raw", baseHandler.Serve(&getCapturedEmailRawHandler)).Name("captured-emails.raw")
+
+		deleteCapturedEmailHandler := notificationHandlers.DeleteCapturedEmailHandler{}
+		notificationGroup.Delete("/captured-emails/:id", baseHandler.Serve(&deleteCapturedEmailHandler)).Name("captured-emails.delete")
+
+		deleteAllCapturedEmailHandler := notificationHandlers.DeleteAllCapturedEmailHandler{}
+		notificationGroup.Delete("/captured-emails", baseHandler.Serve(&deleteAllCapturedEmailHandler)).Name("captured-emails.delete-all")
+
+		// The tracking links are opened from a mailbox and the webhooks are called by the providers, the
+		// project is in the token or found from the message
+		trackEmailOpenHandler := notificationHandlers.TrackEmailOpenHandler{}
+		notificationGroup.Get("/track/open/:token", baseHandler.Serve(&trackEmailOpenHandler))
+
+		trackEmailClickHandler := notificationHandlers.TrackEmailClickHandler{}
+		notificationGroup.Get("/track/click/:token", baseHandler.Serve(&trackEmailClickHandler))
+
+		ingestDeliveryWebhookHandler := notificationHandlers.IngestDeliveryWebhookHandler{}
+		notificationGroup.Post("/webhooks/:provider", baseHandler.Serve(&ingestDeliveryWebhookHandler))
 	}
 }
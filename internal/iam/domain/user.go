@@ -8,6 +8,7 @@ import (
 	valueobject "platform/internal/iam/domain/value_object"
 
 	"platform/pkg/domain"
+	vo "platform/pkg/domain/value_object"
 
 	"github.com/google/uuid"
 )
@@ -30,7 +31,8 @@ type User struct {
 	LastName             *string
 	Email                string
 	EmailValidated       bool
-	Phone                *string
+	Phone                *vo.PhoneNumber
+	LegacyPhone          *string // the stored phone which is not a valid number, kept until the phone is changed
 	PhoneValidated       bool
 	Gender               enum.GenderType
 	BirthDate            *time.Time
@@ -121,11 +123,26 @@ func (u *User) UpdateEmail(newEmail string) {
 	u.EmailValidated = false
 }
 
-func (u *User) UpdatePhone(newPhone string) {
+func (u *User) UpdatePhone(newPhone vo.PhoneNumber) {
 	u.Phone = &newPhone
+	u.LegacyPhone = nil
 	u.PhoneValidated = false
 }
 
+// RemovePhone clears the phone of the user, the legacy value included
+func (u *User) RemovePhone() {
+	u.Phone = nil
+	u.LegacyPhone = nil
+	u.PhoneValidated = false
+}
+
+// VerifyPhone marks the phone as validated once the owner proved to receive messages on it
+func (u *User) VerifyPhone(phone vo.PhoneNumber) {
+	u.Phone = &phone
+	u.LegacyPhone = nil
+	u.PhoneValidated = true
+}
+
 func (u *User) IncrementFailedLoginAttempts() {
 	u.FailedLoginAttempts++
 	if u.FailedLoginAttempts >= max_failed_attempts {
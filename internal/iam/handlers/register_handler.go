@@ -8,16 +8,23 @@ import (
 	"platform/internal/iam/domain/domain_event"
 	"platform/internal/iam/domain/enum"
 	"platform/internal/iam/repositories"
+	"platform/internal/notification/mediatr/commands"
 	baseHandler "platform/internal/shared/handlers"
 	eventBus "platform/pkg/services/eventbus"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 type RegisterRequest struct {
-	FirstName           string `json:"firstName" validate:"max=64"`
-	LastName            string `json:"lastName" validate:"max=64"`
-	Email               string `json:"email" validate:"required,email"`
-	Password            string `json:"password" validate:"required,min=8,max=16,password"`
-	SubscribeNewsletter bool   `json:"subscribeNewsletter"`
+	ProjectID           uuid.UUID `reqHeader:"X-Project-ID" json:"-"`
+	FirstName           string    `json:"firstName" validate:"max=64"`
+	LastName            string    `json:"lastName" validate:"max=64"`
+	Email               string    `json:"email" validate:"required,email"`
+	Password            string    `json:"password" validate:"required,min=8,max=16,password"`
+	SubscribeNewsletter bool      `json:"subscribeNewsletter"`
+	Language            string    `json:"language" validate:"omitempty,max=8"`
 }
 
 type RegisterResponse struct {
@@ -69,5 +76,13 @@ func (h *RegisterHandler) Handle(ctx context.Context, req *RegisterRequest) (*ba
 	event := domain_event.NewUserRegisteredEvent(user.ID.String(), user.Email)
 	h.eventBus.Publish(ctx, event)
 
+	// Opting in at signup creates a pending subscription, a failure must not fail the registration
+	if req.SubscribeNewsletter && req.ProjectID != uuid.Nil {
+		command := commands.SubscribeCommand{Email: req.Email, Language: req.Language}
+		if _, err := mediator.Send[*commands.SubscribeCommand, *commands.SubscribeCommandResponse](ctx, &command); err != nil {
+			zap.L().Warn("newsletter subscription failed on registration", zap.String("email", req.Email), zap.Error(err))
+		}
+	}
+
 	return baseHandler.CreatedResponseWithoutData[RegisterResponse](), nil
 }
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"platform/internal/iam/repositories"
+	phone_verification "platform/internal/iam/services/phoneVerification"
+	notificationDomain "platform/internal/notification/domain"
+	"platform/internal/notification/mediatr/commands"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	vo "platform/pkg/domain/value_object"
+	"platform/pkg/services/mediator"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+type VerifyPhoneRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Phone     string    `reqHeader:"-" params:"-" query:"-" json:"phone" validate:"required,max=32"`
+	Region    string    `reqHeader:"-" params:"-" query:"-" json:"region" validate:"omitempty,len=2"`
+	Language  string    `reqHeader:"-" params:"-" query:"-" json:"language" validate:"omitempty,max=8"`
+}
+
+type VerifyPhoneResponse struct {
+	Phone    string `json:"phone"`
+	ExpireIn int    `json:"expire_in"`
+}
+
+type VerifyPhoneHandler struct {
+	userRepository    repositories.UserRepository
+	phoneVerification *phone_verification.PhoneVerificationService
+}
+
+func NewVerifyPhoneHandler(userRepository *repositories.UserRepository, phoneVerification *phone_verification.PhoneVerificationService) *VerifyPhoneHandler {
+	return &VerifyPhoneHandler{
+		userRepository:    *userRepository,
+		phoneVerification: phoneVerification,
+	}
+}
+
+func (h *VerifyPhoneHandler) Handle(ctx context.Context, req *VerifyPhoneRequest) (*baseHandler.Response[VerifyPhoneResponse], error) {
+	// STEP-1: Find the authenticated user, a phone can only be verified on the caller's own account
+	userID, ok := ctx.Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+	user, err := h.userRepository.GetById(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("an error occurred on phone verification process: %w", err)
+	}
+	if user == nil {
+		return baseHandler.NotFoundResponse[VerifyPhoneResponse](), nil
+	}
+
+	// STEP-2: Normalize the phone number
+	phone, err := vo.ParsePhoneNumber(req.Phone, req.Region)
+	if err != nil {
+		return baseHandler.FailedResponse[VerifyPhoneResponse](err), nil
+	}
+
+	// STEP-3: Issue a new code, users can only request a few of them in a while
+	code, err := h.phoneVerification.Issue(ctx, user.ID, phone)
+	if errors.Is(err, phone_verification.ErrTooManyRequests) {
+		return baseHandler.TooManyRequestsResponse[VerifyPhoneResponse](err), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("an error occurred on phone verification process: %w", err)
+	}
+
+	// STEP-4: Send the code by sms
+	command := commands.SendSmsCommand{
+		To:           phone.Value(),
+		TemplateName: notificationDomain.PHONE_VERIFICATION,
+		Language:     req.Language,
+		Tokens: map[string]string{
+			"Code":          code,
+			"ExpireMinutes": strconv.Itoa(int(phone_verification.CodeTTL.Minutes())),
+		},
+	}
+	_, err = mediator.Send[*commands.SendSmsCommand, *commands.SendSmsCommandResponse](ctx, &command)
+	if err != nil {
+		return nil, fmt.Errorf("an error occurred on phone verification process: %w", err)
+	}
+
+	// STEP-5: Return hateoas links to user
+	respData := VerifyPhoneResponse{
+		Phone:    phone.FormatInternational(),
+		ExpireIn: int(phone_verification.CodeTTL.Seconds()),
+	}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		Route("iam:confirm", "iam.verify-phone.confirm", "Confirm the phone number with the received code").
+		Build()
+	return response, nil
+}
+
+type ConfirmPhoneRequest struct {
+	Code string `reqHeader:"-" params:"-" query:"-" json:"code" validate:"required,len=6,numeric"`
+}
+
+type ConfirmPhoneResponse struct {
+	Phone string `json:"phone"`
+}
+
+type ConfirmPhoneHandler struct {
+	userRepository    repositories.UserRepository
+	phoneVerification *phone_verification.PhoneVerificationService
+}
+
+func NewConfirmPhoneHandler(userRepository *repositories.UserRepository, phoneVerification *phone_verification.PhoneVerificationService) *ConfirmPhoneHandler {
+	return &ConfirmPhoneHandler{
+		userRepository:    *userRepository,
+		phoneVerification: phoneVerification,
+	}
+}
+
+func (h *ConfirmPhoneHandler) Handle(ctx context.Context, req *ConfirmPhoneRequest) (*baseHandler.Response[ConfirmPhoneResponse], error) {
+	// STEP-1: Find the authenticated user, a phone can only be verified on the caller's own account
+	userID, ok := ctx.Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+	user, err := h.userRepository.GetById(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("an error occurred on phone verification process: %w", err)
+	}
+	if user == nil {
+		return baseHandler.NotFoundResponse[ConfirmPhoneResponse](), nil
+	}
+
+	// STEP-2: Check the code
+	phone, err := h.phoneVerification.Verify(ctx, user.ID, req.Code)
+	switch {
+	case errors.Is(err, phone_verification.ErrTooManyAttempts):
+		return baseHandler.TooManyRequestsResponse[ConfirmPhoneResponse](err), nil
+	case errors.Is(err, phone_verification.ErrInvalidCode), errors.Is(err, phone_verification.ErrCodeExpired):
+		return baseHandler.FailedResponse[ConfirmPhoneResponse](err), nil
+	case err != nil:
+		return nil, fmt.Errorf("an error occurred on phone verification process: %w", err)
+	}
+
+	// STEP-3: Save the verified phone
+	user.VerifyPhone(phone)
+	err = h.userRepository.Update(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("an error occurred on phone verification process: %w", err)
+	}
+
+	respData := ConfirmPhoneResponse{Phone: phone.Value()}
+	return baseHandler.SuccessResponse(&respData), nil
+}
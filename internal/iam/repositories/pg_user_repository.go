@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"platform/internal/iam/domain"
 	"platform/internal/shared"
+	vo "platform/pkg/domain/value_object"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
 )
 
 type PgUserRepository struct {
@@ -53,6 +55,7 @@ func (r *PgUserRepository) Create(ctx context.Context, user *domain.User) error
 
 func (r *PgUserRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	var user domain.User
+	var phone *string
 	sql := `SELECT * FROM users WHERE id = $1`
 	err := r.pool.QueryRow(ctx, sql, id).Scan(
 		&user.ID,
@@ -60,7 +63,7 @@ func (r *PgUserRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.U
 		&user.LastName,
 		&user.Email,
 		&user.EmailValidated,
-		&user.Phone,
+		&phone,
 		&user.PhoneValidated,
 		&user.Gender,
 		&user.BirthDate,
@@ -78,11 +81,20 @@ func (r *PgUserRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.U
 		&user.Active,
 		&user.Deleted,
 	)
-	return &user, err
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return &user, err
+	}
+
+	user.Phone, user.LegacyPhone = toPhoneNumber(user.ID, phone)
+	return &user, nil
 }
 
 func (r *PgUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
+	var phone *string
 	sql := `SELECT * FROM users WHERE email = $1`
 	err := r.pool.QueryRow(ctx, sql, email).Scan(
 		&user.ID,
@@ -90,7 +102,7 @@ func (r *PgUserRepository) GetByEmail(ctx context.Context, email string) (*domai
 		&user.LastName,
 		&user.Email,
 		&user.EmailValidated,
-		&user.Phone,
+		&phone,
 		&user.PhoneValidated,
 		&user.Gender,
 		&user.BirthDate,
@@ -112,8 +124,12 @@ func (r *PgUserRepository) GetByEmail(ctx context.Context, email string) (*domai
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	}
+	if err != nil {
+		return &user, err
+	}
 
-	return &user, err
+	user.Phone, user.LegacyPhone = toPhoneNumber(user.ID, phone)
+	return &user, nil
 }
 
 func (r *PgUserRepository) Exists(ctx context.Context, email string) (bool, error) {
@@ -135,7 +151,7 @@ func (r *PgUserRepository) Update(ctx context.Context, user *domain.User) error
 			first_name = $1, 
 			last_name = $2, 
 			email = $3,
-			email_validate = $4,
+			email_validated = $4,
 			phone = $5,
 			phone_validated = $6,
 			gender = $7,
@@ -152,6 +168,7 @@ func (r *PgUserRepository) Update(ctx context.Context, user *domain.User) error
 			admin_comment = $18,
 			active = $19,
 			deleted = $20
+		WHERE id = $21
 	`
 	_, err := r.pool.Exec(
 		ctx,
@@ -160,7 +177,7 @@ func (r *PgUserRepository) Update(ctx context.Context, user *domain.User) error
 		&user.LastName,
 		&user.Email,
 		&user.EmailValidated,
-		&user.Phone,
+		fromPhoneNumber(user),
 		&user.PhoneValidated,
 		&user.Gender,
 		&user.BirthDate,
@@ -175,7 +192,8 @@ func (r *PgUserRepository) Update(ctx context.Context, user *domain.User) error
 		&user.IsSystemUser,
 		&user.AdminComment,
 		&user.Active,
-		&user.Deleted)
+		&user.Deleted,
+		user.ID)
 
 	return err
 }
@@ -185,3 +203,27 @@ func (r *PgUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	_, err := r.pool.Exec(ctx, sql, id)
 	return err
 }
+
+// toPhoneNumber converts the E.164 value stored in the phone column. The free text numbers saved before the
+// numbers were normalized may not parse, the user is loaded without phone then and the value is returned as
+// the legacy phone, so it is written back as is.
+func toPhoneNumber(userID uuid.UUID, phone *string) (*vo.PhoneNumber, *string) {
+	if phone == nil {
+		return nil, nil
+	}
+	p, err := vo.NewPhoneNumber(*phone)
+	if err != nil {
+		zap.L().Warn("ignoring the invalid phone number of the user", zap.String("user_id", userID.String()), zap.Error(err))
+		return nil, phone
+	}
+	return &p, nil
+}
+
+// fromPhoneNumber returns the value stored in the phone column, the legacy value when the user has no phone
+func fromPhoneNumber(user *domain.User) *string {
+	if user.Phone == nil {
+		return user.LegacyPhone
+	}
+	value := user.Phone.Value()
+	return &value
+}
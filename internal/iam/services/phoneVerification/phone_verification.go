@@ -0,0 +1,165 @@
+// Package phone_verification issues one-time codes sent by sms to prove the ownership of a phone number.
+// Codes are kept hashed in the cache, so they disappear on their own once expired.
+package phone_verification
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	vo "platform/pkg/domain/value_object"
+	"platform/pkg/services/cache"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTooManyRequests = errors.New("too many verification codes requested, try again later")
+	ErrInvalidCode     = errors.New("invalid verification code")
+	ErrCodeExpired     = errors.New("verification code expired or not requested")
+	ErrTooManyAttempts = errors.New("too many invalid attempts, request a new code")
+)
+
+const (
+	CodeLength  = 6
+	CodeTTL     = 10 * time.Minute
+	MaxAttempts = 5
+
+	// MaxSends codes can be requested per user in SendWindow
+	MaxSends   = 3
+	SendWindow = 10 * time.Minute
+)
+
+type pendingCode struct {
+	Hash     string    `json:"hash"`
+	Phone    string    `json:"phone"`
+	Attempts int       `json:"attempts"`
+	ExpireAt time.Time `json:"expire_at"`
+}
+
+type sendCounter struct {
+	Count       int       `json:"count"`
+	WindowStart time.Time `json:"window_start"`
+}
+
+type PhoneVerificationService struct {
+	cache cache.CacheManager
+}
+
+func NewPhoneVerificationService(cache cache.CacheManager) *PhoneVerificationService {
+	return &PhoneVerificationService{cache: cache}
+}
+
+// Issue creates a new code for the phone of the user, replacing the previous one, and returns it to be sent
+func (s *PhoneVerificationService) Issue(ctx context.Context, userID uuid.UUID, phone vo.PhoneNumber) (string, error) {
+	// STEP-1: Check the rate limit of the user
+	now := time.Now()
+	counterKey := sendCounterKey(userID)
+	var counter sendCounter
+	if !s.get(ctx, counterKey, &counter) || now.Sub(counter.WindowStart) >= SendWindow {
+		counter = sendCounter{WindowStart: now}
+	}
+	if counter.Count >= MaxSends {
+		return "", ErrTooManyRequests
+	}
+	counter.Count++
+	if err := s.set(ctx, counterKey, counter, counter.WindowStart.Add(SendWindow).Sub(now)); err != nil {
+		return "", err
+	}
+
+	// STEP-2: Generate and store the code
+	code, err := generateCode()
+	if err != nil {
+		return "", err
+	}
+	pending := pendingCode{
+		Hash:     hashCode(userID, code),
+		Phone:    phone.Value(),
+		ExpireAt: now.Add(CodeTTL),
+	}
+	if err := s.set(ctx, pendingCodeKey(userID), pending, CodeTTL); err != nil {
+		return "", err
+	}
+
+	return code, nil
+}
+
+// Verify checks the code of the user and returns the phone number it was sent to
+func (s *PhoneVerificationService) Verify(ctx context.Context, userID uuid.UUID, code string) (vo.PhoneNumber, error) {
+	// STEP-1: Get the pending code
+	key := pendingCodeKey(userID)
+	var pending pendingCode
+	if !s.get(ctx, key, &pending) || time.Now().After(pending.ExpireAt) {
+		return vo.PhoneNumber{}, ErrCodeExpired
+	}
+	if pending.Attempts >= MaxAttempts {
+		return vo.PhoneNumber{}, ErrTooManyAttempts
+	}
+
+	// STEP-2: Compare hashes in constant time, count the failed attempt
+	if subtle.ConstantTimeCompare([]byte(pending.Hash), []byte(hashCode(userID, code))) != 1 {
+		pending.Attempts++
+		if err := s.set(ctx, key, pending, time.Until(pending.ExpireAt)); err != nil {
+			return vo.PhoneNumber{}, err
+		}
+		if pending.Attempts >= MaxAttempts {
+			return vo.PhoneNumber{}, ErrTooManyAttempts
+		}
+		return vo.PhoneNumber{}, ErrInvalidCode
+	}
+
+	// STEP-3: A code can only be used once
+	if err := s.cache.Remove(ctx, key); err != nil {
+		return vo.PhoneNumber{}, err
+	}
+	return vo.NewPhoneNumber(pending.Phone)
+}
+
+// PRIVATE METHODS
+func (s *PhoneVerificationService) get(ctx context.Context, key string, v any) bool {
+	cached, err := s.cache.Get(ctx, cache.CacheKey{Key: key})
+	if err != nil || cached == "" {
+		return false
+	}
+	return json.Unmarshal([]byte(cached), v) == nil
+}
+
+func (s *PhoneVerificationService) set(ctx context.Context, key string, v any, ttl time.Duration) error {
+	serialized, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+	return s.cache.Set(ctx, cache.CacheKey{Key: key, Time: ttl}, string(serialized))
+}
+
+func generateCode() (string, error) {
+	max := big.NewInt(1_000_000)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", CodeLength, n.Int64()), nil
+}
+
+// hashCode binds the code to the user, so a leaked cache entry cannot be used for another account
+func hashCode(userID uuid.UUID, code string) string {
+	sum := sha256.Sum256([]byte(userID.String() + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+func pendingCodeKey(userID uuid.UUID) string {
+	return fmt.Sprintf("iam:phone_verification:%s", userID.String())
+}
+
+func sendCounterKey(userID uuid.UUID) string {
+	return fmt.Sprintf("iam:phone_verification:sends:%s", userID.String())
+}
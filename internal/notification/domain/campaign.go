@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"errors"
+	"platform/pkg/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCampaignTransition = errors.New("campaign cannot be moved to this status")
+
+type CampaignStatus string
+
+const (
+	// CampaignScheduled waits for its schedule, the scheduler then queues an email for every subscriber
+	CampaignScheduled CampaignStatus = "scheduled"
+	CampaignRunning   CampaignStatus = "running"
+	CampaignPaused    CampaignStatus = "paused"
+	CampaignCompleted CampaignStatus = "completed"
+	CampaignCancelled CampaignStatus = "cancelled"
+)
+
+// Campaign sends an email template to the confirmed subscribers of a project, optionally only to the ones
+// speaking one of its languages
+type Campaign struct {
+	domain.AggregateRoot
+	projectID      uuid.UUID
+	emailAccountID uuid.UUID
+	name           string
+	templateName   EmailTemplateName
+	languages      []string
+	scheduledAt    time.Time
+	status         CampaignStatus
+	totalCount     int
+	sentCount      int
+	failedCount    int
+	startedAt      *time.Time
+	completedAt    *time.Time
+	createdAt      time.Time
+}
+
+func NewCampaign(projectID, emailAccountID uuid.UUID, name string, templateName EmailTemplateName, languages []string, scheduledAt time.Time) *Campaign {
+	if languages == nil {
+		languages = []string{}
+	}
+	return &Campaign{
+		AggregateRoot:  domain.NewAggregateRoot(uuid.New()),
+		projectID:      projectID,
+		emailAccountID: emailAccountID,
+		name:           name,
+		templateName:   templateName,
+		languages:      languages,
+		scheduledAt:    scheduledAt,
+		status:         CampaignScheduled,
+		createdAt:      time.Now(),
+	}
+}
+
+// GETTERS
+func (c *Campaign) GetProjectID() uuid.UUID            { return c.projectID }
+func (c *Campaign) GetEmailAccountID() uuid.UUID       { return c.emailAccountID }
+func (c *Campaign) GetName() string                    { return c.name }
+func (c *Campaign) GetTemplateName() EmailTemplateName { return c.templateName }
+func (c *Campaign) GetLanguages() []string             { return c.languages }
+func (c *Campaign) GetScheduledAt() time.Time          { return c.scheduledAt }
+func (c *Campaign) GetStatus() CampaignStatus          { return c.status }
+func (c *Campaign) GetTotalCount() int                 { return c.totalCount }
+func (c *Campaign) GetSentCount() int                  { return c.sentCount }
+func (c *Campaign) GetFailedCount() int                { return c.failedCount }
+func (c *Campaign) GetStartedAt() *time.Time           { return c.startedAt }
+func (c *Campaign) GetCompletedAt() *time.Time         { return c.completedAt }
+func (c *Campaign) GetCreatedAt() time.Time            { return c.createdAt }
+
+// SETTERS
+func (c *Campaign) SetProjectID(projectID uuid.UUID)               { c.projectID = projectID }
+func (c *Campaign) SetEmailAccountID(emailAccountID uuid.UUID)     { c.emailAccountID = emailAccountID }
+func (c *Campaign) SetName(name string)                            { c.name = name }
+func (c *Campaign) SetTemplateName(templateName EmailTemplateName) { c.templateName = templateName }
+func (c *Campaign) SetLanguages(languages []string)                { c.languages = languages }
+func (c *Campaign) SetScheduledAt(scheduledAt time.Time)           { c.scheduledAt = scheduledAt }
+func (c *Campaign) SetStatus(status CampaignStatus)                { c.status = status }
+func (c *Campaign) SetCounters(total, sent, failed int) {
+	c.totalCount, c.sentCount, c.failedCount = total, sent, failed
+}
+func (c *Campaign) SetStartedAt(startedAt *time.Time)     { c.startedAt = startedAt }
+func (c *Campaign) SetCompletedAt(completedAt *time.Time) { c.completedAt = completedAt }
+func (c *Campaign) SetCreatedAt(createdAt time.Time)      { c.createdAt = createdAt }
+
+// Targets reports whether a subscriber speaking the language is part of the audience
+func (c *Campaign) Targets(language string) bool {
+	if len(c.languages) == 0 {
+		return true
+	}
+	for _, l := range c.languages {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+// Progress returns the percentage of the audience the campaign has processed
+func (c *Campaign) Progress() int {
+	if c.totalCount == 0 {
+		if c.status == CampaignCompleted {
+			return 100
+		}
+		return 0
+	}
+	return (c.sentCount + c.failedCount) * 100 / c.totalCount
+}
+
+// Start is called by the scheduler once the emails of the audience are queued
+func (c *Campaign) Start(total int) error {
+	if c.status != CampaignScheduled {
+		return ErrInvalidCampaignTransition
+	}
+	now := time.Now()
+	c.status = CampaignRunning
+	c.totalCount = total
+	c.startedAt = &now
+	return nil
+}
+
+func (c *Campaign) Pause() error {
+	if c.status != CampaignScheduled && c.status != CampaignRunning {
+		return ErrInvalidCampaignTransition
+	}
+	c.status = CampaignPaused
+	return nil
+}
+
+func (c *Campaign) Resume() error {
+	if c.status != CampaignPaused {
+		return ErrInvalidCampaignTransition
+	}
+	// A campaign paused before its schedule has no queued email yet, the scheduler still has to expand it
+	if c.startedAt == nil {
+		c.status = CampaignScheduled
+	} else {
+		c.status = CampaignRunning
+	}
+	return nil
+}
+
+// Cancel stops the campaign for good, the emails which are not sent yet are dropped
+func (c *Campaign) Cancel() error {
+	if c.status == CampaignCompleted || c.status == CampaignCancelled {
+		return ErrInvalidCampaignTransition
+	}
+	now := time.Now()
+	c.status = CampaignCancelled
+	c.completedAt = &now
+	return nil
+}
+
+func (c *Campaign) Complete() error {
+	if c.status != CampaignRunning {
+		return ErrInvalidCampaignTransition
+	}
+	now := time.Now()
+	c.status = CampaignCompleted
+	c.completedAt = &now
+	return nil
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"platform/pkg/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CapturedEmail is a message the sandbox stored instead of delivering it, Raw is the message as it would
+// have been sent. Recipients are the envelope recipients, Bcc included.
+type CapturedEmail struct {
+	domain.AggregateRoot
+	projectID      uuid.UUID
+	emailAccountID uuid.UUID
+	from           string
+	recipients     []string
+	subject        string
+	messageID      string
+	headers        map[string][]string
+	text           string
+	html           string
+	attachments    []CapturedAttachment
+	raw            []byte
+	createdAt      time.Time
+}
+
+type CapturedAttachment struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	ContentID   string `json:"content_id,omitempty"`
+	Size        int    `json:"size"`
+}
+
+func NewCapturedEmail(projectID, emailAccountID uuid.UUID, from string, recipients []string, raw []byte) *CapturedEmail {
+	return &CapturedEmail{
+		AggregateRoot:  domain.NewAggregateRoot(uuid.New()),
+		projectID:      projectID,
+		emailAccountID: emailAccountID,
+		from:           from,
+		recipients:     recipients,
+		headers:        make(map[string][]string),
+		attachments:    make([]CapturedAttachment, 0),
+		raw:            raw,
+		createdAt:      time.Now(),
+	}
+}
+
+// GETTERS
+func (e *CapturedEmail) GetProjectID() uuid.UUID              { return e.projectID }
+func (e *CapturedEmail) GetEmailAccountID() uuid.UUID         { return e.emailAccountID }
+func (e *CapturedEmail) GetFrom() string                      { return e.from }
+func (e *CapturedEmail) GetRecipients() []string              { return e.recipients }
+func (e *CapturedEmail) GetSubject() string                   { return e.subject }
+func (e *CapturedEmail) GetMessageID() string                 { return e.messageID }
+func (e *CapturedEmail) GetHeaders() map[string][]string      { return e.headers }
+func (e *CapturedEmail) GetText() string                      { return e.text }
+func (e *CapturedEmail) GetHTML() string                      { return e.html }
+func (e *CapturedEmail) GetAttachments() []CapturedAttachment { return e.attachments }
+func (e *CapturedEmail) GetRaw() []byte                       { return e.raw }
+func (e *CapturedEmail) GetSize() int                         { return len(e.raw) }
+func (e *CapturedEmail) GetCreatedAt() time.Time              { return e.createdAt }
+
+// SETTERS
+func (e *CapturedEmail) SetSubject(subject string)                       { e.subject = subject }
+func (e *CapturedEmail) SetMessageID(messageID string)                   { e.messageID = messageID }
+func (e *CapturedEmail) SetHeaders(headers map[string][]string)          { e.headers = headers }
+func (e *CapturedEmail) SetText(text string)                             { e.text = text }
+func (e *CapturedEmail) SetHTML(html string)                             { e.html = html }
+func (e *CapturedEmail) SetAttachments(attachments []CapturedAttachment) { e.attachments = attachments }
+func (e *CapturedEmail) SetCreatedAt(createdAt time.Time)                { e.createdAt = createdAt }
@@ -0,0 +1,15 @@
+package domain_event
+
+import (
+	notificationDomain "platform/internal/notification/domain"
+	"platform/pkg/domain"
+	"time"
+)
+
+func NewInboundEmailReceivedEvent(email notificationDomain.InboundEmail) domain.DomainEvent {
+	return &domain.BaseDomainEvent{
+		EventName: "notification.inboundEmailReceived",
+		Timestamp: time.Now(),
+		Payload:   email,
+	}
+}
@@ -14,8 +14,23 @@ const (
 	Login           // Authentication with username and password
 	GmailOAuth2     // OAuth2 authentication with Google APIs
 	MicrosoftOAuth2 // OAuth2 authentication with Microsoft Authentication
+	SendGridAPI     // SendGrid Web API v3 with an API key
+	MailgunAPI      // Mailgun messages API with an API key
+	AmazonSES       // Amazon SES v2 API with an access key
+	GmailAPI        // Gmail API with OAuth2 authentication with Google APIs
+	MicrosoftGraph  // Microsoft Graph API with OAuth2 authentication with Microsoft Authentication
 )
 
+// Default send limits of each account type, they are set by the registration of the email providers.
+// Zero means unlimited.
+var defaultSendLimits = map[int][2]int{}
+
+// SetDefaultSendLimits sets the limits used by the accounts of the type which do not set theirs, it is meant
+// to be called during the initialization
+func SetDefaultSendLimits(typeID, perMinute, perDay int) {
+	defaultSendLimits[typeID] = [2]int{perMinute, perDay}
+}
+
 type EmailAccount struct {
 	domain.AggregateRoot
 	projectID              uuid.UUID
@@ -28,6 +43,12 @@ type EmailAccount struct {
 	traditionalCredentials *voInternal.TraditionalCredentials
 	oAuth2Credentials      *voInternal.OAuth2Credentials
 	tokenInformation       *voInternal.TokenInformation
+	dkimSettings           *voInternal.DkimSettings
+	needsReconsent         bool
+	trackOpens             bool
+	trackClicks            bool
+	maxPerMinute           int
+	maxPerDay              int
 	createdAt              time.Time
 	emailTemplates         []EmailTemplate
 	queuedEmails           []QueuedEmail
@@ -68,6 +89,14 @@ func (ea *EmailAccount) GetOAuth2Credentials() *voInternal.OAuth2Credentials {
 func (ea *EmailAccount) GetTokenInformation() *voInternal.TokenInformation {
 	return ea.tokenInformation
 }
+func (ea *EmailAccount) GetDkimSettings() *voInternal.DkimSettings {
+	return ea.dkimSettings
+}
+func (ea *EmailAccount) GetNeedsReconsent() bool        { return ea.needsReconsent }
+func (ea *EmailAccount) GetTrackOpens() bool            { return ea.trackOpens }
+func (ea *EmailAccount) GetTrackClicks() bool           { return ea.trackClicks }
+func (ea *EmailAccount) GetMaxPerMinute() int           { return ea.maxPerMinute }
+func (ea *EmailAccount) GetMaxPerDay() int              { return ea.maxPerDay }
 func (ea *EmailAccount) GetCreatedAt() time.Time        { return ea.createdAt }
 func (ea *EmailAccount) GetTemplates() []EmailTemplate  { return ea.emailTemplates }
 func (ea *EmailAccount) GetQueuedEmails() []QueuedEmail { return ea.queuedEmails }
@@ -93,4 +122,30 @@ func (ea *EmailAccount) SetTokenInformation(tokenInformation *voInternal.TokenIn
 	ea.traditionalCredentials = nil
 	ea.tokenInformation = tokenInformation
 }
-func (ea *EmailAccount) SetCreatedAt(createdAt time.Time) { ea.createdAt = createdAt }
+func (ea *EmailAccount) SetDkimSettings(dkimSettings *voInternal.DkimSettings) {
+	ea.dkimSettings = dkimSettings
+}
+func (ea *EmailAccount) SetNeedsReconsent(needsReconsent bool) { ea.needsReconsent = needsReconsent }
+func (ea *EmailAccount) SetMaxPerMinute(maxPerMinute int)      { ea.maxPerMinute = maxPerMinute }
+func (ea *EmailAccount) SetMaxPerDay(maxPerDay int)            { ea.maxPerDay = maxPerDay }
+func (ea *EmailAccount) SetCreatedAt(createdAt time.Time)      { ea.createdAt = createdAt }
+
+// SetTracking enables the tracking pixel and the rewriting of the links of the HTML messages
+func (ea *EmailAccount) SetTracking(opens, clicks bool) {
+	ea.trackOpens = opens
+	ea.trackClicks = clicks
+}
+
+// SendLimits returns the number of messages the account may send per minute and per day, using the defaults
+// of its type for the limits which are not set. Zero means unlimited.
+func (ea *EmailAccount) SendLimits() (perMinute, perDay int) {
+	defaults := defaultSendLimits[ea.typeID]
+	perMinute, perDay = ea.maxPerMinute, ea.maxPerDay
+	if perMinute == 0 {
+		perMinute = defaults[0]
+	}
+	if perDay == 0 {
+		perDay = defaults[1]
+	}
+	return perMinute, perDay
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"platform/pkg/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailDeliveryStatus is a step in the life of a sent message
+type EmailDeliveryStatus string
+
+const (
+	EmailQueued   EmailDeliveryStatus = "queued"
+	EmailSent     EmailDeliveryStatus = "sent"
+	EmailDeferred EmailDeliveryStatus = "deferred"
+	EmailBounced  EmailDeliveryStatus = "bounced"
+	EmailOpened   EmailDeliveryStatus = "opened"
+	EmailClicked  EmailDeliveryStatus = "clicked"
+)
+
+var EmailDeliveryStatuses = []EmailDeliveryStatus{EmailQueued, EmailSent, EmailDeferred, EmailBounced, EmailOpened, EmailClicked}
+
+func (s EmailDeliveryStatus) IsValid() bool {
+	for _, status := range EmailDeliveryStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// rank orders the statuses, a status does not replace a higher one. The events of the providers may arrive
+// out of order, e.g. the delivery report after the first open.
+func (s EmailDeliveryStatus) rank() int {
+	switch s {
+	case EmailSent, EmailDeferred:
+		return 1
+	case EmailBounced:
+		return 2
+	case EmailOpened:
+		return 3
+	case EmailClicked:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// EmailDeliveryEvent is an entry of the history of a message, URL is the link of the clicks
+type EmailDeliveryEvent struct {
+	ID         uuid.UUID
+	Status     EmailDeliveryStatus
+	Detail     string
+	URL        string
+	OccurredAt time.Time
+}
+
+// EmailDelivery is the delivery log of a message sent to one recipient. MessageID is the Message-ID header
+// of the message, ProviderMessageID the identifier an HTTP API gave it; the events of the providers and the
+// bounces refer to one of them.
+type EmailDelivery struct {
+	domain.AggregateRoot
+	projectID         uuid.UUID
+	emailAccountID    uuid.UUID
+	campaignID        *uuid.UUID
+	recipient         string
+	subject           string
+	messageID         string
+	providerMessageID string
+	status            EmailDeliveryStatus
+	opens             int
+	clicks            int
+	events            []EmailDeliveryEvent
+	createdAt         time.Time
+	updatedAt         time.Time
+}
+
+func NewEmailDelivery(id, projectID, emailAccountID uuid.UUID, campaignID *uuid.UUID, recipient, subject, messageID string) *EmailDelivery {
+	now := time.Now()
+	d := &EmailDelivery{
+		AggregateRoot:  domain.NewAggregateRoot(id),
+		projectID:      projectID,
+		emailAccountID: emailAccountID,
+		campaignID:     campaignID,
+		recipient:      recipient,
+		subject:        subject,
+		messageID:      messageID,
+		status:         EmailQueued,
+		events:         make([]EmailDeliveryEvent, 0),
+		createdAt:      now,
+		updatedAt:      now,
+	}
+	d.Record(EmailQueued, "", "", now)
+	return d
+}
+
+// GETTERS
+func (d *EmailDelivery) GetProjectID() uuid.UUID         { return d.projectID }
+func (d *EmailDelivery) GetEmailAccountID() uuid.UUID    { return d.emailAccountID }
+func (d *EmailDelivery) GetCampaignID() *uuid.UUID       { return d.campaignID }
+func (d *EmailDelivery) GetRecipient() string            { return d.recipient }
+func (d *EmailDelivery) GetSubject() string              { return d.subject }
+func (d *EmailDelivery) GetMessageID() string            { return d.messageID }
+func (d *EmailDelivery) GetProviderMessageID() string    { return d.providerMessageID }
+func (d *EmailDelivery) GetStatus() EmailDeliveryStatus  { return d.status }
+func (d *EmailDelivery) GetOpens() int                   { return d.opens }
+func (d *EmailDelivery) GetClicks() int                  { return d.clicks }
+func (d *EmailDelivery) GetEvents() []EmailDeliveryEvent { return d.events }
+func (d *EmailDelivery) GetCreatedAt() time.Time         { return d.createdAt }
+func (d *EmailDelivery) GetUpdatedAt() time.Time         { return d.updatedAt }
+
+// SETTERS
+func (d *EmailDelivery) SetProjectID(projectID uuid.UUID)      { d.projectID = projectID }
+func (d *EmailDelivery) SetEmailAccountID(id uuid.UUID)        { d.emailAccountID = id }
+func (d *EmailDelivery) SetCampaignID(campaignID *uuid.UUID)   { d.campaignID = campaignID }
+func (d *EmailDelivery) SetRecipient(recipient string)         { d.recipient = recipient }
+func (d *EmailDelivery) SetSubject(subject string)             { d.subject = subject }
+func (d *EmailDelivery) SetMessageID(messageID string)         { d.messageID = messageID }
+func (d *EmailDelivery) SetProviderMessageID(id string)        { d.providerMessageID = id }
+func (d *EmailDelivery) SetStatus(status EmailDeliveryStatus)  { d.status = status }
+func (d *EmailDelivery) SetCounters(opens, clicks int)         { d.opens, d.clicks = opens, clicks }
+func (d *EmailDelivery) SetEvents(events []EmailDeliveryEvent) { d.events = events }
+func (d *EmailDelivery) SetCreatedAt(createdAt time.Time)      { d.createdAt = createdAt }
+func (d *EmailDelivery) SetUpdatedAt(updatedAt time.Time)      { d.updatedAt = updatedAt }
+
+// Record adds an event to the history, the status follows it unless the message already reached a later step
+func (d *EmailDelivery) Record(status EmailDeliveryStatus, detail, url string, at time.Time) {
+	d.events = append(d.events, EmailDeliveryEvent{
+		ID:         uuid.New(),
+		Status:     status,
+		Detail:     detail,
+		URL:        url,
+		OccurredAt: at,
+	})
+
+	switch status {
+	case EmailOpened:
+		d.opens++
+	case EmailClicked:
+		d.clicks++
+	}
+	if status.rank() >= d.status.rank() {
+		d.status = status
+	}
+	d.updatedAt = time.Now()
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SandboxMode decides what happens to the messages of a project instead of a normal delivery
+type SandboxMode string
+
+const (
+	// SandboxOff delivers the messages
+	SandboxOff SandboxMode = "off"
+
+	// SandboxCapture stores every message instead of delivering it
+	SandboxCapture SandboxMode = "capture"
+
+	// SandboxAllowList delivers the messages to the allowed recipients only, the other recipients are
+	// replaced by the redirect address or, without one, the message is captured
+	SandboxAllowList SandboxMode = "allow_list"
+)
+
+var ErrSandboxEnforced = errors.New("the sandbox of the environment cannot be loosened by a project")
+
+// strictness orders the modes, a project can only choose a mode at least as strict as the environment's
+func (m SandboxMode) strictness() int {
+	switch m {
+	case SandboxCapture:
+		return 2
+	case SandboxAllowList:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Looser reports whether the mode delivers more messages than floor
+func (m SandboxMode) Looser(floor SandboxMode) bool {
+	return m.strictness() < floor.strictness()
+}
+
+// EmailSandbox keeps the messages of a project from reaching real recipients, e.g. in local and staging
+// environments. Entries of the allow list are addresses or domains written as "@example.com".
+type EmailSandbox struct {
+	projectID  uuid.UUID
+	mode       SandboxMode
+	allowList  []string
+	redirectTo string
+	createdAt  time.Time
+	updatedAt  time.Time
+
+	// floor is the allow list of the environment the recipients must also match, see Tighten
+	floor *EmailSandbox
+}
+
+func NewEmailSandbox(projectID uuid.UUID, mode SandboxMode, allowList []string, redirectTo string) *EmailSandbox {
+	now := time.Now()
+	sandbox := &EmailSandbox{
+		projectID:  projectID,
+		mode:       mode,
+		redirectTo: strings.ToLower(strings.TrimSpace(redirectTo)),
+		createdAt:  now,
+		updatedAt:  now,
+	}
+	sandbox.SetAllowList(allowList)
+	return sandbox
+}
+
+func (s *EmailSandbox) GetProjectID() uuid.UUID { return s.projectID }
+func (s *EmailSandbox) GetMode() SandboxMode    { return s.mode }
+func (s *EmailSandbox) GetAllowList() []string  { return s.allowList }
+func (s *EmailSandbox) GetRedirectTo() string   { return s.redirectTo }
+func (s *EmailSandbox) GetCreatedAt() time.Time { return s.createdAt }
+func (s *EmailSandbox) GetUpdatedAt() time.Time { return s.updatedAt }
+
+func (s *EmailSandbox) SetCreatedAt(createdAt time.Time) { s.createdAt = createdAt }
+func (s *EmailSandbox) SetUpdatedAt(updatedAt time.Time) { s.updatedAt = updatedAt }
+
+// SetAllowList keeps the non-empty entries in lower case
+func (s *EmailSandbox) SetAllowList(allowList []string) {
+	s.allowList = make([]string, 0, len(allowList))
+	for _, entry := range allowList {
+		if entry = strings.ToLower(strings.TrimSpace(entry)); entry != "" {
+			s.allowList = append(s.allowList, entry)
+		}
+	}
+}
+
+// Change replaces the settings of the sandbox
+func (s *EmailSandbox) Change(mode SandboxMode, allowList []string, redirectTo string) {
+	s.mode = mode
+	s.SetAllowList(allowList)
+	s.redirectTo = strings.ToLower(strings.TrimSpace(redirectTo))
+	s.updatedAt = time.Now()
+}
+
+// Tighten returns the settings applied to the project when the environment enforces floor: the project can
+// make the sandbox stricter, but it cannot turn it off or allow recipients the environment does not allow.
+func (s *EmailSandbox) Tighten(floor *EmailSandbox) *EmailSandbox {
+	switch {
+	case floor == nil || floor.mode == SandboxOff || s.mode.strictness() > floor.mode.strictness():
+		return s
+	case s.mode.Looser(floor.mode):
+		return floor
+	case s.mode != SandboxAllowList:
+		return s
+	}
+
+	// Both use an allow list, the recipients must match both and the project's redirect address wins
+	tightened := *s
+	tightened.floor = floor
+	if tightened.redirectTo == "" {
+		tightened.redirectTo = floor.redirectTo
+	}
+	return &tightened
+}
+
+// Allows reports whether a message can be delivered to address
+func (s *EmailSandbox) Allows(address string) bool {
+	if s.floor != nil && !s.floor.Allows(address) {
+		return false
+	}
+	address = strings.ToLower(address)
+	_, host, _ := strings.Cut(address, "@")
+	for _, entry := range s.allowList {
+		if entry == address || entry == "@"+host {
+			return true
+		}
+	}
+	return false
+}
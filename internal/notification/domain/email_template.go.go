@@ -7,7 +7,8 @@ import (
 type EmailTemplateName string
 
 const (
-	USER_EMAIL_VALIDATION EmailTemplateName = "USER_EMAIL_VALIDATION"
+	USER_EMAIL_VALIDATION   EmailTemplateName = "USER_EMAIL_VALIDATION"
+	NEWSLETTER_CONFIRMATION EmailTemplateName = "NEWSLETTER_CONFIRMATION"
 )
 
 type EmailTemplate struct {
@@ -31,3 +32,11 @@ func NewEmailTemplate(emailAccountId uuid.UUID, name EmailTemplateName, language
 		allowDirectReply:  allowDirectReply,
 	}
 }
+
+func (et EmailTemplate) GetEmailAccountID() uuid.UUID { return et.emailAccountId }
+func (et EmailTemplate) GetName() EmailTemplateName   { return et.name }
+func (et EmailTemplate) GetLanguage() string          { return et.language }
+func (et EmailTemplate) GetSubject() string           { return et.subject }
+func (et EmailTemplate) GetBody() string              { return et.body }
+func (et EmailTemplate) GetBccEmailAddresses() string { return et.bccEmailAddresses }
+func (et EmailTemplate) GetAllowDirectReply() bool    { return et.allowDirectReply }
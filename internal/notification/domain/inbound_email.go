@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sources of the inbound emails
+const (
+	InboundSourceIMAP = "imap"
+	InboundSourceSMTP = "smtp"
+)
+
+// InboundEmail is a message received by an email account, either read from its IMAP mailbox or delivered
+// to the embedded SMTP receiver. EmailAccountID and ProjectID are nil when no account matches the
+// recipients of a message received over SMTP.
+type InboundEmail struct {
+	ID             uuid.UUID           `json:"id"`
+	ProjectID      uuid.UUID           `json:"project_id"`
+	EmailAccountID uuid.UUID           `json:"email_account_id"`
+	Source         string              `json:"source"`
+	MessageID      string              `json:"message_id"`
+	InReplyTo      string              `json:"in_reply_to,omitempty"`
+	References     []string            `json:"references,omitempty"`
+	From           string              `json:"from"`
+	To             []string            `json:"to"`
+	Cc             []string            `json:"cc,omitempty"`
+	Subject        string              `json:"subject"`
+	Text           string              `json:"text,omitempty"`
+	HTML           string              `json:"html,omitempty"`
+	Attachments    []InboundAttachment `json:"attachments,omitempty"`
+
+	// Bounce is set for the delivery status notifications, their recipients are handled by the bounce handler
+	Bounce     bool      `json:"bounce"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+type InboundAttachment struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	ContentID   string `json:"content_id,omitempty"`
+	Size        int    `json:"size"`
+}
+
+// IsReply reports whether the message answers another message
+func (e InboundEmail) IsReply() bool {
+	return e.InReplyTo != "" || len(e.References) > 0
+}
+
+func (e InboundEmail) Validate() error {
+	if e.From == "" {
+		return errors.New("inbound email has no sender")
+	}
+	return nil
+}
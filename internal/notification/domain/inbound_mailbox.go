@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InboundMailbox is the IMAP folder of an email account whose new messages are processed. The messages
+// are read in UID order, lastUID is the last processed one as long as the UID validity of the folder does
+// not change.
+type InboundMailbox struct {
+	emailAccountID uuid.UUID
+	projectID      uuid.UUID
+	host           string
+	port           int
+	enableSSL      bool
+	folder         string
+	useIdle        bool
+	uidValidity    uint32
+	lastUID        uint32
+	lastError      string
+	createdAt      time.Time
+}
+
+func NewInboundMailbox(emailAccountID, projectID uuid.UUID, host string, port int, enableSSL bool, folder string, useIdle bool) *InboundMailbox {
+	if folder == "" {
+		folder = "INBOX"
+	}
+	return &InboundMailbox{
+		emailAccountID: emailAccountID,
+		projectID:      projectID,
+		host:           host,
+		port:           port,
+		enableSSL:      enableSSL,
+		folder:         folder,
+		useIdle:        useIdle,
+		createdAt:      time.Now(),
+	}
+}
+
+func (m *InboundMailbox) GetEmailAccountID() uuid.UUID { return m.emailAccountID }
+func (m *InboundMailbox) GetProjectID() uuid.UUID      { return m.projectID }
+func (m *InboundMailbox) GetHost() string              { return m.host }
+func (m *InboundMailbox) GetPort() int                 { return m.port }
+func (m *InboundMailbox) GetEnableSSL() bool           { return m.enableSSL }
+func (m *InboundMailbox) GetFolder() string            { return m.folder }
+func (m *InboundMailbox) GetUseIdle() bool             { return m.useIdle }
+func (m *InboundMailbox) GetUIDValidity() uint32       { return m.uidValidity }
+func (m *InboundMailbox) GetLastUID() uint32           { return m.lastUID }
+func (m *InboundMailbox) GetLastError() string         { return m.lastError }
+func (m *InboundMailbox) GetCreatedAt() time.Time      { return m.createdAt }
+
+func (m *InboundMailbox) SetLastError(lastError string)    { m.lastError = lastError }
+func (m *InboundMailbox) SetCreatedAt(createdAt time.Time) { m.createdAt = createdAt }
+
+// SetCursor records the UID validity of the folder and the last processed UID
+func (m *InboundMailbox) SetCursor(uidValidity, lastUID uint32) {
+	m.uidValidity = uidValidity
+	m.lastUID = lastUID
+}
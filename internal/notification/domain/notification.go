@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"platform/pkg/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Channel is a way to reach a recipient
+type Channel string
+
+const (
+	EmailChannel   Channel = "email"
+	SmsChannel     Channel = "sms"
+	WebhookChannel Channel = "webhook"
+)
+
+var Channels = []Channel{EmailChannel, SmsChannel, WebhookChannel}
+
+// UnsubscribeURLKey is the data key holding the unsubscribe link of newsletters, templates can use it as
+// %UnsubscribeURL% and the email channel adds it to the List-Unsubscribe header
+const UnsubscribeURLKey = "UnsubscribeURL"
+
+func (c Channel) IsValid() bool {
+	return c == EmailChannel || c == SmsChannel || c == WebhookChannel
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending DeliveryStatus = "pending"
+	DeliverySent    DeliveryStatus = "sent"
+	DeliveryFailed  DeliveryStatus = "failed"
+
+	// DeliverySkipped is used when the recipient opted out of the channel or cannot be reached on it
+	DeliverySkipped DeliveryStatus = "skipped"
+)
+
+// Delivery is the outcome of a notification on one channel
+type Delivery struct {
+	Channel           Channel
+	Status            DeliveryStatus
+	ProviderMessageID string
+	Error             string
+	UpdatedAt         time.Time
+}
+
+// Notification is a message sent to a recipient on one or more channels from the same template
+type Notification struct {
+	domain.AggregateRoot
+	projectID    uuid.UUID
+	recipient    string
+	templateName string
+	language     string
+	data         map[string]string
+	deliveries   []Delivery
+	createdAt    time.Time
+}
+
+func NewNotification(projectID uuid.UUID, recipient, templateName, language string, data map[string]string) *Notification {
+	return &Notification{
+		AggregateRoot: domain.NewAggregateRoot(uuid.New()),
+		projectID:     projectID,
+		recipient:     recipient,
+		templateName:  templateName,
+		language:      language,
+		data:          data,
+		deliveries:    make([]Delivery, 0),
+		createdAt:     time.Now(),
+	}
+}
+
+// GETTERS
+func (n *Notification) GetProjectID() uuid.UUID    { return n.projectID }
+func (n *Notification) GetRecipient() string       { return n.recipient }
+func (n *Notification) GetTemplateName() string    { return n.templateName }
+func (n *Notification) GetLanguage() string        { return n.language }
+func (n *Notification) GetData() map[string]string { return n.data }
+func (n *Notification) GetDeliveries() []Delivery  { return n.deliveries }
+func (n *Notification) GetCreatedAt() time.Time    { return n.createdAt }
+
+// SETTERS
+func (n *Notification) SetProjectID(projectID uuid.UUID)    { n.projectID = projectID }
+func (n *Notification) SetRecipient(recipient string)       { n.recipient = recipient }
+func (n *Notification) SetTemplateName(templateName string) { n.templateName = templateName }
+func (n *Notification) SetLanguage(language string)         { n.language = language }
+func (n *Notification) SetData(data map[string]string)      { n.data = data }
+func (n *Notification) SetDeliveries(deliveries []Delivery) { n.deliveries = deliveries }
+func (n *Notification) SetCreatedAt(createdAt time.Time)    { n.createdAt = createdAt }
+
+// AddDelivery registers a channel the notification goes to, starting as pending
+func (n *Notification) AddDelivery(channel Channel) {
+	n.deliveries = append(n.deliveries, Delivery{Channel: channel, Status: DeliveryPending, UpdatedAt: time.Now()})
+}
+
+// Skip registers a channel the notification does not go to, with the reason
+func (n *Notification) Skip(channel Channel, reason string) {
+	n.deliveries = append(n.deliveries, Delivery{Channel: channel, Status: DeliverySkipped, Error: reason, UpdatedAt: time.Now()})
+}
+
+func (n *Notification) MarkSent(channel Channel, providerMessageID string) {
+	n.update(channel, func(d *Delivery) {
+		d.Status = DeliverySent
+		d.ProviderMessageID = providerMessageID
+		d.Error = ""
+	})
+}
+
+func (n *Notification) MarkFailed(channel Channel, err error) {
+	n.update(channel, func(d *Delivery) {
+		d.Status = DeliveryFailed
+		d.Error = err.Error()
+	})
+}
+
+// GetDelivery returns the delivery of the channel, or nil if the notification was not sent on it
+func (n *Notification) GetDelivery(channel Channel) *Delivery {
+	for i := range n.deliveries {
+		if n.deliveries[i].Channel == channel {
+			return &n.deliveries[i]
+		}
+	}
+	return nil
+}
+
+func (n *Notification) update(channel Channel, fn func(d *Delivery)) {
+	if d := n.GetDelivery(channel); d != nil {
+		fn(d)
+		d.UpdatedAt = time.Now()
+	}
+}
@@ -11,6 +11,9 @@ const (
 )
 
 type QueuedEmail struct {
+	id             uuid.UUID
+	projectId      uuid.UUID
+	campaignId     *uuid.UUID
 	emailAccountId uuid.UUID
 	to             string
 	replyTo        string
@@ -18,12 +21,16 @@ type QueuedEmail struct {
 	bcc            string
 	subject        string
 	body           string
-	sentAt         time.Time
+	unsubscribeURL string
+	sentAt         *time.Time
 	sentTries      int
+	lastError      string
+	createdAt      time.Time
 }
 
-func NewQueuedEmail(emailAccountId uuid.UUID, to, replyTo, cc, bcc, subject, body string, sentAt time.Time, sentTries int) *QueuedEmail {
+func NewQueuedEmail(emailAccountId uuid.UUID, to, replyTo, cc, bcc, subject, body string, sentAt *time.Time, sentTries int) *QueuedEmail {
 	return &QueuedEmail{
+		id:             uuid.New(),
 		emailAccountId: emailAccountId,
 		to:             to,
 		replyTo:        replyTo,
@@ -33,5 +40,60 @@ func NewQueuedEmail(emailAccountId uuid.UUID, to, replyTo, cc, bcc, subject, bod
 		body:           body,
 		sentAt:         sentAt,
 		sentTries:      sentTries,
+		createdAt:      time.Now(),
 	}
 }
+
+// NewCampaignEmail queues an email of a campaign for one subscriber
+func NewCampaignEmail(campaign *Campaign, to, subject, body, unsubscribeURL string) *QueuedEmail {
+	campaignID := campaign.ID
+	qe := NewQueuedEmail(campaign.GetEmailAccountID(), to, "", "", "", subject, body, nil, 0)
+	qe.projectId = campaign.GetProjectID()
+	qe.campaignId = &campaignID
+	qe.unsubscribeURL = unsubscribeURL
+	return qe
+}
+
+// GETTERS
+func (qe *QueuedEmail) GetID() uuid.UUID             { return qe.id }
+func (qe *QueuedEmail) GetProjectID() uuid.UUID      { return qe.projectId }
+func (qe *QueuedEmail) GetCampaignID() *uuid.UUID    { return qe.campaignId }
+func (qe *QueuedEmail) GetEmailAccountID() uuid.UUID { return qe.emailAccountId }
+func (qe *QueuedEmail) GetTo() string                { return qe.to }
+func (qe *QueuedEmail) GetReplyTo() string           { return qe.replyTo }
+func (qe *QueuedEmail) GetCc() string                { return qe.cc }
+func (qe *QueuedEmail) GetBcc() string               { return qe.bcc }
+func (qe *QueuedEmail) GetSubject() string           { return qe.subject }
+func (qe *QueuedEmail) GetBody() string              { return qe.body }
+func (qe *QueuedEmail) GetUnsubscribeURL() string    { return qe.unsubscribeURL }
+func (qe *QueuedEmail) GetSentAt() *time.Time        { return qe.sentAt }
+func (qe *QueuedEmail) GetSentTries() int            { return qe.sentTries }
+func (qe *QueuedEmail) GetLastError() string         { return qe.lastError }
+func (qe *QueuedEmail) GetCreatedAt() time.Time      { return qe.createdAt }
+
+// SETTERS
+func (qe *QueuedEmail) SetID(id uuid.UUID)                  { qe.id = id }
+func (qe *QueuedEmail) SetProjectID(projectId uuid.UUID)    { qe.projectId = projectId }
+func (qe *QueuedEmail) SetCampaignID(campaignId *uuid.UUID) { qe.campaignId = campaignId }
+func (qe *QueuedEmail) SetUnsubscribeURL(url string)        { qe.unsubscribeURL = url }
+func (qe *QueuedEmail) SetLastError(lastError string)       { qe.lastError = lastError }
+func (qe *QueuedEmail) SetCreatedAt(createdAt time.Time)    { qe.createdAt = createdAt }
+
+func (qe *QueuedEmail) MarkSent() {
+	now := time.Now()
+	qe.sentAt = &now
+	qe.sentTries++
+	qe.lastError = ""
+}
+
+// MarkFailed records a failed try, it returns true when the email will not be tried again
+func (qe *QueuedEmail) MarkFailed(err error) bool {
+	qe.sentTries++
+	qe.lastError = err.Error()
+	return qe.sentTries >= MAX_SENT_TRIES
+}
+
+// GiveUp stops trying to send the email, e.g. after a permanent failure
+func (qe *QueuedEmail) GiveUp() {
+	qe.sentTries = max(qe.sentTries, MAX_SENT_TRIES)
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	vo "platform/pkg/domain/value_object"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecipientPreference holds how a recipient of a project wants to be notified. Recipients are identified by
+// their email address, the other addresses are optional.
+type RecipientPreference struct {
+	projectID  uuid.UUID
+	email      vo.Email
+	phone      *vo.PhoneNumber
+	webhookURL string
+	language   string
+	channels   []Channel
+	updatedAt  time.Time
+}
+
+func NewRecipientPreference(projectID uuid.UUID, email vo.Email, language string, channels []Channel) *RecipientPreference {
+	return &RecipientPreference{
+		projectID: projectID,
+		email:     email,
+		language:  language,
+		channels:  channels,
+		updatedAt: time.Now(),
+	}
+}
+
+// DefaultRecipientPreference is used for recipients without preferences: they only receive emails
+func DefaultRecipientPreference(projectID uuid.UUID, email vo.Email) *RecipientPreference {
+	return NewRecipientPreference(projectID, email, "", []Channel{EmailChannel})
+}
+
+// GETTERS
+func (rp *RecipientPreference) GetProjectID() uuid.UUID   { return rp.projectID }
+func (rp *RecipientPreference) GetEmail() vo.Email        { return rp.email }
+func (rp *RecipientPreference) GetPhone() *vo.PhoneNumber { return rp.phone }
+func (rp *RecipientPreference) GetWebhookURL() string     { return rp.webhookURL }
+func (rp *RecipientPreference) GetLanguage() string       { return rp.language }
+func (rp *RecipientPreference) GetChannels() []Channel    { return rp.channels }
+func (rp *RecipientPreference) GetUpdatedAt() time.Time   { return rp.updatedAt }
+
+// SETTERS
+func (rp *RecipientPreference) SetPhone(phone *vo.PhoneNumber)   { rp.phone = phone }
+func (rp *RecipientPreference) SetWebhookURL(webhookURL string)  { rp.webhookURL = webhookURL }
+func (rp *RecipientPreference) SetLanguage(language string)      { rp.language = language }
+func (rp *RecipientPreference) SetChannels(channels []Channel)   { rp.channels = channels }
+func (rp *RecipientPreference) SetUpdatedAt(updatedAt time.Time) { rp.updatedAt = updatedAt }
+
+func (rp *RecipientPreference) OptedIn(channel Channel) bool {
+	return slices.Contains(rp.channels, channel)
+}
+
+// Reachable tells if the recipient has an address for the channel
+func (rp *RecipientPreference) Reachable(channel Channel) bool {
+	switch channel {
+	case EmailChannel:
+		return rp.email.Value() != ""
+	case SmsChannel:
+		return rp.phone != nil
+	case WebhookChannel:
+		return rp.webhookURL != ""
+	}
+	return false
+}
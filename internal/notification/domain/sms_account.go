@@ -0,0 +1,60 @@
+package domain
+
+import (
+	voInternal "platform/internal/notification/domain/value_object"
+	"platform/pkg/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	_       = iota
+	Twilio  // Twilio Programmable Messaging API
+	Vonage  // Vonage (Nexmo) SMS API
+	FakeSms // Writes messages to the log instead of sending them, for local development
+)
+
+type SmsAccount struct {
+	domain.AggregateRoot
+	projectID   uuid.UUID
+	providerID  int
+	sender      string
+	credentials *voInternal.TraditionalCredentials
+	createdAt   time.Time
+	templates   []SmsTemplate
+}
+
+func NewSmsAccount(id, projectID uuid.UUID, providerID int, sender string) *SmsAccount {
+	return &SmsAccount{
+		AggregateRoot: domain.NewAggregateRoot(id),
+		projectID:     projectID,
+		providerID:    providerID,
+		sender:        sender,
+		createdAt:     time.Now(),
+		templates:     make([]SmsTemplate, 0),
+	}
+}
+
+// GETTERS
+func (sa *SmsAccount) GetProjectID() uuid.UUID     { return sa.projectID }
+func (sa *SmsAccount) GetProviderID() int          { return sa.providerID }
+func (sa *SmsAccount) GetSender() string           { return sa.sender }
+func (sa *SmsAccount) GetCreatedAt() time.Time     { return sa.createdAt }
+func (sa *SmsAccount) GetTemplates() []SmsTemplate { return sa.templates }
+func (sa *SmsAccount) GetCredentials() *voInternal.TraditionalCredentials {
+	return sa.credentials
+}
+
+// SETTERS
+func (sa *SmsAccount) SetProjectID(id uuid.UUID)            { sa.projectID = id }
+func (sa *SmsAccount) SetProviderID(providerID int)         { sa.providerID = providerID }
+func (sa *SmsAccount) SetSender(sender string)              { sa.sender = sender }
+func (sa *SmsAccount) SetCreatedAt(createdAt time.Time)     { sa.createdAt = createdAt }
+func (sa *SmsAccount) SetTemplates(templates []SmsTemplate) { sa.templates = templates }
+
+// SetCredentials stores the provider credentials: the account SID and auth token for Twilio,
+// the API key and secret for Vonage. The secret must already be encrypted.
+func (sa *SmsAccount) SetCredentials(credentials *voInternal.TraditionalCredentials) {
+	sa.credentials = credentials
+}
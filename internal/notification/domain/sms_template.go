@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+type SmsTemplateName string
+
+const (
+	PHONE_VERIFICATION SmsTemplateName = "PHONE_VERIFICATION"
+)
+
+type SmsTemplate struct {
+	smsAccountId uuid.UUID
+	name         SmsTemplateName
+	language     string
+	body         string
+}
+
+func NewSmsTemplate(smsAccountId uuid.UUID, name SmsTemplateName, language, body string) *SmsTemplate {
+	return &SmsTemplate{
+		smsAccountId: smsAccountId,
+		name:         name,
+		language:     language,
+		body:         body,
+	}
+}
+
+func (st SmsTemplate) GetSmsAccountID() uuid.UUID { return st.smsAccountId }
+func (st SmsTemplate) GetName() SmsTemplateName   { return st.name }
+func (st SmsTemplate) GetLanguage() string        { return st.language }
+func (st SmsTemplate) GetBody() string            { return st.body }
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"platform/internal/shared"
 	vo "platform/pkg/domain/value_object"
 	"time"
@@ -8,18 +9,55 @@ import (
 	"github.com/google/uuid"
 )
 
+var ErrSubscriptionAlreadyConfirmed = errors.New("subscription already confirmed")
+
+type SubscriptionStatus string
+
+const (
+	// SubscriptionPending waits for the subscriber to click the link of the confirmation email (double opt-in)
+	SubscriptionPending   SubscriptionStatus = "pending"
+	SubscriptionConfirmed SubscriptionStatus = "confirmed"
+)
+
 type Subscription struct {
-	projectId uuid.UUID
-	email     vo.Email
-	language  shared.Language
-	createdAt time.Time
+	projectId   uuid.UUID
+	email       vo.Email
+	language    shared.Language
+	status      SubscriptionStatus
+	confirmedAt *time.Time
+	createdAt   time.Time
 }
 
+// NewSubscription creates a pending subscription, newsletters are only sent once it is confirmed
 func NewSubscription(projectId uuid.UUID, email vo.Email, language shared.Language) *Subscription {
 	return &Subscription{
 		projectId: projectId,
 		email:     email,
 		language:  language,
+		status:    SubscriptionPending,
 		createdAt: time.Now(),
 	}
 }
+
+func (s *Subscription) GetProjectID() uuid.UUID       { return s.projectId }
+func (s *Subscription) GetEmail() vo.Email            { return s.email }
+func (s *Subscription) GetLanguage() shared.Language  { return s.language }
+func (s *Subscription) GetStatus() SubscriptionStatus { return s.status }
+func (s *Subscription) GetConfirmedAt() *time.Time    { return s.confirmedAt }
+func (s *Subscription) GetCreatedAt() time.Time       { return s.createdAt }
+func (s *Subscription) IsConfirmed() bool             { return s.status == SubscriptionConfirmed }
+
+func (s *Subscription) SetLanguage(language shared.Language)  { s.language = language }
+func (s *Subscription) SetStatus(status SubscriptionStatus)   { s.status = status }
+func (s *Subscription) SetConfirmedAt(confirmedAt *time.Time) { s.confirmedAt = confirmedAt }
+func (s *Subscription) SetCreatedAt(createdAt time.Time)      { s.createdAt = createdAt }
+
+func (s *Subscription) Confirm() error {
+	if s.IsConfirmed() {
+		return ErrSubscriptionAlreadyConfirmed
+	}
+	now := time.Now()
+	s.status = SubscriptionConfirmed
+	s.confirmedAt = &now
+	return nil
+}
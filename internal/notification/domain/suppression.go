@@ -0,0 +1,43 @@
+package domain
+
+import (
+	vo "platform/pkg/domain/value_object"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SuppressionReason string
+
+const (
+	// SuppressionHardBounce is recorded when the server rejects the address for good, e.g. 5.1.1 unknown user
+	SuppressionHardBounce SuppressionReason = "hard_bounce"
+	SuppressionManual     SuppressionReason = "manual"
+)
+
+// Suppression blocks the emails sent to an address of a project
+type Suppression struct {
+	projectID  uuid.UUID
+	email      vo.Email
+	reason     SuppressionReason
+	diagnostic string
+	createdAt  time.Time
+}
+
+func NewSuppression(projectID uuid.UUID, email vo.Email, reason SuppressionReason, diagnostic string) *Suppression {
+	return &Suppression{
+		projectID:  projectID,
+		email:      email,
+		reason:     reason,
+		diagnostic: diagnostic,
+		createdAt:  time.Now(),
+	}
+}
+
+func (s *Suppression) GetProjectID() uuid.UUID      { return s.projectID }
+func (s *Suppression) GetEmail() vo.Email           { return s.email }
+func (s *Suppression) GetReason() SuppressionReason { return s.reason }
+func (s *Suppression) GetDiagnostic() string        { return s.diagnostic }
+func (s *Suppression) GetCreatedAt() time.Time      { return s.createdAt }
+
+func (s *Suppression) SetCreatedAt(createdAt time.Time) { s.createdAt = createdAt }
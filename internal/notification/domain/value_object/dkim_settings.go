@@ -0,0 +1,34 @@
+package vo
+
+import "platform/pkg/domain"
+
+const (
+	DkimRSA     = "rsa"     // RSA-SHA256 signatures
+	DkimEd25519 = "ed25519" // Ed25519-SHA256 signatures (RFC 8463)
+)
+
+// DkimSettings is the DKIM configuration of an email account, privateKey is the encrypted PEM private key
+type DkimSettings struct {
+	domain.BaseValueObject
+	domain     string
+	selector   string
+	algorithm  string
+	privateKey string
+}
+
+func NewDkimSettings(domain, selector, algorithm, privateKey string) *DkimSettings {
+	return &DkimSettings{
+		domain:     domain,
+		selector:   selector,
+		algorithm:  algorithm,
+		privateKey: privateKey,
+	}
+}
+
+func (e *DkimSettings) GetAtomicValues() []interface{} {
+	return []any{e.domain, e.selector, e.algorithm, e.privateKey}
+}
+
+func (e *DkimSettings) Settings() (domain, selector, algorithm, privateKey string) {
+	return e.domain, e.selector, e.algorithm, e.privateKey
+}
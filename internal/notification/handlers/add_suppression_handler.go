@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/commands"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type AddSuppressionRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Email     string    `reqHeader:"-" params:"-" query:"-" json:"email" validate:"required,email"`
+	Reason    string    `reqHeader:"-" params:"-" query:"-" json:"reason" validate:"max=255"`
+}
+
+type AddSuppressionResponse struct {
+	Email string `json:"email"`
+}
+
+type AddSuppressionHandler struct{}
+
+func (h *AddSuppressionHandler) Handle(ctx context.Context, req *AddSuppressionRequest) (*baseHandler.Response[AddSuppressionResponse], error) {
+	// STEP-1: Suppress the address
+	command := commands.AddSuppressionCommand{Email: req.Email, Reason: req.Reason}
+	_, err := mediator.Send[*commands.AddSuppressionCommand, *commands.AddSuppressionCommandResponse](ctx, &command)
+	if err != nil {
+		return baseHandler.FailedResponse[AddSuppressionResponse](err), nil
+	}
+
+	// STEP-2: Return hateoas links to user
+	respData := AddSuppressionResponse{Email: req.Email}
+	response := baseHandler.CreatedResponse(&respData)
+	response.Links = hateoasLinksForSuppression(ctx)
+	return response, nil
+}
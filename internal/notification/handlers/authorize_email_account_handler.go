@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/mediatr/commands"
+	email_provider "platform/internal/notification/services/emailProvider"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuthorizeEmailAccountRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Email     string    `reqHeader:"-" params:"email" query:"-" json:"-" validate:"required,email"`
+}
+
+type AuthorizeEmailAccountResponse struct {
+	AuthorizationURL string    `json:"authorization_url"`
+	ExpireAt         time.Time `json:"expire_at"`
+}
+
+type AuthorizeEmailAccountHandler struct{}
+
+func (h *AuthorizeEmailAccountHandler) Handle(ctx context.Context, req *AuthorizeEmailAccountRequest) (*baseHandler.Response[AuthorizeEmailAccountResponse], error) {
+	// STEP-1: Issue the authorization URL of the provider
+	command := commands.AuthorizeEmailAccountCommand{Email: req.Email}
+	resp, err := mediator.Send[*commands.AuthorizeEmailAccountCommand, *commands.AuthorizeEmailAccountCommandResponse](ctx, &command)
+	switch {
+	case errors.Is(err, shared.ErrNotFound):
+		return baseHandler.NotFoundResponse[AuthorizeEmailAccountResponse](), nil
+	case errors.Is(err, email_provider.ErrNotOAuth2Account), errors.Is(err, email_provider.ErrOAuth2CredentialsMissing):
+		return baseHandler.FailedResponse[AuthorizeEmailAccountResponse](err), nil
+	case err != nil:
+		return nil, err
+	}
+
+	// STEP-2: Return data and hateoas links to user
+	respData := AuthorizeEmailAccountResponse{AuthorizationURL: resp.AuthorizationURL, ExpireAt: resp.ExpireAt}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		Href("nf:authorize", shared.HALLink{Href: resp.AuthorizationURL, Method: "GET", Title: "Grant the access on the consent screen of the provider"}).
+		Route("self", "notification.email-accounts.get", "View this email account", req.Email).
+		Build()
+	return response, nil
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/mediatr/commands"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type ChangeCampaignStatusRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" json:"-" validate:"required,uuid"`
+	ID        uuid.UUID `reqHeader:"-" params:"id" json:"-" validate:"required,uuid"`
+}
+
+type ChangeCampaignStatusResponse struct {
+	Status string `json:"status"`
+}
+
+// ChangeCampaignStatusHandler serves the pause, resume and cancel endpoints of campaigns
+type ChangeCampaignStatusHandler struct {
+	Action commands.CampaignAction
+}
+
+func (h *ChangeCampaignStatusHandler) Handle(ctx context.Context, req *ChangeCampaignStatusRequest) (*baseHandler.Response[ChangeCampaignStatusResponse], error) {
+	// STEP-1: Change the status of the campaign
+	command := commands.ChangeCampaignStatusCommand{ID: req.ID, Action: h.Action}
+	resp, err := mediator.Send[*commands.ChangeCampaignStatusCommand, *commands.ChangeCampaignStatusCommandResponse](ctx, &command)
+	switch {
+	case errors.Is(err, shared.ErrNotFound):
+		return baseHandler.NotFoundResponse[ChangeCampaignStatusResponse](), nil
+	case errors.Is(err, domain.ErrInvalidCampaignTransition):
+		return baseHandler.ConflictResponse[ChangeCampaignStatusResponse](err), nil
+	case err != nil:
+		return nil, err
+	}
+
+	// STEP-2: Return data and hateoas links to user
+	response := baseHandler.SuccessResponse(&ChangeCampaignStatusResponse{Status: resp.Status})
+	response.Links = hateoasLinksForCampaign(ctx, req.ID)
+	return response, nil
+}
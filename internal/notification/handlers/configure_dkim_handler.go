@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/mediatr/commands"
+	email_sender "platform/internal/notification/services/emailSender"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type ConfigureDkimRequest struct {
+	ProjectID  uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Email      string    `reqHeader:"-" params:"email" query:"-" json:"-" validate:"required,email"`
+	Domain     string    `reqHeader:"-" params:"-" query:"-" json:"domain" validate:"required,fqdn,max=253"`
+	Selector   string    `reqHeader:"-" params:"-" query:"-" json:"selector" validate:"required,max=63"`
+	Algorithm  string    `reqHeader:"-" params:"-" query:"-" json:"algorithm" validate:"required,oneof=rsa ed25519"`
+	PrivateKey string    `reqHeader:"-" params:"-" query:"-" json:"private_key"`
+}
+
+type ConfigureDkimResponse struct {
+	RecordName  string `json:"record_name"`
+	RecordValue string `json:"record_value"`
+	ZoneEntry   string `json:"zone_entry"`
+}
+
+type ConfigureDkimHandler struct{}
+
+func (h *ConfigureDkimHandler) Handle(ctx context.Context, req *ConfigureDkimRequest) (*baseHandler.Response[ConfigureDkimResponse], error) {
+	// STEP-1: Save the DKIM settings of the email account
+	command := commands.ConfigureDkimCommand{
+		Email:      req.Email,
+		Domain:     req.Domain,
+		Selector:   req.Selector,
+		Algorithm:  req.Algorithm,
+		PrivateKey: req.PrivateKey,
+	}
+	resp, err := mediator.Send[*commands.ConfigureDkimCommand, *commands.ConfigureDkimCommandResponse](ctx, &command)
+	switch {
+	case errors.Is(err, shared.ErrNotFound):
+		return baseHandler.NotFoundResponse[ConfigureDkimResponse](), nil
+	case errors.Is(err, email_sender.ErrInvalidDkimKey), errors.Is(err, email_sender.ErrUnsupportedDkimKey), errors.Is(err, email_sender.ErrDkimAlgorithmMismatch),
+		errors.Is(err, email_sender.ErrInvalidDkimDomain), errors.Is(err, email_sender.ErrInvalidDkimSelector):
+		return baseHandler.FailedResponse[ConfigureDkimResponse](err), nil
+	case err != nil:
+		return nil, err
+	}
+
+	// STEP-2: Return the DNS record to publish and hateoas links to user
+	respData := ConfigureDkimResponse{
+		RecordName:  resp.RecordName,
+		RecordValue: resp.RecordValue,
+		ZoneEntry:   email_sender.DKIMZoneEntry(resp.RecordName, resp.RecordValue),
+	}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = hateoasLinksForDkim(ctx, req.Email)
+	return response, nil
+}
+
+func hateoasLinksForDkim(ctx context.Context, email string) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		Route("nf:delete", "notification.email-accounts.dkim.delete", "Stop signing the messages of this email account", email).
+		Route("nf:email-account", "notification.email-accounts.get", "View this email account", email).
+		Route("self", "notification.email-accounts.dkim.get", "View the DNS record of the DKIM key", email).
+		Route("nf:update", "notification.email-accounts.dkim.configure", "Change the DKIM settings", email).
+		Build()
+}
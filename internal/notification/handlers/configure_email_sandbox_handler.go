@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/mediatr/commands"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type ConfigureEmailSandboxRequest struct {
+	ProjectID  uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Mode       string    `reqHeader:"-" params:"-" query:"-" json:"mode" validate:"required,oneof=off capture allow_list"`
+	AllowList  []string  `reqHeader:"-" params:"-" query:"-" json:"allow_list" validate:"omitempty,dive,max=255"`
+	RedirectTo string    `reqHeader:"-" params:"-" query:"-" json:"redirect_to" validate:"omitempty,email"`
+}
+
+type ConfigureEmailSandboxResponse struct{}
+
+type ConfigureEmailSandboxHandler struct{}
+
+func (h *ConfigureEmailSandboxHandler) Handle(ctx context.Context, req *ConfigureEmailSandboxRequest) (*baseHandler.Response[ConfigureEmailSandboxResponse], error) {
+	// STEP-1: Save the sandbox settings of the project
+	command := commands.ConfigureEmailSandboxCommand{
+		Mode:       req.Mode,
+		AllowList:  req.AllowList,
+		RedirectTo: req.RedirectTo,
+	}
+	_, err := mediator.Send[*commands.ConfigureEmailSandboxCommand, *commands.ConfigureEmailSandboxCommandResponse](ctx, &command)
+	if errors.Is(err, domain.ErrSandboxEnforced) {
+		return baseHandler.FailedResponse[ConfigureEmailSandboxResponse](err), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Return hateoas links to user
+	respData := ConfigureEmailSandboxResponse{}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = hateoasLinksForEmailSandbox(ctx)
+	return response, nil
+}
+
+func hateoasLinksForEmailSandbox(ctx context.Context) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		RouteWithQuery("nf:captured-emails", "notification.captured-emails.list", "List the captured messages on the first page", firstPage).
+		Route("nf:delete", "notification.email-sandbox.delete", "Use the sandbox default of the environment").
+		Route("self", "notification.email-sandbox.get", "View the sandbox settings of the project").
+		Route("nf:update", "notification.email-sandbox.configure", "Change the sandbox settings of the project").
+		Build()
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/mediatr/commands"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type ConfigureEmailTrackingRequest struct {
+	ProjectID   uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Email       string    `reqHeader:"-" params:"email" query:"-" json:"-" validate:"required,email"`
+	TrackOpens  bool      `reqHeader:"-" params:"-" query:"-" json:"track_opens"`
+	TrackClicks bool      `reqHeader:"-" params:"-" query:"-" json:"track_clicks"`
+}
+
+type ConfigureEmailTrackingResponse struct {
+	TrackOpens  bool `json:"track_opens"`
+	TrackClicks bool `json:"track_clicks"`
+}
+
+type ConfigureEmailTrackingHandler struct{}
+
+func (h *ConfigureEmailTrackingHandler) Handle(ctx context.Context, req *ConfigureEmailTrackingRequest) (*baseHandler.Response[ConfigureEmailTrackingResponse], error) {
+	// STEP-1: Save the tracking settings of the email account
+	command := commands.ConfigureEmailTrackingCommand{
+		Email:       req.Email,
+		TrackOpens:  req.TrackOpens,
+		TrackClicks: req.TrackClicks,
+	}
+	resp, err := mediator.Send[*commands.ConfigureEmailTrackingCommand, *commands.ConfigureEmailTrackingCommandResponse](ctx, &command)
+	switch {
+	case errors.Is(err, shared.ErrNotFound):
+		return baseHandler.NotFoundResponse[ConfigureEmailTrackingResponse](), nil
+	case err != nil:
+		return nil, err
+	}
+
+	// STEP-2: Return data and hateoas links to user
+	respData := ConfigureEmailTrackingResponse{TrackOpens: resp.TrackOpens, TrackClicks: resp.TrackClicks}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		RouteWithQuery("nf:deliveries", "notification.deliveries.list", "List the delivery logs on the first page", firstPage).
+		Route("nf:email-account", "notification.email-accounts.get", "View this email account", req.Email).
+		Build()
+	return response, nil
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/mediatr/commands"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type ConfigureInboundMailboxRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Email     string    `reqHeader:"-" params:"email" query:"-" json:"-" validate:"required,email"`
+	Host      string    `reqHeader:"-" params:"-" query:"-" json:"host" validate:"omitempty,hostname|ip,max=255"`
+	Port      int       `reqHeader:"-" params:"-" query:"-" json:"port" validate:"omitempty,min=1,max=65535"`
+	EnableSSL bool      `reqHeader:"-" params:"-" query:"-" json:"enable_ssl"`
+	Folder    string    `reqHeader:"-" params:"-" query:"-" json:"folder" validate:"max=255"`
+	UseIdle   bool      `reqHeader:"-" params:"-" query:"-" json:"use_idle"`
+}
+
+type ConfigureInboundMailboxResponse struct{}
+
+type ConfigureInboundMailboxHandler struct{}
+
+func (h *ConfigureInboundMailboxHandler) Handle(ctx context.Context, req *ConfigureInboundMailboxRequest) (*baseHandler.Response[ConfigureInboundMailboxResponse], error) {
+	// STEP-1: Save the IMAP settings of the email account
+	command := commands.ConfigureInboundMailboxCommand{
+		Email:     req.Email,
+		Host:      req.Host,
+		Port:      req.Port,
+		EnableSSL: req.EnableSSL,
+		Folder:    req.Folder,
+		UseIdle:   req.UseIdle,
+	}
+	_, err := mediator.Send[*commands.ConfigureInboundMailboxCommand, *commands.ConfigureInboundMailboxCommandResponse](ctx, &command)
+	switch {
+	case errors.Is(err, shared.ErrNotFound):
+		return baseHandler.NotFoundResponse[ConfigureInboundMailboxResponse](), nil
+	case errors.Is(err, commands.ErrInboundHostRequired):
+		return baseHandler.FailedResponse[ConfigureInboundMailboxResponse](err), nil
+	case err != nil:
+		return nil, err
+	}
+
+	// STEP-2: Return hateoas links to user
+	respData := ConfigureInboundMailboxResponse{}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = hateoasLinksForInboundMailbox(ctx, req.Email)
+	return response, nil
+}
+
+func hateoasLinksForInboundMailbox(ctx context.Context, email string) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		Route("nf:delete", "notification.email-accounts.inbound.delete", "Stop receiving the messages of this email account", email).
+		Route("nf:email-account", "notification.email-accounts.get", "View this email account", email).
+		Route("self", "notification.email-accounts.inbound.get", "View the inbound mailbox and the state of its reader", email).
+		Route("nf:update", "notification.email-accounts.inbound.configure", "Change the IMAP settings", email).
+		Build()
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/mediatr/commands"
+	subscription_token "platform/internal/notification/services/subscriptionToken"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+)
+
+type ConfirmSubscriptionRequest struct {
+	Token string `reqHeader:"-" params:"-" query:"token" json:"-" validate:"required"`
+}
+
+type ConfirmSubscriptionResponse struct {
+	Email string `json:"email"`
+}
+
+type ConfirmSubscriptionHandler struct{}
+
+func (h *ConfirmSubscriptionHandler) Handle(ctx context.Context, req *ConfirmSubscriptionRequest) (*baseHandler.Response[ConfirmSubscriptionResponse], error) {
+	// STEP-1: Confirm the subscription
+	command := commands.ConfirmSubscriptionCommand{Token: req.Token}
+	resp, err := mediator.Send[*commands.ConfirmSubscriptionCommand, *commands.ConfirmSubscriptionCommandResponse](ctx, &command)
+	switch {
+	case errors.Is(err, shared.ErrNotFound):
+		return baseHandler.NotFoundResponse[ConfirmSubscriptionResponse](), nil
+	case errors.Is(err, subscription_token.ErrInvalidToken), errors.Is(err, subscription_token.ErrTokenExpired),
+		errors.Is(err, domain.ErrSubscriptionAlreadyConfirmed):
+		return baseHandler.FailedResponse[ConfirmSubscriptionResponse](err), nil
+	case err != nil:
+		return nil, err
+	}
+
+	respData := ConfirmSubscriptionResponse{Email: resp.Email}
+	return baseHandler.SuccessResponse(&respData), nil
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/mediatr/commands"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateCampaignRequest struct {
+	ProjectID    uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Name         string    `reqHeader:"-" params:"-" query:"-" json:"name" validate:"required,max=128"`
+	TemplateName string    `reqHeader:"-" params:"-" query:"-" json:"template_name" validate:"required,max=128"`
+	EmailAccount string    `reqHeader:"-" params:"-" query:"-" json:"email_account" validate:"omitempty,email"`
+	Languages    []string  `reqHeader:"-" params:"-" query:"-" json:"languages" validate:"omitempty,dive,max=8"`
+	ScheduledAt  time.Time `reqHeader:"-" params:"-" query:"-" json:"scheduled_at"`
+}
+
+type CreateCampaignResponse struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type CreateCampaignHandler struct{}
+
+func (h *CreateCampaignHandler) Handle(ctx context.Context, req *CreateCampaignRequest) (*baseHandler.Response[CreateCampaignResponse], error) {
+	// STEP-1: Create the campaign
+	command := commands.CreateCampaignCommand{
+		Name:         req.Name,
+		TemplateName: req.TemplateName,
+		EmailAccount: req.EmailAccount,
+		Languages:    req.Languages,
+		ScheduledAt:  req.ScheduledAt,
+	}
+	resp, err := mediator.Send[*commands.CreateCampaignCommand, *commands.CreateCampaignCommandResponse](ctx, &command)
+	if errors.Is(err, commands.ErrEmailAccountNotFound) {
+		return baseHandler.FailedResponse[CreateCampaignResponse](err), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Return data and hateoas links to user
+	response := baseHandler.CreatedResponse(&CreateCampaignResponse{ID: resp.ID})
+	response.Links = hateoasLinksForCampaign(ctx, resp.ID)
+	return response, nil
+}
+
+func hateoasLinksForCampaign(ctx context.Context, id uuid.UUID) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		Route("self", "notification.campaigns.get", "View the progress of this campaign", id.String()).
+		Route("nf:pause", "notification.campaigns.pause", "Pause this campaign", id.String()).
+		Route("nf:resume", "notification.campaigns.resume", "Resume this campaign", id.String()).
+		Route("nf:cancel", "notification.campaigns.cancel", "Cancel this campaign", id.String()).
+		Build()
+}
@@ -3,10 +3,10 @@ package handlers
 import (
 	"context"
 	"errors"
-	"fmt"
 	"platform/internal/notification/mediatr/commands"
 	event_notification "platform/internal/notification/mediatr/notifications"
 	"platform/internal/notification/mediatr/queries"
+	email_provider "platform/internal/notification/services/emailProvider"
 	"platform/internal/shared"
 	baseHandler "platform/internal/shared/handlers"
 	"platform/pkg/services/mediator"
@@ -18,10 +18,10 @@ type CreateEmailAccountRequest struct {
 	ProjectID    uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid4"`
 	Email        string    `reqHeader:"-" params:"-" query:"-" json:"email" validate:"required,email"`
 	DisplayName  string    `reqHeader:"-" params:"-" query:"-" json:"display_name" validate:"required,max=255"`
-	Host         string    `reqHeader:"-" params:"-" query:"-" json:"host" validate:"required,hostname,max=255"`
-	Port         int       `reqHeader:"-" params:"-" query:"-" json:"port" validate:"required,min=1,max=65535"`
+	Host         string    `reqHeader:"-" params:"-" query:"-" json:"host" validate:"omitempty,hostname,max=255"`
+	Port         int       `reqHeader:"-" params:"-" query:"-" json:"port" validate:"omitempty,min=1,max=65535"`
 	EnableSSL    bool      `reqHeader:"-" params:"-" query:"-" json:"enable_ssl"`
-	TypeID       int       `reqHeader:"-" params:"-" query:"-" json:"type_id" validate:"required,oneof=1 2 3"`
+	TypeID       int       `reqHeader:"-" params:"-" query:"-" json:"type_id" validate:"required,min=1"`
 	Username     string    `reqHeader:"-" params:"-" query:"-" json:"username"`
 	Password     string    `reqHeader:"-" params:"-" query:"-" json:"password"`
 	ClientID     string    `reqHeader:"-" params:"-" query:"-" json:"client_id"`
@@ -60,7 +60,10 @@ func (h *CreateEmailAccountHandler) Handle(ctx context.Context, req *CreateEmail
 		ClientSecret: req.ClientSecret,
 	}
 	_, err = mediator.Send[*commands.CreateEmailAccountCommand, *commands.CreateEmailAccountCommandResponse](ctx, &command)
-	if err != nil {
+	switch {
+	case errors.Is(err, email_provider.ErrUnknownProvider), errors.Is(err, commands.ErrEmailAccountHostRequired):
+		return baseHandler.FailedResponse[CreateEmailAccountResponse](err), nil
+	case err != nil:
 		return nil, err
 	}
 
@@ -71,31 +74,15 @@ func (h *CreateEmailAccountHandler) Handle(ctx context.Context, req *CreateEmail
 	// STEP-4: Return hateoas links to user
 	respData := CreateEmailAccountResponse{}
 	response := baseHandler.CreatedResponse(&respData)
-	response.Links = hateoasLinksForCreate(req.Email)
+	response.Links = hateoasLinksForCreate(ctx, req.Email)
 	return response, nil
 }
 
-func hateoasLinksForCreate(email string) shared.HALLinks {
-	return shared.HALLinks{
-		"delete": {
-			Href:   fmt.Sprintf("/v1/notification/email-accounts/%s", email),
-			Method: "DELETE",
-			Title:  "Delete this email account",
-		},
-		"list": {
-			Href:   "/v1/notification/email-accounts?p=1&ps=10",
-			Method: "GET",
-			Title:  "List all emails on the first page",
-		},
-		"self": {
-			Href:   fmt.Sprintf("/v1/notification/email-accounts/%s", email),
-			Method: "GET",
-			Title:  "View this email account",
-		},
-		"update": {
-			Href:   fmt.Sprintf("/v1/notification/email-accounts/%s", email),
-			Method: "PUT",
-			Title:  "Update this email account",
-		},
-	}
+func hateoasLinksForCreate(ctx context.Context, email string) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		Route("nf:delete", "notification.email-accounts.delete", "Delete this email account", email).
+		RouteWithQuery("nf:list", "notification.email-accounts.list", "List all emails on the first page", firstPage).
+		Route("self", "notification.email-accounts.get", "View this email account", email).
+		Route("nf:update", "notification.email-accounts.update", "Update this email account", email).
+		Build()
 }
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/mediatr/commands"
+	sms_sender "platform/internal/notification/services/smsSender"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type CreateSmsAccountRequest struct {
+	ProjectID  uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid4"`
+	ProviderID int       `reqHeader:"-" params:"-" query:"-" json:"provider_id" validate:"required,oneof=1 2 3"`
+	Sender     string    `reqHeader:"-" params:"-" query:"-" json:"sender" validate:"required,max=32"`
+	Username   string    `reqHeader:"-" params:"-" query:"-" json:"username" validate:"required_unless=ProviderID 3"`
+	Password   string    `reqHeader:"-" params:"-" query:"-" json:"password" validate:"required_unless=ProviderID 3"`
+}
+
+type CreateSmsAccountResponse struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type CreateSmsAccountHandler struct{}
+
+func (h *CreateSmsAccountHandler) Handle(ctx context.Context, req *CreateSmsAccountRequest) (*baseHandler.Response[CreateSmsAccountResponse], error) {
+	// STEP-1: Create a new sms account
+	command := commands.CreateSmsAccountCommand{
+		ProviderID: req.ProviderID,
+		Sender:     req.Sender,
+		Username:   req.Username,
+		Password:   req.Password,
+	}
+	resp, err := mediator.Send[*commands.CreateSmsAccountCommand, *commands.CreateSmsAccountCommandResponse](ctx, &command)
+	switch {
+	case errors.Is(err, sms_sender.ErrFakeProviderDisabled):
+		return baseHandler.FailedResponse[CreateSmsAccountResponse](err), nil
+	case err != nil:
+		return nil, err
+	}
+
+	// STEP-2: Return hateoas links to user
+	respData := CreateSmsAccountResponse{ID: resp.ID}
+	response := baseHandler.CreatedResponse(&respData)
+	response.Links = hateoasLinksForSmsAccountCreate(ctx, resp.ID)
+	return response, nil
+}
+
+func hateoasLinksForSmsAccountCreate(ctx context.Context, id uuid.UUID) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		Route("nf:delete", "notification.sms-accounts.delete", "Delete this sms account", id.String()).
+		Route("nf:list", "notification.sms-accounts.list", "List all sms accounts").
+		Build()
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/mediatr/commands"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type DeleteCapturedEmailRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	ID        uuid.UUID `reqHeader:"-" params:"id" query:"-" json:"-" validate:"required,uuid"`
+}
+
+type DeleteCapturedEmailResponse struct{}
+
+type DeleteCapturedEmailHandler struct{}
+
+func (h *DeleteCapturedEmailHandler) Handle(ctx context.Context, req *DeleteCapturedEmailRequest) (*baseHandler.Response[DeleteCapturedEmailResponse], error) {
+	// STEP-1: Remove the captured message
+	command := commands.DeleteCapturedEmailCommand{ID: req.ID}
+	_, err := mediator.Send[*commands.DeleteCapturedEmailCommand, *commands.DeleteCapturedEmailCommandResponse](ctx, &command)
+	if errors.Is(err, shared.ErrNotFound) {
+		return baseHandler.NotFoundResponse[DeleteCapturedEmailResponse](), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Return hateoas links to user
+	return deletedCapturedEmailResponse(ctx), nil
+}
+
+type DeleteAllCapturedEmailRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+}
+
+type DeleteAllCapturedEmailHandler struct{}
+
+func (h *DeleteAllCapturedEmailHandler) Handle(ctx context.Context, req *DeleteAllCapturedEmailRequest) (*baseHandler.Response[DeleteCapturedEmailResponse], error) {
+	// STEP-1: Remove every captured message of the project
+	command := commands.DeleteCapturedEmailCommand{All: true}
+	_, err := mediator.Send[*commands.DeleteCapturedEmailCommand, *commands.DeleteCapturedEmailCommandResponse](ctx, &command)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Return hateoas links to user
+	return deletedCapturedEmailResponse(ctx), nil
+}
+
+func deletedCapturedEmailResponse(ctx context.Context) *baseHandler.Response[DeleteCapturedEmailResponse] {
+	respData := DeleteCapturedEmailResponse{}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		RouteWithQuery("nf:list", "notification.captured-emails.list", "List the captured messages on the first page", firstPage).
+		Build()
+	return response
+}
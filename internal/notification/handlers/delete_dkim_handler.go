@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/mediatr/commands"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type DeleteDkimRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Email     string    `reqHeader:"-" params:"email" query:"-" json:"-" validate:"required,email"`
+}
+
+type DeleteDkimResponse struct{}
+
+type DeleteDkimHandler struct{}
+
+func (h *DeleteDkimHandler) Handle(ctx context.Context, req *DeleteDkimRequest) (*baseHandler.Response[DeleteDkimResponse], error) {
+	// STEP-1: Remove the DKIM settings of the email account
+	command := commands.DeleteDkimCommand{Email: req.Email}
+	_, err := mediator.Send[*commands.DeleteDkimCommand, *commands.DeleteDkimCommandResponse](ctx, &command)
+	if errors.Is(err, shared.ErrNotFound) {
+		return baseHandler.NotFoundResponse[DeleteDkimResponse](), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Return hateoas links to user
+	respData := DeleteDkimResponse{}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		Route("nf:configure", "notification.email-accounts.dkim.configure", "Sign the messages of this email account with DKIM", req.Email).
+		Build()
+	return response, nil
+}
@@ -37,16 +37,12 @@ func (h *DeleteEmailAccountHandler) Handle(ctx context.Context, req *DeleteEmail
 	// STEP-3: Return hateoas links to client
 	data := DeleteEmailAccountResponse{}
 	response := baseHandler.SuccessResponse(&data)
-	response.Links = hateoasLinksForDelete()
+	response.Links = hateoasLinksForDelete(ctx)
 	return response, nil
 }
 
-func hateoasLinksForDelete() shared.HALLinks {
-	return shared.HALLinks{
-		"list": {
-			Href:   "/v1/notification/email-accounts?p=1&ps=10",
-			Method: "GET",
-			Title:  "List all emails on the first page",
-		},
-	}
+func hateoasLinksForDelete(ctx context.Context) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		RouteWithQuery("nf:list", "notification.email-accounts.list", "List all emails on the first page", firstPage).
+		Build()
 }
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/commands"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type DeleteEmailSandboxRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+}
+
+type DeleteEmailSandboxResponse struct{}
+
+type DeleteEmailSandboxHandler struct{}
+
+func (h *DeleteEmailSandboxHandler) Handle(ctx context.Context, req *DeleteEmailSandboxRequest) (*baseHandler.Response[DeleteEmailSandboxResponse], error) {
+	// STEP-1: Remove the sandbox settings of the project
+	command := commands.DeleteEmailSandboxCommand{}
+	_, err := mediator.Send[*commands.DeleteEmailSandboxCommand, *commands.DeleteEmailSandboxCommandResponse](ctx, &command)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Return hateoas links to user
+	respData := DeleteEmailSandboxResponse{}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		Route("self", "notification.email-sandbox.get", "View the sandbox default of the environment").
+		Build()
+	return response, nil
+}
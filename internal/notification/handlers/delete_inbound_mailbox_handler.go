@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/mediatr/commands"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type DeleteInboundMailboxRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Email     string    `reqHeader:"-" params:"email" query:"-" json:"-" validate:"required,email"`
+}
+
+type DeleteInboundMailboxResponse struct{}
+
+type DeleteInboundMailboxHandler struct{}
+
+func (h *DeleteInboundMailboxHandler) Handle(ctx context.Context, req *DeleteInboundMailboxRequest) (*baseHandler.Response[DeleteInboundMailboxResponse], error) {
+	// STEP-1: Remove the inbound mailbox of the email account
+	command := commands.DeleteInboundMailboxCommand{Email: req.Email}
+	_, err := mediator.Send[*commands.DeleteInboundMailboxCommand, *commands.DeleteInboundMailboxCommandResponse](ctx, &command)
+	if errors.Is(err, shared.ErrNotFound) {
+		return baseHandler.NotFoundResponse[DeleteInboundMailboxResponse](), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Return hateoas links to user
+	respData := DeleteInboundMailboxResponse{}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		Route("nf:configure", "notification.email-accounts.inbound.configure", "Receive the messages of this email account", req.Email).
+		Build()
+	return response, nil
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/commands"
+	"platform/internal/shared"
+	"platform/pkg/services/mediator"
+
+	baseHandler "platform/internal/shared/handlers"
+
+	"github.com/google/uuid"
+)
+
+type DeleteSmsAccountRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" json:"-" validate:"required,uuid"`
+	ID        uuid.UUID `reqHeader:"-" params:"id" json:"-" validate:"required,uuid"`
+}
+
+type DeleteSmsAccountResponse struct {
+}
+
+type DeleteSmsAccountHandler struct{}
+
+func (h *DeleteSmsAccountHandler) Handle(ctx context.Context, req *DeleteSmsAccountRequest) (*baseHandler.Response[DeleteSmsAccountResponse], error) {
+	// STEP-1: Delete the sms account
+	command := commands.DeleteSmsAccountCommand{ID: req.ID}
+	_, err := mediator.Send[*commands.DeleteSmsAccountCommand, *commands.DeleteSmsAccountCommandResponse](ctx, &command)
+	if err != nil {
+		return baseHandler.FailedResponse[DeleteSmsAccountResponse](err), nil
+	}
+
+	// STEP-2: Return hateoas links to client
+	respData := DeleteSmsAccountResponse{}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = hateoasLinksForSmsAccountDelete(ctx)
+	return response, nil
+}
+
+func hateoasLinksForSmsAccountDelete(ctx context.Context) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		Route("nf:list", "notification.sms-accounts.list", "List all sms accounts").
+		Build()
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/commands"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type DeleteSuppressionRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Email     string    `reqHeader:"-" params:"email" query:"-" json:"-" validate:"required,email"`
+}
+
+type DeleteSuppressionResponse struct {
+	Email string `json:"email"`
+}
+
+type DeleteSuppressionHandler struct{}
+
+func (h *DeleteSuppressionHandler) Handle(ctx context.Context, req *DeleteSuppressionRequest) (*baseHandler.Response[DeleteSuppressionResponse], error) {
+	// STEP-1: Delete the suppression
+	command := commands.DeleteSuppressionCommand{Email: req.Email}
+	_, err := mediator.Send[*commands.DeleteSuppressionCommand, *commands.DeleteSuppressionCommandResponse](ctx, &command)
+	if err != nil {
+		return baseHandler.FailedResponse[DeleteSuppressionResponse](err), nil
+	}
+
+	// STEP-2: Return hateoas links to user
+	respData := DeleteSuppressionResponse{Email: req.Email}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = hateoasLinksForSuppression(ctx)
+	return response, nil
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/queries"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetAllCampaignRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Page      int       `reqHeader:"-" params:"-" query:"p" json:"-" validate:"gt=0"`
+	PageSize  int       `reqHeader:"-" params:"-" query:"ps" json:"-" validate:"gt=0,lte=100"`
+}
+
+type GetAllCampaignResponse struct {
+	TotalCount int            `json:"total_count"`
+	List       []campaignData `json:"list"`
+}
+
+type campaignData struct {
+	ID           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	TemplateName string     `json:"template_name"`
+	Languages    []string   `json:"languages"`
+	Status       string     `json:"status"`
+	ScheduledAt  time.Time  `json:"scheduled_at"`
+	TotalCount   int        `json:"total_count"`
+	SentCount    int        `json:"sent_count"`
+	FailedCount  int        `json:"failed_count"`
+	Progress     int        `json:"progress"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type GetAllCampaignHandler struct{}
+
+func (h *GetAllCampaignHandler) Handle(ctx context.Context, req *GetAllCampaignRequest) (*baseHandler.Response[GetAllCampaignResponse], error) {
+	// STEP-1: Get all campaigns
+	query := &queries.GetAllCampaignQuery{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	resp, err := mediator.Send[*queries.GetAllCampaignQuery, *queries.GetAllCampaignQueryResponse](ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Fill the response data
+	respData := GetAllCampaignResponse{
+		TotalCount: resp.TotalCount,
+		List:       make([]campaignData, 0, len(resp.List)),
+	}
+	for _, li := range resp.List {
+		respData.List = append(respData.List, toCampaignData(li))
+	}
+
+	// STEP-3: Return data and hateoas links to user
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		Route("nf:create", "notification.campaigns.create", "Schedule a new campaign").
+		Build()
+	return response, nil
+}
+
+func toCampaignData(li queries.CampaignData) campaignData {
+	return campaignData{
+		ID:           li.ID,
+		Name:         li.Name,
+		TemplateName: li.TemplateName,
+		Languages:    li.Languages,
+		Status:       li.Status,
+		ScheduledAt:  li.ScheduledAt,
+		TotalCount:   li.TotalCount,
+		SentCount:    li.SentCount,
+		FailedCount:  li.FailedCount,
+		Progress:     li.Progress,
+		StartedAt:    li.StartedAt,
+		CompletedAt:  li.CompletedAt,
+		CreatedAt:    li.CreatedAt,
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/queries"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetAllCapturedEmailRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Page      int       `reqHeader:"-" params:"-" query:"p" json:"-" validate:"gt=0"`
+	PageSize  int       `reqHeader:"-" params:"-" query:"ps" json:"-" validate:"gt=0,lte=100"`
+	Recipient string    `reqHeader:"-" params:"-" query:"recipient" json:"-" validate:"omitempty,email"`
+}
+
+type GetAllCapturedEmailResponse struct {
+	TotalCount int                 `json:"total_count"`
+	List       []capturedEmailData `json:"list"`
+}
+
+type capturedEmailData struct {
+	ID             uuid.UUID `json:"id"`
+	EmailAccountID uuid.UUID `json:"email_account_id"`
+	From           string    `json:"from"`
+	Recipients     []string  `json:"recipients"`
+	Subject        string    `json:"subject"`
+	MessageID      string    `json:"message_id,omitempty"`
+	Attachments    int       `json:"attachments"`
+	Size           int       `json:"size"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type GetAllCapturedEmailHandler struct{}
+
+func (h *GetAllCapturedEmailHandler) Handle(ctx context.Context, req *GetAllCapturedEmailRequest) (*baseHandler.Response[GetAllCapturedEmailResponse], error) {
+	// STEP-1: Get the captured messages
+	query := &queries.GetAllCapturedEmailQuery{
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+		Recipient: req.Recipient,
+	}
+	resp, err := mediator.Send[*queries.GetAllCapturedEmailQuery, *queries.GetAllCapturedEmailQueryResponse](ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Fill the response data
+	respData := GetAllCapturedEmailResponse{
+		TotalCount: resp.TotalCount,
+		List:       make([]capturedEmailData, 0, len(resp.List)),
+	}
+	for _, li := range resp.List {
+		respData.List = append(respData.List, toCapturedEmailData(li))
+	}
+
+	// STEP-3: Return data and hateoas links to user
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		Route("nf:delete-all", "notification.captured-emails.delete-all", "Delete every captured message").
+		Route("nf:sandbox", "notification.email-sandbox.get", "View the sandbox settings of the project").
+		Build()
+	return response, nil
+}
+
+func toCapturedEmailData(li queries.CapturedEmailData) capturedEmailData {
+	return capturedEmailData{
+		ID:             li.ID,
+		EmailAccountID: li.EmailAccountID,
+		From:           li.From,
+		Recipients:     li.Recipients,
+		Subject:        li.Subject,
+		MessageID:      li.MessageID,
+		Attachments:    li.Attachments,
+		Size:           li.Size,
+		CreatedAt:      li.CreatedAt,
+	}
+}
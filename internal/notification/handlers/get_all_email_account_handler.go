@@ -2,6 +2,9 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"net/url"
 	"platform/internal/notification/mediatr/queries"
 	"platform/internal/shared"
 	baseHandler "platform/internal/shared/handlers"
@@ -13,8 +16,9 @@ import (
 
 type GetAllEmailAccountRequest struct {
 	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
-	Page      int       `reqHeader:"-" params:"-" query:"p" json:"-" validate:"gt=0"`
-	PageSize  int       `reqHeader:"-" params:"-" query:"ps" json:"-" validate:"gt=0,lte=100"`
+	TypeID    string    `reqHeader:"-" params:"-" query:"type_id" json:"-" validate:"omitempty,numeric"`
+	Search    string    `reqHeader:"-" params:"-" query:"q" json:"-" validate:"max=128"`
+	baseHandler.ListRequest
 }
 
 type GetAllEmailAccountResponse struct {
@@ -32,12 +36,17 @@ type data struct {
 type GetAllEmailAccountHandler struct{}
 
 func (h *GetAllEmailAccountHandler) Handle(ctx context.Context, req *GetAllEmailAccountRequest) (*baseHandler.Response[GetAllEmailAccountResponse], error) {
-	// STEP-1: Get all email accounts
-	query := &queries.GetAllEmailAccountQuery{
-		Page:     req.Page,
-		PageSize: req.PageSize,
+	// STEP-1: Get the page of email accounts
+	filters := map[string]string{"type_id": req.TypeID, "q": req.Search}
+	options, err := req.ListOptions("created_at", filters, "email", "display_name", "type_id", "created_at")
+	if err != nil {
+		return baseHandler.FailedResponse[GetAllEmailAccountResponse](err), nil
 	}
+	query := &queries.GetAllEmailAccountQuery{Options: options}
 	resp, err := mediator.Send[*queries.GetAllEmailAccountQuery, *queries.GetAllEmailAccountQueryResponse](ctx, query)
+	if errors.Is(err, shared.ErrInvalidCursor) {
+		return baseHandler.FailedResponse[GetAllEmailAccountResponse](err), nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +57,8 @@ func (h *GetAllEmailAccountHandler) Handle(ctx context.Context, req *GetAllEmail
 		List:       make([]data, 0, len(resp.List)),
 	}
 
-	// STEP-3: Fill the response data
+	// STEP-3: Fill the response data, the links of each account are embedded
+	accounts := make([]emailAccountResource, 0, len(resp.List))
 	for _, li := range resp.List {
 		respData.List = append(respData.List, data{
 			Email:       li.Email,
@@ -56,25 +66,39 @@ func (h *GetAllEmailAccountHandler) Handle(ctx context.Context, req *GetAllEmail
 			TypeId:      li.TypeId,
 			CreatedAt:   li.CreatedAt,
 		})
+		accounts = append(accounts, emailAccountResource{
+			Email:       li.Email,
+			HALResource: shared.HALResource{Links: hateoasLinksForEmailAccount(ctx, li.Email)},
+		})
 	}
 
-	// STEP-4: Return data and hateoas links to user
-	response := baseHandler.SuccessResponse(&respData)
-	response.Links = hateoasLinksForAll()
+	// STEP-4: Return data, page and hateoas links to user
+	path, _ := baseHandler.RoutePath("notification.email-accounts.list")
+	pageInfo := baseHandler.PageInfo{TotalCount: resp.TotalCount, NextCursor: resp.NextCursor}
+	response := baseHandler.PagedResponse(&respData, path, options, pageInfo)
+	maps.Copy(response.Links, hateoasLinksForAll(ctx))
+	response.Embed("nf:email-accounts", accounts)
 	return response, nil
 }
 
-func hateoasLinksForAll() shared.HALLinks {
-	return shared.HALLinks{
-		"self": {
-			Href:   "/v1/notification/email-accounts/:email",
-			Method: "GET",
-			Title:  "View this email account",
-		},
-		"delete": {
-			Href:   "/v1/notification/email-accounts/:email",
-			Method: "DELETE",
-			Title:  "Delete this email account",
-		},
-	}
+// firstPage is the query of the links to the first page of a list
+var firstPage = url.Values{"p": {"1"}, "ps": {"10"}}
+
+type emailAccountResource struct {
+	Email string `json:"email"`
+	shared.HALResource
+}
+
+func hateoasLinksForAll(ctx context.Context) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		Route("nf:email-account", "notification.email-accounts.get", "View an email account").
+		Route("nf:create", "notification.email-accounts.create", "Create an email account").
+		Build()
+}
+
+func hateoasLinksForEmailAccount(ctx context.Context, email string) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		Route("self", "notification.email-accounts.get", "View this email account", email).
+		Route("nf:delete", "notification.email-accounts.delete", "Delete this email account", email).
+		Build()
 }
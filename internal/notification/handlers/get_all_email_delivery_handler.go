@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+	"platform/internal/notification/mediatr/queries"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetAllEmailDeliveryRequest struct {
+	ProjectID      uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Page           int       `reqHeader:"-" params:"-" query:"p" json:"-" validate:"gt=0"`
+	PageSize       int       `reqHeader:"-" params:"-" query:"ps" json:"-" validate:"gt=0,lte=100"`
+	Status         string    `reqHeader:"-" params:"-" query:"status" json:"-" validate:"omitempty,oneof=queued sent deferred bounced opened clicked"`
+	Recipient      string    `reqHeader:"-" params:"-" query:"recipient" json:"-" validate:"omitempty,email"`
+	EmailAccountID string    `reqHeader:"-" params:"-" query:"email_account_id" json:"-" validate:"omitempty,uuid"`
+	CampaignID     string    `reqHeader:"-" params:"-" query:"campaign_id" json:"-" validate:"omitempty,uuid"`
+	From           string    `reqHeader:"-" params:"-" query:"from" json:"-" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To             string    `reqHeader:"-" params:"-" query:"to" json:"-" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+type GetAllEmailDeliveryResponse struct {
+	TotalCount int                 `json:"total_count"`
+	List       []emailDeliveryData `json:"list"`
+}
+
+type emailDeliveryData struct {
+	ID                uuid.UUID  `json:"id"`
+	EmailAccountID    uuid.UUID  `json:"email_account_id"`
+	CampaignID        *uuid.UUID `json:"campaign_id,omitempty"`
+	Recipient         string     `json:"recipient"`
+	Subject           string     `json:"subject"`
+	MessageID         string     `json:"message_id"`
+	ProviderMessageID string     `json:"provider_message_id,omitempty"`
+	Status            string     `json:"status"`
+	Opens             int        `json:"opens"`
+	Clicks            int        `json:"clicks"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+type GetAllEmailDeliveryHandler struct{}
+
+func (h *GetAllEmailDeliveryHandler) Handle(ctx context.Context, req *GetAllEmailDeliveryRequest) (*baseHandler.Response[GetAllEmailDeliveryResponse], error) {
+	// STEP-1: Get the delivery logs matching the filter, the values were validated
+	query := &queries.GetAllEmailDeliveryQuery{
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+		Status:    req.Status,
+		Recipient: req.Recipient,
+	}
+	if req.EmailAccountID != "" {
+		id := uuid.MustParse(req.EmailAccountID)
+		query.EmailAccountID = &id
+	}
+	if req.CampaignID != "" {
+		id := uuid.MustParse(req.CampaignID)
+		query.CampaignID = &id
+	}
+	query.From, _ = parseOptionalTime(req.From)
+	query.To, _ = parseOptionalTime(req.To)
+
+	resp, err := mediator.Send[*queries.GetAllEmailDeliveryQuery, *queries.GetAllEmailDeliveryQueryResponse](ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Fill the response data
+	respData := GetAllEmailDeliveryResponse{
+		TotalCount: resp.TotalCount,
+		List:       make([]emailDeliveryData, 0, len(resp.List)),
+	}
+	for _, li := range resp.List {
+		respData.List = append(respData.List, toEmailDeliveryData(li))
+	}
+
+	// STEP-3: Return data and hateoas links to user
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		RouteWithQuery("nf:bounced", "notification.deliveries.list", "List the bounced messages on the first page", url.Values{"p": {"1"}, "ps": {"10"}, "status": {"bounced"}}).
+		Build()
+	return response, nil
+}
+
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func toEmailDeliveryData(li queries.EmailDeliveryData) emailDeliveryData {
+	return emailDeliveryData{
+		ID:                li.ID,
+		EmailAccountID:    li.EmailAccountID,
+		CampaignID:        li.CampaignID,
+		Recipient:         li.Recipient,
+		Subject:           li.Subject,
+		MessageID:         li.MessageID,
+		ProviderMessageID: li.ProviderMessageID,
+		Status:            li.Status,
+		Opens:             li.Opens,
+		Clicks:            li.Clicks,
+		CreatedAt:         li.CreatedAt,
+		UpdatedAt:         li.UpdatedAt,
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/queries"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type GetAllEmailProviderRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+}
+
+type GetAllEmailProviderResponse struct {
+	List []emailProviderData `json:"list"`
+}
+
+type emailProviderData struct {
+	TypeID       int    `json:"type_id"`
+	Name         string `json:"name"`
+	Mechanism    string `json:"mechanism"`
+	OAuth2       bool   `json:"oauth2"`
+	Transport    string `json:"transport"`
+	Host         string `json:"host,omitempty"`
+	Port         int    `json:"port,omitempty"`
+	EnableSSL    bool   `json:"enable_ssl"`
+	MaxPerMinute int    `json:"max_per_minute"`
+	MaxPerDay    int    `json:"max_per_day"`
+}
+
+type GetAllEmailProviderHandler struct{}
+
+func (h *GetAllEmailProviderHandler) Handle(ctx context.Context, req *GetAllEmailProviderRequest) (*baseHandler.Response[GetAllEmailProviderResponse], error) {
+	// STEP-1: Get the registered email providers
+	query := &queries.GetAllEmailProviderQuery{}
+	resp, err := mediator.Send[*queries.GetAllEmailProviderQuery, *queries.GetAllEmailProviderQueryResponse](ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Fill the response data
+	respData := GetAllEmailProviderResponse{List: make([]emailProviderData, 0, len(resp.List))}
+	for _, li := range resp.List {
+		respData.List = append(respData.List, emailProviderData{
+			TypeID:       li.TypeID,
+			Name:         li.Name,
+			Mechanism:    li.Mechanism,
+			OAuth2:       li.OAuth2,
+			Transport:    li.Transport,
+			Host:         li.Defaults.Host,
+			Port:         li.Defaults.Port,
+			EnableSSL:    li.Defaults.EnableSSL,
+			MaxPerMinute: li.Defaults.MaxPerMinute,
+			MaxPerDay:    li.Defaults.MaxPerDay,
+		})
+	}
+
+	// STEP-3: Return data and hateoas links to user
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = hateoasLinksForEmailProvider(ctx)
+	return response, nil
+}
+
+func hateoasLinksForEmailProvider(ctx context.Context) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		Route("nf:create", "notification.email-accounts.create", "Create an email account of a provider").
+		Build()
+}
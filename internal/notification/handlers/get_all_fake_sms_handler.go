@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/queries"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetAllFakeSmsRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+}
+
+type GetAllFakeSmsResponse struct {
+	List []fakeSmsData `json:"list"`
+}
+
+type fakeSmsData struct {
+	ID     string    `json:"id"`
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+	Body   string    `json:"body"`
+	SentAt time.Time `json:"sent_at"`
+}
+
+// GetAllFakeSmsHandler shows the messages of the fake sms accounts, the route is only registered in the
+// environments allowing them
+type GetAllFakeSmsHandler struct{}
+
+func (h *GetAllFakeSmsHandler) Handle(ctx context.Context, req *GetAllFakeSmsRequest) (*baseHandler.Response[GetAllFakeSmsResponse], error) {
+	// STEP-1: Get the messages of the project
+	resp, err := mediator.Send[*queries.GetAllFakeSmsQuery, *queries.GetAllFakeSmsQueryResponse](ctx, &queries.GetAllFakeSmsQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Fill the response data
+	respData := GetAllFakeSmsResponse{
+		List: make([]fakeSmsData, 0, len(resp.List)),
+	}
+	for _, li := range resp.List {
+		respData.List = append(respData.List, fakeSmsData{
+			ID:     li.ID,
+			From:   li.From,
+			To:     li.To,
+			Body:   li.Body,
+			SentAt: li.SentAt,
+		})
+	}
+
+	// STEP-3: Return data and hateoas links to user
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		Route("sms-accounts", "notification.sms-accounts.list", "List all sms accounts").
+		Build()
+	return response, nil
+}
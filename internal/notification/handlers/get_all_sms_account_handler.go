@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/queries"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetAllSmsAccountRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+}
+
+type GetAllSmsAccountResponse struct {
+	List []smsAccountData
+}
+
+type smsAccountData struct {
+	ID         uuid.UUID
+	ProviderID int
+	Sender     string
+	CreatedAt  time.Time
+}
+
+type GetAllSmsAccountHandler struct{}
+
+func (h *GetAllSmsAccountHandler) Handle(ctx context.Context, req *GetAllSmsAccountRequest) (*baseHandler.Response[GetAllSmsAccountResponse], error) {
+	// STEP-1: Get all sms accounts
+	query := &queries.GetAllSmsAccountQuery{}
+	resp, err := mediator.Send[*queries.GetAllSmsAccountQuery, *queries.GetAllSmsAccountQueryResponse](ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Fill the response data
+	respData := GetAllSmsAccountResponse{
+		List: make([]smsAccountData, 0, len(resp.List)),
+	}
+	for _, li := range resp.List {
+		respData.List = append(respData.List, smsAccountData{
+			ID:         li.ID,
+			ProviderID: li.ProviderID,
+			Sender:     li.Sender,
+			CreatedAt:  li.CreatedAt,
+		})
+	}
+
+	// STEP-3: Return data and hateoas links to user
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = hateoasLinksForSmsAccountAll(ctx)
+	return response, nil
+}
+
+func hateoasLinksForSmsAccountAll(ctx context.Context) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		Route("nf:create", "notification.sms-accounts.create", "Create a new sms account").
+		Route("nf:delete", "notification.sms-accounts.delete", "Delete this sms account").
+		Build()
+}
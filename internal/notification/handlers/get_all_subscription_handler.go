@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/queries"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetAllSubscriptionRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Page      int       `reqHeader:"-" params:"-" query:"p" json:"-" validate:"gt=0"`
+	PageSize  int       `reqHeader:"-" params:"-" query:"ps" json:"-" validate:"gt=0,lte=100"`
+}
+
+type GetAllSubscriptionResponse struct {
+	TotalCount int
+	List       []subscriptionData
+}
+
+type subscriptionData struct {
+	Email       string
+	Language    string
+	Status      string
+	ConfirmedAt *time.Time
+	CreatedAt   time.Time
+}
+
+type GetAllSubscriptionHandler struct{}
+
+func (h *GetAllSubscriptionHandler) Handle(ctx context.Context, req *GetAllSubscriptionRequest) (*baseHandler.Response[GetAllSubscriptionResponse], error) {
+	// STEP-1: Get all subscriptions
+	query := &queries.GetAllSubscriptionQuery{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	resp, err := mediator.Send[*queries.GetAllSubscriptionQuery, *queries.GetAllSubscriptionQueryResponse](ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Fill the response data
+	respData := GetAllSubscriptionResponse{
+		TotalCount: resp.TotalCount,
+		List:       make([]subscriptionData, 0, len(resp.List)),
+	}
+	for _, li := range resp.List {
+		respData.List = append(respData.List, subscriptionData{
+			Email:       li.Email,
+			Language:    li.Language,
+			Status:      li.Status,
+			ConfirmedAt: li.ConfirmedAt,
+			CreatedAt:   li.CreatedAt,
+		})
+	}
+
+	// STEP-3: Return data and hateoas links to user
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		Route("nf:subscribe", "notification.subscriptions.create", "Subscribe to the newsletter").
+		Route("nf:unsubscribe", "notification.subscriptions.delete", "Unsubscribe from the newsletter").
+		Build()
+	return response, nil
+}
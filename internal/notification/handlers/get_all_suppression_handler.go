@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/queries"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetAllSuppressionRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Page      int       `reqHeader:"-" params:"-" query:"p" json:"-" validate:"gt=0"`
+	PageSize  int       `reqHeader:"-" params:"-" query:"ps" json:"-" validate:"gt=0,lte=100"`
+}
+
+type GetAllSuppressionResponse struct {
+	TotalCount int
+	List       []suppressionData
+}
+
+type suppressionData struct {
+	Email      string
+	Reason     string
+	Diagnostic string
+	CreatedAt  time.Time
+}
+
+type GetAllSuppressionHandler struct{}
+
+func (h *GetAllSuppressionHandler) Handle(ctx context.Context, req *GetAllSuppressionRequest) (*baseHandler.Response[GetAllSuppressionResponse], error) {
+	// STEP-1: Get all suppressions
+	query := &queries.GetAllSuppressionQuery{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	resp, err := mediator.Send[*queries.GetAllSuppressionQuery, *queries.GetAllSuppressionQueryResponse](ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Fill the response data
+	respData := GetAllSuppressionResponse{
+		TotalCount: resp.TotalCount,
+		List:       make([]suppressionData, 0, len(resp.List)),
+	}
+	for _, li := range resp.List {
+		respData.List = append(respData.List, suppressionData{
+			Email:      li.Email,
+			Reason:     li.Reason,
+			Diagnostic: li.Diagnostic,
+			CreatedAt:  li.CreatedAt,
+		})
+	}
+
+	// STEP-3: Return data and hateoas links to user
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = hateoasLinksForSuppression(ctx)
+	return response, nil
+}
+
+func hateoasLinksForSuppression(ctx context.Context) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		Route("nf:add", "notification.suppressions.create", "Block the emails sent to an address").
+		Route("nf:delete", "notification.suppressions.delete", "Allow sending emails to an address again").
+		Build()
+}
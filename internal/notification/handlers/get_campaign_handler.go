@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/queries"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type GetCampaignRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" json:"-" validate:"required,uuid"`
+	ID        uuid.UUID `reqHeader:"-" params:"id" json:"-" validate:"required,uuid"`
+}
+
+type GetCampaignHandler struct{}
+
+func (h *GetCampaignHandler) Handle(ctx context.Context, req *GetCampaignRequest) (*baseHandler.Response[campaignData], error) {
+	// STEP-1: Get the campaign
+	query := queries.GetCampaignQuery{ID: req.ID}
+	resp, err := mediator.Send[*queries.GetCampaignQuery, *queries.GetCampaignQueryResponse](ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return baseHandler.NotFoundResponse[campaignData](), nil
+	}
+
+	// STEP-2: Return data and hateoas links to user
+	respData := toCampaignData(resp.CampaignData)
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = hateoasLinksForCampaign(ctx, resp.ID)
+	return response, nil
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/mediatr/queries"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type GetCapturedEmailRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" json:"-" validate:"required,uuid"`
+	ID        uuid.UUID `reqHeader:"-" params:"id" json:"-" validate:"required,uuid"`
+}
+
+type GetCapturedEmailResponse struct {
+	capturedEmailData
+	Headers     map[string][]string         `json:"headers"`
+	Text        string                      `json:"text,omitempty"`
+	HTML        string                      `json:"html,omitempty"`
+	Attachments []domain.CapturedAttachment `json:"attachments"`
+}
+
+type GetCapturedEmailHandler struct{}
+
+func (h *GetCapturedEmailHandler) Handle(ctx context.Context, req *GetCapturedEmailRequest) (*baseHandler.Response[GetCapturedEmailResponse], error) {
+	// STEP-1: Get the captured message with its content
+	query := queries.GetCapturedEmailQuery{ID: req.ID}
+	resp, err := mediator.Send[*queries.GetCapturedEmailQuery, *queries.GetCapturedEmailQueryResponse](ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return baseHandler.NotFoundResponse[GetCapturedEmailResponse](), nil
+	}
+
+	// STEP-2: Return data and hateoas links to user
+	respData := GetCapturedEmailResponse{
+		capturedEmailData: toCapturedEmailData(resp.CapturedEmailData),
+		Headers:           resp.Headers,
+		Text:              resp.Text,
+		HTML:              resp.HTML,
+		Attachments:       resp.Attachments,
+	}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		Route("nf:delete", "notification.captured-emails.delete", "Delete this captured message", req.ID.String()).
+		Route("nf:download", "notification.captured-emails.raw", "Download the message as an .eml file", req.ID.String()).
+		RouteWithQuery("nf:list", "notification.captured-emails.list", "List the captured messages on the first page", firstPage).
+		Build()
+	return response, nil
+}
+
+type GetCapturedEmailRawHandler struct{}
+
+// Handle returns the message as it would have been sent, as an attachment the mail clients can open
+func (h *GetCapturedEmailRawHandler) Handle(ctx context.Context, req *GetCapturedEmailRequest) (*baseHandler.Response[GetCapturedEmailResponse], error) {
+	query := queries.GetCapturedEmailQuery{ID: req.ID}
+	resp, err := mediator.Send[*queries.GetCapturedEmailQuery, *queries.GetCapturedEmailQueryResponse](ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return baseHandler.NotFoundResponse[GetCapturedEmailResponse](), nil
+	}
+
+	response := baseHandler.ContentResponse[GetCapturedEmailResponse]("message/rfc822", resp.Raw)
+	response.Headers = map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="%s.eml"`, req.ID),
+	}
+	return response, nil
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/mediatr/queries"
+	email_sender "platform/internal/notification/services/emailSender"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type GetDkimRecordRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Email     string    `reqHeader:"-" params:"email" query:"-" json:"-" validate:"required,email"`
+}
+
+type GetDkimRecordResponse struct {
+	Domain      string `json:"domain"`
+	Selector    string `json:"selector"`
+	Algorithm   string `json:"algorithm"`
+	RecordName  string `json:"record_name"`
+	RecordValue string `json:"record_value"`
+	ZoneEntry   string `json:"zone_entry"`
+}
+
+type GetDkimRecordHandler struct{}
+
+func (h *GetDkimRecordHandler) Handle(ctx context.Context, req *GetDkimRecordRequest) (*baseHandler.Response[GetDkimRecordResponse], error) {
+	// STEP-1: Get the DNS record of the DKIM key
+	query := queries.GetDkimRecordQuery{Email: req.Email}
+	resp, err := mediator.Send[*queries.GetDkimRecordQuery, *queries.GetDkimRecordQueryResponse](ctx, &query)
+	if errors.Is(err, shared.ErrNotFound) {
+		return baseHandler.NotFoundResponse[GetDkimRecordResponse](), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Return data and hateoas links to user
+	respData := GetDkimRecordResponse{
+		Domain:      resp.Domain,
+		Selector:    resp.Selector,
+		Algorithm:   resp.Algorithm,
+		RecordName:  resp.RecordName,
+		RecordValue: resp.RecordValue,
+		ZoneEntry:   email_sender.DKIMZoneEntry(resp.RecordName, resp.RecordValue),
+	}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = hateoasLinksForDkim(ctx, req.Email)
+	return response, nil
+}
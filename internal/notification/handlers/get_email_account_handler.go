@@ -2,18 +2,13 @@ package handlers
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/json"
-	"fmt"
-	"platform/internal/notification/domain"
 	"platform/internal/notification/mediatr/queries"
+	email_provider "platform/internal/notification/services/emailProvider"
 	"platform/internal/shared"
 	baseHandler "platform/internal/shared/handlers"
 	"platform/pkg/services/mediator"
 
 	"github.com/google/uuid"
-	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/google"
 )
 
 type GetEmailAccountRequest struct {
@@ -33,7 +28,13 @@ type GetEmailAccountResponse struct {
 	ClientID     string `json:"client_id,omitempty"`
 	TenantID     string `json:"tenant_id,omitempty"`
 	ClientSecret string `json:"client_secret,omitempty"`
-	OAuth2Url    string `json:"oauth2_url,omitempty"`
+
+	// NeedsReconsent is set when the provider revoked the access, the account must be authorized again
+	NeedsReconsent bool `json:"needs_reconsent"`
+
+	// TrackOpens and TrackClicks add the tracking pixel and rewrite the links of the sent messages
+	TrackOpens  bool `json:"track_opens"`
+	TrackClicks bool `json:"track_clicks"`
 }
 
 type GetEmailAccountHandler struct{}
@@ -57,10 +58,14 @@ func (h *GetEmailAccountHandler) Handle(ctx context.Context, req *GetEmailAccoun
 		Port:        resp.Port,
 		EnableSSL:   resp.EnableSSL,
 		TypeId:      resp.TypeId,
+
+		NeedsReconsent: resp.NeedsReconsent,
+		TrackOpens:     resp.TrackOpens,
+		TrackClicks:    resp.TrackClicks,
 	}
 
 	// STEP-3: Get the related credentials
-	if resp.TypeId == domain.Login {
+	if !email_provider.UsesOAuth2(resp.TypeId) {
 		username, password := resp.TraditionalCredentials.Credentials()
 		data.Username = username
 		data.Password = password
@@ -71,68 +76,20 @@ func (h *GetEmailAccountHandler) Handle(ctx context.Context, req *GetEmailAccoun
 		data.ClientSecret = clientSecret
 	}
 
-	// STEP-4: Get OAut2 URL
-	if resp.OAuth2Credentials != nil {
-		data.OAuth2Url = getOAuth2Url(req.ProjectID, req.Email, data.ClientID, data.TenantID, data.ClientSecret)
-	}
-
 	// STEP-4: Returns hateoas links to user
 	response := baseHandler.SuccessResponse(&data)
-	response.Links = hateoasLinksForGet(req.Email)
+	response.Links = hateoasLinksForGet(ctx, req.Email, resp.OAuth2Credentials != nil)
 	return response, nil
 }
 
-func getOAuth2Url(projectID uuid.UUID, email, clientID, tenantID, clientSecret string) string {
-	if clientID == "" || tenantID == "" || clientSecret == "" {
-		return ""
-	}
-
-	oauth2Config := &oauth2.Config{
-		ClientID:     clientID,
-		ClientSecret: clientSecret,
-		RedirectURL:  "http://localhost:3000/v1/notification/email-accounts/oauth2-callback",
-	}
-
-	if tenantID != "" {
-		oauth2Config.Scopes = []string{
-			"https://outlook.office365.com/SMTP.Send",
-			"offline_access",
-		}
-		oauth2Config.Endpoint = oauth2.Endpoint{
-			AuthURL:  fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/authorize", tenantID),
-			TokenURL: fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", tenantID),
-		}
-	} else {
-		oauth2Config.Scopes = []string{"https://mail.google.com/"}
-		oauth2Config.Endpoint = google.Endpoint
-	}
-
-	stateObj := map[string]string{
-		"project_id": projectID.String(),
-		"email":      email,
-	}
-	stateBytes, _ := json.Marshal(stateObj)
-	encodedState := base64.URLEncoding.EncodeToString(stateBytes)
-
-	return oauth2Config.AuthCodeURL(encodedState, oauth2.AccessTypeOffline)
-}
-
-func hateoasLinksForGet(email string) shared.HALLinks {
-	return shared.HALLinks{
-		"delete": {
-			Href:   fmt.Sprintf("/v1/notification/email-accounts/%s", email),
-			Method: "DELETE",
-			Title:  "Delete this email account",
-		},
-		"list": {
-			Href:   "/v1/notification/email-accounts?p=0&ps=1",
-			Method: "GET",
-			Title:  "List all emails on the first page",
-		},
-		"update": {
-			Href:   fmt.Sprintf("/v1/notification/email-accounts/%s", email),
-			Method: "PUT",
-			Title:  "Update this email account",
-		},
+func hateoasLinksForGet(ctx context.Context, email string, usesOAuth2 bool) shared.HALLinks {
+	links := baseHandler.NewLinks(ctx).
+		Route("nf:delete", "notification.email-accounts.delete", "Delete this email account", email).
+		RouteWithQuery("nf:list", "notification.email-accounts.list", "List all emails on the first page", firstPage).
+		Route("nf:tracking", "notification.email-accounts.tracking.configure", "Change the open and click tracking of this email account", email).
+		Route("nf:update", "notification.email-accounts.update", "Update this email account", email)
+	if usesOAuth2 {
+		links.Route("nf:authorize", "notification.email-accounts.authorize", "Get the URL granting the access to this email account", email)
 	}
+	return links.Build()
 }
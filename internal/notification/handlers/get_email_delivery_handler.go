@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+	"platform/internal/notification/mediatr/queries"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetEmailDeliveryRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" json:"-" validate:"required,uuid"`
+	ID        uuid.UUID `reqHeader:"-" params:"id" json:"-" validate:"required,uuid"`
+}
+
+type GetEmailDeliveryResponse struct {
+	emailDeliveryData
+	Events []emailDeliveryEventData `json:"events"`
+}
+
+type emailDeliveryEventData struct {
+	Status     string    `json:"status"`
+	Detail     string    `json:"detail,omitempty"`
+	URL        string    `json:"url,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type GetEmailDeliveryHandler struct{}
+
+func (h *GetEmailDeliveryHandler) Handle(ctx context.Context, req *GetEmailDeliveryRequest) (*baseHandler.Response[GetEmailDeliveryResponse], error) {
+	// STEP-1: Get the delivery log with its history
+	query := queries.GetEmailDeliveryQuery{ID: req.ID}
+	resp, err := mediator.Send[*queries.GetEmailDeliveryQuery, *queries.GetEmailDeliveryQueryResponse](ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return baseHandler.NotFoundResponse[GetEmailDeliveryResponse](), nil
+	}
+
+	// STEP-2: Fill the response data
+	respData := GetEmailDeliveryResponse{
+		emailDeliveryData: toEmailDeliveryData(resp.EmailDeliveryData),
+		Events:            make([]emailDeliveryEventData, 0, len(resp.Events)),
+	}
+	for _, event := range resp.Events {
+		respData.Events = append(respData.Events, emailDeliveryEventData{
+			Status:     event.Status,
+			Detail:     event.Detail,
+			URL:        event.URL,
+			OccurredAt: event.OccurredAt,
+		})
+	}
+
+	// STEP-3: Return data and hateoas links to user
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		RouteWithQuery("nf:list", "notification.deliveries.list", "List the delivery logs on the first page", firstPage).
+		RouteWithQuery("nf:recipient", "notification.deliveries.list", "List the messages sent to this recipient", url.Values{"p": {"1"}, "ps": {"10"}, "recipient": {resp.Recipient}}).
+		Build()
+	return response, nil
+}
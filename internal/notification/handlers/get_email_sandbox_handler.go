@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/queries"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetEmailSandboxRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+}
+
+type GetEmailSandboxResponse struct {
+	Mode       string     `json:"mode"`
+	AllowList  []string   `json:"allow_list"`
+	RedirectTo string     `json:"redirect_to,omitempty"`
+	Source     string     `json:"source"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+type GetEmailSandboxHandler struct{}
+
+func (h *GetEmailSandboxHandler) Handle(ctx context.Context, req *GetEmailSandboxRequest) (*baseHandler.Response[GetEmailSandboxResponse], error) {
+	// STEP-1: Get the sandbox settings applied to the project
+	query := queries.GetEmailSandboxQuery{}
+	resp, err := mediator.Send[*queries.GetEmailSandboxQuery, *queries.GetEmailSandboxQueryResponse](ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Return data and hateoas links to user
+	respData := GetEmailSandboxResponse{
+		Mode:       resp.Mode,
+		AllowList:  resp.AllowList,
+		RedirectTo: resp.RedirectTo,
+		Source:     resp.Source,
+		UpdatedAt:  resp.UpdatedAt,
+	}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = hateoasLinksForEmailSandbox(ctx)
+	return response, nil
+}
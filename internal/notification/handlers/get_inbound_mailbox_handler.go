@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/mediatr/queries"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type GetInboundMailboxRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Email     string    `reqHeader:"-" params:"email" query:"-" json:"-" validate:"required,email"`
+}
+
+type GetInboundMailboxResponse struct {
+	Host      string `json:"host"`
+	Port      int    `json:"port"`
+	EnableSSL bool   `json:"enable_ssl"`
+	Folder    string `json:"folder"`
+	UseIdle   bool   `json:"use_idle"`
+	LastUID   uint32 `json:"last_uid"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+type GetInboundMailboxHandler struct{}
+
+func (h *GetInboundMailboxHandler) Handle(ctx context.Context, req *GetInboundMailboxRequest) (*baseHandler.Response[GetInboundMailboxResponse], error) {
+	// STEP-1: Get the inbound mailbox of the email account
+	query := queries.GetInboundMailboxQuery{Email: req.Email}
+	resp, err := mediator.Send[*queries.GetInboundMailboxQuery, *queries.GetInboundMailboxQueryResponse](ctx, &query)
+	if errors.Is(err, shared.ErrNotFound) {
+		return baseHandler.NotFoundResponse[GetInboundMailboxResponse](), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Return data and hateoas links to user
+	respData := GetInboundMailboxResponse{
+		Host:      resp.Host,
+		Port:      resp.Port,
+		EnableSSL: resp.EnableSSL,
+		Folder:    resp.Folder,
+		UseIdle:   resp.UseIdle,
+		LastUID:   resp.LastUID,
+		LastError: resp.LastError,
+	}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = hateoasLinksForInboundMailbox(ctx, req.Email)
+	return response, nil
+}
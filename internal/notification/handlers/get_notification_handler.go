@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/queries"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetNotificationRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" json:"-" validate:"required,uuid"`
+	ID        uuid.UUID `reqHeader:"-" params:"id" json:"-" validate:"required,uuid"`
+}
+
+type GetNotificationResponse struct {
+	ID           uuid.UUID      `json:"id"`
+	Recipient    string         `json:"recipient"`
+	TemplateName string         `json:"template_name"`
+	Language     string         `json:"language"`
+	Deliveries   []deliveryData `json:"deliveries"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+type GetNotificationHandler struct{}
+
+func (h *GetNotificationHandler) Handle(ctx context.Context, req *GetNotificationRequest) (*baseHandler.Response[GetNotificationResponse], error) {
+	// STEP-1: Get the notification
+	query := queries.GetNotificationQuery{ID: req.ID}
+	resp, err := mediator.Send[*queries.GetNotificationQuery, *queries.GetNotificationQueryResponse](ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return baseHandler.NotFoundResponse[GetNotificationResponse](), nil
+	}
+
+	// STEP-2: Return data and hateoas links to user
+	respData := GetNotificationResponse{
+		ID:           resp.ID,
+		Recipient:    resp.Recipient,
+		TemplateName: resp.TemplateName,
+		Language:     resp.Language,
+		Deliveries:   toDeliveryData(resp.Deliveries),
+		CreatedAt:    resp.CreatedAt,
+	}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = hateoasLinksForNotification(ctx, resp.ID)
+	return response, nil
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/mediatr/commands"
+	delivery_tracker "platform/internal/notification/services/deliveryTracker"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+)
+
+// IngestDeliveryWebhookRequest is the event notification of a provider, the body is kept as it is sent
+// since SendGrid posts a JSON array and Mailgun signs the payload
+type IngestDeliveryWebhookRequest struct {
+	Provider string `reqHeader:"-" params:"provider" query:"-" json:"-" validate:"required,oneof=sendgrid mailgun ses"`
+	Secret   string `reqHeader:"-" params:"-" query:"secret" json:"-" validate:"required"`
+	Body     []byte `reqHeader:"-" params:"-" query:"-" json:"-"`
+}
+
+func (r *IngestDeliveryWebhookRequest) SetRawBody(body []byte) {
+	r.Body = body
+}
+
+type IngestDeliveryWebhookResponse struct {
+	Recorded int `json:"recorded"`
+	Skipped  int `json:"skipped"`
+}
+
+type IngestDeliveryWebhookHandler struct{}
+
+func (h *IngestDeliveryWebhookHandler) Handle(ctx context.Context, req *IngestDeliveryWebhookRequest) (*baseHandler.Response[IngestDeliveryWebhookResponse], error) {
+	command := commands.IngestDeliveryWebhookCommand{
+		Provider: req.Provider,
+		Secret:   req.Secret,
+		Body:     req.Body,
+	}
+	resp, err := mediator.Send[*commands.IngestDeliveryWebhookCommand, *commands.IngestDeliveryWebhookCommandResponse](ctx, &command)
+	switch {
+	case errors.Is(err, commands.ErrInvalidWebhookSecret), errors.Is(err, delivery_tracker.ErrInvalidSignature),
+		errors.Is(err, delivery_tracker.ErrInvalidWebhook), errors.Is(err, delivery_tracker.ErrUnknownProvider):
+		return baseHandler.FailedResponse[IngestDeliveryWebhookResponse](err), nil
+	case err != nil:
+		return nil, err
+	}
+
+	respData := IngestDeliveryWebhookResponse{Recorded: resp.Recorded, Skipped: resp.Skipped}
+	return baseHandler.SuccessResponse(&respData), nil
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"platform/internal/notification/mediatr/queries"
+	email_sender "platform/internal/notification/services/emailSender"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+// previewRecipient receives the previewed messages when the request has no recipient
+const previewRecipient = "recipient@example.com"
+
+type PreviewTemplateRequest struct {
+	ProjectID    uuid.UUID         `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Name         string            `reqHeader:"-" params:"name" query:"-" json:"-" validate:"required,max=64"`
+	Format       string            `reqHeader:"-" params:"-" query:"format" json:"-" validate:"omitempty,oneof=json eml"`
+	EmailAccount string            `reqHeader:"-" params:"-" query:"-" json:"email_account" validate:"omitempty,email"`
+	Language     string            `reqHeader:"-" params:"-" query:"-" json:"language" validate:"max=8"`
+	To           string            `reqHeader:"-" params:"-" query:"-" json:"to" validate:"omitempty,email"`
+	Data         map[string]string `reqHeader:"-" params:"-" query:"-" json:"data"`
+}
+
+type PreviewTemplateResponse struct {
+	Language string              `json:"language"`
+	Subject  string              `json:"subject"`
+	HTML     string              `json:"html"`
+	Text     string              `json:"text"`
+	Headers  map[string][]string `json:"headers"`
+	Files    []previewFileData   `json:"files"`
+	Raw      string              `json:"raw"`
+	Lint     []previewLintIssue  `json:"lint"`
+}
+
+type previewFileData struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	ContentID   string `json:"content_id,omitempty"`
+	Inline      bool   `json:"inline"`
+	Size        int    `json:"size"`
+}
+
+type previewLintIssue struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+type PreviewTemplateHandler struct{}
+
+func (h *PreviewTemplateHandler) Handle(ctx context.Context, req *PreviewTemplateRequest) (*baseHandler.Response[PreviewTemplateResponse], error) {
+	// STEP-1: Render the template and build its message
+	query := queries.PreviewTemplateQuery{
+		Name:     req.Name,
+		Email:    req.EmailAccount,
+		Language: req.Language,
+		To:       req.To,
+		Data:     req.Data,
+	}
+	if query.To == "" {
+		query.To = previewRecipient
+	}
+	resp, err := mediator.Send[*queries.PreviewTemplateQuery, *queries.PreviewTemplateQueryResponse](ctx, &query)
+	switch {
+	case errors.Is(err, shared.ErrNotFound):
+		return baseHandler.NotFoundResponse[PreviewTemplateResponse](), nil
+	case errors.Is(err, email_sender.ErrSubjectRequired), errors.Is(err, email_sender.ErrBodyRequired):
+		return baseHandler.FailedResponse[PreviewTemplateResponse](err), nil
+	case err != nil:
+		return nil, err
+	}
+
+	// STEP-2: Return the message as an .eml file when it is asked for
+	if req.Format == "eml" {
+		response := baseHandler.ContentResponse[PreviewTemplateResponse]("message/rfc822", resp.Message)
+		response.Headers = map[string]string{
+			"Content-Disposition": fmt.Sprintf(`attachment; filename="%s.eml"`, req.Name),
+		}
+		return response, nil
+	}
+
+	// STEP-3: Fill the response data
+	respData := PreviewTemplateResponse{
+		Language: resp.Language,
+		Subject:  resp.Subject,
+		HTML:     resp.HTML,
+		Text:     resp.Text,
+		Headers:  resp.Header,
+		Files:    make([]previewFileData, 0, len(resp.Files)),
+		Raw:      string(resp.Message),
+		Lint:     make([]previewLintIssue, 0, len(resp.Issues)),
+	}
+	for _, file := range resp.Files {
+		respData.Files = append(respData.Files, previewFileData{
+			Name:        file.Name,
+			ContentType: file.ContentType,
+			ContentID:   file.ContentID,
+			Inline:      file.Inline,
+			Size:        file.Size,
+		})
+	}
+	for _, issue := range resp.Issues {
+		respData.Lint = append(respData.Lint, previewLintIssue{
+			Rule:     issue.Rule,
+			Severity: string(issue.Severity),
+			Message:  issue.Message,
+		})
+	}
+
+	// STEP-4: Return data and hateoas links to user
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		RouteWithQuery("nf:download", "notification.templates.preview", "Download the previewed message as an .eml file", url.Values{"format": {"eml"}}, req.Name).
+		Build()
+	return response, nil
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/mediatr/commands"
+	bounce_handler "platform/internal/notification/services/bounceHandler"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type ProcessBounceRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Message   string    `reqHeader:"-" params:"-" query:"-" json:"message" validate:"required"`
+}
+
+type ProcessBounceResponse struct {
+	ReportingMTA      string                `json:"reporting_mta"`
+	OriginalMessageID string                `json:"original_message_id"`
+	Recipients        []bounceRecipientData `json:"recipients"`
+	Suppressed        []string              `json:"suppressed"`
+}
+
+type bounceRecipientData struct {
+	Recipient      string `json:"recipient"`
+	Action         string `json:"action"`
+	Status         string `json:"status"`
+	DiagnosticCode string `json:"diagnostic_code"`
+	HardBounce     bool   `json:"hard_bounce"`
+}
+
+type ProcessBounceHandler struct{}
+
+func (h *ProcessBounceHandler) Handle(ctx context.Context, req *ProcessBounceRequest) (*baseHandler.Response[ProcessBounceResponse], error) {
+	// STEP-1: Parse the delivery report and suppress the hard bounced recipients
+	command := commands.ProcessBounceCommand{Message: req.Message}
+	resp, err := mediator.Send[*commands.ProcessBounceCommand, *commands.ProcessBounceCommandResponse](ctx, &command)
+	if errors.Is(err, bounce_handler.ErrNotDeliveryReport) {
+		return baseHandler.FailedResponse[ProcessBounceResponse](err), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Fill the response data
+	respData := ProcessBounceResponse{
+		ReportingMTA:      resp.Report.ReportingMTA,
+		OriginalMessageID: resp.Report.OriginalMessageID,
+		Recipients:        make([]bounceRecipientData, 0, len(resp.Report.Recipients)),
+		Suppressed:        resp.Suppressed,
+	}
+	if respData.Suppressed == nil {
+		respData.Suppressed = []string{}
+	}
+	for _, r := range resp.Report.Recipients {
+		respData.Recipients = append(respData.Recipients, bounceRecipientData{
+			Recipient:      r.Recipient,
+			Action:         r.Action,
+			Status:         r.Status,
+			DiagnosticCode: r.DiagnosticCode,
+			HardBounce:     r.HardBounce(),
+		})
+	}
+
+	// STEP-3: Return data and hateoas links to user
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		RouteWithQuery("nf:suppressions", "notification.suppressions.list", "List the suppressed addresses on the first page", firstPage).
+		Build()
+	return response, nil
+}
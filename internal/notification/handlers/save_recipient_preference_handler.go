@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/mediatr/commands"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type SaveRecipientPreferenceRequest struct {
+	ProjectID  uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Email      string    `reqHeader:"-" params:"email" query:"-" json:"-" validate:"required,email"`
+	Phone      string    `reqHeader:"-" params:"-" query:"-" json:"phone" validate:"omitempty,max=32"`
+	Region     string    `reqHeader:"-" params:"-" query:"-" json:"region" validate:"omitempty,len=2"`
+	WebhookURL string    `reqHeader:"-" params:"-" query:"-" json:"webhook_url" validate:"omitempty,url"`
+	Language   string    `reqHeader:"-" params:"-" query:"-" json:"language" validate:"omitempty,max=8"`
+	Channels   []string  `reqHeader:"-" params:"-" query:"-" json:"channels" validate:"unique,dive,oneof=email sms webhook"`
+}
+
+type SaveRecipientPreferenceResponse struct {
+}
+
+type SaveRecipientPreferenceHandler struct{}
+
+func (h *SaveRecipientPreferenceHandler) Handle(ctx context.Context, req *SaveRecipientPreferenceRequest) (*baseHandler.Response[SaveRecipientPreferenceResponse], error) {
+	// STEP-1: Save the preferences
+	channels := make([]domain.Channel, 0, len(req.Channels))
+	for _, channel := range req.Channels {
+		channels = append(channels, domain.Channel(channel))
+	}
+	command := commands.SaveRecipientPreferenceCommand{
+		Email:      req.Email,
+		Phone:      req.Phone,
+		Region:     req.Region,
+		WebhookURL: req.WebhookURL,
+		Language:   req.Language,
+		Channels:   channels,
+	}
+	_, err := mediator.Send[*commands.SaveRecipientPreferenceCommand, *commands.SaveRecipientPreferenceCommandResponse](ctx, &command)
+	if err != nil {
+		return baseHandler.FailedResponse[SaveRecipientPreferenceResponse](err), nil
+	}
+
+	// STEP-2: Return hateoas links to user
+	respData := SaveRecipientPreferenceResponse{}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		Route("self", "notification.preferences.save", "Update the preferences of this recipient", req.Email).
+		Route("nf:notify", "notification.notifications.send", "Send a notification").
+		Build()
+	return response, nil
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/mediatr/commands"
+	"platform/internal/shared"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SendNotificationRequest struct {
+	ProjectID    uuid.UUID         `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Recipient    string            `reqHeader:"-" params:"-" query:"-" json:"recipient" validate:"required,email"`
+	TemplateName string            `reqHeader:"-" params:"-" query:"-" json:"template_name" validate:"required,max=128"`
+	Language     string            `reqHeader:"-" params:"-" query:"-" json:"language" validate:"omitempty,max=8"`
+	Data         map[string]string `reqHeader:"-" params:"-" query:"-" json:"data"`
+	Channels     []string          `reqHeader:"-" params:"-" query:"-" json:"channels" validate:"omitempty,unique,dive,oneof=email sms webhook"`
+}
+
+type SendNotificationResponse struct {
+	ID         uuid.UUID      `json:"id"`
+	Language   string         `json:"language"`
+	Deliveries []deliveryData `json:"deliveries"`
+}
+
+type deliveryData struct {
+	Channel           string    `json:"channel"`
+	Status            string    `json:"status"`
+	ProviderMessageID string    `json:"provider_message_id,omitempty"`
+	Error             string    `json:"error,omitempty"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type SendNotificationHandler struct{}
+
+func (h *SendNotificationHandler) Handle(ctx context.Context, req *SendNotificationRequest) (*baseHandler.Response[SendNotificationResponse], error) {
+	// STEP-1: Send the notification
+	channels := make([]domain.Channel, 0, len(req.Channels))
+	for _, channel := range req.Channels {
+		channels = append(channels, domain.Channel(channel))
+	}
+	command := commands.SendNotificationCommand{
+		Recipient:    req.Recipient,
+		TemplateName: req.TemplateName,
+		Language:     req.Language,
+		Data:         req.Data,
+		Channels:     channels,
+	}
+	resp, err := mediator.Send[*commands.SendNotificationCommand, *commands.SendNotificationCommandResponse](ctx, &command)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Return the status of each channel and hateoas links to user
+	respData := SendNotificationResponse{
+		ID:         resp.NotificationID,
+		Language:   resp.Language,
+		Deliveries: toDeliveryData(resp.Deliveries),
+	}
+	response := baseHandler.CreatedResponse(&respData)
+	response.Links = hateoasLinksForNotification(ctx, resp.NotificationID)
+	return response, nil
+}
+
+func toDeliveryData(deliveries []domain.Delivery) []deliveryData {
+	list := make([]deliveryData, 0, len(deliveries))
+	for _, d := range deliveries {
+		list = append(list, deliveryData{
+			Channel:           string(d.Channel),
+			Status:            string(d.Status),
+			ProviderMessageID: d.ProviderMessageID,
+			Error:             d.Error,
+			UpdatedAt:         d.UpdatedAt,
+		})
+	}
+	return list
+}
+
+func hateoasLinksForNotification(ctx context.Context, id uuid.UUID) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		Route("self", "notification.notifications.get", "View the delivery status of this notification", id.String()).
+		Build()
+}
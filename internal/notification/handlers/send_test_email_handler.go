@@ -2,9 +2,10 @@ package handlers
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"platform/internal/notification/mediatr/commands"
 	"platform/internal/notification/mediatr/queries"
+	email_sender "platform/internal/notification/services/emailSender"
 	"platform/internal/shared"
 	"platform/pkg/services/mediator"
 
@@ -41,6 +42,11 @@ func (h *SendTestEmailHandler) Handle(ctx context.Context, req *SendTestEmailReq
 		To:   req.To,
 	}
 	_, err = mediator.Send[*commands.SendTestEmailCommand, *commands.SendTestEmailCommandResponse](ctx, &command)
+	var sendErr *email_sender.SendError
+	if errors.As(err, &sendErr) {
+		// Wrong settings of the account are reported to the user, e.g. "auth: 535 Authentication failed"
+		return baseHandler.FailedResponse[SendTestEmailResponse](sendErr), nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -48,31 +54,15 @@ func (h *SendTestEmailHandler) Handle(ctx context.Context, req *SendTestEmailReq
 	// STEP-10: Return hateoas links to user
 	respData := SendTestEmailResponse{}
 	response := baseHandler.SuccessResponse(&respData)
-	response.Links = hateoasLinksForTestEmail(req.From)
+	response.Links = hateoasLinksForTestEmail(ctx, req.From)
 	return response, nil
 }
 
-func hateoasLinksForTestEmail(email string) shared.HALLinks {
-	return shared.HALLinks{
-		"delete": {
-			Href:   fmt.Sprintf("/v1/notification/email-accounts/%s", email),
-			Method: "DELETE",
-			Title:  "Delete this email account",
-		},
-		"list": {
-			Href:   "/v1/notification/email-accounts?p=1&ps=10",
-			Method: "GET",
-			Title:  "List all emails on the first page",
-		},
-		"self": {
-			Href:   fmt.Sprintf("/v1/notification/email-accounts/%s", email),
-			Method: "GET",
-			Title:  "View this email account",
-		},
-		"update": {
-			Href:   fmt.Sprintf("/v1/notification/email-accounts/%s", email),
-			Method: "PUT",
-			Title:  "Update this email account",
-		},
-	}
+func hateoasLinksForTestEmail(ctx context.Context, email string) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		Route("nf:delete", "notification.email-accounts.delete", "Delete this email account", email).
+		RouteWithQuery("nf:list", "notification.email-accounts.list", "List all emails on the first page", firstPage).
+		Route("self", "notification.email-accounts.get", "View this email account", email).
+		Route("nf:update", "notification.email-accounts.update", "Update this email account", email).
+		Build()
 }
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"context"
+	"platform/internal/notification/mediatr/commands"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type SubscribeRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Email     string    `reqHeader:"-" params:"-" query:"-" json:"email" validate:"required,email"`
+	Language  string    `reqHeader:"-" params:"-" query:"-" json:"language" validate:"omitempty,max=8"`
+}
+
+type SubscribeResponse struct {
+	Status string `json:"status"`
+}
+
+type SubscribeHandler struct{}
+
+func (h *SubscribeHandler) Handle(ctx context.Context, req *SubscribeRequest) (*baseHandler.Response[SubscribeResponse], error) {
+	// STEP-1: Create the pending subscription and send the confirmation email
+	command := commands.SubscribeCommand{Email: req.Email, Language: req.Language}
+	resp, err := mediator.Send[*commands.SubscribeCommand, *commands.SubscribeCommandResponse](ctx, &command)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Return hateoas links to user
+	respData := SubscribeResponse{Status: "pending"}
+	if resp.AlreadyConfirmed {
+		respData.Status = "confirmed"
+	}
+	response := baseHandler.CreatedResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		RouteWithQuery("nf:list", "notification.subscriptions.list", "List all subscriptions on the first page", firstPage).
+		Build()
+	return response, nil
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/mediatr/commands"
+	delivery_tracker "platform/internal/notification/services/deliveryTracker"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"go.uber.org/zap"
+)
+
+// transparentGIF is the 1x1 tracking pixel
+var transparentGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// noStore keeps the mail clients and proxies from caching the tracking responses, every open is requested
+var noStore = map[string]string{"Cache-Control": "no-store, no-cache, must-revalidate, max-age=0"}
+
+type TrackEmailOpenRequest struct {
+	Token string `reqHeader:"-" params:"token" query:"-" json:"-" validate:"required"`
+}
+
+type TrackEmailResponse struct{}
+
+type TrackEmailOpenHandler struct{}
+
+// Handle always returns the pixel, a broken image would show in the message
+func (h *TrackEmailOpenHandler) Handle(ctx context.Context, req *TrackEmailOpenRequest) (*baseHandler.Response[TrackEmailResponse], error) {
+	command := commands.TrackEmailOpenCommand{Token: req.Token}
+	_, err := mediator.Send[*commands.TrackEmailOpenCommand, *commands.TrackEmailOpenCommandResponse](ctx, &command)
+	if err != nil && !errors.Is(err, delivery_tracker.ErrInvalidToken) && !errors.Is(err, delivery_tracker.ErrDeliveryNotFound) {
+		zap.L().Error("failed to record email open", zap.Error(err))
+	}
+
+	response := baseHandler.ContentResponse[TrackEmailResponse]("image/gif", transparentGIF)
+	response.Headers = noStore
+	return response, nil
+}
+
+type TrackEmailClickRequest struct {
+	Token string `reqHeader:"-" params:"token" query:"-" json:"-" validate:"required"`
+}
+
+type TrackEmailClickHandler struct{}
+
+// Handle sends the reader to the original link, even when the click cannot be saved. The links of unknown
+// messages are not followed, the endpoint would redirect anywhere otherwise.
+func (h *TrackEmailClickHandler) Handle(ctx context.Context, req *TrackEmailClickRequest) (*baseHandler.Response[TrackEmailResponse], error) {
+	command := commands.TrackEmailClickCommand{Token: req.Token}
+	resp, err := mediator.Send[*commands.TrackEmailClickCommand, *commands.TrackEmailClickCommandResponse](ctx, &command)
+	switch {
+	case errors.Is(err, delivery_tracker.ErrInvalidToken), errors.Is(err, delivery_tracker.ErrDeliveryNotFound):
+		return baseHandler.NotFoundResponse[TrackEmailResponse](), nil
+	case resp == nil:
+		return nil, err
+	case err != nil:
+		zap.L().Error("failed to record email click", zap.Error(err))
+	}
+
+	response := baseHandler.RedirectResponse[TrackEmailResponse](resp.URL)
+	response.Headers = noStore
+	return response, nil
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"platform/internal/notification/mediatr/commands"
+	"platform/internal/notification/mediatr/queries"
+	subscription_token "platform/internal/notification/services/subscriptionToken"
+	baseHandler "platform/internal/shared/handlers"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+type UnsubscribeRequest struct {
+	ProjectID uuid.UUID `reqHeader:"X-Project-ID" params:"-" query:"-" json:"-" validate:"required,uuid"`
+	Email     string    `reqHeader:"-" params:"email" query:"-" json:"-" validate:"required,email"`
+}
+
+type UnsubscribeResponse struct {
+	Email string `json:"email"`
+}
+
+type UnsubscribeHandler struct{}
+
+func (h *UnsubscribeHandler) Handle(ctx context.Context, req *UnsubscribeRequest) (*baseHandler.Response[UnsubscribeResponse], error) {
+	// STEP-1: Delete the subscription
+	command := commands.UnsubscribeCommand{Email: req.Email}
+	resp, err := mediator.Send[*commands.UnsubscribeCommand, *commands.UnsubscribeCommandResponse](ctx, &command)
+	if err != nil {
+		return baseHandler.FailedResponse[UnsubscribeResponse](err), nil
+	}
+
+	// STEP-2: Return hateoas links to user
+	respData := UnsubscribeResponse{Email: resp.Email}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		RouteWithQuery("nf:list", "notification.subscriptions.list", "List all subscriptions on the first page", firstPage).
+		Build()
+	return response, nil
+}
+
+// OneClickUnsubscribeRequest is sent by mail clients with List-Unsubscribe=One-Click in a form body (RFC 8058),
+// or by the page confirming the link of the email
+type OneClickUnsubscribeRequest struct {
+	Token string `reqHeader:"-" params:"-" query:"token" json:"-" validate:"required"`
+}
+
+type OneClickUnsubscribeHandler struct{}
+
+func (h *OneClickUnsubscribeHandler) Handle(ctx context.Context, req *OneClickUnsubscribeRequest) (*baseHandler.Response[UnsubscribeResponse], error) {
+	command := commands.UnsubscribeCommand{Token: req.Token}
+	resp, err := mediator.Send[*commands.UnsubscribeCommand, *commands.UnsubscribeCommandResponse](ctx, &command)
+	switch {
+	case errors.Is(err, subscription_token.ErrInvalidToken), errors.Is(err, subscription_token.ErrTokenExpired):
+		return baseHandler.FailedResponse[UnsubscribeResponse](err), nil
+	case err != nil:
+		return nil, err
+	}
+
+	respData := UnsubscribeResponse{Email: resp.Email}
+	return baseHandler.SuccessResponse(&respData), nil
+}
+
+// UnsubscribeConfirmationHandler answers a browser opening the one-click unsubscribe link of an email. It does not
+// unsubscribe, link scanners of mailboxes open the links too, the returned link unsubscribes.
+type UnsubscribeConfirmationHandler struct{}
+
+func (h *UnsubscribeConfirmationHandler) Handle(ctx context.Context, req *OneClickUnsubscribeRequest) (*baseHandler.Response[UnsubscribeResponse], error) {
+	// STEP-1: Read the address of the token
+	query := queries.GetUnsubscribeTokenQuery{Token: req.Token}
+	resp, err := mediator.Send[*queries.GetUnsubscribeTokenQuery, *queries.GetUnsubscribeTokenQueryResponse](ctx, &query)
+	switch {
+	case errors.Is(err, subscription_token.ErrInvalidToken), errors.Is(err, subscription_token.ErrTokenExpired):
+		return baseHandler.FailedResponse[UnsubscribeResponse](err), nil
+	case err != nil:
+		return nil, err
+	}
+
+	// STEP-2: Return the link confirming the unsubscription
+	respData := UnsubscribeResponse{Email: resp.Email}
+	response := baseHandler.SuccessResponse(&respData)
+	response.Links = baseHandler.NewLinks(ctx).
+		RouteWithQuery("nf:unsubscribe", "notification.subscriptions.one-click-unsubscribe", "Unsubscribe "+resp.Email, url.Values{"token": {req.Token}}).
+		Build()
+	return response, nil
+}
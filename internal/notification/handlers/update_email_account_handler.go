@@ -2,10 +2,11 @@ package handlers
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"platform/internal/notification/mediatr/commands"
 	event_notification "platform/internal/notification/mediatr/notifications"
 	"platform/internal/notification/mediatr/queries"
+	email_provider "platform/internal/notification/services/emailProvider"
 	"platform/internal/shared"
 	"platform/pkg/services/mediator"
 
@@ -21,12 +22,14 @@ type UpdateEmailAccountRequest struct {
 	Host         string    `reqHeader:"-" params:"-" query:"-" json:"host" validate:"required,hostname|ip,max=255"`
 	Port         int       `reqHeader:"-" params:"-" query:"-" json:"port" validate:"required,min=1,max=65535"`
 	EnableSSL    bool      `reqHeader:"-" params:"-" query:"-" json:"enable_ssl"`
-	TypeID       int       `reqHeader:"-" params:"-" query:"-" json:"type_id" validate:"required,oneof=1 2 3"`
+	TypeID       int       `reqHeader:"-" params:"-" query:"-" json:"type_id" validate:"required,min=1"`
 	Username     string    `reqHeader:"-" params:"-" query:"-" json:"username"`
 	Password     string    `reqHeader:"-" params:"-" query:"-" json:"password"`
 	ClientID     string    `reqHeader:"-" params:"-" query:"-" json:"client_id"`
 	TenantID     string    `reqHeader:"-" params:"-" query:"-" json:"tenant_id"`
 	ClientSecret string    `reqHeader:"-" params:"-" query:"-" json:"client_secret"`
+	MaxPerMinute int       `reqHeader:"-" params:"-" query:"-" json:"max_per_minute" validate:"min=0"`
+	MaxPerDay    int       `reqHeader:"-" params:"-" query:"-" json:"max_per_day" validate:"min=0"`
 }
 
 type UpdateEmailAccountResponse struct {
@@ -58,9 +61,14 @@ func (h *UpdateEmailAccountHandler) Handle(ctx context.Context, req *UpdateEmail
 		ClientID:     req.ClientID,
 		TenantID:     req.TenantID,
 		ClientSecret: req.ClientSecret,
+		MaxPerMinute: req.MaxPerMinute,
+		MaxPerDay:    req.MaxPerDay,
 	}
 	_, err = mediator.Send[*commands.UpdateEmailAccountCommand, *commands.UpdateEmailAccountCommandResponse](ctx, &command)
-	if err != nil {
+	switch {
+	case errors.Is(err, email_provider.ErrUnknownProvider):
+		return baseHandler.FailedResponse[UpdateEmailAccountResponse](err), nil
+	case err != nil:
 		return nil, err
 	}
 
@@ -71,31 +79,15 @@ func (h *UpdateEmailAccountHandler) Handle(ctx context.Context, req *UpdateEmail
 	// STEP-4: Return hateoas links to user
 	respData := UpdateEmailAccountResponse{}
 	response := baseHandler.SuccessResponse(&respData)
-	response.Links = hateoasLinksForUpdate(req.Email)
+	response.Links = hateoasLinksForUpdate(ctx, req.Email)
 	return response, nil
 }
 
-func hateoasLinksForUpdate(email string) shared.HALLinks {
-	return shared.HALLinks{
-		"delete": {
-			Href:   fmt.Sprintf("/v1/notification/email-accounts/%s", email),
-			Method: "DELETE",
-			Title:  "Delete this email account",
-		},
-		"list": {
-			Href:   "/v1/notification/email-accounts?p=1&ps=10",
-			Method: "GET",
-			Title:  "List all emails on the first page",
-		},
-		"self": {
-			Href:   fmt.Sprintf("/v1/notification/email-accounts/%s", email),
-			Method: "GET",
-			Title:  "View this email account",
-		},
-		"update": {
-			Href:   fmt.Sprintf("/v1/notification/email-accounts/%s", email),
-			Method: "PUT",
-			Title:  "Update this email account",
-		},
-	}
+func hateoasLinksForUpdate(ctx context.Context, email string) shared.HALLinks {
+	return baseHandler.NewLinks(ctx).
+		Route("nf:delete", "notification.email-accounts.delete", "Delete this email account", email).
+		RouteWithQuery("nf:list", "notification.email-accounts.list", "List all emails on the first page", firstPage).
+		Route("self", "notification.email-accounts.get", "View this email account", email).
+		Route("nf:update", "notification.email-accounts.update", "Update this email account", email).
+		Build()
 }
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+
+	"github.com/google/uuid"
+)
+
+// AddSuppressionCommand blocks the emails sent to an address
+type AddSuppressionCommand struct {
+	Email  string
+	Reason string
+}
+
+type AddSuppressionCommandResponse struct{}
+
+type AddSuppressionCommandHandler struct {
+	repository repositories.SuppressionRepository
+}
+
+func NewAddSuppressionCommandHandler(repository repositories.SuppressionRepository) *AddSuppressionCommandHandler {
+	return &AddSuppressionCommandHandler{repository: repository}
+}
+
+func (c *AddSuppressionCommandHandler) Handle(ctx context.Context, command *AddSuppressionCommand) (*AddSuppressionCommandResponse, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	email, err := voExternal.NewEmail(command.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Save the suppression
+	suppression := domain.NewSuppression(projectID, email, domain.SuppressionManual, command.Reason)
+	if err := c.repository.Save(ctx, suppression); err != nil {
+		return nil, err
+	}
+	return &AddSuppressionCommandResponse{}, nil
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	email_provider "platform/internal/notification/services/emailProvider"
+	oauth2_state "platform/internal/notification/services/oauth2State"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+// AuthorizeEmailAccountCommand starts the OAuth2 authorization flow of an email account, the user opens the
+// returned URL to grant the access on the consent screen of the provider
+type AuthorizeEmailAccountCommand struct {
+	Email string
+}
+
+type AuthorizeEmailAccountCommandResponse struct {
+	AuthorizationURL string
+	ExpireAt         time.Time
+}
+
+type AuthorizeEmailAccountCommandHandler struct {
+	states      *oauth2_state.Store
+	repository  repositories.EmailAccountRepository
+	redirectURL string
+}
+
+func NewAuthorizeEmailAccountCommandHandler(states *oauth2_state.Store, repository repositories.EmailAccountRepository, redirectURL string) *AuthorizeEmailAccountCommandHandler {
+	return &AuthorizeEmailAccountCommandHandler{
+		states:      states,
+		repository:  repository,
+		redirectURL: redirectURL,
+	}
+}
+
+func (c *AuthorizeEmailAccountCommandHandler) Handle(ctx context.Context, command *AuthorizeEmailAccountCommand) (*AuthorizeEmailAccountCommandResponse, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Get the email account
+	email, err := voExternal.NewEmail(command.Email)
+	if err != nil {
+		return nil, err
+	}
+	ea, err := c.repository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if ea == nil {
+		return nil, shared.ErrNotFound
+	}
+	if ea.GetOAuth2Credentials() == nil {
+		return nil, email_provider.ErrNotOAuth2Account
+	}
+
+	// STEP-3: Get the OAuth2 configuration of the provider of the account
+	clientID, tenantID, clientSecret := ea.GetOAuth2Credentials().Credentials()
+	conf, err := email_provider.OAuth2Config(ea.GetSmtpType(), clientID, tenantID, clientSecret, c.redirectURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-4: Issue a single-use state with its PKCE verifier
+	state, verifier, err := c.states.Issue(ctx, projectID, email.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	// The consent prompt makes the providers return a refresh token even when the access was granted before
+	url := conf.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier), oauth2.SetAuthURLParam("prompt", "consent"))
+	return &AuthorizeEmailAccountCommandResponse{
+		AuthorizationURL: url,
+		ExpireAt:         time.Now().Add(oauth2_state.StateTTL),
+	}, nil
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	"platform/internal/shared"
+
+	"github.com/google/uuid"
+)
+
+type CampaignAction string
+
+const (
+	PauseCampaign  CampaignAction = "pause"
+	ResumeCampaign CampaignAction = "resume"
+	CancelCampaign CampaignAction = "cancel"
+)
+
+// ChangeCampaignStatusCommand pauses, resumes or cancels a campaign
+type ChangeCampaignStatusCommand struct {
+	ID     uuid.UUID
+	Action CampaignAction
+}
+
+type ChangeCampaignStatusCommandResponse struct {
+	Status string
+}
+
+type ChangeCampaignStatusCommandHandler struct {
+	campaignRepository    repositories.CampaignRepository
+	queuedEmailRepository repositories.QueuedEmailRepository
+}
+
+func NewChangeCampaignStatusCommandHandler(campaignRepository repositories.CampaignRepository, queuedEmailRepository repositories.QueuedEmailRepository) *ChangeCampaignStatusCommandHandler {
+	return &ChangeCampaignStatusCommandHandler{
+		campaignRepository:    campaignRepository,
+		queuedEmailRepository: queuedEmailRepository,
+	}
+}
+
+func (c *ChangeCampaignStatusCommandHandler) Handle(ctx context.Context, command *ChangeCampaignStatusCommand) (*ChangeCampaignStatusCommandResponse, error) {
+	// STEP-1: Get the campaign
+	campaign, err := c.campaignRepository.GetByID(ctx, command.ID)
+	if err != nil {
+		return nil, err
+	}
+	if campaign == nil {
+		return nil, shared.ErrNotFound
+	}
+
+	// STEP-2: Apply the action
+	switch command.Action {
+	case PauseCampaign:
+		err = campaign.Pause()
+	case ResumeCampaign:
+		err = campaign.Resume()
+	case CancelCampaign:
+		err = campaign.Cancel()
+	default:
+		err = shared.ErrValidation
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-3: Save the campaign, a cancelled campaign drops the emails it has not sent
+	if err := c.campaignRepository.Update(ctx, campaign); err != nil {
+		return nil, err
+	}
+	if campaign.GetStatus() == domain.CampaignCancelled {
+		if err := c.queuedEmailRepository.DeleteUnsent(ctx, campaign.GetID()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ChangeCampaignStatusCommandResponse{Status: string(campaign.GetStatus())}, nil
+}
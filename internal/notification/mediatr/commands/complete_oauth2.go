@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	voInternal "platform/internal/notification/domain/value_object"
+	"platform/internal/notification/repositories"
+	email_provider "platform/internal/notification/services/emailProvider"
+	oauth2_state "platform/internal/notification/services/oauth2State"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+var ErrOAuth2Denied = errors.New("oauth2 authorization was not granted")
+
+// CompleteOAuth2Command handles the redirection of the provider at the end of the authorization flow, the
+// state decides which email account receives the tokens
+type CompleteOAuth2Command struct {
+	State            string
+	Code             string
+	Error            string
+	ErrorDescription string
+}
+
+type CompleteOAuth2CommandResponse struct {
+	ProjectID uuid.UUID
+	Email     string
+}
+
+type CompleteOAuth2CommandHandler struct {
+	states      *oauth2_state.Store
+	repository  repositories.EmailAccountRepository
+	redirectURL string
+}
+
+func NewCompleteOAuth2CommandHandler(states *oauth2_state.Store, repository repositories.EmailAccountRepository, redirectURL string) *CompleteOAuth2CommandHandler {
+	return &CompleteOAuth2CommandHandler{
+		states:      states,
+		repository:  repository,
+		redirectURL: redirectURL,
+	}
+}
+
+func (c *CompleteOAuth2CommandHandler) Handle(ctx context.Context, command *CompleteOAuth2Command) (*CompleteOAuth2CommandResponse, error) {
+	// STEP-1: Consume the state, it cannot be used again even when the user denied the access
+	grant, err := c.states.Consume(ctx, command.State)
+	if err != nil {
+		return nil, err
+	}
+	if command.Error != "" {
+		return nil, fmt.Errorf("%w: %s %s", ErrOAuth2Denied, command.Error, command.ErrorDescription)
+	}
+	if command.Code == "" {
+		return nil, ErrOAuth2Denied
+	}
+
+	// STEP-2: Get the email account of the project the state was issued for
+	ctx = context.WithValue(ctx, shared.ProjectIDContextKey, grant.ProjectID)
+	email, err := voExternal.NewEmail(grant.Email)
+	if err != nil {
+		return nil, err
+	}
+	ea, err := c.repository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if ea == nil {
+		return nil, shared.ErrNotFound
+	}
+	if ea.GetOAuth2Credentials() == nil {
+		return nil, email_provider.ErrNotOAuth2Account
+	}
+
+	// STEP-3: Exchange the code with the PKCE verifier
+	clientID, tenantID, clientSecret := ea.GetOAuth2Credentials().Credentials()
+	conf, err := email_provider.OAuth2Config(ea.GetSmtpType(), clientID, tenantID, clientSecret, c.redirectURL)
+	if err != nil {
+		return nil, err
+	}
+	token, err := conf.Exchange(ctx, command.Code, oauth2.VerifierOption(grant.Verifier))
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-4: Save the tokens, providers may not send the refresh token again
+	refreshToken := token.RefreshToken
+	if refreshToken == "" && ea.GetTokenInformation() != nil {
+		_, refreshToken, _ = ea.GetTokenInformation().TokenInformation()
+	}
+	ea.SetTokenInformation(voInternal.NewTokenInformation(token.AccessToken, refreshToken, token.Expiry))
+	ea.SetNeedsReconsent(false)
+	if err := c.repository.Update(ctx, ea); err != nil {
+		return nil, err
+	}
+
+	return &CompleteOAuth2CommandResponse{ProjectID: grant.ProjectID, Email: email.Value()}, nil
+}
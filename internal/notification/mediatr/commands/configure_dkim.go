@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"context"
+	voInternal "platform/internal/notification/domain/value_object"
+	"platform/internal/notification/repositories"
+	email_sender "platform/internal/notification/services/emailSender"
+	"platform/internal/notification/services/encryption"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+)
+
+// ConfigureDkimCommand enables the DKIM signing of the messages sent by an email account. A new key is
+// generated when PrivateKey is empty.
+type ConfigureDkimCommand struct {
+	Email      string
+	Domain     string
+	Selector   string
+	Algorithm  string
+	PrivateKey string
+}
+
+type ConfigureDkimCommandResponse struct {
+	RecordName  string
+	RecordValue string
+}
+
+type ConfigureDkimCommandHandler struct {
+	encryption encryption.EncryptionService
+	repository repositories.EmailAccountRepository
+}
+
+func NewConfigureDkimCommandHandler(encryption encryption.EncryptionService, repository repositories.EmailAccountRepository) *ConfigureDkimCommandHandler {
+	return &ConfigureDkimCommandHandler{
+		encryption: encryption,
+		repository: repository,
+	}
+}
+
+func (c *ConfigureDkimCommandHandler) Handle(ctx context.Context, command *ConfigureDkimCommand) (*ConfigureDkimCommandResponse, error) {
+	// STEP-1: Get the email account
+	email, err := voExternal.NewEmail(command.Email)
+	if err != nil {
+		return nil, err
+	}
+	ea, err := c.repository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if ea == nil {
+		return nil, shared.ErrNotFound
+	}
+
+	// STEP-2: Generate a key or check the given one
+	privateKey := command.PrivateKey
+	if privateKey == "" {
+		if privateKey, err = email_sender.GenerateDKIMKey(command.Algorithm); err != nil {
+			return nil, err
+		}
+	} else if err := email_sender.CheckDKIMKey(command.Algorithm, privateKey); err != nil {
+		return nil, err
+	}
+
+	recordName, recordValue, err := email_sender.DKIMRecord(command.Domain, command.Selector, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-3: Save the encrypted key
+	encrypted, err := c.encryption.Encrypt(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	ea.SetDkimSettings(voInternal.NewDkimSettings(command.Domain, command.Selector, command.Algorithm, encrypted))
+	if err := c.repository.Update(ctx, ea); err != nil {
+		return nil, err
+	}
+
+	return &ConfigureDkimCommandResponse{RecordName: recordName, RecordValue: recordValue}, nil
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	email_sandbox "platform/internal/notification/services/emailSandbox"
+	"platform/internal/shared"
+
+	"github.com/google/uuid"
+)
+
+// ConfigureEmailSandboxCommand captures or redirects the messages of the project instead of delivering them,
+// it overrides the sandbox default of the environment with a mode at least as strict
+type ConfigureEmailSandboxCommand struct {
+	Mode       string
+	AllowList  []string
+	RedirectTo string
+}
+
+type ConfigureEmailSandboxCommandResponse struct{}
+
+type ConfigureEmailSandboxCommandHandler struct {
+	sandbox    *email_sandbox.Service
+	repository repositories.EmailSandboxRepository
+}
+
+func NewConfigureEmailSandboxCommandHandler(sandbox *email_sandbox.Service, repository repositories.EmailSandboxRepository) *ConfigureEmailSandboxCommandHandler {
+	return &ConfigureEmailSandboxCommandHandler{sandbox: sandbox, repository: repository}
+}
+
+func (c *ConfigureEmailSandboxCommandHandler) Handle(ctx context.Context, command *ConfigureEmailSandboxCommand) (*ConfigureEmailSandboxCommandResponse, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: The projects cannot loosen the sandbox of the environment
+	mode := domain.SandboxMode(command.Mode)
+	if mode.Looser(c.sandbox.Default().GetMode()) {
+		return nil, domain.ErrSandboxEnforced
+	}
+
+	// STEP-3: Change the settings of the project or create them
+	sandbox, err := c.repository.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sandbox == nil {
+		sandbox = domain.NewEmailSandbox(projectID, mode, command.AllowList, command.RedirectTo)
+	} else {
+		sandbox.Change(mode, command.AllowList, command.RedirectTo)
+	}
+
+	if err := c.repository.Save(ctx, sandbox); err != nil {
+		return nil, err
+	}
+	return &ConfigureEmailSandboxCommandResponse{}, nil
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+)
+
+// ConfigureEmailTrackingCommand turns the tracking pixel and the rewriting of the links of the messages sent
+// by an email account on or off
+type ConfigureEmailTrackingCommand struct {
+	Email       string
+	TrackOpens  bool
+	TrackClicks bool
+}
+
+type ConfigureEmailTrackingCommandResponse struct {
+	TrackOpens  bool
+	TrackClicks bool
+}
+
+type ConfigureEmailTrackingCommandHandler struct {
+	repository repositories.EmailAccountRepository
+}
+
+func NewConfigureEmailTrackingCommandHandler(repository repositories.EmailAccountRepository) *ConfigureEmailTrackingCommandHandler {
+	return &ConfigureEmailTrackingCommandHandler{repository: repository}
+}
+
+func (c *ConfigureEmailTrackingCommandHandler) Handle(ctx context.Context, command *ConfigureEmailTrackingCommand) (*ConfigureEmailTrackingCommandResponse, error) {
+	// STEP-1: Get the email account
+	email, err := voExternal.NewEmail(command.Email)
+	if err != nil {
+		return nil, err
+	}
+	ea, err := c.repository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if ea == nil {
+		return nil, shared.ErrNotFound
+	}
+
+	// STEP-2: Save the tracking settings
+	ea.SetTracking(command.TrackOpens, command.TrackClicks)
+	if err := c.repository.Update(ctx, ea); err != nil {
+		return nil, err
+	}
+
+	return &ConfigureEmailTrackingCommandResponse{TrackOpens: ea.GetTrackOpens(), TrackClicks: ea.GetTrackClicks()}, nil
+}
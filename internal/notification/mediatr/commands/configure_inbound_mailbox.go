@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	email_provider "platform/internal/notification/services/emailProvider"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+)
+
+var ErrInboundHostRequired = errors.New("the IMAP host is required, the provider of the email account has no default IMAP server")
+
+// ConfigureInboundMailboxCommand reads the new messages of the IMAP folder of an email account. The IMAP
+// server of the provider is used when Host is empty.
+type ConfigureInboundMailboxCommand struct {
+	Email     string
+	Host      string
+	Port      int
+	EnableSSL bool
+	Folder    string
+	UseIdle   bool
+}
+
+type ConfigureInboundMailboxCommandResponse struct{}
+
+type ConfigureInboundMailboxCommandHandler struct {
+	repository             repositories.InboundMailboxRepository
+	emailAccountRepository repositories.EmailAccountRepository
+}
+
+func NewConfigureInboundMailboxCommandHandler(repository repositories.InboundMailboxRepository, emailAccountRepository repositories.EmailAccountRepository) *ConfigureInboundMailboxCommandHandler {
+	return &ConfigureInboundMailboxCommandHandler{
+		repository:             repository,
+		emailAccountRepository: emailAccountRepository,
+	}
+}
+
+func (c *ConfigureInboundMailboxCommandHandler) Handle(ctx context.Context, command *ConfigureInboundMailboxCommand) (*ConfigureInboundMailboxCommandResponse, error) {
+	// STEP-1: Get the email account
+	email, err := voExternal.NewEmail(command.Email)
+	if err != nil {
+		return nil, err
+	}
+	ea, err := c.emailAccountRepository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if ea == nil {
+		return nil, shared.ErrNotFound
+	}
+
+	// STEP-2: Fill the server settings from the provider of the account
+	host, port, enableSSL := command.Host, command.Port, command.EnableSSL
+	if host == "" {
+		provider, err := email_provider.Get(ea.GetSmtpType())
+		if err != nil {
+			return nil, err
+		}
+		defaults := provider.Defaults()
+		if defaults.IMAPHost == "" {
+			return nil, ErrInboundHostRequired
+		}
+		host, port, enableSSL = defaults.IMAPHost, defaults.IMAPPort, true
+	}
+	if port == 0 {
+		port = 993
+		if !enableSSL {
+			port = 143
+		}
+	}
+	mailbox := domain.NewInboundMailbox(ea.GetID(), ea.GetProjectID(), host, port, enableSSL, command.Folder, command.UseIdle)
+
+	// STEP-3: Keep reading from the last processed message when the folder stays the same
+	old, err := c.repository.GetByEmailAccount(ctx, ea.GetID())
+	if err != nil {
+		return nil, err
+	}
+	if old != nil {
+		mailbox.SetCreatedAt(old.GetCreatedAt())
+		if old.GetHost() == mailbox.GetHost() && old.GetFolder() == mailbox.GetFolder() {
+			mailbox.SetCursor(old.GetUIDValidity(), old.GetLastUID())
+		}
+	}
+
+	if err := c.repository.Save(ctx, mailbox); err != nil {
+		return nil, err
+	}
+	return &ConfigureInboundMailboxCommandResponse{}, nil
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	subscription_token "platform/internal/notification/services/subscriptionToken"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+)
+
+// ConfirmSubscriptionCommand confirms the subscription the token of the confirmation email was issued for
+type ConfirmSubscriptionCommand struct {
+	Token string
+}
+
+type ConfirmSubscriptionCommandResponse struct {
+	Email string
+}
+
+type ConfirmSubscriptionCommandHandler struct {
+	signer     *subscription_token.Signer
+	repository repositories.SubscriptionRepository
+}
+
+func NewConfirmSubscriptionCommandHandler(signer *subscription_token.Signer, repository repositories.SubscriptionRepository) *ConfirmSubscriptionCommandHandler {
+	return &ConfirmSubscriptionCommandHandler{
+		signer:     signer,
+		repository: repository,
+	}
+}
+
+func (c *ConfirmSubscriptionCommandHandler) Handle(ctx context.Context, command *ConfirmSubscriptionCommand) (*ConfirmSubscriptionCommandResponse, error) {
+	// STEP-1: Verify the token, the project comes from the token since the link is opened from a mailbox
+	projectID, address, err := c.signer.Verify(command.Token, subscription_token.Confirm)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, shared.ProjectIDContextKey, projectID)
+
+	email, err := voExternal.NewEmail(address)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Confirm the subscription
+	subscription, err := c.repository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, shared.ErrNotFound
+	}
+	if err := subscription.Confirm(); err != nil {
+		return nil, err
+	}
+
+	// STEP-3: Save to database
+	if err := c.repository.Save(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return &ConfirmSubscriptionCommandResponse{Email: email.Value()}, nil
+}
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrEmailAccountNotFound = errors.New("email account not found")
+
+// CreateCampaignCommand schedules a campaign, it is sent with the default email account of the project
+// when EmailAccount is empty and to every language when Languages is empty
+type CreateCampaignCommand struct {
+	Name         string
+	TemplateName string
+	EmailAccount string
+	Languages    []string
+	ScheduledAt  time.Time
+}
+
+type CreateCampaignCommandResponse struct {
+	ID uuid.UUID
+}
+
+type CreateCampaignCommandHandler struct {
+	campaignRepository     repositories.CampaignRepository
+	emailAccountRepository repositories.EmailAccountRepository
+}
+
+func NewCreateCampaignCommandHandler(campaignRepository repositories.CampaignRepository, emailAccountRepository repositories.EmailAccountRepository) *CreateCampaignCommandHandler {
+	return &CreateCampaignCommandHandler{
+		campaignRepository:     campaignRepository,
+		emailAccountRepository: emailAccountRepository,
+	}
+}
+
+func (c *CreateCampaignCommandHandler) Handle(ctx context.Context, command *CreateCampaignCommand) (*CreateCampaignCommandResponse, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Get the email account sending the campaign
+	account, err := c.getEmailAccount(ctx, command.EmailAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-3: Keep the cultures of the languages, unknown languages fall back to the default one
+	languages := make([]string, 0, len(command.Languages))
+	for _, language := range command.Languages {
+		languages = append(languages, shared.GetLanguageManager().GetLanguageByCulture(language).GetCulture())
+	}
+
+	// STEP-4: Save the campaign, the scheduler picks it up when its time comes
+	scheduledAt := command.ScheduledAt
+	if scheduledAt.IsZero() {
+		scheduledAt = time.Now()
+	}
+	campaign := domain.NewCampaign(projectID, account.GetID(), command.Name, domain.EmailTemplateName(command.TemplateName), languages, scheduledAt)
+	if err := c.campaignRepository.Create(ctx, campaign); err != nil {
+		return nil, err
+	}
+
+	return &CreateCampaignCommandResponse{ID: campaign.GetID()}, nil
+}
+
+func (c *CreateCampaignCommandHandler) getEmailAccount(ctx context.Context, email string) (*domain.EmailAccount, error) {
+	var account *domain.EmailAccount
+	if email == "" {
+		defaultAccount, err := c.emailAccountRepository.GetDefault(ctx)
+		if err != nil {
+			return nil, err
+		}
+		account = defaultAccount
+	} else {
+		address, err := voExternal.NewEmail(email)
+		if err != nil {
+			return nil, err
+		}
+		if account, err = c.emailAccountRepository.GetByEmail(ctx, address); err != nil {
+			return nil, err
+		}
+	}
+
+	if account == nil {
+		return nil, ErrEmailAccountNotFound
+	}
+	return account, nil
+}
@@ -2,9 +2,11 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"platform/internal/notification/domain"
 	voInternal "platform/internal/notification/domain/value_object"
 	"platform/internal/notification/repositories"
+	email_provider "platform/internal/notification/services/emailProvider"
 	"platform/internal/notification/services/encryption"
 	"platform/internal/shared"
 	voExternal "platform/pkg/domain/value_object"
@@ -12,6 +14,8 @@ import (
 	"github.com/google/uuid"
 )
 
+var ErrEmailAccountHostRequired = errors.New("host and port are required, the email provider has no default server")
+
 type CreateEmailAccountCommand struct {
 	Email        string
 	DisplayName  string
@@ -54,6 +58,20 @@ func (c *CreateEmailAccountCommandHandler) Handle(ctx context.Context, command *
 		return nil, err
 	}
 
+	// STEP-2: Use the server of the provider when none is given
+	provider, err := email_provider.Get(command.TypeID)
+	if err != nil {
+		return nil, err
+	}
+	if command.Host == "" {
+		defaults := provider.Defaults()
+		command.Host, command.Port, command.EnableSSL = defaults.Host, defaults.Port, defaults.EnableSSL
+	}
+	if command.Host == "" || command.Port == 0 {
+		return nil, ErrEmailAccountHostRequired
+	}
+
+	// STEP-3: Create the email account with the credentials of its provider
 	ea := domain.EmailAccount{}
 	emailAccountID := uuid.New()
 	ea.SetID(emailAccountID)
@@ -65,7 +83,7 @@ func (c *CreateEmailAccountCommandHandler) Handle(ctx context.Context, command *
 	ea.SetEnableSSL(command.EnableSSL)
 	ea.SetSmtpType(command.TypeID)
 
-	if command.TypeID == domain.Login {
+	if !provider.Mechanism().OAuth2() {
 		encrypted, err := c.encryption.Encrypt(command.Password)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	voInternal "platform/internal/notification/domain/value_object"
+	"platform/internal/notification/repositories"
+	"platform/internal/notification/services/encryption"
+	sms_sender "platform/internal/notification/services/smsSender"
+	"platform/internal/shared"
+
+	"github.com/google/uuid"
+)
+
+type CreateSmsAccountCommand struct {
+	ProviderID int
+	Sender     string
+	Username   string
+	Password   string
+}
+
+type CreateSmsAccountCommandResponse struct {
+	ID uuid.UUID
+}
+
+type CreateSmsAccountCommandHandler struct {
+	encryption encryption.EncryptionService
+	repository repositories.SmsAccountRepository
+}
+
+func NewCreateSmsAccountCommandHandler(encryption encryption.EncryptionService, repository repositories.SmsAccountRepository) *CreateSmsAccountCommandHandler {
+	return &CreateSmsAccountCommandHandler{
+		encryption: encryption,
+		repository: repository,
+	}
+}
+
+func (c *CreateSmsAccountCommandHandler) Handle(ctx context.Context, command *CreateSmsAccountCommand) (*CreateSmsAccountCommandResponse, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Create the account, the secret is stored encrypted
+	if command.ProviderID == domain.FakeSms && !sms_sender.FakeProviderEnabled() {
+		return nil, sms_sender.ErrFakeProviderDisabled
+	}
+	sa := domain.NewSmsAccount(uuid.New(), projectID, command.ProviderID, command.Sender)
+	if command.ProviderID != domain.FakeSms {
+		encrypted, err := c.encryption.Encrypt(command.Password)
+		if err != nil {
+			return nil, err
+		}
+		sa.SetCredentials(voInternal.NewTraditionalCredentials(command.Username, encrypted))
+	}
+
+	// STEP-3: Save to database
+	err := c.repository.Create(ctx, sa)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateSmsAccountCommandResponse{ID: sa.GetID()}, nil
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	"platform/internal/shared"
+
+	"github.com/google/uuid"
+)
+
+// DeleteCapturedEmailCommand removes a captured message of the project, every message is removed when All
+// is set
+type DeleteCapturedEmailCommand struct {
+	ID  uuid.UUID
+	All bool
+}
+
+type DeleteCapturedEmailCommandResponse struct{}
+
+type DeleteCapturedEmailCommandHandler struct {
+	repository repositories.CapturedEmailRepository
+}
+
+func NewDeleteCapturedEmailCommandHandler(repository repositories.CapturedEmailRepository) *DeleteCapturedEmailCommandHandler {
+	return &DeleteCapturedEmailCommandHandler{repository: repository}
+}
+
+func (c *DeleteCapturedEmailCommandHandler) Handle(ctx context.Context, command *DeleteCapturedEmailCommand) (*DeleteCapturedEmailCommandResponse, error) {
+	if command.All {
+		if err := c.repository.DeleteAll(ctx); err != nil {
+			return nil, err
+		}
+		return &DeleteCapturedEmailCommandResponse{}, nil
+	}
+
+	email, err := c.repository.GetByID(ctx, command.ID)
+	if err != nil {
+		return nil, err
+	}
+	if email == nil {
+		return nil, shared.ErrNotFound
+	}
+	if err := c.repository.Delete(ctx, command.ID); err != nil {
+		return nil, err
+	}
+	return &DeleteCapturedEmailCommandResponse{}, nil
+}
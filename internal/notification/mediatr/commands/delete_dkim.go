@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+)
+
+// DeleteDkimCommand stops signing the messages of an email account and drops its key
+type DeleteDkimCommand struct {
+	Email string
+}
+
+type DeleteDkimCommandResponse struct{}
+
+type DeleteDkimCommandHandler struct {
+	repository repositories.EmailAccountRepository
+}
+
+func NewDeleteDkimCommandHandler(repository repositories.EmailAccountRepository) *DeleteDkimCommandHandler {
+	return &DeleteDkimCommandHandler{repository: repository}
+}
+
+func (c *DeleteDkimCommandHandler) Handle(ctx context.Context, command *DeleteDkimCommand) (*DeleteDkimCommandResponse, error) {
+	email, err := voExternal.NewEmail(command.Email)
+	if err != nil {
+		return nil, err
+	}
+	ea, err := c.repository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if ea == nil {
+		return nil, shared.ErrNotFound
+	}
+
+	ea.SetDkimSettings(nil)
+	if err := c.repository.Update(ctx, ea); err != nil {
+		return nil, err
+	}
+	return &DeleteDkimCommandResponse{}, nil
+}
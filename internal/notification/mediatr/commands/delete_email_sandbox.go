@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+)
+
+// DeleteEmailSandboxCommand removes the sandbox settings of the project, the default of the environment
+// applies again
+type DeleteEmailSandboxCommand struct{}
+
+type DeleteEmailSandboxCommandResponse struct{}
+
+type DeleteEmailSandboxCommandHandler struct {
+	repository repositories.EmailSandboxRepository
+}
+
+func NewDeleteEmailSandboxCommandHandler(repository repositories.EmailSandboxRepository) *DeleteEmailSandboxCommandHandler {
+	return &DeleteEmailSandboxCommandHandler{repository: repository}
+}
+
+func (c *DeleteEmailSandboxCommandHandler) Handle(ctx context.Context, command *DeleteEmailSandboxCommand) (*DeleteEmailSandboxCommandResponse, error) {
+	if err := c.repository.Delete(ctx); err != nil {
+		return nil, err
+	}
+	return &DeleteEmailSandboxCommandResponse{}, nil
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+)
+
+// DeleteInboundMailboxCommand stops reading the IMAP folder of an email account
+type DeleteInboundMailboxCommand struct {
+	Email string
+}
+
+type DeleteInboundMailboxCommandResponse struct{}
+
+type DeleteInboundMailboxCommandHandler struct {
+	repository             repositories.InboundMailboxRepository
+	emailAccountRepository repositories.EmailAccountRepository
+}
+
+func NewDeleteInboundMailboxCommandHandler(repository repositories.InboundMailboxRepository, emailAccountRepository repositories.EmailAccountRepository) *DeleteInboundMailboxCommandHandler {
+	return &DeleteInboundMailboxCommandHandler{
+		repository:             repository,
+		emailAccountRepository: emailAccountRepository,
+	}
+}
+
+func (c *DeleteInboundMailboxCommandHandler) Handle(ctx context.Context, command *DeleteInboundMailboxCommand) (*DeleteInboundMailboxCommandResponse, error) {
+	email, err := voExternal.NewEmail(command.Email)
+	if err != nil {
+		return nil, err
+	}
+	ea, err := c.emailAccountRepository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if ea == nil {
+		return nil, shared.ErrNotFound
+	}
+
+	if err := c.repository.Delete(ctx, ea.GetID()); err != nil {
+		return nil, err
+	}
+	return &DeleteInboundMailboxCommandResponse{}, nil
+}
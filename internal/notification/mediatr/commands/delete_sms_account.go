@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+
+	"github.com/google/uuid"
+)
+
+type DeleteSmsAccountCommand struct {
+	ID uuid.UUID
+}
+
+type DeleteSmsAccountCommandResponse struct {
+}
+
+type DeleteSmsAccountCommandHandler struct {
+	repository repositories.SmsAccountRepository
+}
+
+func NewDeleteSmsAccountCommandHandler(repository repositories.SmsAccountRepository) *DeleteSmsAccountCommandHandler {
+	return &DeleteSmsAccountCommandHandler{repository: repository}
+}
+
+func (c *DeleteSmsAccountCommandHandler) Handle(ctx context.Context, command *DeleteSmsAccountCommand) (*DeleteSmsAccountCommandResponse, error) {
+	err := c.repository.Delete(ctx, command.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteSmsAccountCommandResponse{}, nil
+}
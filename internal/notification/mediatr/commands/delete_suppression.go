@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	voExternal "platform/pkg/domain/value_object"
+)
+
+// DeleteSuppressionCommand allows sending emails to an address again, e.g. after the recipient fixed
+// their mailbox
+type DeleteSuppressionCommand struct {
+	Email string
+}
+
+type DeleteSuppressionCommandResponse struct{}
+
+type DeleteSuppressionCommandHandler struct {
+	repository repositories.SuppressionRepository
+}
+
+func NewDeleteSuppressionCommandHandler(repository repositories.SuppressionRepository) *DeleteSuppressionCommandHandler {
+	return &DeleteSuppressionCommandHandler{repository: repository}
+}
+
+func (c *DeleteSuppressionCommandHandler) Handle(ctx context.Context, command *DeleteSuppressionCommand) (*DeleteSuppressionCommandResponse, error) {
+	email, err := voExternal.NewEmail(command.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.repository.Delete(ctx, email); err != nil {
+		return nil, err
+	}
+	return &DeleteSuppressionCommandResponse{}, nil
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	delivery_tracker "platform/internal/notification/services/deliveryTracker"
+)
+
+var ErrInvalidWebhookSecret = errors.New("invalid webhook secret")
+
+// IngestDeliveryWebhookCommand adds the events of a provider notification to the delivery logs, the events
+// of unknown messages are skipped. The providers authenticate with the shared secret of the webhook URL.
+type IngestDeliveryWebhookCommand struct {
+	Provider string
+	Secret   string
+	Body     []byte
+}
+
+type IngestDeliveryWebhookCommandResponse struct {
+	Recorded int
+	Skipped  int
+}
+
+type IngestDeliveryWebhookCommandHandler struct {
+	secret   string
+	webhooks *delivery_tracker.Webhooks
+	tracker  *delivery_tracker.Tracker
+}
+
+func NewIngestDeliveryWebhookCommandHandler(secret string, webhooks *delivery_tracker.Webhooks, tracker *delivery_tracker.Tracker) *IngestDeliveryWebhookCommandHandler {
+	return &IngestDeliveryWebhookCommandHandler{
+		secret:   secret,
+		webhooks: webhooks,
+		tracker:  tracker,
+	}
+}
+
+func (c *IngestDeliveryWebhookCommandHandler) Handle(ctx context.Context, command *IngestDeliveryWebhookCommand) (*IngestDeliveryWebhookCommandResponse, error) {
+	// STEP-1: Check the secret, the webhooks are disabled while no secret is configured
+	if c.secret == "" || subtle.ConstantTimeCompare([]byte(c.secret), []byte(command.Secret)) != 1 {
+		return nil, ErrInvalidWebhookSecret
+	}
+
+	// STEP-2: Read the events of the provider
+	events, err := c.webhooks.Parse(ctx, command.Provider, command.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-3: Add them to the delivery logs
+	response := IngestDeliveryWebhookCommandResponse{}
+	for _, event := range events {
+		err := c.tracker.Record(ctx, event)
+		switch {
+		case errors.Is(err, delivery_tracker.ErrDeliveryNotFound):
+			response.Skipped++
+		case err != nil:
+			return nil, err
+		default:
+			response.Recorded++
+		}
+	}
+	return &response, nil
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"context"
+	bounce_handler "platform/internal/notification/services/bounceHandler"
+	delivery_tracker "platform/internal/notification/services/deliveryTracker"
+	"strings"
+)
+
+// ProcessBounceCommand reads a delivery status notification received after sending and suppresses the
+// recipients which bounced for good, the outcome is added to the delivery log of the original message
+type ProcessBounceCommand struct {
+	Message string
+}
+
+type ProcessBounceCommandResponse struct {
+	Report     *bounce_handler.Report
+	Suppressed []string
+}
+
+type ProcessBounceCommandHandler struct {
+	bounces *bounce_handler.Handler
+	tracker *delivery_tracker.Tracker
+}
+
+func NewProcessBounceCommandHandler(bounces *bounce_handler.Handler, tracker *delivery_tracker.Tracker) *ProcessBounceCommandHandler {
+	return &ProcessBounceCommandHandler{bounces: bounces, tracker: tracker}
+}
+
+func (c *ProcessBounceCommandHandler) Handle(ctx context.Context, command *ProcessBounceCommand) (*ProcessBounceCommandResponse, error) {
+	report, suppressed, err := c.bounces.HandleDSN(ctx, strings.NewReader(command.Message))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.tracker.RecordDSN(ctx, report); err != nil {
+		return nil, err
+	}
+	return &ProcessBounceCommandResponse{Report: report, Suppressed: suppressed}, nil
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+
+	"github.com/google/uuid"
+)
+
+type SaveRecipientPreferenceCommand struct {
+	Email      string
+	Phone      string
+	Region     string
+	WebhookURL string
+	Language   string
+	Channels   []domain.Channel
+}
+
+type SaveRecipientPreferenceCommandResponse struct {
+}
+
+type SaveRecipientPreferenceCommandHandler struct {
+	repository repositories.RecipientPreferenceRepository
+}
+
+func NewSaveRecipientPreferenceCommandHandler(repository repositories.RecipientPreferenceRepository) *SaveRecipientPreferenceCommandHandler {
+	return &SaveRecipientPreferenceCommandHandler{repository: repository}
+}
+
+func (c *SaveRecipientPreferenceCommandHandler) Handle(ctx context.Context, command *SaveRecipientPreferenceCommand) (*SaveRecipientPreferenceCommandResponse, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	email, err := voExternal.NewEmail(command.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Build the preferences, the phone is normalized to E.164
+	preference := domain.NewRecipientPreference(projectID, email, command.Language, command.Channels)
+	preference.SetWebhookURL(command.WebhookURL)
+	if command.Phone != "" {
+		phone, err := voExternal.ParsePhoneNumber(command.Phone, command.Region)
+		if err != nil {
+			return nil, err
+		}
+		preference.SetPhone(&phone)
+	}
+
+	// STEP-3: Save to database
+	if err := c.repository.Save(ctx, preference); err != nil {
+		return nil, err
+	}
+
+	return &SaveRecipientPreferenceCommandResponse{}, nil
+}
@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	"platform/internal/notification/services/dispatcher"
+	subscription_token "platform/internal/notification/services/subscriptionToken"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+
+	"github.com/google/uuid"
+)
+
+// SendNotificationCommand notifies a recipient, identified by email, on the channels they opted in to.
+// Channels limits the notification to some channels, all of them are used when it is empty.
+// Newsletters are only sent to confirmed subscribers and carry an unsubscribe link.
+type SendNotificationCommand struct {
+	Recipient    string
+	TemplateName string
+	Language     string
+	Data         map[string]string
+	Channels     []domain.Channel
+	Newsletter   bool
+}
+
+type SendNotificationCommandResponse struct {
+	NotificationID uuid.UUID
+	Language       string
+	Deliveries     []domain.Delivery
+}
+
+type SendNotificationCommandHandler struct {
+	dispatcher             *dispatcher.Dispatcher
+	signer                 *subscription_token.Signer
+	notificationRepository repositories.NotificationRepository
+	preferenceRepository   repositories.RecipientPreferenceRepository
+	subscriptionRepository repositories.SubscriptionRepository
+	suppressionRepository  repositories.SuppressionRepository
+}
+
+func NewSendNotificationCommandHandler(
+	dispatcher *dispatcher.Dispatcher,
+	signer *subscription_token.Signer,
+	notificationRepository repositories.NotificationRepository,
+	preferenceRepository repositories.RecipientPreferenceRepository,
+	subscriptionRepository repositories.SubscriptionRepository,
+	suppressionRepository repositories.SuppressionRepository,
+) *SendNotificationCommandHandler {
+	return &SendNotificationCommandHandler{
+		dispatcher:             dispatcher,
+		signer:                 signer,
+		notificationRepository: notificationRepository,
+		preferenceRepository:   preferenceRepository,
+		subscriptionRepository: subscriptionRepository,
+		suppressionRepository:  suppressionRepository,
+	}
+}
+
+func (c *SendNotificationCommandHandler) Handle(ctx context.Context, command *SendNotificationCommand) (*SendNotificationCommandResponse, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	email, err := voExternal.NewEmail(command.Recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Get the preferences of the recipient, recipients without preferences only receive emails
+	recipient, err := c.preferenceRepository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if recipient == nil {
+		recipient = domain.DefaultRecipientPreference(projectID, email)
+	}
+
+	// STEP-3: Resolve the language and check newsletter subscription
+	subscription, err := c.subscriptionRepository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	language := resolveLanguage(recipient, subscription, command.Language)
+
+	data := make(map[string]string, len(command.Data)+1)
+	for key, value := range command.Data {
+		data[key] = value
+	}
+	subscribed := subscription != nil && subscription.IsConfirmed()
+	if command.Newsletter && subscribed {
+		data[domain.UnsubscribeURLKey] = c.signer.UnsubscribeURL(projectID, email.Value())
+	}
+
+	// STEP-4: Decide which channels the notification goes to, hard-bounced addresses do not get emails
+	suppressed, err := c.suppressionRepository.IsSuppressed(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	notification := domain.NewNotification(projectID, email.Value(), command.TemplateName, language, data)
+	addDeliveries(notification, command.Channels, recipient, command.Newsletter && !subscribed, suppressed)
+
+	// STEP-5: Record the notification before sending, so failures can be tracked
+	if err := c.notificationRepository.Create(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	// STEP-6: Send and record the status of each channel
+	c.dispatcher.Dispatch(ctx, notification, recipient)
+	if err := c.notificationRepository.UpdateDeliveries(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	return &SendNotificationCommandResponse{
+		NotificationID: notification.GetID(),
+		Language:       language,
+		Deliveries:     notification.GetDeliveries(),
+	}, nil
+}
+
+// addDeliveries registers each requested channel once, every channel when none is requested. A channel is
+// pending, or skipped with the reason the recipient does not get the notification on it.
+func addDeliveries(notification *domain.Notification, channels []domain.Channel, recipient *domain.RecipientPreference, notSubscribed, suppressed bool) {
+	if len(channels) == 0 {
+		channels = domain.Channels
+	}
+	added := make(map[domain.Channel]bool, len(channels))
+	for _, channel := range channels {
+		if added[channel] {
+			continue
+		}
+		added[channel] = true
+
+		switch {
+		case notSubscribed:
+			notification.Skip(channel, "recipient is not subscribed to the newsletter")
+		case !recipient.OptedIn(channel):
+			notification.Skip(channel, "recipient opted out")
+		case !recipient.Reachable(channel):
+			notification.Skip(channel, "recipient has no address for this channel")
+		case channel == domain.EmailChannel && suppressed:
+			notification.Skip(channel, "recipient address is on the suppression list")
+		default:
+			notification.AddDelivery(channel)
+		}
+	}
+}
+
+// resolveLanguage prefers the language chosen by the recipient, then the language of their newsletter
+// subscription and finally the one given by the caller
+func resolveLanguage(recipient *domain.RecipientPreference, subscription *domain.Subscription, requested string) string {
+	if recipient.GetLanguage() != "" {
+		return recipient.GetLanguage()
+	}
+	if subscription != nil {
+		return subscription.GetLanguage().GetCulture()
+	}
+	return shared.GetLanguageManager().GetLanguageByCulture(requested).GetCulture()
+}
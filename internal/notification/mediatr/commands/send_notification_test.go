@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"platform/internal/notification/domain"
+	vo "platform/pkg/domain/value_object"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAddDeliveries(t *testing.T) {
+	projectID := uuid.New()
+	email, _ := vo.NewEmail("john@example.org")
+	phone, _ := vo.NewPhoneNumber("+905551112233")
+
+	everywhere := domain.NewRecipientPreference(projectID, email, "", domain.Channels)
+	everywhere.SetPhone(&phone)
+	everywhere.SetWebhookURL("https://hooks.example.org/notify")
+	noPhone := domain.NewRecipientPreference(projectID, email, "", []domain.Channel{domain.EmailChannel, domain.SmsChannel})
+
+	tests := []struct {
+		name          string
+		channels      []domain.Channel
+		recipient     *domain.RecipientPreference
+		notSubscribed bool
+		suppressed    bool
+		want          map[domain.Channel]domain.DeliveryStatus
+	}{
+		{"every channel", nil, everywhere, false, false, map[domain.Channel]domain.DeliveryStatus{
+			domain.EmailChannel: domain.DeliveryPending, domain.SmsChannel: domain.DeliveryPending, domain.WebhookChannel: domain.DeliveryPending,
+		}},
+		{"repeated channel", []domain.Channel{domain.EmailChannel, domain.EmailChannel}, everywhere, false, false, map[domain.Channel]domain.DeliveryStatus{
+			domain.EmailChannel: domain.DeliveryPending,
+		}},
+		{"opted out", nil, domain.DefaultRecipientPreference(projectID, email), false, false, map[domain.Channel]domain.DeliveryStatus{
+			domain.EmailChannel: domain.DeliveryPending, domain.SmsChannel: domain.DeliverySkipped, domain.WebhookChannel: domain.DeliverySkipped,
+		}},
+		{"unreachable", []domain.Channel{domain.SmsChannel}, noPhone, false, false, map[domain.Channel]domain.DeliveryStatus{
+			domain.SmsChannel: domain.DeliverySkipped,
+		}},
+		{"suppressed", nil, everywhere, false, true, map[domain.Channel]domain.DeliveryStatus{
+			domain.EmailChannel: domain.DeliverySkipped, domain.SmsChannel: domain.DeliveryPending, domain.WebhookChannel: domain.DeliveryPending,
+		}},
+		{"not subscribed", []domain.Channel{domain.EmailChannel, domain.SmsChannel}, everywhere, true, false, map[domain.Channel]domain.DeliveryStatus{
+			domain.EmailChannel: domain.DeliverySkipped, domain.SmsChannel: domain.DeliverySkipped,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notification := domain.NewNotification(projectID, email.Value(), "welcome", "en-US", nil)
+			addDeliveries(notification, tt.channels, tt.recipient, tt.notSubscribed, tt.suppressed)
+
+			deliveries := notification.GetDeliveries()
+			if len(deliveries) != len(tt.want) {
+				t.Fatalf("deliveries = %+v, want %v", deliveries, tt.want)
+			}
+			for _, delivery := range deliveries {
+				if delivery.Status != tt.want[delivery.Channel] {
+					t.Errorf("%s = %s, want %s", delivery.Channel, delivery.Status, tt.want[delivery.Channel])
+				}
+				if delivery.Status == domain.DeliverySkipped && delivery.Error == "" {
+					t.Errorf("%s is skipped without reason", delivery.Channel)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	"platform/internal/notification/services/encryption"
+	sms_sender "platform/internal/notification/services/smsSender"
+	voExternal "platform/pkg/domain/value_object"
+)
+
+var ErrSmsAccountNotFound = errors.New("project has no sms account")
+
+// SendSmsCommand sends a templated message with the default sms account of the project
+type SendSmsCommand struct {
+	To           string
+	TemplateName domain.SmsTemplateName
+	Language     string
+	Tokens       map[string]string
+}
+
+type SendSmsCommandResponse struct {
+	MessageID string
+}
+
+type SendSmsCommandHandler struct {
+	encryption encryption.EncryptionService
+	repository repositories.SmsAccountRepository
+}
+
+func NewSendSmsCommandHandler(encryption encryption.EncryptionService, repository repositories.SmsAccountRepository) *SendSmsCommandHandler {
+	return &SendSmsCommandHandler{
+		encryption: encryption,
+		repository: repository,
+	}
+}
+
+func (c *SendSmsCommandHandler) Handle(ctx context.Context, command *SendSmsCommand) (*SendSmsCommandResponse, error) {
+	// STEP-1: Validate the recipient
+	to, err := voExternal.NewPhoneNumber(command.To)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Get the sms account of the project
+	sa, err := c.repository.GetDefault(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sa == nil {
+		return nil, ErrSmsAccountNotFound
+	}
+
+	// STEP-3: Render the template in the requested language
+	templates, err := c.repository.GetTemplates(ctx, sa.GetID(), command.TemplateName)
+	if err != nil {
+		return nil, err
+	}
+	body, err := sms_sender.RenderTemplate(templates, command.Language, command.Tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-4: Send the message
+	message := sms_sender.SmsMessage{To: to, Body: body}
+	messageID, err := sms_sender.SendSms(ctx, c.encryption, sa, &message)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendSmsCommandResponse{MessageID: messageID}, nil
+}
@@ -44,7 +44,11 @@ func (c *SendTestEmailCommandHandler) Handle(ctx context.Context, command *SendT
 		return nil, fmt.Errorf("email not found: %s", command.From)
 	}
 
-	email, _ := email_sender.BaseEmailDetail("Test Email", "<h1>Hello World!</h1>", fromEmail, toEmail)
-	email_sender.SendEmail(c.encryption, ea, email)
-	return nil, nil
+	from := voExternal.NewAddress(ea.GetDisplayName(), fromEmail)
+	to := voExternal.NewAddress("", toEmail)
+	email, _ := email_sender.BaseEmailDetail("Test Email", "<h1>Hello World!</h1>", from, to)
+	if _, err := email_sender.SendEmail(ctx, c.encryption, ea, email); err != nil {
+		return nil, err
+	}
+	return &SendTestEmailCommandResponse{}, nil
 }
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	subscription_token "platform/internal/notification/services/subscriptionToken"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+	"platform/pkg/services/mediator"
+
+	"github.com/google/uuid"
+)
+
+// SubscribeCommand creates a pending newsletter subscription and emails the confirmation link (double opt-in)
+type SubscribeCommand struct {
+	Email    string
+	Language string
+}
+
+type SubscribeCommandResponse struct {
+	AlreadyConfirmed bool
+}
+
+type SubscribeCommandHandler struct {
+	signer     *subscription_token.Signer
+	repository repositories.SubscriptionRepository
+}
+
+func NewSubscribeCommandHandler(signer *subscription_token.Signer, repository repositories.SubscriptionRepository) *SubscribeCommandHandler {
+	return &SubscribeCommandHandler{
+		signer:     signer,
+		repository: repository,
+	}
+}
+
+func (c *SubscribeCommandHandler) Handle(ctx context.Context, command *SubscribeCommand) (*SubscribeCommandResponse, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	email, err := voExternal.NewEmail(command.Email)
+	if err != nil {
+		return nil, err
+	}
+	language := shared.GetLanguageManager().GetLanguageByCulture(command.Language)
+
+	// STEP-2: Confirmed subscriptions only get their language updated
+	subscription, err := c.repository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if subscription != nil && subscription.IsConfirmed() {
+		subscription.SetLanguage(*language)
+		return &SubscribeCommandResponse{AlreadyConfirmed: true}, c.repository.Save(ctx, subscription)
+	}
+
+	// STEP-3: Save the pending subscription
+	subscription = domain.NewSubscription(projectID, email, *language)
+	if err := c.repository.Save(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	// STEP-4: Send the confirmation link
+	notification := SendNotificationCommand{
+		Recipient:    email.Value(),
+		TemplateName: string(domain.NEWSLETTER_CONFIRMATION),
+		Language:     language.GetCulture(),
+		Data:         map[string]string{"ConfirmationURL": c.signer.ConfirmationURL(projectID, email.Value())},
+		Channels:     []domain.Channel{domain.EmailChannel},
+	}
+	_, err = mediator.Send[*SendNotificationCommand, *SendNotificationCommandResponse](ctx, &notification)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubscribeCommandResponse{}, nil
+}
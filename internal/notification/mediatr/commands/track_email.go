@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+	delivery_tracker "platform/internal/notification/services/deliveryTracker"
+)
+
+// TrackEmailOpenCommand records the loading of the tracking pixel of a message
+type TrackEmailOpenCommand struct {
+	Token string
+}
+
+type TrackEmailOpenCommandResponse struct{}
+
+type TrackEmailOpenCommandHandler struct {
+	tracker *delivery_tracker.Tracker
+}
+
+func NewTrackEmailOpenCommandHandler(tracker *delivery_tracker.Tracker) *TrackEmailOpenCommandHandler {
+	return &TrackEmailOpenCommandHandler{tracker: tracker}
+}
+
+func (c *TrackEmailOpenCommandHandler) Handle(ctx context.Context, command *TrackEmailOpenCommand) (*TrackEmailOpenCommandResponse, error) {
+	if err := c.tracker.Open(ctx, command.Token); err != nil {
+		return nil, err
+	}
+	return &TrackEmailOpenCommandResponse{}, nil
+}
+
+// TrackEmailClickCommand records the click on a rewritten link of a message, the response has the original
+// URL even when the click cannot be recorded
+type TrackEmailClickCommand struct {
+	Token string
+}
+
+type TrackEmailClickCommandResponse struct {
+	URL string
+}
+
+type TrackEmailClickCommandHandler struct {
+	tracker *delivery_tracker.Tracker
+}
+
+func NewTrackEmailClickCommandHandler(tracker *delivery_tracker.Tracker) *TrackEmailClickCommandHandler {
+	return &TrackEmailClickCommandHandler{tracker: tracker}
+}
+
+func (c *TrackEmailClickCommandHandler) Handle(ctx context.Context, command *TrackEmailClickCommand) (*TrackEmailClickCommandResponse, error) {
+	url, err := c.tracker.Click(ctx, command.Token)
+	if url == "" {
+		return nil, err
+	}
+	return &TrackEmailClickCommandResponse{URL: url}, err
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	subscription_token "platform/internal/notification/services/subscriptionToken"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+)
+
+// UnsubscribeCommand removes a subscription, either by email for the project of the context, or with the
+// token of a one-click unsubscribe link
+type UnsubscribeCommand struct {
+	Email string
+	Token string
+}
+
+type UnsubscribeCommandResponse struct {
+	Email string
+}
+
+type UnsubscribeCommandHandler struct {
+	signer     *subscription_token.Signer
+	repository repositories.SubscriptionRepository
+}
+
+func NewUnsubscribeCommandHandler(signer *subscription_token.Signer, repository repositories.SubscriptionRepository) *UnsubscribeCommandHandler {
+	return &UnsubscribeCommandHandler{
+		signer:     signer,
+		repository: repository,
+	}
+}
+
+func (c *UnsubscribeCommandHandler) Handle(ctx context.Context, command *UnsubscribeCommand) (*UnsubscribeCommandResponse, error) {
+	// STEP-1: The project and the email of a token override the ones of the request
+	address := command.Email
+	if command.Token != "" {
+		projectID, tokenEmail, err := c.signer.Verify(command.Token, subscription_token.Unsubscribe)
+		if err != nil {
+			return nil, err
+		}
+		ctx = context.WithValue(ctx, shared.ProjectIDContextKey, projectID)
+		address = tokenEmail
+	}
+
+	email, err := voExternal.NewEmail(address)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Delete the subscription, unsubscribing twice is not an error
+	if err := c.repository.Delete(ctx, email); err != nil {
+		return nil, err
+	}
+
+	return &UnsubscribeCommandResponse{Email: email.Value()}, nil
+}
@@ -2,9 +2,9 @@ package commands
 
 import (
 	"context"
-	"platform/internal/notification/domain"
 	voInternal "platform/internal/notification/domain/value_object"
 	"platform/internal/notification/repositories"
+	email_provider "platform/internal/notification/services/emailProvider"
 	"platform/internal/notification/services/encryption"
 	voExternal "platform/pkg/domain/value_object"
 	"time"
@@ -25,6 +25,10 @@ type UpdateEmailAccountCommand struct {
 	AccessToken  string
 	RefreshToken string
 	ExpireAt     time.Time
+
+	// Send limits, zero uses the quota of the account type
+	MaxPerMinute int
+	MaxPerDay    int
 }
 
 type UpdateEmailAccountCommandResponse struct{}
@@ -52,6 +56,11 @@ func (c *UpdateEmailAccountCommandHandler) Handle(ctx context.Context, command *
 		return nil, nil
 	}
 
+	provider, err := email_provider.Get(command.TypeID)
+	if err != nil {
+		return nil, err
+	}
+
 	if ea.GetDisplayName() != command.DisplayName {
 		ea.SetDisplayName(command.DisplayName)
 	}
@@ -72,7 +81,7 @@ func (c *UpdateEmailAccountCommandHandler) Handle(ctx context.Context, command *
 		ea.SetSmtpType(command.TypeID)
 	}
 
-	if command.TypeID == domain.Login {
+	if !provider.Mechanism().OAuth2() {
 		encrypted, err := c.encryption.Encrypt(command.Password)
 		if err != nil {
 			return nil, err
@@ -82,20 +91,26 @@ func (c *UpdateEmailAccountCommandHandler) Handle(ctx context.Context, command *
 		if (oldCredentials == nil && newCredentials != nil) || !oldCredentials.Equals(newCredentials) {
 			ea.SetTraditionalCredentials(newCredentials)
 		}
-	} else if command.TypeID == domain.GmailOAuth2 || command.TypeID == domain.MicrosoftOAuth2 {
+	} else {
 		oldCredentials := ea.GetOAuth2Credentials()
 		newCredentials := voInternal.NewOAuth2Credentials(command.ClientID, command.TenantID, command.ClientSecret)
 		if (oldCredentials == nil && newCredentials != nil) || !oldCredentials.Equals(newCredentials) {
 			ea.SetOAuth2Credentials(newCredentials)
 		}
 
-		oldTokenInfo := ea.GetTokenInformation()
-		newTokenInfo := voInternal.NewTokenInformation(command.AccessToken, command.RefreshToken, command.ExpireAt)
-		if (oldTokenInfo == nil && newTokenInfo != nil) || !oldTokenInfo.Equals(newTokenInfo) {
-			ea.SetTokenInformation(newTokenInfo)
+		// The tokens are kept when none are given, they are saved by the authorization flow and the refreshes
+		if command.AccessToken != "" || command.RefreshToken != "" {
+			oldTokenInfo := ea.GetTokenInformation()
+			newTokenInfo := voInternal.NewTokenInformation(command.AccessToken, command.RefreshToken, command.ExpireAt)
+			if (oldTokenInfo == nil && newTokenInfo != nil) || !oldTokenInfo.Equals(newTokenInfo) {
+				ea.SetTokenInformation(newTokenInfo)
+			}
 		}
 	}
 
+	ea.SetMaxPerMinute(command.MaxPerMinute)
+	ea.SetMaxPerDay(command.MaxPerDay)
+
 	err = c.repository.Update(ctx, ea)
 	if err != nil {
 		return nil, err
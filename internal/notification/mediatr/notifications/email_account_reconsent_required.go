@@ -0,0 +1,37 @@
+package event_notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// EmailAccountReconsentRequiredEvent is published when the provider revoked the grant of an OAuth2 email
+// account, the account cannot send emails until the user authorizes it again
+type EmailAccountReconsentRequiredEvent struct {
+	ProjectID uuid.UUID `json:"project_id"`
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+func NewEmailAccountReconsentRequiredEvent(projectID uuid.UUID, email, reason string) EmailAccountReconsentRequiredEvent {
+	return EmailAccountReconsentRequiredEvent{
+		ProjectID: projectID,
+		Email:     email,
+		Reason:    reason,
+		RevokedAt: time.Now(),
+	}
+}
+
+type EmailAccountReconsentRequiredEventHandler struct{}
+
+func (c *EmailAccountReconsentRequiredEventHandler) Handle(ctx context.Context, event *EmailAccountReconsentRequiredEvent) error {
+	zap.L().Warn("email account needs to be authorized again",
+		zap.String("project_id", event.ProjectID.String()),
+		zap.String("email", event.Email),
+		zap.String("reason", event.Reason))
+	return nil
+}
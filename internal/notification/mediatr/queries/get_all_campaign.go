@@ -0,0 +1,90 @@
+package queries
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetAllCampaignQuery struct {
+	Page     int
+	PageSize int
+}
+
+type GetAllCampaignQueryResponse struct {
+	TotalCount int
+	List       []CampaignData
+}
+
+type CampaignData struct {
+	ID           uuid.UUID
+	Name         string
+	TemplateName string
+	Languages    []string
+	Status       string
+	ScheduledAt  time.Time
+	TotalCount   int
+	SentCount    int
+	FailedCount  int
+	Progress     int
+	StartedAt    *time.Time
+	CompletedAt  *time.Time
+	CreatedAt    time.Time
+}
+
+type GetAllCampaignQueryHandler struct {
+	repository repositories.CampaignRepository
+}
+
+func NewGetAllCampaignQueryHandler(repository repositories.CampaignRepository) *GetAllCampaignQueryHandler {
+	return &GetAllCampaignQueryHandler{repository: repository}
+}
+
+func (c *GetAllCampaignQueryHandler) Handle(ctx context.Context, query *GetAllCampaignQuery) (*GetAllCampaignQueryResponse, error) {
+	campaigns, err := c.repository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(campaigns)
+	start := (query.Page - 1) * query.PageSize
+	if start > total {
+		start = total
+	}
+	end := start + query.PageSize
+	if end > total {
+		end = total
+	}
+	paged := campaigns[start:end]
+
+	response := GetAllCampaignQueryResponse{
+		TotalCount: total,
+		List:       make([]CampaignData, 0, len(paged)),
+	}
+	for _, campaign := range paged {
+		response.List = append(response.List, toCampaignData(campaign))
+	}
+
+	return &response, nil
+}
+
+func toCampaignData(campaign *domain.Campaign) CampaignData {
+	return CampaignData{
+		ID:           campaign.GetID(),
+		Name:         campaign.GetName(),
+		TemplateName: string(campaign.GetTemplateName()),
+		Languages:    campaign.GetLanguages(),
+		Status:       string(campaign.GetStatus()),
+		ScheduledAt:  campaign.GetScheduledAt(),
+		TotalCount:   campaign.GetTotalCount(),
+		SentCount:    campaign.GetSentCount(),
+		FailedCount:  campaign.GetFailedCount(),
+		Progress:     campaign.Progress(),
+		StartedAt:    campaign.GetStartedAt(),
+		CompletedAt:  campaign.GetCompletedAt(),
+		CreatedAt:    campaign.GetCreatedAt(),
+	}
+}
@@ -0,0 +1,74 @@
+package queries
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GetAllCapturedEmailQuery lists the captured messages of the project, newest first, only those sent to
+// Recipient when it is set
+type GetAllCapturedEmailQuery struct {
+	Page      int
+	PageSize  int
+	Recipient string
+}
+
+type GetAllCapturedEmailQueryResponse struct {
+	TotalCount int
+	List       []CapturedEmailData
+}
+
+type CapturedEmailData struct {
+	ID             uuid.UUID
+	EmailAccountID uuid.UUID
+	From           string
+	Recipients     []string
+	Subject        string
+	MessageID      string
+	Attachments    int
+	Size           int
+	CreatedAt      time.Time
+}
+
+type GetAllCapturedEmailQueryHandler struct {
+	repository repositories.CapturedEmailRepository
+}
+
+func NewGetAllCapturedEmailQueryHandler(repository repositories.CapturedEmailRepository) *GetAllCapturedEmailQueryHandler {
+	return &GetAllCapturedEmailQueryHandler{repository: repository}
+}
+
+func (c *GetAllCapturedEmailQueryHandler) Handle(ctx context.Context, query *GetAllCapturedEmailQuery) (*GetAllCapturedEmailQueryResponse, error) {
+	emails, total, err := c.repository.GetAll(ctx, query.Recipient, query.Page, query.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	response := GetAllCapturedEmailQueryResponse{
+		TotalCount: total,
+		List:       make([]CapturedEmailData, 0, len(emails)),
+	}
+	for _, email := range emails {
+		response.List = append(response.List, toCapturedEmailData(email))
+	}
+
+	return &response, nil
+}
+
+func toCapturedEmailData(email *domain.CapturedEmail) CapturedEmailData {
+	return CapturedEmailData{
+		ID:             email.GetID(),
+		EmailAccountID: email.GetEmailAccountID(),
+		From:           email.GetFrom(),
+		Recipients:     email.GetRecipients(),
+		Subject:        email.GetSubject(),
+		MessageID:      email.GetMessageID(),
+		Attachments:    len(email.GetAttachments()),
+		Size:           email.GetSize(),
+		CreatedAt:      email.GetCreatedAt(),
+	}
+}
@@ -3,16 +3,17 @@ package queries
 import (
 	"context"
 	"platform/internal/notification/repositories"
+	"platform/internal/shared"
 	"time"
 )
 
 type GetAllEmailAccountQuery struct {
-	Page     int
-	PageSize int
+	Options shared.ListOptions
 }
 
 type GetAllEmailAccountQueryResponse struct {
 	TotalCount int
+	NextCursor string
 	List       []data
 }
 
@@ -32,28 +33,18 @@ func NewGetAllEmailAccountQueryHandler(repository repositories.EmailAccountRepos
 }
 
 func (c *GetAllEmailAccountQueryHandler) Handle(ctx context.Context, query *GetAllEmailAccountQuery) (*GetAllEmailAccountQueryResponse, error) {
-	emailAccounts, err := c.repository.GetAll(ctx)
+	result, err := c.repository.GetAll(ctx, query.Options)
 	if err != nil {
 		return nil, err
 	}
 
-	total := len(emailAccounts)
-	start := (query.Page - 1) * query.PageSize
-	if start > total {
-		start = total
-	}
-	end := start + query.PageSize
-	if end > total {
-		end = total
-	}
-	pagedAccounts := emailAccounts[start:end]
-
 	response := GetAllEmailAccountQueryResponse{
-		TotalCount: total,
-		List:       make([]data, 0, len(pagedAccounts)),
+		TotalCount: result.TotalCount,
+		NextCursor: result.NextCursor,
+		List:       make([]data, 0, len(result.Items)),
 	}
 
-	for _, acc := range emailAccounts {
+	for _, acc := range result.Items {
 		response.List = append(response.List, data{
 			Email:       acc.GetEmail().Value(),
 			DisplayName: acc.GetDisplayName(),
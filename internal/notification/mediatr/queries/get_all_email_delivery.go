@@ -0,0 +1,93 @@
+package queries
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GetAllEmailDeliveryQuery lists the delivery logs of the project, newest first. The empty fields of the
+// filter are not applied and To is exclusive.
+type GetAllEmailDeliveryQuery struct {
+	Page           int
+	PageSize       int
+	Status         string
+	Recipient      string
+	EmailAccountID *uuid.UUID
+	CampaignID     *uuid.UUID
+	From           time.Time
+	To             time.Time
+}
+
+type GetAllEmailDeliveryQueryResponse struct {
+	TotalCount int
+	List       []EmailDeliveryData
+}
+
+type EmailDeliveryData struct {
+	ID                uuid.UUID
+	EmailAccountID    uuid.UUID
+	CampaignID        *uuid.UUID
+	Recipient         string
+	Subject           string
+	MessageID         string
+	ProviderMessageID string
+	Status            string
+	Opens             int
+	Clicks            int
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type GetAllEmailDeliveryQueryHandler struct {
+	repository repositories.EmailDeliveryRepository
+}
+
+func NewGetAllEmailDeliveryQueryHandler(repository repositories.EmailDeliveryRepository) *GetAllEmailDeliveryQueryHandler {
+	return &GetAllEmailDeliveryQueryHandler{repository: repository}
+}
+
+func (c *GetAllEmailDeliveryQueryHandler) Handle(ctx context.Context, query *GetAllEmailDeliveryQuery) (*GetAllEmailDeliveryQueryResponse, error) {
+	filter := repositories.EmailDeliveryFilter{
+		Status:         domain.EmailDeliveryStatus(query.Status),
+		Recipient:      query.Recipient,
+		EmailAccountID: query.EmailAccountID,
+		CampaignID:     query.CampaignID,
+		From:           query.From,
+		To:             query.To,
+	}
+	deliveries, total, err := c.repository.GetAll(ctx, filter, query.Page, query.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	response := GetAllEmailDeliveryQueryResponse{
+		TotalCount: total,
+		List:       make([]EmailDeliveryData, 0, len(deliveries)),
+	}
+	for _, delivery := range deliveries {
+		response.List = append(response.List, toEmailDeliveryData(delivery))
+	}
+
+	return &response, nil
+}
+
+func toEmailDeliveryData(delivery *domain.EmailDelivery) EmailDeliveryData {
+	return EmailDeliveryData{
+		ID:                delivery.GetID(),
+		EmailAccountID:    delivery.GetEmailAccountID(),
+		CampaignID:        delivery.GetCampaignID(),
+		Recipient:         delivery.GetRecipient(),
+		Subject:           delivery.GetSubject(),
+		MessageID:         delivery.GetMessageID(),
+		ProviderMessageID: delivery.GetProviderMessageID(),
+		Status:            string(delivery.GetStatus()),
+		Opens:             delivery.GetOpens(),
+		Clicks:            delivery.GetClicks(),
+		CreatedAt:         delivery.GetCreatedAt(),
+		UpdatedAt:         delivery.GetUpdatedAt(),
+	}
+}
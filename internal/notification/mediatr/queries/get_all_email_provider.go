@@ -0,0 +1,44 @@
+package queries
+
+import (
+	"context"
+	email_provider "platform/internal/notification/services/emailProvider"
+)
+
+type GetAllEmailProviderQuery struct{}
+
+type GetAllEmailProviderQueryResponse struct {
+	List []EmailProviderData
+}
+
+type EmailProviderData struct {
+	TypeID    int
+	Name      string
+	Mechanism string
+	OAuth2    bool
+	Transport string
+	Defaults  email_provider.Defaults
+}
+
+type GetAllEmailProviderQueryHandler struct{}
+
+func NewGetAllEmailProviderQueryHandler() *GetAllEmailProviderQueryHandler {
+	return &GetAllEmailProviderQueryHandler{}
+}
+
+func (c *GetAllEmailProviderQueryHandler) Handle(ctx context.Context, query *GetAllEmailProviderQuery) (*GetAllEmailProviderQueryResponse, error) {
+	providers := email_provider.All()
+
+	list := make([]EmailProviderData, 0, len(providers))
+	for _, provider := range providers {
+		list = append(list, EmailProviderData{
+			TypeID:    provider.TypeID(),
+			Name:      provider.Name(),
+			Mechanism: string(provider.Mechanism()),
+			OAuth2:    provider.Mechanism().OAuth2(),
+			Transport: provider.Transport(),
+			Defaults:  provider.Defaults(),
+		})
+	}
+	return &GetAllEmailProviderQueryResponse{List: list}, nil
+}
@@ -0,0 +1,56 @@
+package queries
+
+import (
+	"context"
+	sms_sender "platform/internal/notification/services/smsSender"
+	"platform/internal/shared"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GetAllFakeSmsQuery returns the last messages sent by the fake sms accounts of the project, the newest first
+type GetAllFakeSmsQuery struct{}
+
+type GetAllFakeSmsQueryResponse struct {
+	List []FakeSmsData
+}
+
+type FakeSmsData struct {
+	ID     string
+	From   string
+	To     string
+	Body   string
+	SentAt time.Time
+}
+
+type GetAllFakeSmsQueryHandler struct{}
+
+func NewGetAllFakeSmsQueryHandler() *GetAllFakeSmsQueryHandler {
+	return &GetAllFakeSmsQueryHandler{}
+}
+
+func (c *GetAllFakeSmsQueryHandler) Handle(ctx context.Context, query *GetAllFakeSmsQuery) (*GetAllFakeSmsQueryResponse, error) {
+	projectID, ok := ctx.Value(shared.ProjectIDContextKey).(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+	messages, err := sms_sender.FakeSentMessages(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := GetAllFakeSmsQueryResponse{
+		List: make([]FakeSmsData, 0, len(messages)),
+	}
+	for i := len(messages) - 1; i >= 0; i-- {
+		response.List = append(response.List, FakeSmsData{
+			ID:     messages[i].ID,
+			From:   messages[i].From,
+			To:     messages[i].To.Value(),
+			Body:   messages[i].Body,
+			SentAt: messages[i].SentAt,
+		})
+	}
+	return &response, nil
+}
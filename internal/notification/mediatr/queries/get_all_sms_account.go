@@ -0,0 +1,51 @@
+package queries
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetAllSmsAccountQuery struct{}
+
+type GetAllSmsAccountQueryResponse struct {
+	List []SmsAccountData
+}
+
+type SmsAccountData struct {
+	ID         uuid.UUID
+	ProviderID int
+	Sender     string
+	CreatedAt  time.Time
+}
+
+type GetAllSmsAccountQueryHandler struct {
+	repository repositories.SmsAccountRepository
+}
+
+func NewGetAllSmsAccountQueryHandler(repository repositories.SmsAccountRepository) *GetAllSmsAccountQueryHandler {
+	return &GetAllSmsAccountQueryHandler{repository: repository}
+}
+
+func (c *GetAllSmsAccountQueryHandler) Handle(ctx context.Context, query *GetAllSmsAccountQuery) (*GetAllSmsAccountQueryResponse, error) {
+	smsAccounts, err := c.repository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := GetAllSmsAccountQueryResponse{
+		List: make([]SmsAccountData, 0, len(smsAccounts)),
+	}
+	for _, acc := range smsAccounts {
+		response.List = append(response.List, SmsAccountData{
+			ID:         acc.GetID(),
+			ProviderID: acc.GetProviderID(),
+			Sender:     acc.GetSender(),
+			CreatedAt:  acc.GetCreatedAt(),
+		})
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,67 @@
+package queries
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	"time"
+)
+
+type GetAllSubscriptionQuery struct {
+	Page     int
+	PageSize int
+}
+
+type GetAllSubscriptionQueryResponse struct {
+	TotalCount int
+	List       []SubscriptionData
+}
+
+type SubscriptionData struct {
+	Email       string
+	Language    string
+	Status      string
+	ConfirmedAt *time.Time
+	CreatedAt   time.Time
+}
+
+type GetAllSubscriptionQueryHandler struct {
+	repository repositories.SubscriptionRepository
+}
+
+func NewGetAllSubscriptionQueryHandler(repository repositories.SubscriptionRepository) *GetAllSubscriptionQueryHandler {
+	return &GetAllSubscriptionQueryHandler{repository: repository}
+}
+
+func (c *GetAllSubscriptionQueryHandler) Handle(ctx context.Context, query *GetAllSubscriptionQuery) (*GetAllSubscriptionQueryResponse, error) {
+	subscriptions, err := c.repository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(subscriptions)
+	start := (query.Page - 1) * query.PageSize
+	if start > total {
+		start = total
+	}
+	end := start + query.PageSize
+	if end > total {
+		end = total
+	}
+	paged := subscriptions[start:end]
+
+	response := GetAllSubscriptionQueryResponse{
+		TotalCount: total,
+		List:       make([]SubscriptionData, 0, len(paged)),
+	}
+	for _, s := range paged {
+		response.List = append(response.List, SubscriptionData{
+			Email:       s.GetEmail().Value(),
+			Language:    s.GetLanguage().GetCulture(),
+			Status:      string(s.GetStatus()),
+			ConfirmedAt: s.GetConfirmedAt(),
+			CreatedAt:   s.GetCreatedAt(),
+		})
+	}
+
+	return &response, nil
+}
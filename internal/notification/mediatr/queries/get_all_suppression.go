@@ -0,0 +1,65 @@
+package queries
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	"time"
+)
+
+type GetAllSuppressionQuery struct {
+	Page     int
+	PageSize int
+}
+
+type GetAllSuppressionQueryResponse struct {
+	TotalCount int
+	List       []SuppressionData
+}
+
+type SuppressionData struct {
+	Email      string
+	Reason     string
+	Diagnostic string
+	CreatedAt  time.Time
+}
+
+type GetAllSuppressionQueryHandler struct {
+	repository repositories.SuppressionRepository
+}
+
+func NewGetAllSuppressionQueryHandler(repository repositories.SuppressionRepository) *GetAllSuppressionQueryHandler {
+	return &GetAllSuppressionQueryHandler{repository: repository}
+}
+
+func (c *GetAllSuppressionQueryHandler) Handle(ctx context.Context, query *GetAllSuppressionQuery) (*GetAllSuppressionQueryResponse, error) {
+	suppressions, err := c.repository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(suppressions)
+	start := (query.Page - 1) * query.PageSize
+	if start > total {
+		start = total
+	}
+	end := start + query.PageSize
+	if end > total {
+		end = total
+	}
+	paged := suppressions[start:end]
+
+	response := GetAllSuppressionQueryResponse{
+		TotalCount: total,
+		List:       make([]SuppressionData, 0, len(paged)),
+	}
+	for _, s := range paged {
+		response.List = append(response.List, SuppressionData{
+			Email:      s.GetEmail().Value(),
+			Reason:     string(s.GetReason()),
+			Diagnostic: s.GetDiagnostic(),
+			CreatedAt:  s.GetCreatedAt(),
+		})
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,36 @@
+package queries
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+
+	"github.com/google/uuid"
+)
+
+type GetCampaignQuery struct {
+	ID uuid.UUID
+}
+
+type GetCampaignQueryResponse struct {
+	CampaignData
+}
+
+type GetCampaignQueryHandler struct {
+	repository repositories.CampaignRepository
+}
+
+func NewGetCampaignQueryHandler(repository repositories.CampaignRepository) *GetCampaignQueryHandler {
+	return &GetCampaignQueryHandler{repository: repository}
+}
+
+func (c *GetCampaignQueryHandler) Handle(ctx context.Context, query *GetCampaignQuery) (*GetCampaignQueryResponse, error) {
+	campaign, err := c.repository.GetByID(ctx, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	if campaign == nil {
+		return nil, nil
+	}
+
+	return &GetCampaignQueryResponse{CampaignData: toCampaignData(campaign)}, nil
+}
@@ -0,0 +1,51 @@
+package queries
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+
+	"github.com/google/uuid"
+)
+
+// GetCapturedEmailQuery returns a captured message with its content, Raw is the message as it would have
+// been sent
+type GetCapturedEmailQuery struct {
+	ID uuid.UUID
+}
+
+type GetCapturedEmailQueryResponse struct {
+	CapturedEmailData
+	Headers     map[string][]string
+	Text        string
+	HTML        string
+	Attachments []domain.CapturedAttachment
+	Raw         []byte
+}
+
+type GetCapturedEmailQueryHandler struct {
+	repository repositories.CapturedEmailRepository
+}
+
+func NewGetCapturedEmailQueryHandler(repository repositories.CapturedEmailRepository) *GetCapturedEmailQueryHandler {
+	return &GetCapturedEmailQueryHandler{repository: repository}
+}
+
+func (c *GetCapturedEmailQueryHandler) Handle(ctx context.Context, query *GetCapturedEmailQuery) (*GetCapturedEmailQueryResponse, error) {
+	email, err := c.repository.GetByID(ctx, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	if email == nil {
+		return nil, nil
+	}
+
+	return &GetCapturedEmailQueryResponse{
+		CapturedEmailData: toCapturedEmailData(email),
+		Headers:           email.GetHeaders(),
+		Text:              email.GetText(),
+		HTML:              email.GetHTML(),
+		Attachments:       email.GetAttachments(),
+		Raw:               email.GetRaw(),
+	}, nil
+}
@@ -0,0 +1,67 @@
+package queries
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	email_sender "platform/internal/notification/services/emailSender"
+	"platform/internal/notification/services/encryption"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+)
+
+// GetDkimRecordQuery returns the DNS TXT record publishing the DKIM public key of an email account
+type GetDkimRecordQuery struct {
+	Email string
+}
+
+type GetDkimRecordQueryResponse struct {
+	Domain      string
+	Selector    string
+	Algorithm   string
+	RecordName  string
+	RecordValue string
+}
+
+type GetDkimRecordQueryHandler struct {
+	encryption encryption.EncryptionService
+	repository repositories.EmailAccountRepository
+}
+
+func NewGetDkimRecordQueryHandler(encryption encryption.EncryptionService, repository repositories.EmailAccountRepository) *GetDkimRecordQueryHandler {
+	return &GetDkimRecordQueryHandler{
+		encryption: encryption,
+		repository: repository,
+	}
+}
+
+func (c *GetDkimRecordQueryHandler) Handle(ctx context.Context, query *GetDkimRecordQuery) (*GetDkimRecordQueryResponse, error) {
+	email, err := voExternal.NewEmail(query.Email)
+	if err != nil {
+		return nil, err
+	}
+	ea, err := c.repository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if ea == nil || ea.GetDkimSettings() == nil {
+		return nil, shared.ErrNotFound
+	}
+
+	domain, selector, algorithm, encryptedKey := ea.GetDkimSettings().Settings()
+	privateKey, err := c.encryption.Decrypt(encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	recordName, recordValue, err := email_sender.DKIMRecord(domain, selector, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetDkimRecordQueryResponse{
+		Domain:      domain,
+		Selector:    selector,
+		Algorithm:   algorithm,
+		RecordName:  recordName,
+		RecordValue: recordValue,
+	}, nil
+}
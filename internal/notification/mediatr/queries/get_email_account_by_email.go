@@ -25,6 +25,9 @@ type GetEmailAccountByEmailQueryResponse struct {
 	TypeId                 int
 	TraditionalCredentials *voInternal.TraditionalCredentials
 	OAuth2Credentials      *voInternal.OAuth2Credentials
+	NeedsReconsent         bool
+	TrackOpens             bool
+	TrackClicks            bool
 	CreatedAt              time.Time
 }
 
@@ -61,6 +64,9 @@ func (c *GetEmailAccountByEmailQueryHandler) Handle(ctx context.Context, query *
 		TypeId:                 emailAccount.GetSmtpType(),
 		TraditionalCredentials: emailAccount.GetTraditionalCredentials(),
 		OAuth2Credentials:      emailAccount.GetOAuth2Credentials(),
+		NeedsReconsent:         emailAccount.GetNeedsReconsent(),
+		TrackOpens:             emailAccount.GetTrackOpens(),
+		TrackClicks:            emailAccount.GetTrackClicks(),
 		CreatedAt:              emailAccount.GetCreatedAt(),
 	}, nil
 }
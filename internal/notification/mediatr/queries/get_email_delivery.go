@@ -0,0 +1,57 @@
+package queries
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetEmailDeliveryQuery struct {
+	ID uuid.UUID
+}
+
+type GetEmailDeliveryQueryResponse struct {
+	EmailDeliveryData
+	Events []EmailDeliveryEventData
+}
+
+type EmailDeliveryEventData struct {
+	Status     string
+	Detail     string
+	URL        string
+	OccurredAt time.Time
+}
+
+type GetEmailDeliveryQueryHandler struct {
+	repository repositories.EmailDeliveryRepository
+}
+
+func NewGetEmailDeliveryQueryHandler(repository repositories.EmailDeliveryRepository) *GetEmailDeliveryQueryHandler {
+	return &GetEmailDeliveryQueryHandler{repository: repository}
+}
+
+func (c *GetEmailDeliveryQueryHandler) Handle(ctx context.Context, query *GetEmailDeliveryQuery) (*GetEmailDeliveryQueryResponse, error) {
+	delivery, err := c.repository.GetByID(ctx, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, nil
+	}
+
+	response := GetEmailDeliveryQueryResponse{
+		EmailDeliveryData: toEmailDeliveryData(delivery),
+		Events:            make([]EmailDeliveryEventData, 0, len(delivery.GetEvents())),
+	}
+	for _, event := range delivery.GetEvents() {
+		response.Events = append(response.Events, EmailDeliveryEventData{
+			Status:     string(event.Status),
+			Detail:     event.Detail,
+			URL:        event.URL,
+			OccurredAt: event.OccurredAt,
+		})
+	}
+	return &response, nil
+}
@@ -0,0 +1,56 @@
+package queries
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	email_sandbox "platform/internal/notification/services/emailSandbox"
+	"time"
+)
+
+// GetEmailSandboxQuery returns the sandbox settings applied to the project, its own or the default of the
+// environment when the project has none or its own are looser
+type GetEmailSandboxQuery struct{}
+
+type GetEmailSandboxQueryResponse struct {
+	Mode       string
+	AllowList  []string
+	RedirectTo string
+	// Source is "project" for the settings of the project, "environment" for the enforced default
+	Source    string
+	UpdatedAt *time.Time
+}
+
+type GetEmailSandboxQueryHandler struct {
+	sandbox    *email_sandbox.Service
+	repository repositories.EmailSandboxRepository
+}
+
+func NewGetEmailSandboxQueryHandler(sandbox *email_sandbox.Service, repository repositories.EmailSandboxRepository) *GetEmailSandboxQueryHandler {
+	return &GetEmailSandboxQueryHandler{sandbox: sandbox, repository: repository}
+}
+
+func (c *GetEmailSandboxQueryHandler) Handle(ctx context.Context, query *GetEmailSandboxQuery) (*GetEmailSandboxQueryResponse, error) {
+	sandbox, err := c.repository.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := c.sandbox.Default()
+	if sandbox == nil || sandbox.Tighten(defaults) == defaults {
+		return &GetEmailSandboxQueryResponse{
+			Mode:       string(defaults.GetMode()),
+			AllowList:  defaults.GetAllowList(),
+			RedirectTo: defaults.GetRedirectTo(),
+			Source:     "environment",
+		}, nil
+	}
+
+	updatedAt := sandbox.GetUpdatedAt()
+	return &GetEmailSandboxQueryResponse{
+		Mode:       string(sandbox.GetMode()),
+		AllowList:  sandbox.GetAllowList(),
+		RedirectTo: sandbox.Tighten(defaults).GetRedirectTo(),
+		Source:     "project",
+		UpdatedAt:  &updatedAt,
+	}, nil
+}
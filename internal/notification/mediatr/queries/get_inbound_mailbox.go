@@ -0,0 +1,69 @@
+package queries
+
+import (
+	"context"
+	"platform/internal/notification/repositories"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+)
+
+// GetInboundMailboxQuery returns the IMAP folder read for an email account and the state of its reader
+type GetInboundMailboxQuery struct {
+	Email string
+}
+
+type GetInboundMailboxQueryResponse struct {
+	Host        string
+	Port        int
+	EnableSSL   bool
+	Folder      string
+	UseIdle     bool
+	UIDValidity uint32
+	LastUID     uint32
+	LastError   string
+}
+
+type GetInboundMailboxQueryHandler struct {
+	repository             repositories.InboundMailboxRepository
+	emailAccountRepository repositories.EmailAccountRepository
+}
+
+func NewGetInboundMailboxQueryHandler(repository repositories.InboundMailboxRepository, emailAccountRepository repositories.EmailAccountRepository) *GetInboundMailboxQueryHandler {
+	return &GetInboundMailboxQueryHandler{
+		repository:             repository,
+		emailAccountRepository: emailAccountRepository,
+	}
+}
+
+func (c *GetInboundMailboxQueryHandler) Handle(ctx context.Context, query *GetInboundMailboxQuery) (*GetInboundMailboxQueryResponse, error) {
+	email, err := voExternal.NewEmail(query.Email)
+	if err != nil {
+		return nil, err
+	}
+	ea, err := c.emailAccountRepository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if ea == nil {
+		return nil, shared.ErrNotFound
+	}
+
+	mailbox, err := c.repository.GetByEmailAccount(ctx, ea.GetID())
+	if err != nil {
+		return nil, err
+	}
+	if mailbox == nil {
+		return nil, shared.ErrNotFound
+	}
+
+	return &GetInboundMailboxQueryResponse{
+		Host:        mailbox.GetHost(),
+		Port:        mailbox.GetPort(),
+		EnableSSL:   mailbox.GetEnableSSL(),
+		Folder:      mailbox.GetFolder(),
+		UseIdle:     mailbox.GetUseIdle(),
+		UIDValidity: mailbox.GetUIDValidity(),
+		LastUID:     mailbox.GetLastUID(),
+		LastError:   mailbox.GetLastError(),
+	}, nil
+}
@@ -0,0 +1,50 @@
+package queries
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetNotificationQuery struct {
+	ID uuid.UUID
+}
+
+type GetNotificationQueryResponse struct {
+	ID           uuid.UUID
+	Recipient    string
+	TemplateName string
+	Language     string
+	Deliveries   []domain.Delivery
+	CreatedAt    time.Time
+}
+
+type GetNotificationQueryHandler struct {
+	repository repositories.NotificationRepository
+}
+
+func NewGetNotificationQueryHandler(repository repositories.NotificationRepository) *GetNotificationQueryHandler {
+	return &GetNotificationQueryHandler{repository: repository}
+}
+
+func (c *GetNotificationQueryHandler) Handle(ctx context.Context, query *GetNotificationQuery) (*GetNotificationQueryResponse, error) {
+	notification, err := c.repository.GetByID(ctx, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	if notification == nil {
+		return nil, nil
+	}
+
+	return &GetNotificationQueryResponse{
+		ID:           notification.GetID(),
+		Recipient:    notification.GetRecipient(),
+		TemplateName: notification.GetTemplateName(),
+		Language:     notification.GetLanguage(),
+		Deliveries:   notification.GetDeliveries(),
+		CreatedAt:    notification.GetCreatedAt(),
+	}, nil
+}
@@ -0,0 +1,33 @@
+package queries
+
+import (
+	"context"
+	subscription_token "platform/internal/notification/services/subscriptionToken"
+)
+
+// GetUnsubscribeTokenQuery returns the address a one-click unsubscribe token is for, without unsubscribing it
+type GetUnsubscribeTokenQuery struct {
+	Token string
+}
+
+type GetUnsubscribeTokenQueryResponse struct {
+	Email string
+}
+
+type GetUnsubscribeTokenQueryHandler struct {
+	signer *subscription_token.Signer
+}
+
+func NewGetUnsubscribeTokenQueryHandler(signer *subscription_token.Signer) *GetUnsubscribeTokenQueryHandler {
+	return &GetUnsubscribeTokenQueryHandler{
+		signer: signer,
+	}
+}
+
+func (c *GetUnsubscribeTokenQueryHandler) Handle(ctx context.Context, query *GetUnsubscribeTokenQuery) (*GetUnsubscribeTokenQueryResponse, error) {
+	_, email, err := c.signer.Verify(query.Token, subscription_token.Unsubscribe)
+	if err != nil {
+		return nil, err
+	}
+	return &GetUnsubscribeTokenQueryResponse{Email: email}, nil
+}
@@ -0,0 +1,108 @@
+package queries
+
+import (
+	"context"
+	"maps"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	email_linter "platform/internal/notification/services/emailLinter"
+	email_sender "platform/internal/notification/services/emailSender"
+	template_renderer "platform/internal/notification/services/templateRenderer"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+)
+
+// PreviewTemplateQuery renders a template with sample data and builds the message it would be sent as,
+// nothing is sent. The default email account of the project is used when Email is empty and the %Email%
+// token is the address of the recipient unless Data has one.
+type PreviewTemplateQuery struct {
+	Name     string
+	Email    string
+	Language string
+	To       string
+	Data     map[string]string
+}
+
+type PreviewTemplateQueryResponse struct {
+	Language string
+	Subject  string
+	Text     string
+	HTML     string
+	Header   map[string][]string
+	Files    []email_sender.PreviewFile
+	Message  []byte
+	Issues   []email_linter.Issue
+}
+
+type PreviewTemplateQueryHandler struct {
+	repository repositories.EmailAccountRepository
+}
+
+func NewPreviewTemplateQueryHandler(repository repositories.EmailAccountRepository) *PreviewTemplateQueryHandler {
+	return &PreviewTemplateQueryHandler{repository: repository}
+}
+
+func (c *PreviewTemplateQueryHandler) Handle(ctx context.Context, query *PreviewTemplateQuery) (*PreviewTemplateQueryResponse, error) {
+	// STEP-1: Get the email account, the default one of the project unless an address is given
+	var ea *domain.EmailAccount
+	if query.Email != "" {
+		email, err := voExternal.NewEmail(query.Email)
+		if err != nil {
+			return nil, err
+		}
+		if ea, err = c.repository.GetByEmail(ctx, email); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if ea, err = c.repository.GetDefault(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if ea == nil {
+		return nil, shared.ErrNotFound
+	}
+
+	// STEP-2: Render the template in the requested language
+	templates, err := c.repository.GetTemplates(ctx, ea.GetID(), domain.EmailTemplateName(query.Name))
+	if err != nil {
+		return nil, err
+	}
+	template, err := template_renderer.Select(templates, query.Language)
+	if err != nil {
+		return nil, shared.ErrNotFound
+	}
+	toEmail, err := voExternal.NewEmail(query.To)
+	if err != nil {
+		return nil, err
+	}
+	tokens := map[string]string{"Email": toEmail.Value()}
+	maps.Copy(tokens, query.Data)
+	subject := template_renderer.Render(template.GetSubject(), tokens)
+	body := template_renderer.Render(template.GetBody(), tokens)
+
+	// STEP-3: Build the message as the email channel would send it
+	from := voExternal.NewAddress(ea.GetDisplayName(), ea.GetEmail())
+	detail, err := email_sender.BaseEmailDetail(subject, body, from, voExternal.NewAddress("", toEmail))
+	if err != nil {
+		return nil, err
+	}
+	if unsubscribeURL := tokens[domain.UnsubscribeURLKey]; unsubscribeURL != "" {
+		detail.WithListUnsubscribe(unsubscribeURL)
+	}
+	preview, err := email_sender.PreviewEmail(ctx, detail)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PreviewTemplateQueryResponse{
+		Language: template.GetLanguage(),
+		Subject:  preview.Subject,
+		Text:     preview.Text,
+		HTML:     preview.HTML,
+		Header:   preview.Header,
+		Files:    preview.Files,
+		Message:  preview.Message,
+		Issues:   email_linter.Lint(preview),
+	}, nil
+}
@@ -0,0 +1,41 @@
+-- ****************************
+-- ****** SMS ACCOUNTS ********
+-- ****************************
+
+DROP TABLE IF EXISTS notification.sms_accounts;
+
+CREATE TABLE IF NOT EXISTS notification.sms_accounts
+(
+    id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    provider_id smallint NOT NULL,
+    sender character varying(32) COLLATE pg_catalog."default" NOT NULL,
+    username text COLLATE pg_catalog."default",
+    password text COLLATE pg_catalog."default",
+    created_at timestamp without time zone NOT NULL,
+    CONSTRAINT "PK_sms_accounts" PRIMARY KEY (id),
+    CONSTRAINT "UX_sms_accounts_project_id_sender" UNIQUE (project_id, sender)
+);
+
+ALTER TABLE IF EXISTS notification.sms_accounts OWNER to admin;
+
+-- *****************************
+-- ****** SMS TEMPLATES ********
+-- *****************************
+
+DROP TABLE IF EXISTS notification.sms_templates;
+
+CREATE TABLE IF NOT EXISTS notification.sms_templates
+(
+    sms_account_id uuid NOT NULL,
+    name character varying(128) COLLATE pg_catalog."default" NOT NULL,
+    language character varying(8) COLLATE pg_catalog."default" NOT NULL,
+    body text COLLATE pg_catalog."default" NOT NULL,
+    CONSTRAINT "UX_sms_templates_sms_account_id_name_language" UNIQUE (sms_account_id, name, language),
+    CONSTRAINT "FK_sms_templates_sms_account_id" FOREIGN KEY (sms_account_id)
+        REFERENCES notification.sms_accounts (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS notification.sms_templates OWNER to admin;
@@ -0,0 +1,16 @@
+DO $$
+    DECLARE
+        project_id UUID := 'd3a99d5e-3c8c-4b39-84e2-a814da4db011';
+        sms_account_id UUID := '0b8d2f0e-6f5c-4c1e-9a57-3f1f8e6d2c41';
+    BEGIN    
+    
+        INSERT INTO notification.sms_accounts (id, project_id, provider_id, sender, created_at)
+        VALUES 
+            (sms_account_id, project_id, 3, 'YEYU', CURRENT_TIMESTAMP);
+        
+        INSERT INTO notification.sms_templates (sms_account_id, name, language, body)
+        VALUES
+            (sms_account_id, 'PHONE_VERIFICATION', 'tr-TR', 'Yeyu doğrulama kodunuz: %Code%. Kod %ExpireMinutes% dakika geçerlidir.'),
+            (sms_account_id, 'PHONE_VERIFICATION', 'en-US', 'Your Yeyu verification code is %Code%. It expires in %ExpireMinutes% minutes.');
+
+    END $$;
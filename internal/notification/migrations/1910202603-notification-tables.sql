@@ -0,0 +1,64 @@
+-- **************************************
+-- ****** RECIPIENT PREFERENCES *********
+-- **************************************
+
+DROP TABLE IF EXISTS notification.recipient_preferences;
+
+CREATE TABLE IF NOT EXISTS notification.recipient_preferences
+(
+    project_id uuid NOT NULL,
+    email character varying(128) COLLATE pg_catalog."default" NOT NULL,
+    phone character varying(16) COLLATE pg_catalog."default",
+    webhook_url text COLLATE pg_catalog."default",
+    language character varying(8) COLLATE pg_catalog."default",
+    channels text[] NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    CONSTRAINT "PK_recipient_preferences" PRIMARY KEY (project_id, email)
+);
+
+ALTER TABLE IF EXISTS notification.recipient_preferences OWNER to admin;
+
+-- *****************************
+-- ****** NOTIFICATIONS ********
+-- *****************************
+
+DROP TABLE IF EXISTS notification.notifications;
+
+CREATE TABLE IF NOT EXISTS notification.notifications
+(
+    id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    recipient character varying(128) COLLATE pg_catalog."default" NOT NULL,
+    template_name character varying(128) COLLATE pg_catalog."default" NOT NULL,
+    language character varying(8) COLLATE pg_catalog."default" NOT NULL,
+    data jsonb NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    CONSTRAINT "PK_notifications" PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS "IX_notifications_project_id_recipient" ON notification.notifications (project_id, recipient);
+
+ALTER TABLE IF EXISTS notification.notifications OWNER to admin;
+
+-- **************************************
+-- ****** NOTIFICATION DELIVERIES *******
+-- **************************************
+
+DROP TABLE IF EXISTS notification.notification_deliveries;
+
+CREATE TABLE IF NOT EXISTS notification.notification_deliveries
+(
+    notification_id uuid NOT NULL,
+    channel character varying(16) COLLATE pg_catalog."default" NOT NULL,
+    status character varying(16) COLLATE pg_catalog."default" NOT NULL,
+    provider_message_id text COLLATE pg_catalog."default",
+    error text COLLATE pg_catalog."default",
+    updated_at timestamp without time zone NOT NULL,
+    CONSTRAINT "PK_notification_deliveries" PRIMARY KEY (notification_id, channel),
+    CONSTRAINT "FK_notification_deliveries_notification_id" FOREIGN KEY (notification_id)
+        REFERENCES notification.notifications (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS notification.notification_deliveries OWNER to admin;
@@ -0,0 +1,15 @@
+-- *****************************
+-- ****** SUBSCRIPTIONS ********
+-- *****************************
+
+ALTER TABLE IF EXISTS notification.subscriptions
+    ALTER COLUMN language TYPE character varying(8);
+
+ALTER TABLE IF EXISTS notification.subscriptions
+    ADD COLUMN IF NOT EXISTS status character varying(16) COLLATE pg_catalog."default" NOT NULL DEFAULT 'pending';
+
+ALTER TABLE IF EXISTS notification.subscriptions
+    ADD COLUMN IF NOT EXISTS confirmed_at timestamp without time zone;
+
+ALTER TABLE IF EXISTS notification.subscriptions
+    ADD CONSTRAINT "PK_subscriptions" PRIMARY KEY (project_id, email);
@@ -0,0 +1,11 @@
+DO $$
+    DECLARE
+        email_account_id UUID := '44e7ac3f-d914-4890-8e1a-91713c375219';
+    BEGIN    
+    
+        INSERT INTO notification.email_templates (email_account_id, name, language, subject, body, allow_direct_reply)
+        VALUES
+            (email_account_id, 'NEWSLETTER_CONFIRMATION', 'tr-TR', 'Bülten Aboneliği', 'Yeyu bültenine abone olmak için buraya <a href="%ConfirmationURL%">tıklayın</a>.<br />Bu isteği siz yapmadıysanız bu e-postayı dikkate almayın.<br />', FALSE),
+            (email_account_id, 'NEWSLETTER_CONFIRMATION', 'en-US', 'Newsletter Subscription', 'To subscribe to the Yeyu newsletter <a href="%ConfirmationURL%">click here</a>.<br />If you did not request it, just ignore this email.<br />', FALSE);
+
+    END $$;
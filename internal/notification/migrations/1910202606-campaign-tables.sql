@@ -0,0 +1,79 @@
+-- *****************************
+-- ****** EMAIL ACCOUNTS *******
+-- *****************************
+
+-- Send limits of the account, NULL uses the default quota of the account type
+ALTER TABLE notification.email_accounts ADD COLUMN IF NOT EXISTS max_per_minute integer;
+ALTER TABLE notification.email_accounts ADD COLUMN IF NOT EXISTS max_per_day integer;
+
+-- *************************
+-- ****** CAMPAIGNS ********
+-- *************************
+
+DROP TABLE IF EXISTS notification.campaigns;
+
+CREATE TABLE IF NOT EXISTS notification.campaigns
+(
+    id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    email_account_id uuid NOT NULL,
+    name character varying(128) COLLATE pg_catalog."default" NOT NULL,
+    template_name character varying(128) COLLATE pg_catalog."default" NOT NULL,
+    languages text[] NOT NULL,
+    scheduled_at timestamp without time zone NOT NULL,
+    status character varying(16) COLLATE pg_catalog."default" NOT NULL,
+    total_count integer NOT NULL DEFAULT 0,
+    sent_count integer NOT NULL DEFAULT 0,
+    failed_count integer NOT NULL DEFAULT 0,
+    started_at timestamp without time zone,
+    completed_at timestamp without time zone,
+    created_at timestamp without time zone NOT NULL,
+    CONSTRAINT "PK_campaigns" PRIMARY KEY (id),
+    CONSTRAINT "FK_campaigns_email_account_id" FOREIGN KEY (email_account_id)
+        REFERENCES notification.email_accounts (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "IX_campaigns_status_scheduled_at" ON notification.campaigns (status, scheduled_at);
+
+ALTER TABLE IF EXISTS notification.campaigns OWNER to admin;
+
+-- *****************************
+-- ****** QUEUED EMAILS ********
+-- *****************************
+
+DROP TABLE IF EXISTS notification.queued_emails;
+
+CREATE TABLE IF NOT EXISTS notification.queued_emails
+(
+    id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    campaign_id uuid,
+    email_account_id uuid NOT NULL,
+    "to" character varying(128) COLLATE pg_catalog."default" NOT NULL,
+    reply_to character varying(128) COLLATE pg_catalog."default",
+    cc text COLLATE pg_catalog."default",
+    bcc text COLLATE pg_catalog."default",
+    subject character varying(128) COLLATE pg_catalog."default" NOT NULL,
+    body text COLLATE pg_catalog."default" NOT NULL,
+    unsubscribe_url text COLLATE pg_catalog."default",
+    sent_at timestamp without time zone,
+    sent_tries smallint NOT NULL,
+    last_error text COLLATE pg_catalog."default",
+    created_at timestamp without time zone NOT NULL,
+    CONSTRAINT "PK_queued_emails" PRIMARY KEY (id),
+    CONSTRAINT "FK_queued_emails_email_account_id" FOREIGN KEY (email_account_id)
+        REFERENCES notification.email_accounts (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT "FK_queued_emails_campaign_id" FOREIGN KEY (campaign_id)
+        REFERENCES notification.campaigns (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "IX_queued_emails_email_account_id_sent_at" ON notification.queued_emails (email_account_id, sent_at);
+CREATE INDEX IF NOT EXISTS "IX_queued_emails_campaign_id" ON notification.queued_emails (campaign_id);
+
+ALTER TABLE IF EXISTS notification.queued_emails OWNER to admin;
@@ -0,0 +1,17 @@
+-- *****************************
+-- ****** SUPPRESSIONS *********
+-- *****************************
+
+DROP TABLE IF EXISTS notification.suppressions;
+
+CREATE TABLE IF NOT EXISTS notification.suppressions
+(
+    project_id uuid NOT NULL,
+    email character varying(128) COLLATE pg_catalog."default" NOT NULL,
+    reason character varying(16) COLLATE pg_catalog."default" NOT NULL,
+    diagnostic text COLLATE pg_catalog."default",
+    created_at timestamp without time zone NOT NULL,
+    CONSTRAINT "PK_suppressions" PRIMARY KEY (project_id, email)
+);
+
+ALTER TABLE IF EXISTS notification.suppressions OWNER to admin;
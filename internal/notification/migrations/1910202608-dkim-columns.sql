@@ -0,0 +1,9 @@
+-- *****************************
+-- ****** EMAIL ACCOUNTS *******
+-- *****************************
+
+-- DKIM signing of the outgoing messages, NULL disables signing. The private key is encrypted.
+ALTER TABLE notification.email_accounts ADD COLUMN IF NOT EXISTS dkim_domain character varying(255);
+ALTER TABLE notification.email_accounts ADD COLUMN IF NOT EXISTS dkim_selector character varying(63);
+ALTER TABLE notification.email_accounts ADD COLUMN IF NOT EXISTS dkim_algorithm character varying(16);
+ALTER TABLE notification.email_accounts ADD COLUMN IF NOT EXISTS dkim_private_key text;
@@ -0,0 +1,9 @@
+-- *****************************
+-- ****** EMAIL ACCOUNTS *******
+-- *****************************
+
+-- Set when the provider revoked the grant of an OAuth2 account, the user has to authorize it again
+ALTER TABLE notification.email_accounts ADD COLUMN IF NOT EXISTS needs_reconsent boolean NOT NULL DEFAULT false;
+
+-- Lookup of the tokens the background job refreshes before they expire
+CREATE INDEX IF NOT EXISTS "IX_email_accounts_expire_at" ON notification.email_accounts (expire_at) WHERE refresh_token IS NOT NULL;
@@ -0,0 +1,27 @@
+-- *****************************
+-- ***** INBOUND MAILBOXES *****
+-- *****************************
+
+DROP TABLE IF EXISTS notification.inbound_mailboxes;
+
+CREATE TABLE IF NOT EXISTS notification.inbound_mailboxes
+(
+    email_account_id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    host character varying(255) COLLATE pg_catalog."default" NOT NULL,
+    port integer NOT NULL,
+    enable_ssl boolean NOT NULL DEFAULT true,
+    folder character varying(255) COLLATE pg_catalog."default" NOT NULL DEFAULT 'INBOX',
+    use_idle boolean NOT NULL DEFAULT true,
+    uid_validity bigint NOT NULL DEFAULT 0,
+    last_uid bigint NOT NULL DEFAULT 0,
+    last_error text COLLATE pg_catalog."default",
+    created_at timestamp without time zone NOT NULL,
+    CONSTRAINT "PK_inbound_mailboxes" PRIMARY KEY (email_account_id),
+    CONSTRAINT "FK_inbound_mailboxes_email_account_id" FOREIGN KEY (email_account_id)
+        REFERENCES notification.email_accounts (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS notification.inbound_mailboxes OWNER to admin;
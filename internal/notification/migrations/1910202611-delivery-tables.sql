@@ -0,0 +1,61 @@
+-- *****************************
+-- ****** EMAIL ACCOUNTS *******
+-- *****************************
+
+-- Tracking pixel and link rewriting of the HTML messages
+ALTER TABLE notification.email_accounts ADD COLUMN IF NOT EXISTS track_opens boolean NOT NULL DEFAULT false;
+ALTER TABLE notification.email_accounts ADD COLUMN IF NOT EXISTS track_clicks boolean NOT NULL DEFAULT false;
+
+-- *****************************
+-- ***** EMAIL DELIVERIES ******
+-- *****************************
+
+DROP TABLE IF EXISTS notification.email_delivery_events;
+DROP TABLE IF EXISTS notification.email_deliveries;
+
+CREATE TABLE IF NOT EXISTS notification.email_deliveries
+(
+    id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    email_account_id uuid NOT NULL,
+    campaign_id uuid,
+    recipient character varying(128) COLLATE pg_catalog."default" NOT NULL,
+    subject character varying(998) COLLATE pg_catalog."default" NOT NULL,
+    message_id character varying(255) COLLATE pg_catalog."default" NOT NULL,
+    provider_message_id character varying(255) COLLATE pg_catalog."default",
+    status character varying(16) COLLATE pg_catalog."default" NOT NULL,
+    opens integer NOT NULL DEFAULT 0,
+    clicks integer NOT NULL DEFAULT 0,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    CONSTRAINT "PK_email_deliveries" PRIMARY KEY (id),
+    CONSTRAINT "FK_email_deliveries_email_account_id" FOREIGN KEY (email_account_id)
+        REFERENCES notification.email_accounts (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "IX_email_deliveries_project_id_created_at" ON notification.email_deliveries (project_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS "IX_email_deliveries_message_id" ON notification.email_deliveries (message_id);
+CREATE INDEX IF NOT EXISTS "IX_email_deliveries_provider_message_id" ON notification.email_deliveries (provider_message_id);
+
+ALTER TABLE IF EXISTS notification.email_deliveries OWNER to admin;
+
+CREATE TABLE IF NOT EXISTS notification.email_delivery_events
+(
+    id uuid NOT NULL,
+    delivery_id uuid NOT NULL,
+    status character varying(16) COLLATE pg_catalog."default" NOT NULL,
+    detail text COLLATE pg_catalog."default",
+    url text COLLATE pg_catalog."default",
+    occurred_at timestamp without time zone NOT NULL,
+    CONSTRAINT "PK_email_delivery_events" PRIMARY KEY (id),
+    CONSTRAINT "FK_email_delivery_events_delivery_id" FOREIGN KEY (delivery_id)
+        REFERENCES notification.email_deliveries (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "IX_email_delivery_events_delivery_id" ON notification.email_delivery_events (delivery_id, occurred_at);
+
+ALTER TABLE IF EXISTS notification.email_delivery_events OWNER to admin;
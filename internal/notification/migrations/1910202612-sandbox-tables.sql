@@ -0,0 +1,50 @@
+-- *****************************
+-- ****** EMAIL SANDBOXES ******
+-- *****************************
+
+DROP TABLE IF EXISTS notification.email_sandboxes;
+
+CREATE TABLE IF NOT EXISTS notification.email_sandboxes
+(
+    project_id uuid NOT NULL,
+    mode character varying(16) COLLATE pg_catalog."default" NOT NULL,
+    allow_list text[] NOT NULL DEFAULT '{}',
+    redirect_to character varying(128) COLLATE pg_catalog."default",
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    CONSTRAINT "PK_email_sandboxes" PRIMARY KEY (project_id)
+);
+
+ALTER TABLE IF EXISTS notification.email_sandboxes OWNER to admin;
+
+-- *****************************
+-- ****** CAPTURED EMAILS ******
+-- *****************************
+
+DROP TABLE IF EXISTS notification.captured_emails;
+
+CREATE TABLE IF NOT EXISTS notification.captured_emails
+(
+    id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    email_account_id uuid NOT NULL,
+    sender character varying(255) COLLATE pg_catalog."default" NOT NULL,
+    recipients text[] NOT NULL DEFAULT '{}',
+    subject character varying(998) COLLATE pg_catalog."default" NOT NULL,
+    message_id character varying(255) COLLATE pg_catalog."default",
+    headers jsonb NOT NULL DEFAULT '{}',
+    text_body text COLLATE pg_catalog."default",
+    html_body text COLLATE pg_catalog."default",
+    attachments jsonb NOT NULL DEFAULT '[]',
+    raw bytea NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    CONSTRAINT "PK_captured_emails" PRIMARY KEY (id),
+    CONSTRAINT "FK_captured_emails_email_account_id" FOREIGN KEY (email_account_id)
+        REFERENCES notification.email_accounts (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "IX_captured_emails_project_id_created_at" ON notification.captured_emails (project_id, created_at DESC);
+
+ALTER TABLE IF EXISTS notification.captured_emails OWNER to admin;
@@ -0,0 +1,14 @@
+-- *****************************
+-- ******* QUEUED EMAILS *******
+-- *****************************
+
+-- A campaign queues one email per recipient, expanding it again after a failure does not queue them twice
+CREATE UNIQUE INDEX IF NOT EXISTS "UX_queued_emails_campaign_id_to" ON notification.queued_emails (campaign_id, "to") WHERE campaign_id IS NOT NULL;
+
+-- *****************************
+-- ***** EMAIL DELIVERIES ******
+-- *****************************
+
+-- The send limits of an account count the messages it sent in the last minute and day, whatever sent them
+CREATE INDEX IF NOT EXISTS "IX_email_deliveries_email_account_id" ON notification.email_deliveries (email_account_id);
+CREATE INDEX IF NOT EXISTS "IX_email_delivery_events_status_occurred_at" ON notification.email_delivery_events (status, occurred_at);
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CampaignRepository interface {
+	// QUERY
+	GetAll(ctx context.Context) ([]*domain.Campaign, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Campaign, error)
+
+	// GetDue and GetRunning are used by the campaign scheduler, they are not scoped to a project
+	GetDue(ctx context.Context, now time.Time) ([]*domain.Campaign, error)
+	GetRunning(ctx context.Context) ([]*domain.Campaign, error)
+
+	// COMMAND
+	Create(ctx context.Context, campaign *domain.Campaign) error
+	Update(ctx context.Context, campaign *domain.Campaign) error
+	IncrementCounters(ctx context.Context, id uuid.UUID, sent, failed int) error
+}
+
+type QueuedEmailRepository interface {
+	// QUERY
+	GetPending(ctx context.Context, emailAccountID uuid.UUID, limit int) ([]*domain.QueuedEmail, error)
+	CountPending(ctx context.Context, campaignID uuid.UUID) (int, error)
+
+	// COMMAND
+	// CreateBatch skips the emails of a campaign already queued for their recipient
+	CreateBatch(ctx context.Context, emails []*domain.QueuedEmail) error
+	Update(ctx context.Context, email *domain.QueuedEmail) error
+	DeleteUnsent(ctx context.Context, campaignID uuid.UUID) error
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+
+	"github.com/google/uuid"
+)
+
+type CapturedEmailRepository interface {
+	// QUERY
+
+	// GetAll returns a page of the captured messages of the project, newest first, with the number of
+	// messages sent to recipient, or of all of them when it is empty.
+	GetAll(ctx context.Context, recipient string, page, pageSize int) ([]*domain.CapturedEmail, int, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.CapturedEmail, error)
+
+	// COMMAND
+	Save(ctx context.Context, email *domain.CapturedEmail) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteAll(ctx context.Context) error
+}
@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"platform/internal/notification/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CampaignDTO maps campaigns rows to domain objects and back.
+type CampaignDTO struct {
+	ID             uuid.UUID  `db:"id"`
+	ProjectID      uuid.UUID  `db:"project_id"`
+	EmailAccountID uuid.UUID  `db:"email_account_id"`
+	Name           string     `db:"name"`
+	TemplateName   string     `db:"template_name"`
+	Languages      []string   `db:"languages"`
+	ScheduledAt    time.Time  `db:"scheduled_at"`
+	Status         string     `db:"status"`
+	TotalCount     int        `db:"total_count"`
+	SentCount      int        `db:"sent_count"`
+	FailedCount    int        `db:"failed_count"`
+	StartedAt      *time.Time `db:"started_at"`
+	CompletedAt    *time.Time `db:"completed_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+}
+
+// ToDomain converts the DTO into a domain Campaign.
+func (dto *CampaignDTO) ToDomain() *domain.Campaign {
+	entity := &domain.Campaign{}
+	entity.SetID(dto.ID)
+	entity.SetProjectID(dto.ProjectID)
+	entity.SetEmailAccountID(dto.EmailAccountID)
+	entity.SetName(dto.Name)
+	entity.SetTemplateName(domain.EmailTemplateName(dto.TemplateName))
+	entity.SetLanguages(dto.Languages)
+	entity.SetScheduledAt(dto.ScheduledAt)
+	entity.SetStatus(domain.CampaignStatus(dto.Status))
+	entity.SetCounters(dto.TotalCount, dto.SentCount, dto.FailedCount)
+	entity.SetStartedAt(dto.StartedAt)
+	entity.SetCompletedAt(dto.CompletedAt)
+	entity.SetCreatedAt(dto.CreatedAt)
+	return entity
+}
+
+// Convert from entity to database row
+func (dto *CampaignDTO) ToDTO(c *domain.Campaign) *CampaignDTO {
+	dto.ID = c.GetID()
+	dto.ProjectID = c.GetProjectID()
+	dto.EmailAccountID = c.GetEmailAccountID()
+	dto.Name = c.GetName()
+	dto.TemplateName = string(c.GetTemplateName())
+	dto.Languages = c.GetLanguages()
+	dto.ScheduledAt = c.GetScheduledAt()
+	dto.Status = string(c.GetStatus())
+	dto.TotalCount = c.GetTotalCount()
+	dto.SentCount = c.GetSentCount()
+	dto.FailedCount = c.GetFailedCount()
+	dto.StartedAt = c.GetStartedAt()
+	dto.CompletedAt = c.GetCompletedAt()
+	dto.CreatedAt = c.GetCreatedAt()
+	return dto
+}
+
+// GetValues returns a flat slice of fields in order for inserts/updates.
+func (dto *CampaignDTO) GetValues() []any {
+	return []any{
+		dto.ID,
+		dto.ProjectID,
+		dto.EmailAccountID,
+		dto.Name,
+		dto.TemplateName,
+		dto.Languages,
+		dto.ScheduledAt,
+		dto.Status,
+		dto.TotalCount,
+		dto.SentCount,
+		dto.FailedCount,
+		dto.StartedAt,
+		dto.CompletedAt,
+		dto.CreatedAt,
+	}
+}
+
+// QueuedEmailDTO maps queued_emails rows to domain objects and back.
+type QueuedEmailDTO struct {
+	ID             uuid.UUID  `db:"id"`
+	ProjectID      uuid.UUID  `db:"project_id"`
+	CampaignID     *uuid.UUID `db:"campaign_id"`
+	EmailAccountID uuid.UUID  `db:"email_account_id"`
+	To             string     `db:"to"`
+	ReplyTo        *string    `db:"reply_to"`
+	Cc             *string    `db:"cc"`
+	Bcc            *string    `db:"bcc"`
+	Subject        string     `db:"subject"`
+	Body           string     `db:"body"`
+	UnsubscribeURL *string    `db:"unsubscribe_url"`
+	SentAt         *time.Time `db:"sent_at"`
+	SentTries      int16      `db:"sent_tries"`
+	LastError      *string    `db:"last_error"`
+	CreatedAt      time.Time  `db:"created_at"`
+}
+
+// ToDomain converts the DTO into a domain QueuedEmail.
+func (dto *QueuedEmailDTO) ToDomain() *domain.QueuedEmail {
+	entity := domain.NewQueuedEmail(
+		dto.EmailAccountID,
+		dto.To,
+		ptrToString(dto.ReplyTo),
+		ptrToString(dto.Cc),
+		ptrToString(dto.Bcc),
+		dto.Subject,
+		dto.Body,
+		dto.SentAt,
+		int(dto.SentTries),
+	)
+	entity.SetID(dto.ID)
+	entity.SetProjectID(dto.ProjectID)
+	entity.SetCampaignID(dto.CampaignID)
+	entity.SetUnsubscribeURL(ptrToString(dto.UnsubscribeURL))
+	entity.SetLastError(ptrToString(dto.LastError))
+	entity.SetCreatedAt(dto.CreatedAt)
+	return entity
+}
+
+// Convert from entity to database row
+func (dto *QueuedEmailDTO) ToDTO(qe *domain.QueuedEmail) *QueuedEmailDTO {
+	dto.ID = qe.GetID()
+	dto.ProjectID = qe.GetProjectID()
+	dto.CampaignID = qe.GetCampaignID()
+	dto.EmailAccountID = qe.GetEmailAccountID()
+	dto.To = qe.GetTo()
+	dto.ReplyTo = ptrToStringValue(qe.GetReplyTo())
+	dto.Cc = ptrToStringValue(qe.GetCc())
+	dto.Bcc = ptrToStringValue(qe.GetBcc())
+	dto.Subject = qe.GetSubject()
+	dto.Body = qe.GetBody()
+	dto.UnsubscribeURL = ptrToStringValue(qe.GetUnsubscribeURL())
+	dto.SentAt = qe.GetSentAt()
+	dto.SentTries = int16(qe.GetSentTries())
+	dto.LastError = ptrToStringValue(qe.GetLastError())
+	dto.CreatedAt = qe.GetCreatedAt()
+	return dto
+}
+
+// GetValues returns a flat slice of fields in order for inserts/updates.
+func (dto *QueuedEmailDTO) GetValues() []any {
+	return []any{
+		dto.ID,
+		dto.ProjectID,
+		dto.CampaignID,
+		dto.EmailAccountID,
+		dto.To,
+		dto.ReplyTo,
+		dto.Cc,
+		dto.Bcc,
+		dto.Subject,
+		dto.Body,
+		dto.UnsubscribeURL,
+		dto.SentAt,
+		dto.SentTries,
+		dto.LastError,
+		dto.CreatedAt,
+	}
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"encoding/json"
+	"platform/internal/notification/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CapturedEmailDTO maps captured_emails rows to domain objects and back.
+type CapturedEmailDTO struct {
+	ID             uuid.UUID `db:"id"`
+	ProjectID      uuid.UUID `db:"project_id"`
+	EmailAccountID uuid.UUID `db:"email_account_id"`
+	Sender         string    `db:"sender"`
+	Recipients     []string  `db:"recipients"`
+	Subject        string    `db:"subject"`
+	MessageID      *string   `db:"message_id"`
+	Headers        []byte    `db:"headers"`
+	TextBody       *string   `db:"text_body"`
+	HTMLBody       *string   `db:"html_body"`
+	Attachments    []byte    `db:"attachments"`
+	Raw            []byte    `db:"raw"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// ToDomain converts the DTO into a domain CapturedEmail.
+func (dto *CapturedEmailDTO) ToDomain() *domain.CapturedEmail {
+	headers := map[string][]string{}
+	_ = json.Unmarshal(dto.Headers, &headers)
+	attachments := []domain.CapturedAttachment{}
+	_ = json.Unmarshal(dto.Attachments, &attachments)
+
+	entity := domain.NewCapturedEmail(dto.ProjectID, dto.EmailAccountID, dto.Sender, dto.Recipients, dto.Raw)
+	entity.SetID(dto.ID)
+	entity.SetSubject(dto.Subject)
+	entity.SetMessageID(ptrToString(dto.MessageID))
+	entity.SetHeaders(headers)
+	entity.SetText(ptrToString(dto.TextBody))
+	entity.SetHTML(ptrToString(dto.HTMLBody))
+	entity.SetAttachments(attachments)
+	entity.SetCreatedAt(dto.CreatedAt)
+	return entity
+}
+
+// Convert from entity to database row
+func (dto *CapturedEmailDTO) ToDTO(e *domain.CapturedEmail) *CapturedEmailDTO {
+	dto.ID = e.GetID()
+	dto.ProjectID = e.GetProjectID()
+	dto.EmailAccountID = e.GetEmailAccountID()
+	dto.Sender = e.GetFrom()
+	dto.Recipients = e.GetRecipients()
+	dto.Subject = e.GetSubject()
+	dto.MessageID = ptrToStringValue(e.GetMessageID())
+	dto.Headers, _ = json.Marshal(e.GetHeaders())
+	dto.TextBody = ptrToStringValue(e.GetText())
+	dto.HTMLBody = ptrToStringValue(e.GetHTML())
+	dto.Attachments, _ = json.Marshal(e.GetAttachments())
+	dto.Raw = e.GetRaw()
+	dto.CreatedAt = e.GetCreatedAt()
+	return dto
+}
+
+// GetValues returns a flat slice of fields in order for inserts.
+func (dto *CapturedEmailDTO) GetValues() []any {
+	return []any{
+		dto.ID,
+		dto.ProjectID,
+		dto.EmailAccountID,
+		dto.Sender,
+		dto.Recipients,
+		dto.Subject,
+		dto.MessageID,
+		dto.Headers,
+		dto.TextBody,
+		dto.HTMLBody,
+		dto.Attachments,
+		dto.Raw,
+		dto.CreatedAt,
+	}
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"platform/internal/notification/domain"
 	voInternal "platform/internal/notification/domain/value_object"
+	email_provider "platform/internal/notification/services/emailProvider"
 	voExternal "platform/pkg/domain/value_object"
 	"time"
 
@@ -11,23 +12,32 @@ import (
 
 // EmailAccountDTO maps database rows to domain objects and back.
 type EmailAccountDTO struct {
-	ID           uuid.UUID  `db:"id"`
-	ProjectID    uuid.UUID  `db:"project_id"`
-	Email        string     `db:"email"`
-	DisplayName  string     `db:"display_name"`
-	Host         string     `db:"host"`
-	Port         int        `db:"port"`
-	EnableSsl    bool       `db:"enable_ssl"`
-	TypeID       int        `db:"type_id"`
-	Username     *string    `db:"username"`
-	Password     *string    `db:"password"`
-	ClientID     *string    `db:"client_id"`
-	TenantID     *string    `db:"tenant_id"`
-	ClientSecret *string    `db:"client_secret"`
-	AccessToken  *string    `db:"access_token"`
-	RefreshToken *string    `db:"refresh_token"`
-	ExpireAt     *time.Time `db:"expire_at"`
-	CreatedAt    time.Time  `db:"created_at"`
+	ID             uuid.UUID  `db:"id"`
+	ProjectID      uuid.UUID  `db:"project_id"`
+	Email          string     `db:"email"`
+	DisplayName    string     `db:"display_name"`
+	Host           string     `db:"host"`
+	Port           int        `db:"port"`
+	EnableSsl      bool       `db:"enable_ssl"`
+	TypeID         int        `db:"type_id"`
+	Username       *string    `db:"username"`
+	Password       *string    `db:"password"`
+	ClientID       *string    `db:"client_id"`
+	TenantID       *string    `db:"tenant_id"`
+	ClientSecret   *string    `db:"client_secret"`
+	AccessToken    *string    `db:"access_token"`
+	RefreshToken   *string    `db:"refresh_token"`
+	ExpireAt       *time.Time `db:"expire_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	MaxPerMinute   *int       `db:"max_per_minute"`
+	MaxPerDay      *int       `db:"max_per_day"`
+	DkimDomain     *string    `db:"dkim_domain"`
+	DkimSelector   *string    `db:"dkim_selector"`
+	DkimAlgorithm  *string    `db:"dkim_algorithm"`
+	DkimPrivateKey *string    `db:"dkim_private_key"`
+	NeedsReconsent bool       `db:"needs_reconsent"`
+	TrackOpens     bool       `db:"track_opens"`
+	TrackClicks    bool       `db:"track_clicks"`
 }
 
 // ToDomain converts the DTO into a domain EmailAccount.
@@ -46,8 +56,15 @@ func (dto *EmailAccountDTO) ToDomain() *domain.EmailAccount {
 	entity.SetEnableSSL(dto.EnableSsl)
 	entity.SetSmtpType(dto.TypeID)
 	entity.SetCreatedAt(dto.CreatedAt)
+	entity.SetMaxPerMinute(ptrToInt(dto.MaxPerMinute))
+	entity.SetMaxPerDay(ptrToInt(dto.MaxPerDay))
+	entity.SetNeedsReconsent(dto.NeedsReconsent)
+	entity.SetTracking(dto.TrackOpens, dto.TrackClicks)
+	if dto.DkimDomain != nil {
+		entity.SetDkimSettings(voInternal.NewDkimSettings(*dto.DkimDomain, ptrToString(dto.DkimSelector), ptrToString(dto.DkimAlgorithm), ptrToString(dto.DkimPrivateKey)))
+	}
 
-	if dto.TypeID == domain.Login {
+	if !email_provider.UsesOAuth2(dto.TypeID) {
 		// Username and password can be null in database, so we should check it and if they are null set to empty string
 		username := ptrToString(dto.Username)
 		password := ptrToString(dto.Password)
@@ -85,6 +102,19 @@ func (dto *EmailAccountDTO) ToDTO(ea *domain.EmailAccount) *EmailAccountDTO {
 	dto.EnableSsl = ea.GetEnableSSL()
 	dto.TypeID = ea.GetSmtpType()
 	dto.CreatedAt = ea.GetCreatedAt()
+	dto.MaxPerMinute = ptrToIntValue(ea.GetMaxPerMinute())
+	dto.MaxPerDay = ptrToIntValue(ea.GetMaxPerDay())
+	dto.NeedsReconsent = ea.GetNeedsReconsent()
+	dto.TrackOpens = ea.GetTrackOpens()
+	dto.TrackClicks = ea.GetTrackClicks()
+
+	if dkimSettings := ea.GetDkimSettings(); dkimSettings != nil {
+		dkimDomain, selector, algorithm, privateKey := dkimSettings.Settings()
+		dto.DkimDomain = ptrToStringValue(dkimDomain)
+		dto.DkimSelector = ptrToStringValue(selector)
+		dto.DkimAlgorithm = ptrToStringValue(algorithm)
+		dto.DkimPrivateKey = ptrToStringValue(privateKey)
+	}
 
 	traditionalCredentials := ea.GetTraditionalCredentials()
 	if traditionalCredentials != nil {
@@ -133,5 +163,14 @@ func (dto *EmailAccountDTO) GetValues() []any {
 		dto.RefreshToken,
 		dto.ExpireAt,
 		dto.CreatedAt,
+		dto.MaxPerMinute,
+		dto.MaxPerDay,
+		dto.DkimDomain,
+		dto.DkimSelector,
+		dto.DkimAlgorithm,
+		dto.DkimPrivateKey,
+		dto.NeedsReconsent,
+		dto.TrackOpens,
+		dto.TrackClicks,
 	}
 }
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"platform/internal/notification/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailDeliveryDTO maps email_deliveries rows to domain objects and back.
+type EmailDeliveryDTO struct {
+	ID                uuid.UUID  `db:"id"`
+	ProjectID         uuid.UUID  `db:"project_id"`
+	EmailAccountID    uuid.UUID  `db:"email_account_id"`
+	CampaignID        *uuid.UUID `db:"campaign_id"`
+	Recipient         string     `db:"recipient"`
+	Subject           string     `db:"subject"`
+	MessageID         string     `db:"message_id"`
+	ProviderMessageID *string    `db:"provider_message_id"`
+	Status            string     `db:"status"`
+	Opens             int        `db:"opens"`
+	Clicks            int        `db:"clicks"`
+	CreatedAt         time.Time  `db:"created_at"`
+	UpdatedAt         time.Time  `db:"updated_at"`
+}
+
+// ToDomain converts the DTO into a domain EmailDelivery, the events are loaded separately.
+func (dto *EmailDeliveryDTO) ToDomain() *domain.EmailDelivery {
+	entity := &domain.EmailDelivery{}
+	entity.SetID(dto.ID)
+	entity.SetProjectID(dto.ProjectID)
+	entity.SetEmailAccountID(dto.EmailAccountID)
+	entity.SetCampaignID(dto.CampaignID)
+	entity.SetRecipient(dto.Recipient)
+	entity.SetSubject(dto.Subject)
+	entity.SetMessageID(dto.MessageID)
+	entity.SetProviderMessageID(ptrToString(dto.ProviderMessageID))
+	entity.SetStatus(domain.EmailDeliveryStatus(dto.Status))
+	entity.SetCounters(dto.Opens, dto.Clicks)
+	entity.SetEvents(make([]domain.EmailDeliveryEvent, 0))
+	entity.SetCreatedAt(dto.CreatedAt)
+	entity.SetUpdatedAt(dto.UpdatedAt)
+	return entity
+}
+
+// Convert from entity to database row
+func (dto *EmailDeliveryDTO) ToDTO(d *domain.EmailDelivery) *EmailDeliveryDTO {
+	dto.ID = d.GetID()
+	dto.ProjectID = d.GetProjectID()
+	dto.EmailAccountID = d.GetEmailAccountID()
+	dto.CampaignID = d.GetCampaignID()
+	dto.Recipient = d.GetRecipient()
+	dto.Subject = d.GetSubject()
+	dto.MessageID = d.GetMessageID()
+	dto.ProviderMessageID = ptrToStringValue(d.GetProviderMessageID())
+	dto.Status = string(d.GetStatus())
+	dto.Opens = d.GetOpens()
+	dto.Clicks = d.GetClicks()
+	dto.CreatedAt = d.GetCreatedAt()
+	dto.UpdatedAt = d.GetUpdatedAt()
+	return dto
+}
+
+// GetValues returns a flat slice of fields in order for inserts/updates.
+func (dto *EmailDeliveryDTO) GetValues() []any {
+	return []any{
+		dto.ID,
+		dto.ProjectID,
+		dto.EmailAccountID,
+		dto.CampaignID,
+		dto.Recipient,
+		dto.Subject,
+		dto.MessageID,
+		dto.ProviderMessageID,
+		dto.Status,
+		dto.Opens,
+		dto.Clicks,
+		dto.CreatedAt,
+		dto.UpdatedAt,
+	}
+}
+
+// EmailDeliveryEventDTO maps email_delivery_events rows to domain objects and back.
+type EmailDeliveryEventDTO struct {
+	ID         uuid.UUID `db:"id"`
+	DeliveryID uuid.UUID `db:"delivery_id"`
+	Status     string    `db:"status"`
+	Detail     *string   `db:"detail"`
+	URL        *string   `db:"url"`
+	OccurredAt time.Time `db:"occurred_at"`
+}
+
+func (dto *EmailDeliveryEventDTO) ToDomain() domain.EmailDeliveryEvent {
+	return domain.EmailDeliveryEvent{
+		ID:         dto.ID,
+		Status:     domain.EmailDeliveryStatus(dto.Status),
+		Detail:     ptrToString(dto.Detail),
+		URL:        ptrToString(dto.URL),
+		OccurredAt: dto.OccurredAt,
+	}
+}
+
+func (dto *EmailDeliveryEventDTO) ToDTO(deliveryID uuid.UUID, e domain.EmailDeliveryEvent) *EmailDeliveryEventDTO {
+	dto.ID = e.ID
+	dto.DeliveryID = deliveryID
+	dto.Status = string(e.Status)
+	dto.Detail = ptrToStringValue(e.Detail)
+	dto.URL = ptrToStringValue(e.URL)
+	dto.OccurredAt = e.OccurredAt
+	return dto
+}
+
+// GetValues returns a flat slice of fields in order for inserts.
+func (dto *EmailDeliveryEventDTO) GetValues() []any {
+	return []any{
+		dto.ID,
+		dto.DeliveryID,
+		dto.Status,
+		dto.Detail,
+		dto.URL,
+		dto.OccurredAt,
+	}
+}
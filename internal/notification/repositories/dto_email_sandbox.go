@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"platform/internal/notification/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailSandboxDTO maps email_sandboxes rows to domain objects and back.
+type EmailSandboxDTO struct {
+	ProjectID  uuid.UUID `db:"project_id"`
+	Mode       string    `db:"mode"`
+	AllowList  []string  `db:"allow_list"`
+	RedirectTo *string   `db:"redirect_to"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// ToDomain converts the DTO into a domain EmailSandbox.
+func (dto *EmailSandboxDTO) ToDomain() *domain.EmailSandbox {
+	entity := domain.NewEmailSandbox(dto.ProjectID, domain.SandboxMode(dto.Mode), dto.AllowList, ptrToString(dto.RedirectTo))
+	entity.SetCreatedAt(dto.CreatedAt)
+	entity.SetUpdatedAt(dto.UpdatedAt)
+	return entity
+}
+
+// Convert from entity to database row
+func (dto *EmailSandboxDTO) ToDTO(s *domain.EmailSandbox) *EmailSandboxDTO {
+	dto.ProjectID = s.GetProjectID()
+	dto.Mode = string(s.GetMode())
+	dto.AllowList = s.GetAllowList()
+	dto.RedirectTo = ptrToStringValue(s.GetRedirectTo())
+	dto.CreatedAt = s.GetCreatedAt()
+	dto.UpdatedAt = s.GetUpdatedAt()
+	return dto
+}
+
+// GetValues returns a flat slice of fields in order for inserts/updates.
+func (dto *EmailSandboxDTO) GetValues() []any {
+	return []any{
+		dto.ProjectID,
+		dto.Mode,
+		dto.AllowList,
+		dto.RedirectTo,
+		dto.CreatedAt,
+		dto.UpdatedAt,
+	}
+}
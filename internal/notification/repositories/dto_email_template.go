@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"platform/internal/notification/domain"
+
+	"github.com/google/uuid"
+)
+
+// EmailTemplateDTO maps email_templates rows to domain objects.
+type EmailTemplateDTO struct {
+	EmailAccountID    uuid.UUID `db:"email_account_id"`
+	Name              string    `db:"name"`
+	Language          string    `db:"language"`
+	Subject           string    `db:"subject"`
+	Body              string    `db:"body"`
+	BccEmailAddresses *string   `db:"bcc_email_addresses"`
+	AllowDirectReply  bool      `db:"allow_direct_reply"`
+}
+
+// ToDomain converts the DTO into a domain EmailTemplate.
+func (dto *EmailTemplateDTO) ToDomain() domain.EmailTemplate {
+	return *domain.NewEmailTemplate(
+		dto.EmailAccountID,
+		domain.EmailTemplateName(dto.Name),
+		dto.Language,
+		dto.Subject,
+		dto.Body,
+		ptrToString(dto.BccEmailAddresses),
+		dto.AllowDirectReply,
+	)
+}
@@ -33,3 +33,19 @@ func ptrToTimeValue(t time.Time) *time.Time {
 	}
 	return nil
 }
+
+// ptrToInt returns int value or zero.
+func ptrToInt(i *int) int {
+	if i != nil {
+		return *i
+	}
+	return 0
+}
+
+// ptrToIntValue returns *int if non-zero.
+func ptrToIntValue(i int) *int {
+	if i != 0 {
+		return &i
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"platform/internal/notification/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InboundMailboxDTO maps inbound_mailboxes rows to domain objects and back.
+type InboundMailboxDTO struct {
+	EmailAccountID uuid.UUID `db:"email_account_id"`
+	ProjectID      uuid.UUID `db:"project_id"`
+	Host           string    `db:"host"`
+	Port           int       `db:"port"`
+	EnableSsl      bool      `db:"enable_ssl"`
+	Folder         string    `db:"folder"`
+	UseIdle        bool      `db:"use_idle"`
+	UIDValidity    int64     `db:"uid_validity"`
+	LastUID        int64     `db:"last_uid"`
+	LastError      *string   `db:"last_error"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// ToDomain converts the DTO into a domain InboundMailbox.
+func (dto *InboundMailboxDTO) ToDomain() *domain.InboundMailbox {
+	entity := domain.NewInboundMailbox(dto.EmailAccountID, dto.ProjectID, dto.Host, dto.Port, dto.EnableSsl, dto.Folder, dto.UseIdle)
+	entity.SetCursor(uint32(dto.UIDValidity), uint32(dto.LastUID))
+	entity.SetLastError(ptrToString(dto.LastError))
+	entity.SetCreatedAt(dto.CreatedAt)
+	return entity
+}
+
+// Convert from entity to database row
+func (dto *InboundMailboxDTO) ToDTO(m *domain.InboundMailbox) *InboundMailboxDTO {
+	dto.EmailAccountID = m.GetEmailAccountID()
+	dto.ProjectID = m.GetProjectID()
+	dto.Host = m.GetHost()
+	dto.Port = m.GetPort()
+	dto.EnableSsl = m.GetEnableSSL()
+	dto.Folder = m.GetFolder()
+	dto.UseIdle = m.GetUseIdle()
+	dto.UIDValidity = int64(m.GetUIDValidity())
+	dto.LastUID = int64(m.GetLastUID())
+	dto.LastError = ptrToStringValue(m.GetLastError())
+	dto.CreatedAt = m.GetCreatedAt()
+	return dto
+}
+
+// GetValues returns a flat slice of fields in order for inserts/updates.
+func (dto *InboundMailboxDTO) GetValues() []any {
+	return []any{
+		dto.EmailAccountID,
+		dto.ProjectID,
+		dto.Host,
+		dto.Port,
+		dto.EnableSsl,
+		dto.Folder,
+		dto.UseIdle,
+		dto.UIDValidity,
+		dto.LastUID,
+		dto.LastError,
+		dto.CreatedAt,
+	}
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"encoding/json"
+	"platform/internal/notification/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationDTO maps notifications rows to domain objects and back.
+type NotificationDTO struct {
+	ID           uuid.UUID `db:"id"`
+	ProjectID    uuid.UUID `db:"project_id"`
+	Recipient    string    `db:"recipient"`
+	TemplateName string    `db:"template_name"`
+	Language     string    `db:"language"`
+	Data         []byte    `db:"data"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// DeliveryDTO maps notification_deliveries rows to domain objects and back.
+type DeliveryDTO struct {
+	NotificationID    uuid.UUID `db:"notification_id"`
+	Channel           string    `db:"channel"`
+	Status            string    `db:"status"`
+	ProviderMessageID *string   `db:"provider_message_id"`
+	Error             *string   `db:"error"`
+	UpdatedAt         time.Time `db:"updated_at"`
+}
+
+// ToDomain converts the DTOs into a domain Notification.
+func (dto *NotificationDTO) ToDomain(deliveries []DeliveryDTO) *domain.Notification {
+	data := map[string]string{}
+	_ = json.Unmarshal(dto.Data, &data)
+
+	entity := &domain.Notification{}
+	entity.SetID(dto.ID)
+	entity.SetProjectID(dto.ProjectID)
+	entity.SetRecipient(dto.Recipient)
+	entity.SetTemplateName(dto.TemplateName)
+	entity.SetLanguage(dto.Language)
+	entity.SetData(data)
+	entity.SetCreatedAt(dto.CreatedAt)
+
+	list := make([]domain.Delivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		list = append(list, domain.Delivery{
+			Channel:           domain.Channel(d.Channel),
+			Status:            domain.DeliveryStatus(d.Status),
+			ProviderMessageID: ptrToString(d.ProviderMessageID),
+			Error:             ptrToString(d.Error),
+			UpdatedAt:         d.UpdatedAt,
+		})
+	}
+	entity.SetDeliveries(list)
+
+	return entity
+}
+
+// Convert from entity to database row
+func (dto *NotificationDTO) ToDTO(n *domain.Notification) *NotificationDTO {
+	dto.ID = n.GetID()
+	dto.ProjectID = n.GetProjectID()
+	dto.Recipient = n.GetRecipient()
+	dto.TemplateName = n.GetTemplateName()
+	dto.Language = n.GetLanguage()
+	dto.CreatedAt = n.GetCreatedAt()
+	dto.Data, _ = json.Marshal(n.GetData())
+	return dto
+}
+
+// GetValues returns a flat slice of fields in order for inserts.
+func (dto *NotificationDTO) GetValues() []any {
+	return []any{
+		dto.ID,
+		dto.ProjectID,
+		dto.Recipient,
+		dto.TemplateName,
+		dto.Language,
+		dto.Data,
+		dto.CreatedAt,
+	}
+}
+
+// deliveryValues returns the fields of a delivery in order for inserts/updates.
+func deliveryValues(notificationID uuid.UUID, d domain.Delivery) []any {
+	return []any{
+		notificationID,
+		string(d.Channel),
+		string(d.Status),
+		ptrToStringValue(d.ProviderMessageID),
+		ptrToStringValue(d.Error),
+		d.UpdatedAt,
+	}
+}
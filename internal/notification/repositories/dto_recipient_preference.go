@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"platform/internal/notification/domain"
+	voExternal "platform/pkg/domain/value_object"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecipientPreferenceDTO maps database rows to domain objects and back.
+type RecipientPreferenceDTO struct {
+	ProjectID  uuid.UUID `db:"project_id"`
+	Email      string    `db:"email"`
+	Phone      *string   `db:"phone"`
+	WebhookURL *string   `db:"webhook_url"`
+	Language   *string   `db:"language"`
+	Channels   []string  `db:"channels"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// ToDomain converts the DTO into a domain RecipientPreference.
+func (dto *RecipientPreferenceDTO) ToDomain() *domain.RecipientPreference {
+	// Email and phone are coming from database, we are sure they are valid, so ignore errors
+	email, _ := voExternal.NewEmail(dto.Email)
+
+	channels := make([]domain.Channel, 0, len(dto.Channels))
+	for _, channel := range dto.Channels {
+		channels = append(channels, domain.Channel(channel))
+	}
+
+	entity := domain.NewRecipientPreference(dto.ProjectID, email, ptrToString(dto.Language), channels)
+	entity.SetWebhookURL(ptrToString(dto.WebhookURL))
+	entity.SetUpdatedAt(dto.UpdatedAt)
+	if dto.Phone != nil {
+		if phone, err := voExternal.NewPhoneNumber(*dto.Phone); err == nil {
+			entity.SetPhone(&phone)
+		}
+	}
+
+	return entity
+}
+
+// Convert from entity to database row
+func (dto *RecipientPreferenceDTO) ToDTO(rp *domain.RecipientPreference) *RecipientPreferenceDTO {
+	dto.ProjectID = rp.GetProjectID()
+	dto.Email = rp.GetEmail().Value()
+	dto.WebhookURL = ptrToStringValue(rp.GetWebhookURL())
+	dto.Language = ptrToStringValue(rp.GetLanguage())
+	dto.UpdatedAt = rp.GetUpdatedAt()
+
+	if phone := rp.GetPhone(); phone != nil {
+		dto.Phone = ptrToStringValue(phone.Value())
+	}
+
+	dto.Channels = make([]string, 0, len(rp.GetChannels()))
+	for _, channel := range rp.GetChannels() {
+		dto.Channels = append(dto.Channels, string(channel))
+	}
+
+	return dto
+}
+
+// GetValues returns a flat slice of fields in order for inserts/updates.
+func (dto *RecipientPreferenceDTO) GetValues() []any {
+	return []any{
+		dto.ProjectID,
+		dto.Email,
+		dto.Phone,
+		dto.WebhookURL,
+		dto.Language,
+		dto.Channels,
+		dto.UpdatedAt,
+	}
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"platform/internal/notification/domain"
+	voInternal "platform/internal/notification/domain/value_object"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SmsAccountDTO maps database rows to domain objects and back.
+type SmsAccountDTO struct {
+	ID         uuid.UUID `db:"id"`
+	ProjectID  uuid.UUID `db:"project_id"`
+	ProviderID int       `db:"provider_id"`
+	Sender     string    `db:"sender"`
+	Username   *string   `db:"username"`
+	Password   *string   `db:"password"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// ToDomain converts the DTO into a domain SmsAccount.
+func (dto *SmsAccountDTO) ToDomain() *domain.SmsAccount {
+	entity := &domain.SmsAccount{}
+	entity.SetID(dto.ID)
+	entity.SetProjectID(dto.ProjectID)
+	entity.SetProviderID(dto.ProviderID)
+	entity.SetSender(dto.Sender)
+	entity.SetCreatedAt(dto.CreatedAt)
+
+	// The fake provider has no credentials, so they can be null in database
+	username := ptrToString(dto.Username)
+	password := ptrToString(dto.Password)
+	entity.SetCredentials(voInternal.NewTraditionalCredentials(username, password))
+
+	return entity
+}
+
+// Convert from entity to database row
+func (dto *SmsAccountDTO) ToDTO(sa *domain.SmsAccount) *SmsAccountDTO {
+	dto.ID = sa.GetID()
+	dto.ProjectID = sa.GetProjectID()
+	dto.ProviderID = sa.GetProviderID()
+	dto.Sender = sa.GetSender()
+	dto.CreatedAt = sa.GetCreatedAt()
+
+	credentials := sa.GetCredentials()
+	if credentials != nil {
+		username, password := credentials.Credentials()
+		dto.Username = ptrToStringValue(username)
+		dto.Password = ptrToStringValue(password)
+	}
+
+	return dto
+}
+
+// GetValues returns a flat slice of fields in order for inserts.
+func (dto *SmsAccountDTO) GetValues() []any {
+	return []any{
+		dto.ID,
+		dto.ProjectID,
+		dto.ProviderID,
+		dto.Sender,
+		dto.Username,
+		dto.Password,
+		dto.CreatedAt,
+	}
+}
+
+// SmsTemplateDTO maps sms_templates rows to domain objects.
+type SmsTemplateDTO struct {
+	SmsAccountID uuid.UUID `db:"sms_account_id"`
+	Name         string    `db:"name"`
+	Language     string    `db:"language"`
+	Body         string    `db:"body"`
+}
+
+// ToDomain converts the DTO into a domain SmsTemplate.
+func (dto *SmsTemplateDTO) ToDomain() domain.SmsTemplate {
+	return *domain.NewSmsTemplate(dto.SmsAccountID, domain.SmsTemplateName(dto.Name), dto.Language, dto.Body)
+}
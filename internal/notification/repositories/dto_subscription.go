@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"platform/internal/notification/domain"
+	"platform/internal/shared"
+	voExternal "platform/pkg/domain/value_object"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubscriptionDTO maps subscriptions rows to domain objects and back.
+type SubscriptionDTO struct {
+	ProjectID   uuid.UUID  `db:"project_id"`
+	Email       string     `db:"email"`
+	Language    string     `db:"language"`
+	CreatedAt   time.Time  `db:"created_at"`
+	Status      string     `db:"status"`
+	ConfirmedAt *time.Time `db:"confirmed_at"`
+}
+
+// ToDomain converts the DTO into a domain Subscription.
+func (dto *SubscriptionDTO) ToDomain() *domain.Subscription {
+	// Email is coming from database, we are sure it is valid, so ignore error
+	email, _ := voExternal.NewEmail(dto.Email)
+	language := shared.GetLanguageManager().GetLanguageByCulture(dto.Language)
+
+	entity := domain.NewSubscription(dto.ProjectID, email, *language)
+	entity.SetStatus(domain.SubscriptionStatus(dto.Status))
+	entity.SetConfirmedAt(dto.ConfirmedAt)
+	entity.SetCreatedAt(dto.CreatedAt)
+	return entity
+}
+
+// Convert from entity to database row
+func (dto *SubscriptionDTO) ToDTO(s *domain.Subscription) *SubscriptionDTO {
+	dto.ProjectID = s.GetProjectID()
+	dto.Email = s.GetEmail().Value()
+	dto.Language = s.GetLanguage().GetCulture()
+	dto.CreatedAt = s.GetCreatedAt()
+	dto.Status = string(s.GetStatus())
+	dto.ConfirmedAt = s.GetConfirmedAt()
+	return dto
+}
+
+// GetValues returns a flat slice of fields in order for inserts/updates.
+func (dto *SubscriptionDTO) GetValues() []any {
+	return []any{
+		dto.ProjectID,
+		dto.Email,
+		dto.Language,
+		dto.CreatedAt,
+		dto.Status,
+		dto.ConfirmedAt,
+	}
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"platform/internal/notification/domain"
+	voExternal "platform/pkg/domain/value_object"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SuppressionDTO maps suppressions rows to domain objects and back.
+type SuppressionDTO struct {
+	ProjectID  uuid.UUID `db:"project_id"`
+	Email      string    `db:"email"`
+	Reason     string    `db:"reason"`
+	Diagnostic *string   `db:"diagnostic"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// ToDomain converts the DTO into a domain Suppression.
+func (dto *SuppressionDTO) ToDomain() *domain.Suppression {
+	// Email is coming from database, we are sure it is valid, so ignore error
+	email, _ := voExternal.NewEmail(dto.Email)
+
+	entity := domain.NewSuppression(dto.ProjectID, email, domain.SuppressionReason(dto.Reason), ptrToString(dto.Diagnostic))
+	entity.SetCreatedAt(dto.CreatedAt)
+	return entity
+}
+
+// Convert from entity to database row
+func (dto *SuppressionDTO) ToDTO(s *domain.Suppression) *SuppressionDTO {
+	dto.ProjectID = s.GetProjectID()
+	dto.Email = s.GetEmail().Value()
+	dto.Reason = string(s.GetReason())
+	dto.Diagnostic = ptrToStringValue(s.GetDiagnostic())
+	dto.CreatedAt = s.GetCreatedAt()
+	return dto
+}
+
+// GetValues returns a flat slice of fields in order for inserts/updates.
+func (dto *SuppressionDTO) GetValues() []any {
+	return []any{
+		dto.ProjectID,
+		dto.Email,
+		dto.Reason,
+		dto.Diagnostic,
+		dto.CreatedAt,
+	}
+}
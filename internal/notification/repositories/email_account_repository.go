@@ -3,13 +3,21 @@ package repositories
 import (
 	"context"
 	"platform/internal/notification/domain"
+	"platform/internal/shared"
 	vo "platform/pkg/domain/value_object"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type EmailAccountRepository interface {
 	// QUERY
-	GetAll(ctx context.Context) ([]*domain.EmailAccount, error)
+	GetAll(ctx context.Context, options shared.ListOptions) (*shared.ListResult[*domain.EmailAccount], error)
 	GetByEmail(ctx context.Context, email vo.Email) (*domain.EmailAccount, error)
+	GetDefault(ctx context.Context) (*domain.EmailAccount, error)
+	GetTemplates(ctx context.Context, emailAccountID uuid.UUID, name domain.EmailTemplateName) ([]domain.EmailTemplate, error)
+	GetExpiringTokens(ctx context.Context, before time.Time) ([]*domain.EmailAccount, error)
+	GetByAddress(ctx context.Context, email vo.Email) ([]*domain.EmailAccount, error)
 
 	// COMMAND
 	Create(ctx context.Context, account *domain.EmailAccount) error
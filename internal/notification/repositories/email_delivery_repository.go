@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailDeliveryFilter narrows the delivery logs of a project, the zero values match everything
+type EmailDeliveryFilter struct {
+	Status         domain.EmailDeliveryStatus
+	Recipient      string
+	EmailAccountID *uuid.UUID
+	CampaignID     *uuid.UUID
+	From           time.Time
+	To             time.Time
+}
+
+type EmailDeliveryRepository interface {
+	// QUERY
+	GetAll(ctx context.Context, filter EmailDeliveryFilter, page, pageSize int) ([]*domain.EmailDelivery, int, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.EmailDelivery, error)
+
+	// GetByMessageID matches the Message-ID header or the identifier given by the provider, it is used for
+	// the webhooks and the bounces which are not scoped to a project
+	GetByMessageID(ctx context.Context, messageID string) (*domain.EmailDelivery, error)
+
+	// CountSentSince counts the messages the email account sent since the time, of every project
+	CountSentSince(ctx context.Context, emailAccountID uuid.UUID, since time.Time) (int, error)
+
+	// COMMAND
+	Save(ctx context.Context, delivery *domain.EmailDelivery) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+)
+
+type EmailSandboxRepository interface {
+	// QUERY
+	Get(ctx context.Context) (*domain.EmailSandbox, error)
+
+	// COMMAND
+	Save(ctx context.Context, sandbox *domain.EmailSandbox) error
+	Delete(ctx context.Context) error
+}
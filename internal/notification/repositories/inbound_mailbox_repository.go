@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+
+	"github.com/google/uuid"
+)
+
+type InboundMailboxRepository interface {
+	// QUERY
+	GetAll(ctx context.Context) ([]*domain.InboundMailbox, error)
+	GetByEmailAccount(ctx context.Context, emailAccountID uuid.UUID) (*domain.InboundMailbox, error)
+
+	// COMMAND
+	Save(ctx context.Context, mailbox *domain.InboundMailbox) error
+	UpdateCursor(ctx context.Context, mailbox *domain.InboundMailbox) error
+	Delete(ctx context.Context, emailAccountID uuid.UUID) error
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"fmt"
+	"platform/internal/shared"
+	"strings"
+)
+
+// sortColumn is a column a list can be sorted on. cast is the type the values of the cursors are cast to
+// and value reads the value of a row for the next cursor.
+type sortColumn[T any] struct {
+	name  string
+	cast  string
+	value func(row *T) string
+}
+
+// keyset builds the ORDER BY clause of a list, the id column is the last key so the order is total, and
+// the condition selecting the rows after the cursor of the options. The values of the cursor are appended
+// to args.
+type keyset[T any] struct {
+	keys []sortColumn[T]
+	desc []bool
+}
+
+func newKeyset[T any](options shared.ListOptions, id sortColumn[T], columns map[string]sortColumn[T]) (*keyset[T], error) {
+	k := &keyset[T]{}
+	for _, field := range options.Sort {
+		column, ok := columns[field.Field]
+		if !ok {
+			return nil, shared.ErrInvalidSort
+		}
+		k.keys = append(k.keys, column)
+		k.desc = append(k.desc, field.Desc)
+	}
+	k.keys = append(k.keys, id)
+	k.desc = append(k.desc, false)
+
+	if options.After != nil && len(options.After.Values) != len(k.keys) {
+		return nil, shared.ErrInvalidCursor
+	}
+	return k, nil
+}
+
+func (k *keyset[T]) orderBy() string {
+	parts := make([]string, len(k.keys))
+	for i, column := range k.keys {
+		parts[i] = column.name
+		if k.desc[i] {
+			parts[i] += " DESC"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// after returns the condition of the rows following the cursor, for (a ASC, b DESC, id ASC):
+// a > $1 OR (a = $1 AND b < $2) OR (a = $1 AND b = $2 AND id > $3)
+func (k *keyset[T]) after(cursor *shared.Cursor, args []any) (string, []any) {
+	placeholders := make([]string, len(k.keys))
+	for i, column := range k.keys {
+		args = append(args, cursor.Values[i])
+		placeholders[i] = fmt.Sprintf("$%d::%s", len(args), column.cast)
+	}
+
+	alternatives := make([]string, len(k.keys))
+	for i, column := range k.keys {
+		terms := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			terms = append(terms, fmt.Sprintf("%s = %s", k.keys[j].name, placeholders[j]))
+		}
+		operator := ">"
+		if k.desc[i] {
+			operator = "<"
+		}
+		terms = append(terms, fmt.Sprintf("%s %s %s", column.name, operator, placeholders[i]))
+		alternatives[i] = "(" + strings.Join(terms, " AND ") + ")"
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+// limit returns the LIMIT and OFFSET clause of the page, one more row than the page size tells whether a
+// next page exists. A zero page size selects every row after the cursor.
+func (k *keyset[T]) limit(options shared.ListOptions, args []any) (string, []any) {
+	if options.PageSize == 0 {
+		return "", args
+	}
+	args = append(args, options.PageSize+1, options.Offset())
+	return fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args)), args
+}
+
+// page trims the extra row selected by limit and returns the cursor of the next page, or an empty cursor
+// when rows is the last page
+func (k *keyset[T]) page(options shared.ListOptions, rows []T) ([]T, string) {
+	if options.PageSize == 0 || len(rows) <= options.PageSize {
+		return rows, ""
+	}
+	rows = rows[:options.PageSize]
+	return rows, k.cursor(options, &rows[len(rows)-1])
+}
+
+// cursor returns the cursor of the position after row
+func (k *keyset[T]) cursor(options shared.ListOptions, row *T) string {
+	values := make([]string, len(k.keys))
+	for i, column := range k.keys {
+		values[i] = column.value(row)
+	}
+	cursor := shared.Cursor{Sort: options.SortString(), Values: values}
+	return cursor.Encode()
+}
+
+// likePattern returns a LIKE pattern matching the values which contain text
+func likePattern(text string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text) + "%"
+}
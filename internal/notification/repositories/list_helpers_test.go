@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"platform/internal/shared"
+	"testing"
+)
+
+type testRow struct {
+	ID   string
+	Name string
+}
+
+var testIDColumn = sortColumn[testRow]{name: "id", cast: "uuid", value: func(row *testRow) string { return row.ID }}
+
+var testSortColumns = map[string]sortColumn[testRow]{
+	"name": {name: "name", cast: "text", value: func(row *testRow) string { return row.Name }},
+}
+
+func TestKeysetPage(t *testing.T) {
+	rows := []testRow{{"1", "a"}, {"2", "b"}, {"3", "c"}}
+	sort := []shared.SortField{{Field: "name"}}
+
+	tests := []struct {
+		name     string
+		options  shared.ListOptions
+		limit    string
+		args     int
+		rows     int
+		lastName string
+	}{
+		{"every row with filters", shared.ListOptions{Filters: map[string]string{"q": "x"}}, "", 1, 3, ""},
+		{"every row with sort", shared.ListOptions{Sort: sort}, "", 1, 3, ""},
+		{"first page", shared.ListOptions{Page: 1, PageSize: 2, Sort: sort}, " LIMIT $2 OFFSET $3", 3, 2, "b"},
+		{"last page", shared.ListOptions{Page: 2, PageSize: 3}, " LIMIT $2 OFFSET $3", 3, 3, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := newKeyset(tt.options, testIDColumn, testSortColumns)
+			if err != nil {
+				t.Fatalf("newKeyset: %v", err)
+			}
+
+			limit, args := keys.limit(tt.options, []any{"project"})
+			if limit != tt.limit || len(args) != tt.args {
+				t.Errorf("limit = %q with %d args, want %q with %d", limit, len(args), tt.limit, tt.args)
+			}
+
+			page, next := keys.page(tt.options, rows)
+			if len(page) != tt.rows {
+				t.Errorf("page has %d rows, want %d", len(page), tt.rows)
+			}
+			if tt.lastName == "" {
+				if next != "" {
+					t.Errorf("next cursor = %q, want none", next)
+				}
+				return
+			}
+			cursor, err := shared.DecodeCursor(next, tt.options.SortString())
+			if err != nil {
+				t.Fatalf("decode cursor: %v", err)
+			}
+			if cursor.Values[0] != tt.lastName {
+				t.Errorf("cursor values = %v, want the last row %q", cursor.Values, tt.lastName)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/shared"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// memoryCapturedEmailRepository keeps the last captured messages of every project in memory, they are lost
+// on restart. It is meant for local development where no database is set up for them.
+type memoryCapturedEmailRepository struct {
+	mu     sync.Mutex
+	limit  int
+	emails map[uuid.UUID][]*domain.CapturedEmail
+}
+
+// NewMemoryCapturedEmailRepository creates a store keeping at most limit messages per project, the oldest
+// ones are dropped first
+func NewMemoryCapturedEmailRepository(limit int) CapturedEmailRepository {
+	return &memoryCapturedEmailRepository{limit: limit, emails: make(map[uuid.UUID][]*domain.CapturedEmail)}
+}
+
+// QUERY
+func (m *memoryCapturedEmailRepository) GetAll(ctx context.Context, recipient string, page, pageSize int) ([]*domain.CapturedEmail, int, error) {
+	projectID, ok := ctx.Value(shared.ProjectIDContextKey).(uuid.UUID)
+	if !ok {
+		return nil, 0, shared.ErrInvalidContext
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Messages are appended, so the newest are at the end
+	stored := m.emails[projectID]
+	matching := make([]*domain.CapturedEmail, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		if recipient == "" || sentTo(stored[i], recipient) {
+			matching = append(matching, stored[i])
+		}
+	}
+
+	start := min((page-1)*pageSize, len(matching))
+	end := min(start+pageSize, len(matching))
+	return matching[start:end], len(matching), nil
+}
+
+func (m *memoryCapturedEmailRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.CapturedEmail, error) {
+	projectID, ok := ctx.Value(shared.ProjectIDContextKey).(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, email := range m.emails[projectID] {
+		if email.GetID() == id {
+			return email, nil
+		}
+	}
+	return nil, nil
+}
+
+// COMMAND
+func (m *memoryCapturedEmailRepository) Save(ctx context.Context, e *domain.CapturedEmail) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored := append(m.emails[e.GetProjectID()], e)
+	if m.limit > 0 && len(stored) > m.limit {
+		stored = stored[len(stored)-m.limit:]
+	}
+	m.emails[e.GetProjectID()] = stored
+	return nil
+}
+
+func (m *memoryCapturedEmailRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	projectID, ok := ctx.Value(shared.ProjectIDContextKey).(uuid.UUID)
+	if !ok {
+		return shared.ErrInvalidContext
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored := m.emails[projectID]
+	for i, email := range stored {
+		if email.GetID() == id {
+			m.emails[projectID] = append(stored[:i:i], stored[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *memoryCapturedEmailRepository) DeleteAll(ctx context.Context) error {
+	projectID, ok := ctx.Value(shared.ProjectIDContextKey).(uuid.UUID)
+	if !ok {
+		return shared.ErrInvalidContext
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.emails, projectID)
+	return nil
+}
+
+func sentTo(email *domain.CapturedEmail, recipient string) bool {
+	for _, r := range email.GetRecipients() {
+		if strings.EqualFold(r, recipient) {
+			return true
+		}
+	}
+	return false
+}
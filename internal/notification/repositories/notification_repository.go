@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+
+	"github.com/google/uuid"
+)
+
+type NotificationRepository interface {
+	// QUERY
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Notification, error)
+
+	// COMMAND
+	Create(ctx context.Context, notification *domain.Notification) error
+	UpdateDeliveries(ctx context.Context, notification *domain.Notification) error
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"platform/internal/notification/domain"
+	"platform/internal/shared"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pgCampaignRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgCampaignRepository(pool *pgxpool.Pool) CampaignRepository {
+	return &pgCampaignRepository{pool: pool}
+}
+
+// QUERY
+func (p *pgCampaignRepository) GetAll(ctx context.Context) ([]*domain.Campaign, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Get result from database
+	sql := `SELECT * FROM notification.campaigns WHERE project_id = $1 ORDER BY created_at DESC`
+	return p.query(ctx, sql, projectID)
+}
+
+func (p *pgCampaignRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Campaign, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Get result from database
+	sql := `SELECT * FROM notification.campaigns WHERE project_id = $1 AND id = $2`
+	rows, err := p.pool.Query(ctx, sql, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dto, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[CampaignDTO])
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dto.ToDomain(), nil
+}
+
+func (p *pgCampaignRepository) GetDue(ctx context.Context, now time.Time) ([]*domain.Campaign, error) {
+	sql := `SELECT * FROM notification.campaigns WHERE status = $1 AND scheduled_at <= $2 ORDER BY scheduled_at`
+	return p.query(ctx, sql, string(domain.CampaignScheduled), now)
+}
+
+func (p *pgCampaignRepository) GetRunning(ctx context.Context) ([]*domain.Campaign, error) {
+	sql := `SELECT * FROM notification.campaigns WHERE status = $1 ORDER BY started_at`
+	return p.query(ctx, sql, string(domain.CampaignRunning))
+}
+
+// COMMAND
+func (p *pgCampaignRepository) Create(ctx context.Context, c *domain.Campaign) error {
+	query := `
+		INSERT INTO notification.campaigns (
+			id,
+			project_id,
+			email_account_id,
+			name,
+			template_name,
+			languages,
+			scheduled_at,
+			status,
+			total_count,
+			sent_count,
+			failed_count,
+			started_at,
+			completed_at,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	dto := CampaignDTO{}
+	_, err := p.pool.Exec(ctx, query, dto.ToDTO(c).GetValues()...)
+	if err != nil {
+		return fmt.Errorf("failed to create campaign: %w", err)
+	}
+	return nil
+}
+
+// Update saves the status of the campaign, the sent and failed counters are only changed by IncrementCounters
+// because the scheduler updates them while the campaign can be paused or cancelled
+func (p *pgCampaignRepository) Update(ctx context.Context, c *domain.Campaign) error {
+	query := `
+		UPDATE notification.campaigns
+		SET
+			status = $2,
+			total_count = $3,
+			started_at = $4,
+			completed_at = $5
+		WHERE id = $1`
+
+	_, err := p.pool.Exec(ctx, query, c.GetID(), string(c.GetStatus()), c.GetTotalCount(), c.GetStartedAt(), c.GetCompletedAt())
+	if err != nil {
+		return fmt.Errorf("failed to update campaign: %w", err)
+	}
+	return nil
+}
+
+func (p *pgCampaignRepository) IncrementCounters(ctx context.Context, id uuid.UUID, sent, failed int) error {
+	query := `UPDATE notification.campaigns SET sent_count = sent_count + $2, failed_count = failed_count + $3 WHERE id = $1`
+	_, err := p.pool.Exec(ctx, query, id, sent, failed)
+	if err != nil {
+		return fmt.Errorf("failed to update campaign counters: %w", err)
+	}
+	return nil
+}
+
+func (p *pgCampaignRepository) query(ctx context.Context, sql string, args ...any) ([]*domain.Campaign, error) {
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dtoList, err := pgx.CollectRows(rows, pgx.RowToStructByName[CampaignDTO])
+	if err != nil {
+		return nil, err
+	}
+
+	campaigns := make([]*domain.Campaign, 0, len(dtoList))
+	for _, dto := range dtoList {
+		campaigns = append(campaigns, dto.ToDomain())
+	}
+	return campaigns, nil
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"platform/internal/notification/domain"
+	"platform/internal/shared"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pgCapturedEmailRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgCapturedEmailRepository(pool *pgxpool.Pool) CapturedEmailRepository {
+	return &pgCapturedEmailRepository{pool: pool}
+}
+
+// QUERY
+func (p *pgCapturedEmailRepository) GetAll(ctx context.Context, recipient string, page, pageSize int) ([]*domain.CapturedEmail, int, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, 0, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Build the conditions of the filter
+	clause := "project_id = $1"
+	args := []any{projectID}
+	if recipient != "" {
+		args = append(args, strings.ToLower(recipient))
+		clause += " AND EXISTS (SELECT 1 FROM unnest(recipients) AS r WHERE lower(r) = $2)"
+	}
+
+	// STEP-3: Count the matching messages
+	var total int
+	if err := p.pool.QueryRow(ctx, `SELECT COUNT(*) FROM notification.captured_emails WHERE `+clause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// STEP-4: Get the page from database
+	sql := fmt.Sprintf(`SELECT * FROM notification.captured_emails WHERE %s ORDER BY created_at DESC LIMIT $%d OFFSET $%d`, clause, len(args)+1, len(args)+2)
+	rows, err := p.pool.Query(ctx, sql, append(args, pageSize, (page-1)*pageSize)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	dtoList, err := pgx.CollectRows(rows, pgx.RowToStructByName[CapturedEmailDTO])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	emails := make([]*domain.CapturedEmail, 0, len(dtoList))
+	for _, dto := range dtoList {
+		emails = append(emails, dto.ToDomain())
+	}
+	return emails, total, nil
+}
+
+// GetByID returns the captured message of the project, or nil if it does not exist
+func (p *pgCapturedEmailRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.CapturedEmail, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Get result from database
+	sql := `SELECT * FROM notification.captured_emails WHERE project_id = $1 AND id = $2`
+	rows, err := p.pool.Query(ctx, sql, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dto, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[CapturedEmailDTO])
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return dto.ToDomain(), nil
+}
+
+// COMMAND
+func (p *pgCapturedEmailRepository) Save(ctx context.Context, e *domain.CapturedEmail) error {
+	query := `
+		INSERT INTO notification.captured_emails (
+			id,
+			project_id,
+			email_account_id,
+			sender,
+			recipients,
+			subject,
+			message_id,
+			headers,
+			text_body,
+			html_body,
+			attachments,
+			raw,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	dto := CapturedEmailDTO{}
+	_, err := p.pool.Exec(ctx, query, dto.ToDTO(e).GetValues()...)
+	if err != nil {
+		return fmt.Errorf("failed to save captured email: %w", err)
+	}
+	return nil
+}
+
+func (p *pgCapturedEmailRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return shared.ErrInvalidContext
+	}
+
+	// STEP-2: Delete from database
+	sql := "DELETE FROM notification.captured_emails WHERE project_id = $1 AND id = $2"
+	_, err := p.pool.Exec(ctx, sql, projectID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete captured email: %w", err)
+	}
+	return nil
+}
+
+func (p *pgCapturedEmailRepository) DeleteAll(ctx context.Context) error {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return shared.ErrInvalidContext
+	}
+
+	// STEP-2: Delete from database
+	sql := "DELETE FROM notification.captured_emails WHERE project_id = $1"
+	_, err := p.pool.Exec(ctx, sql, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to delete captured emails: %w", err)
+	}
+	return nil
+}
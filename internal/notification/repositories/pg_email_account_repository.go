@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"platform/internal/notification/domain"
+	email_provider "platform/internal/notification/services/emailProvider"
 	"platform/internal/shared"
 	vo "platform/pkg/domain/value_object"
 	"platform/pkg/services/cache"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -28,7 +32,94 @@ func NewPgEmailAccountRepository(pool *pgxpool.Pool, cache cache.CacheManager) E
 }
 
 // QUERY
-func (p *pgEmailAccountRepository) GetAll(ctx context.Context) ([]*domain.EmailAccount, error) {
+
+// emailAccountSortColumns are the fields the email accounts can be sorted on
+var emailAccountSortColumns = map[string]sortColumn[EmailAccountDTO]{
+	"email":        {name: "email", cast: "text", value: func(dto *EmailAccountDTO) string { return dto.Email }},
+	"display_name": {name: "display_name", cast: "text", value: func(dto *EmailAccountDTO) string { return dto.DisplayName }},
+	"type_id":      {name: "type_id", cast: "integer", value: func(dto *EmailAccountDTO) string { return strconv.Itoa(dto.TypeID) }},
+	"created_at":   {name: "created_at", cast: "timestamp", value: func(dto *EmailAccountDTO) string { return dto.CreatedAt.Format(time.RFC3339Nano) }},
+}
+
+var emailAccountIDColumn = sortColumn[EmailAccountDTO]{name: "id", cast: "uuid", value: func(dto *EmailAccountDTO) string { return dto.ID.String() }}
+
+// GetAll returns a page of the email accounts of the project. The filters are "type_id" and "q", which
+// matches the addresses and the display names containing it. Without page size, filters and sort every
+// account is returned in order of creation from the cache.
+func (p *pgEmailAccountRepository) GetAll(ctx context.Context, options shared.ListOptions) (*shared.ListResult[*domain.EmailAccount], error) {
+	if options.PageSize == 0 && len(options.Filters) == 0 && len(options.Sort) == 0 {
+		accounts, err := p.getAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &shared.ListResult[*domain.EmailAccount]{Items: accounts, TotalCount: len(accounts)}, nil
+	}
+
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+	keys, err := newKeyset(options, emailAccountIDColumn, emailAccountSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-2: Build the conditions of the filters
+	conditions := []string{"project_id = $1"}
+	args := []any{projectID}
+	if value, ok := options.Filters["type_id"]; ok {
+		typeID, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, shared.ErrValidation
+		}
+		args = append(args, typeID)
+		conditions = append(conditions, fmt.Sprintf("type_id = $%d", len(args)))
+	}
+	if value, ok := options.Filters["q"]; ok {
+		args = append(args, likePattern(value))
+		conditions = append(conditions, fmt.Sprintf("(email ILIKE $%d OR display_name ILIKE $%d)", len(args), len(args)))
+	}
+	clause := strings.Join(conditions, " AND ")
+
+	// STEP-3: Count the matching accounts
+	var total int
+	if err := p.pool.QueryRow(ctx, `SELECT COUNT(*) FROM notification.email_accounts WHERE `+clause, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	// STEP-4: Get the page from database, every matching account without page size
+	if options.After != nil {
+		var after string
+		after, args = keys.after(options.After, args)
+		clause += " AND " + after
+	}
+	limit, args := keys.limit(options, args)
+	sql := fmt.Sprintf(`SELECT * FROM notification.email_accounts WHERE %s ORDER BY %s%s`, clause, keys.orderBy(), limit)
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dtoList, err := pgx.CollectRows(rows, pgx.RowToStructByName[EmailAccountDTO])
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-5: Convert from dto to domain
+	result := &shared.ListResult[*domain.EmailAccount]{TotalCount: total}
+	dtoList, result.NextCursor = keys.page(options, dtoList)
+	result.Items = make([]*domain.EmailAccount, 0, len(dtoList))
+	for _, dto := range dtoList {
+		result.Items = append(result.Items, dto.ToDomain())
+	}
+	return result, nil
+}
+
+// getAll returns every email account of the project in order of creation
+func (p *pgEmailAccountRepository) getAll(ctx context.Context) ([]*domain.EmailAccount, error) {
 	// STEP-1: Get project identifier and validate
 	pidVal := ctx.Value(shared.ProjectIDContextKey)
 	projectID, ok := pidVal.(uuid.UUID)
@@ -148,6 +239,110 @@ func (p *pgEmailAccountRepository) GetByEmail(ctx context.Context, email vo.Emai
 	return dto.ToDomain(), nil
 }
 
+// GetDefault returns the first account created for the project, or nil if it has none
+func (p *pgEmailAccountRepository) GetDefault(ctx context.Context) (*domain.EmailAccount, error) {
+	accounts, err := p.getAll(ctx)
+	if err != nil || len(accounts) == 0 {
+		return nil, err
+	}
+	return accounts[0], nil
+}
+
+// GetTemplates returns the templates with the given name in every language of the account
+func (p *pgEmailAccountRepository) GetTemplates(ctx context.Context, emailAccountID uuid.UUID, name domain.EmailTemplateName) ([]domain.EmailTemplate, error) {
+	// STEP-1: Create a cache key
+	cacheKey := cache.CacheKey{
+		Key:  cacheKeyTemplates(emailAccountID, name),
+		Time: cache.DefaultTTL,
+	}
+
+	// STEP-2: Check if the data is in the cache service
+	var dtoList []EmailTemplateDTO
+	cached, err := p.cache.Get(ctx, cacheKey)
+	if err != nil {
+		zap.L().Warn("cache GET error", zap.Error(err), zap.String("key", cacheKey.Key))
+	} else if cached != "" {
+		if err := json.Unmarshal([]byte(cached), &dtoList); err == nil {
+			return emailTemplatesToDomain(dtoList), nil
+		}
+
+		// Clear any corrupted data
+		p.clearCaches(ctx, cacheKey.Key)
+		zap.L().Warn("cache unmarshal failed, key removed", zap.String("key", cacheKey.Key), zap.Error(err))
+	}
+
+	// STEP-3: Get result from database
+	sql := `SELECT * FROM notification.email_templates WHERE email_account_id = $1 AND name = $2`
+	rows, err := p.pool.Query(ctx, sql, emailAccountID, string(name))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dtoList, err = pgx.CollectRows(rows, pgx.RowToStructByName[EmailTemplateDTO])
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-4: Save result the cache service
+	if serialized, err := json.Marshal(dtoList); err == nil {
+		if err := p.cache.Set(ctx, cacheKey, string(serialized)); err != nil {
+			zap.L().Error("an error occurred while writing to cache", zap.Error(err))
+		}
+	}
+
+	return emailTemplatesToDomain(dtoList), nil
+}
+
+// GetExpiringTokens returns the OAuth2 accounts of every project whose access token expires before the
+// given time and which can still be refreshed
+func (p *pgEmailAccountRepository) GetExpiringTokens(ctx context.Context, before time.Time) ([]*domain.EmailAccount, error) {
+	sql := `
+		SELECT * FROM notification.email_accounts
+		WHERE type_id = ANY($1)
+			AND needs_reconsent = false
+			AND refresh_token IS NOT NULL
+			AND (expire_at IS NULL OR expire_at < $2)`
+	rows, err := p.pool.Query(ctx, sql, email_provider.OAuth2TypeIDs(), before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dtoList, err := pgx.CollectRows(rows, pgx.RowToStructByName[EmailAccountDTO])
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*domain.EmailAccount, 0, len(dtoList))
+	for _, dto := range dtoList {
+		accounts = append(accounts, dto.ToDomain())
+	}
+	return accounts, nil
+}
+
+// GetByAddress returns the accounts of every project using the given address, it is used to route the
+// messages received by the embedded SMTP server
+func (p *pgEmailAccountRepository) GetByAddress(ctx context.Context, email vo.Email) ([]*domain.EmailAccount, error) {
+	sql := `SELECT * FROM notification.email_accounts WHERE lower(email) = lower($1) ORDER BY created_at`
+	rows, err := p.pool.Query(ctx, sql, email.Value())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dtoList, err := pgx.CollectRows(rows, pgx.RowToStructByName[EmailAccountDTO])
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*domain.EmailAccount, 0, len(dtoList))
+	for _, dto := range dtoList {
+		accounts = append(accounts, dto.ToDomain())
+	}
+	return accounts, nil
+}
+
 // COMMAND
 func (p *pgEmailAccountRepository) Create(ctx context.Context, ea *domain.EmailAccount) error {
 	query := `
@@ -168,8 +363,17 @@ func (p *pgEmailAccountRepository) Create(ctx context.Context, ea *domain.EmailA
 			access_token,
 			refresh_token,
 			expire_at,
-			created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+			created_at,
+			max_per_minute,
+			max_per_day,
+			dkim_domain,
+			dkim_selector,
+			dkim_algorithm,
+			dkim_private_key,
+			needs_reconsent,
+			track_opens,
+			track_clicks
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)`
 
 	dto := EmailAccountDTO{}
 	_, err := p.pool.Exec(ctx, query, dto.ToDTO(ea).GetValues()...)
@@ -220,11 +424,21 @@ func (p *pgEmailAccountRepository) Update(ctx context.Context, ea *domain.EmailA
 			client_secret = $12,
 			access_token = $13,
 			refresh_token = $14,
-			expire_at = $15
+			expire_at = $15,
+			max_per_minute = $16,
+			max_per_day = $17,
+			dkim_domain = $18,
+			dkim_selector = $19,
+			dkim_algorithm = $20,
+			dkim_private_key = $21,
+			needs_reconsent = $22,
+			track_opens = $23,
+			track_clicks = $24
 		WHERE project_id = $1 AND email = $2
 	`
 	dto := EmailAccountDTO{}
-	_, err := p.pool.Exec(ctx, query, dto.ToDTO(ea).GetValues()[1:16]...)
+	values := dto.ToDTO(ea).GetValues()
+	_, err := p.pool.Exec(ctx, query, append(values[1:16], values[17:26]...)...)
 	if err != nil {
 		return fmt.Errorf("failed to update email account: %w", err)
 	}
@@ -244,6 +458,14 @@ func (p *pgEmailAccountRepository) clearCaches(ctx context.Context, keys ...stri
 	}
 }
 
+func emailTemplatesToDomain(dtoList []EmailTemplateDTO) []domain.EmailTemplate {
+	templates := make([]domain.EmailTemplate, 0, len(dtoList))
+	for _, dto := range dtoList {
+		templates = append(templates, dto.ToDomain())
+	}
+	return templates
+}
+
 func cacheKeyByEmail(projectID uuid.UUID, email vo.Email) string {
 	return fmt.Sprintf("notification:email_accounts:%s:%s", projectID.String(), email.Value())
 }
@@ -251,3 +473,7 @@ func cacheKeyByEmail(projectID uuid.UUID, email vo.Email) string {
 func cacheKeyAll(projectID uuid.UUID) string {
 	return fmt.Sprintf("notification:email_accounts:%s", projectID.String())
 }
+
+func cacheKeyTemplates(emailAccountID uuid.UUID, name domain.EmailTemplateName) string {
+	return fmt.Sprintf("notification:email_templates:%s:%s", emailAccountID.String(), name)
+}
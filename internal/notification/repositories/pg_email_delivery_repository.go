@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"platform/internal/notification/domain"
+	"platform/internal/shared"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pgEmailDeliveryRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgEmailDeliveryRepository(pool *pgxpool.Pool) EmailDeliveryRepository {
+	return &pgEmailDeliveryRepository{pool: pool}
+}
+
+// QUERY
+
+// GetAll returns a page of the delivery logs of the project matching the filter, newest first, with the
+// number of logs matching it. The events are not loaded.
+func (p *pgEmailDeliveryRepository) GetAll(ctx context.Context, filter EmailDeliveryFilter, page, pageSize int) ([]*domain.EmailDelivery, int, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, 0, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Build the conditions of the filter
+	conditions := []string{"project_id = $1"}
+	args := []any{projectID}
+	where := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Status != "" {
+		where("status = $%d", string(filter.Status))
+	}
+	if filter.Recipient != "" {
+		where("lower(recipient) = lower($%d)", filter.Recipient)
+	}
+	if filter.EmailAccountID != nil {
+		where("email_account_id = $%d", *filter.EmailAccountID)
+	}
+	if filter.CampaignID != nil {
+		where("campaign_id = $%d", *filter.CampaignID)
+	}
+	if !filter.From.IsZero() {
+		where("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		where("created_at < $%d", filter.To)
+	}
+	clause := strings.Join(conditions, " AND ")
+
+	// STEP-3: Count the matching logs
+	var total int
+	if err := p.pool.QueryRow(ctx, `SELECT COUNT(*) FROM notification.email_deliveries WHERE `+clause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// STEP-4: Get the page from database
+	sql := fmt.Sprintf(`SELECT * FROM notification.email_deliveries WHERE %s ORDER BY created_at DESC LIMIT $%d OFFSET $%d`, clause, len(args)+1, len(args)+2)
+	rows, err := p.pool.Query(ctx, sql, append(args, pageSize, (page-1)*pageSize)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	dtoList, err := pgx.CollectRows(rows, pgx.RowToStructByName[EmailDeliveryDTO])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	deliveries := make([]*domain.EmailDelivery, 0, len(dtoList))
+	for _, dto := range dtoList {
+		deliveries = append(deliveries, dto.ToDomain())
+	}
+	return deliveries, total, nil
+}
+
+// GetByID returns the delivery log of the project with its events, or nil if it does not exist
+func (p *pgEmailDeliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.EmailDelivery, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Get result from database
+	sql := `SELECT * FROM notification.email_deliveries WHERE project_id = $1 AND id = $2`
+	return p.getOne(ctx, sql, projectID, id)
+}
+
+func (p *pgEmailDeliveryRepository) GetByMessageID(ctx context.Context, messageID string) (*domain.EmailDelivery, error) {
+	sql := `
+		SELECT * FROM notification.email_deliveries
+		WHERE message_id = $1 OR provider_message_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1`
+	return p.getOne(ctx, sql, messageID)
+}
+
+func (p *pgEmailDeliveryRepository) CountSentSince(ctx context.Context, emailAccountID uuid.UUID, since time.Time) (int, error) {
+	var count int
+	sql := `
+		SELECT COUNT(*) FROM notification.email_delivery_events e
+		JOIN notification.email_deliveries d ON d.id = e.delivery_id
+		WHERE d.email_account_id = $1 AND e.status = $2 AND e.occurred_at >= $3`
+	err := p.pool.QueryRow(ctx, sql, emailAccountID, string(domain.EmailSent), since).Scan(&count)
+	return count, err
+}
+
+func (p *pgEmailDeliveryRepository) getOne(ctx context.Context, sql string, args ...any) (*domain.EmailDelivery, error) {
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dto, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[EmailDeliveryDTO])
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	delivery := dto.ToDomain()
+
+	// Load the history of the delivery
+	rows, err = p.pool.Query(ctx, `SELECT * FROM notification.email_delivery_events WHERE delivery_id = $1 ORDER BY occurred_at`, delivery.GetID())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	eventDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[EmailDeliveryEventDTO])
+	if err != nil {
+		return nil, err
+	}
+	events := make([]domain.EmailDeliveryEvent, 0, len(eventDTOs))
+	for _, eventDTO := range eventDTOs {
+		events = append(events, eventDTO.ToDomain())
+	}
+	delivery.SetEvents(events)
+	return delivery, nil
+}
+
+// COMMAND
+
+// Save inserts or updates the delivery log and adds its new events, the events already saved are kept
+func (p *pgEmailDeliveryRepository) Save(ctx context.Context, d *domain.EmailDelivery) error {
+	query := `
+		INSERT INTO notification.email_deliveries (
+			id,
+			project_id,
+			email_account_id,
+			campaign_id,
+			recipient,
+			subject,
+			message_id,
+			provider_message_id,
+			status,
+			opens,
+			clicks,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (id) DO UPDATE SET
+			provider_message_id = EXCLUDED.provider_message_id,
+			status = EXCLUDED.status,
+			opens = EXCLUDED.opens,
+			clicks = EXCLUDED.clicks,
+			updated_at = EXCLUDED.updated_at`
+	eventQuery := `
+		INSERT INTO notification.email_delivery_events (
+			id,
+			delivery_id,
+			status,
+			detail,
+			url,
+			occurred_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO NOTHING`
+
+	batch := &pgx.Batch{}
+	dto := EmailDeliveryDTO{}
+	batch.Queue(query, dto.ToDTO(d).GetValues()...)
+	for _, event := range d.GetEvents() {
+		eventDTO := EmailDeliveryEventDTO{}
+		batch.Queue(eventQuery, eventDTO.ToDTO(d.GetID(), event).GetValues()...)
+	}
+
+	if err := p.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to save email delivery: %w", err)
+	}
+	return nil
+}
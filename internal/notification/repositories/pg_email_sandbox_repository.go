@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"platform/internal/notification/domain"
+	"platform/internal/shared"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pgEmailSandboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgEmailSandboxRepository(pool *pgxpool.Pool) EmailSandboxRepository {
+	return &pgEmailSandboxRepository{pool: pool}
+}
+
+// QUERY
+
+// Get returns the sandbox settings of the project, or nil if it has none
+func (p *pgEmailSandboxRepository) Get(ctx context.Context) (*domain.EmailSandbox, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Get result from database
+	sql := `SELECT * FROM notification.email_sandboxes WHERE project_id = $1`
+	rows, err := p.pool.Query(ctx, sql, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dto, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[EmailSandboxDTO])
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return dto.ToDomain(), nil
+}
+
+// COMMAND
+func (p *pgEmailSandboxRepository) Save(ctx context.Context, s *domain.EmailSandbox) error {
+	query := `
+		INSERT INTO notification.email_sandboxes (
+			project_id,
+			mode,
+			allow_list,
+			redirect_to,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (project_id) DO UPDATE SET
+			mode = EXCLUDED.mode,
+			allow_list = EXCLUDED.allow_list,
+			redirect_to = EXCLUDED.redirect_to,
+			updated_at = EXCLUDED.updated_at`
+
+	dto := EmailSandboxDTO{}
+	_, err := p.pool.Exec(ctx, query, dto.ToDTO(s).GetValues()...)
+	if err != nil {
+		return fmt.Errorf("failed to save email sandbox: %w", err)
+	}
+	return nil
+}
+
+func (p *pgEmailSandboxRepository) Delete(ctx context.Context) error {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return shared.ErrInvalidContext
+	}
+
+	// STEP-2: Delete from database
+	sql := "DELETE FROM notification.email_sandboxes WHERE project_id = $1"
+	_, err := p.pool.Exec(ctx, sql, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to delete email sandbox: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"platform/internal/notification/domain"
+	"platform/internal/shared"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pgInboundMailboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgInboundMailboxRepository(pool *pgxpool.Pool) InboundMailboxRepository {
+	return &pgInboundMailboxRepository{pool: pool}
+}
+
+// QUERY
+
+// GetAll returns the mailboxes of every project, they are read by the inbound workers
+func (p *pgInboundMailboxRepository) GetAll(ctx context.Context) ([]*domain.InboundMailbox, error) {
+	sql := `SELECT * FROM notification.inbound_mailboxes`
+	rows, err := p.pool.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dtoList, err := pgx.CollectRows(rows, pgx.RowToStructByName[InboundMailboxDTO])
+	if err != nil {
+		return nil, err
+	}
+
+	mailboxes := make([]*domain.InboundMailbox, 0, len(dtoList))
+	for _, dto := range dtoList {
+		mailboxes = append(mailboxes, dto.ToDomain())
+	}
+	return mailboxes, nil
+}
+
+func (p *pgInboundMailboxRepository) GetByEmailAccount(ctx context.Context, emailAccountID uuid.UUID) (*domain.InboundMailbox, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Get result from database
+	sql := `SELECT * FROM notification.inbound_mailboxes WHERE project_id = $1 AND email_account_id = $2`
+	rows, err := p.pool.Query(ctx, sql, projectID, emailAccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dto, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[InboundMailboxDTO])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToDomain(), nil
+}
+
+// COMMAND
+func (p *pgInboundMailboxRepository) Save(ctx context.Context, m *domain.InboundMailbox) error {
+	query := `
+		INSERT INTO notification.inbound_mailboxes (
+			email_account_id,
+			project_id,
+			host,
+			port,
+			enable_ssl,
+			folder,
+			use_idle,
+			uid_validity,
+			last_uid,
+			last_error,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (email_account_id) DO UPDATE SET
+			host = EXCLUDED.host,
+			port = EXCLUDED.port,
+			enable_ssl = EXCLUDED.enable_ssl,
+			folder = EXCLUDED.folder,
+			use_idle = EXCLUDED.use_idle,
+			uid_validity = EXCLUDED.uid_validity,
+			last_uid = EXCLUDED.last_uid,
+			last_error = EXCLUDED.last_error`
+
+	dto := InboundMailboxDTO{}
+	_, err := p.pool.Exec(ctx, query, dto.ToDTO(m).GetValues()...)
+	if err != nil {
+		return fmt.Errorf("failed to save inbound mailbox: %w", err)
+	}
+	return nil
+}
+
+// UpdateCursor saves the position of the worker reading the mailbox, the settings are left as they are
+func (p *pgInboundMailboxRepository) UpdateCursor(ctx context.Context, m *domain.InboundMailbox) error {
+	sql := `
+		UPDATE notification.inbound_mailboxes
+		SET uid_validity = $2, last_uid = $3, last_error = $4
+		WHERE email_account_id = $1`
+	_, err := p.pool.Exec(ctx, sql, m.GetEmailAccountID(), int64(m.GetUIDValidity()), int64(m.GetLastUID()), ptrToStringValue(m.GetLastError()))
+	if err != nil {
+		return fmt.Errorf("failed to update inbound mailbox: %w", err)
+	}
+	return nil
+}
+
+func (p *pgInboundMailboxRepository) Delete(ctx context.Context, emailAccountID uuid.UUID) error {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return shared.ErrInvalidContext
+	}
+
+	// STEP-2: Delete from database
+	sql := "DELETE FROM notification.inbound_mailboxes WHERE project_id = $1 AND email_account_id = $2"
+	_, err := p.pool.Exec(ctx, sql, projectID, emailAccountID)
+	if err != nil {
+		return fmt.Errorf("failed to delete inbound mailbox: %w", err)
+	}
+	return nil
+}
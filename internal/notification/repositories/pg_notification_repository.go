@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"platform/internal/notification/domain"
+	"platform/internal/shared"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pgNotificationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgNotificationRepository(pool *pgxpool.Pool) NotificationRepository {
+	return &pgNotificationRepository{pool: pool}
+}
+
+const upsertDeliverySql = `
+	INSERT INTO notification.notification_deliveries (
+		notification_id,
+		channel,
+		status,
+		provider_message_id,
+		error,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (notification_id, channel) DO UPDATE SET
+		status = EXCLUDED.status,
+		provider_message_id = EXCLUDED.provider_message_id,
+		error = EXCLUDED.error,
+		updated_at = EXCLUDED.updated_at`
+
+// QUERY
+func (p *pgNotificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Notification, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Get the notification
+	sql := `SELECT * FROM notification.notifications WHERE project_id = $1 AND id = $2`
+	rows, err := p.pool.Query(ctx, sql, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+	dto, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[NotificationDTO])
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// STEP-3: Get its deliveries
+	sql = `SELECT * FROM notification.notification_deliveries WHERE notification_id = $1 ORDER BY channel`
+	rows, err = p.pool.Query(ctx, sql, id)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := pgx.CollectRows(rows, pgx.RowToStructByName[DeliveryDTO])
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToDomain(deliveries), nil
+}
+
+// COMMAND
+func (p *pgNotificationRepository) Create(ctx context.Context, n *domain.Notification) error {
+	query := `
+		INSERT INTO notification.notifications (
+			id,
+			project_id,
+			recipient,
+			template_name,
+			language,
+			data,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	dto := NotificationDTO{}
+	err := shared.RunInTransaction(ctx, p.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, query, dto.ToDTO(n).GetValues()...); err != nil {
+			return err
+		}
+		for _, delivery := range n.GetDeliveries() {
+			if _, err := tx.Exec(ctx, upsertDeliverySql, deliveryValues(n.GetID(), delivery)...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+func (p *pgNotificationRepository) UpdateDeliveries(ctx context.Context, n *domain.Notification) error {
+	batch := &pgx.Batch{}
+	for _, delivery := range n.GetDeliveries() {
+		batch.Queue(upsertDeliverySql, deliveryValues(n.GetID(), delivery)...)
+	}
+
+	if err := p.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to update notification deliveries: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"platform/internal/notification/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pgQueuedEmailRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgQueuedEmailRepository(pool *pgxpool.Pool) QueuedEmailRepository {
+	return &pgQueuedEmailRepository{pool: pool}
+}
+
+// QUERY
+
+// GetPending returns the emails of the account which are not sent yet, emails of campaigns which are not
+// running are left in the queue
+func (p *pgQueuedEmailRepository) GetPending(ctx context.Context, emailAccountID uuid.UUID, limit int) ([]*domain.QueuedEmail, error) {
+	sql := `
+		SELECT q.* FROM notification.queued_emails q
+		LEFT JOIN notification.campaigns c ON c.id = q.campaign_id
+		WHERE q.email_account_id = $1
+			AND q.sent_at IS NULL
+			AND q.sent_tries < $2
+			AND (q.campaign_id IS NULL OR c.status = $3)
+		ORDER BY q.created_at
+		LIMIT $4`
+	rows, err := p.pool.Query(ctx, sql, emailAccountID, domain.MAX_SENT_TRIES, string(domain.CampaignRunning), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dtoList, err := pgx.CollectRows(rows, pgx.RowToStructByName[QueuedEmailDTO])
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make([]*domain.QueuedEmail, 0, len(dtoList))
+	for _, dto := range dtoList {
+		emails = append(emails, dto.ToDomain())
+	}
+	return emails, nil
+}
+
+func (p *pgQueuedEmailRepository) CountPending(ctx context.Context, campaignID uuid.UUID) (int, error) {
+	var count int
+	sql := `SELECT COUNT(*) FROM notification.queued_emails WHERE campaign_id = $1 AND sent_at IS NULL AND sent_tries < $2`
+	err := p.pool.QueryRow(ctx, sql, campaignID, domain.MAX_SENT_TRIES).Scan(&count)
+	return count, err
+}
+
+// COMMAND
+func (p *pgQueuedEmailRepository) CreateBatch(ctx context.Context, emails []*domain.QueuedEmail) error {
+	query := `
+		INSERT INTO notification.queued_emails (
+			id,
+			project_id,
+			campaign_id,
+			email_account_id,
+			"to",
+			reply_to,
+			cc,
+			bcc,
+			subject,
+			body,
+			unsubscribe_url,
+			sent_at,
+			sent_tries,
+			last_error,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (campaign_id, "to") WHERE campaign_id IS NOT NULL DO NOTHING`
+
+	batch := &pgx.Batch{}
+	for _, email := range emails {
+		dto := QueuedEmailDTO{}
+		batch.Queue(query, dto.ToDTO(email).GetValues()...)
+	}
+
+	if err := p.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to queue emails: %w", err)
+	}
+	return nil
+}
+
+func (p *pgQueuedEmailRepository) Update(ctx context.Context, qe *domain.QueuedEmail) error {
+	query := `UPDATE notification.queued_emails SET sent_at = $2, sent_tries = $3, last_error = $4 WHERE id = $1`
+	_, err := p.pool.Exec(ctx, query, qe.GetID(), qe.GetSentAt(), qe.GetSentTries(), ptrToStringValue(qe.GetLastError()))
+	if err != nil {
+		return fmt.Errorf("failed to update queued email: %w", err)
+	}
+	return nil
+}
+
+func (p *pgQueuedEmailRepository) DeleteUnsent(ctx context.Context, campaignID uuid.UUID) error {
+	query := `DELETE FROM notification.queued_emails WHERE campaign_id = $1 AND sent_at IS NULL`
+	_, err := p.pool.Exec(ctx, query, campaignID)
+	if err != nil {
+		return fmt.Errorf("failed to delete queued emails: %w", err)
+	}
+	return nil
+}
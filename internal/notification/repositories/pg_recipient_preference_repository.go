@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"platform/internal/notification/domain"
+	"platform/internal/shared"
+	vo "platform/pkg/domain/value_object"
+	"platform/pkg/services/cache"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type pgRecipientPreferenceRepository struct {
+	pool  *pgxpool.Pool
+	cache cache.CacheManager
+}
+
+func NewPgRecipientPreferenceRepository(pool *pgxpool.Pool, cache cache.CacheManager) RecipientPreferenceRepository {
+	return &pgRecipientPreferenceRepository{
+		pool:  pool,
+		cache: cache,
+	}
+}
+
+// QUERY
+func (p *pgRecipientPreferenceRepository) GetByEmail(ctx context.Context, email vo.Email) (*domain.RecipientPreference, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Create a cache key
+	cacheKey := cache.CacheKey{
+		Key:  cacheKeyPreference(projectID, email),
+		Time: cache.DefaultTTL,
+	}
+
+	// STEP-3: Check if the data is in the cache service
+	var dto RecipientPreferenceDTO
+	cached, err := p.cache.Get(ctx, cacheKey)
+	if err != nil {
+		zap.L().Warn("cache GET error", zap.Error(err), zap.String("key", cacheKey.Key))
+	} else if cached != "" {
+		if err := json.Unmarshal([]byte(cached), &dto); err == nil {
+			return dto.ToDomain(), nil
+		}
+
+		// Clear any corrupted data
+		if err := p.cache.Remove(ctx, cacheKey.Key); err != nil {
+			zap.L().Warn("an error occurred while removing cache key", zap.Error(err))
+		}
+		zap.L().Warn("cache unmarshal failed, key removed", zap.String("key", cacheKey.Key), zap.Error(err))
+	}
+
+	// STEP-4: Get result from database
+	sql := `SELECT * FROM notification.recipient_preferences WHERE project_id = $1 AND email = $2`
+	rows, err := p.pool.Query(ctx, sql, projectID, email.Value())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dto, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[RecipientPreferenceDTO])
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// STEP-5: Save result the cache service
+	if serialized, err := json.Marshal(dto); err == nil {
+		if err := p.cache.Set(ctx, cacheKey, string(serialized)); err != nil {
+			zap.L().Error("an error occurred while writing to cache", zap.Error(err))
+		}
+	}
+
+	return dto.ToDomain(), nil
+}
+
+// COMMAND
+func (p *pgRecipientPreferenceRepository) Save(ctx context.Context, rp *domain.RecipientPreference) error {
+	// STEP-1: Insert or update the preferences
+	query := `
+		INSERT INTO notification.recipient_preferences (
+			project_id,
+			email,
+			phone,
+			webhook_url,
+			language,
+			channels,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (project_id, email) DO UPDATE SET
+			phone = EXCLUDED.phone,
+			webhook_url = EXCLUDED.webhook_url,
+			language = EXCLUDED.language,
+			channels = EXCLUDED.channels,
+			updated_at = EXCLUDED.updated_at`
+
+	dto := RecipientPreferenceDTO{}
+	_, err := p.pool.Exec(ctx, query, dto.ToDTO(rp).GetValues()...)
+	if err != nil {
+		return fmt.Errorf("failed to save recipient preference: %w", err)
+	}
+
+	// STEP-2: Remove related caches
+	if err := p.cache.Remove(ctx, cacheKeyPreference(rp.GetProjectID(), rp.GetEmail())); err != nil {
+		zap.L().Warn("an error occurred while removing cache key", zap.Error(err))
+	}
+	return nil
+}
+
+func cacheKeyPreference(projectID uuid.UUID, email vo.Email) string {
+	return fmt.Sprintf("notification:recipient_preferences:%s:%s", projectID.String(), email.Value())
+}
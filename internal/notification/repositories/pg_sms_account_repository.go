@@ -0,0 +1,228 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"platform/internal/notification/domain"
+	"platform/internal/shared"
+	"platform/pkg/services/cache"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type pgSmsAccountRepository struct {
+	pool  *pgxpool.Pool
+	cache cache.CacheManager
+}
+
+func NewPgSmsAccountRepository(pool *pgxpool.Pool, cache cache.CacheManager) SmsAccountRepository {
+	return &pgSmsAccountRepository{
+		pool:  pool,
+		cache: cache,
+	}
+}
+
+// QUERY
+func (p *pgSmsAccountRepository) GetAll(ctx context.Context) ([]*domain.SmsAccount, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Create a cache key
+	cacheKey := cache.CacheKey{
+		Key:  smsCacheKeyAll(projectID),
+		Time: cache.DefaultTTL,
+	}
+
+	// STEP-3: Check if the data is in the cache service
+	cached, err := p.cache.Get(ctx, cacheKey)
+	if err != nil {
+		zap.L().Warn("cache GET error", zap.Error(err), zap.String("key", cacheKey.Key))
+	} else if cached != "" {
+		var dtoList []SmsAccountDTO
+		if err := json.Unmarshal([]byte(cached), &dtoList); err == nil {
+			accounts := make([]*domain.SmsAccount, 0, len(dtoList))
+			for _, dto := range dtoList {
+				accounts = append(accounts, dto.ToDomain())
+			}
+			return accounts, nil
+		}
+
+		// Clear any corrupted data
+		p.clearCaches(ctx, cacheKey.Key)
+		zap.L().Warn("cache unmarshal failed, key removed", zap.String("key", cacheKey.Key), zap.Error(err))
+	}
+
+	// STEP-4: Get result from database
+	sql := `SELECT * FROM notification.sms_accounts WHERE project_id = $1 ORDER BY created_at`
+	rows, err := p.pool.Query(ctx, sql, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dtoList, err := pgx.CollectRows(rows, pgx.RowToStructByName[SmsAccountDTO])
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-5: Convert from dto to domain
+	accounts := make([]*domain.SmsAccount, 0, len(dtoList))
+	for _, dto := range dtoList {
+		accounts = append(accounts, dto.ToDomain())
+	}
+
+	// STEP-6: Save result the cache service
+	if serialized, err := json.Marshal(dtoList); err == nil {
+		if err := p.cache.Set(ctx, cacheKey, string(serialized)); err != nil {
+			zap.L().Error("an error occurred while writing to cache", zap.Error(err))
+		}
+	}
+
+	return accounts, nil
+}
+
+func (p *pgSmsAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.SmsAccount, error) {
+	accounts, err := p.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range accounts {
+		if account.GetID() == id {
+			return account, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetDefault returns the first account created for the project, or nil if it has none
+func (p *pgSmsAccountRepository) GetDefault(ctx context.Context) (*domain.SmsAccount, error) {
+	accounts, err := p.GetAll(ctx)
+	if err != nil || len(accounts) == 0 {
+		return nil, err
+	}
+	return accounts[0], nil
+}
+
+// GetTemplates returns the templates with the given name in every language of the account
+func (p *pgSmsAccountRepository) GetTemplates(ctx context.Context, smsAccountID uuid.UUID, name domain.SmsTemplateName) ([]domain.SmsTemplate, error) {
+	// STEP-1: Create a cache key
+	cacheKey := cache.CacheKey{
+		Key:  smsCacheKeyTemplates(smsAccountID, name),
+		Time: cache.DefaultTTL,
+	}
+
+	// STEP-2: Check if the data is in the cache service
+	var dtoList []SmsTemplateDTO
+	cached, err := p.cache.Get(ctx, cacheKey)
+	if err != nil {
+		zap.L().Warn("cache GET error", zap.Error(err), zap.String("key", cacheKey.Key))
+	} else if cached != "" {
+		if err := json.Unmarshal([]byte(cached), &dtoList); err == nil {
+			return smsTemplatesToDomain(dtoList), nil
+		}
+
+		// Clear any corrupted data
+		p.clearCaches(ctx, cacheKey.Key)
+		zap.L().Warn("cache unmarshal failed, key removed", zap.String("key", cacheKey.Key), zap.Error(err))
+	}
+
+	// STEP-3: Get result from database
+	sql := `SELECT * FROM notification.sms_templates WHERE sms_account_id = $1 AND name = $2`
+	rows, err := p.pool.Query(ctx, sql, smsAccountID, string(name))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dtoList, err = pgx.CollectRows(rows, pgx.RowToStructByName[SmsTemplateDTO])
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-4: Save result the cache service
+	if serialized, err := json.Marshal(dtoList); err == nil {
+		if err := p.cache.Set(ctx, cacheKey, string(serialized)); err != nil {
+			zap.L().Error("an error occurred while writing to cache", zap.Error(err))
+		}
+	}
+
+	return smsTemplatesToDomain(dtoList), nil
+}
+
+// COMMAND
+func (p *pgSmsAccountRepository) Create(ctx context.Context, sa *domain.SmsAccount) error {
+	query := `
+		INSERT INTO notification.sms_accounts (
+			id,
+			project_id,
+			provider_id,
+			sender,
+			username,
+			password,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	dto := SmsAccountDTO{}
+	_, err := p.pool.Exec(ctx, query, dto.ToDTO(sa).GetValues()...)
+	if err != nil {
+		return fmt.Errorf("failed to create sms account: %w", err)
+	}
+
+	p.clearCaches(ctx, smsCacheKeyAll(sa.GetProjectID()))
+	return nil
+}
+
+func (p *pgSmsAccountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return shared.ErrInvalidContext
+	}
+
+	// STEP-2: Delete from database, templates are removed by the foreign key
+	sql := "DELETE FROM notification.sms_accounts WHERE project_id = $1 AND id = $2"
+	_, err := p.pool.Exec(ctx, sql, projectID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete sms account: %w", err)
+	}
+
+	// STEP-3: Remove related caches
+	p.clearCaches(ctx, smsCacheKeyAll(projectID), smsCacheKeyTemplates(id, domain.PHONE_VERIFICATION))
+	return nil
+}
+
+// PRIVATE METHODS
+func (p *pgSmsAccountRepository) clearCaches(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		err := p.cache.Remove(ctx, key)
+		if err != nil {
+			zap.L().Warn("an error occurred while removing cache key", zap.Error(err))
+		}
+	}
+}
+
+func smsTemplatesToDomain(dtoList []SmsTemplateDTO) []domain.SmsTemplate {
+	templates := make([]domain.SmsTemplate, 0, len(dtoList))
+	for _, dto := range dtoList {
+		templates = append(templates, dto.ToDomain())
+	}
+	return templates
+}
+
+func smsCacheKeyAll(projectID uuid.UUID) string {
+	return fmt.Sprintf("notification:sms_accounts:%s", projectID.String())
+}
+
+func smsCacheKeyTemplates(smsAccountID uuid.UUID, name domain.SmsTemplateName) string {
+	return fmt.Sprintf("notification:sms_templates:%s:%s", smsAccountID.String(), name)
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"platform/internal/notification/domain"
+	"platform/internal/shared"
+	vo "platform/pkg/domain/value_object"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pgSubscriptionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgSubscriptionRepository(pool *pgxpool.Pool) SubscriptionRepository {
+	return &pgSubscriptionRepository{pool: pool}
+}
+
+// QUERY
+func (p *pgSubscriptionRepository) GetAll(ctx context.Context) ([]*domain.Subscription, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Get result from database
+	sql := `SELECT * FROM notification.subscriptions WHERE project_id = $1 ORDER BY created_at`
+	rows, err := p.pool.Query(ctx, sql, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dtoList, err := pgx.CollectRows(rows, pgx.RowToStructByName[SubscriptionDTO])
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-3: Convert from dto to domain
+	subscriptions := make([]*domain.Subscription, 0, len(dtoList))
+	for _, dto := range dtoList {
+		subscriptions = append(subscriptions, dto.ToDomain())
+	}
+	return subscriptions, nil
+}
+
+func (p *pgSubscriptionRepository) GetByEmail(ctx context.Context, email vo.Email) (*domain.Subscription, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Get result from database
+	sql := `SELECT * FROM notification.subscriptions WHERE project_id = $1 AND email = $2`
+	rows, err := p.pool.Query(ctx, sql, projectID, email.Value())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dto, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[SubscriptionDTO])
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dto.ToDomain(), nil
+}
+
+// COMMAND
+func (p *pgSubscriptionRepository) Save(ctx context.Context, s *domain.Subscription) error {
+	query := `
+		INSERT INTO notification.subscriptions (
+			project_id,
+			email,
+			language,
+			created_at,
+			status,
+			confirmed_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (project_id, email) DO UPDATE SET
+			language = EXCLUDED.language,
+			status = EXCLUDED.status,
+			confirmed_at = EXCLUDED.confirmed_at`
+
+	dto := SubscriptionDTO{}
+	_, err := p.pool.Exec(ctx, query, dto.ToDTO(s).GetValues()...)
+	if err != nil {
+		return fmt.Errorf("failed to save subscription: %w", err)
+	}
+	return nil
+}
+
+func (p *pgSubscriptionRepository) Delete(ctx context.Context, email vo.Email) error {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return shared.ErrInvalidContext
+	}
+
+	// STEP-2: Delete from database
+	sql := "DELETE FROM notification.subscriptions WHERE project_id = $1 AND email = $2"
+	_, err := p.pool.Exec(ctx, sql, projectID, email.Value())
+	if err != nil {
+		return fmt.Errorf("failed to delete subscription: %w", err)
+	}
+	return nil
+}
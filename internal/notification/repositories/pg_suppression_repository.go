@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"platform/internal/notification/domain"
+	"platform/internal/shared"
+	vo "platform/pkg/domain/value_object"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pgSuppressionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgSuppressionRepository(pool *pgxpool.Pool) SuppressionRepository {
+	return &pgSuppressionRepository{pool: pool}
+}
+
+// QUERY
+func (p *pgSuppressionRepository) GetAll(ctx context.Context) ([]*domain.Suppression, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return nil, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Get result from database
+	sql := `SELECT * FROM notification.suppressions WHERE project_id = $1 ORDER BY created_at DESC`
+	rows, err := p.pool.Query(ctx, sql, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dtoList, err := pgx.CollectRows(rows, pgx.RowToStructByName[SuppressionDTO])
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-3: Convert from dto to domain
+	suppressions := make([]*domain.Suppression, 0, len(dtoList))
+	for _, dto := range dtoList {
+		suppressions = append(suppressions, dto.ToDomain())
+	}
+	return suppressions, nil
+}
+
+func (p *pgSuppressionRepository) IsSuppressed(ctx context.Context, email vo.Email) (bool, error) {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return false, shared.ErrInvalidContext
+	}
+
+	// STEP-2: Get result from database
+	var exists bool
+	sql := `SELECT EXISTS(SELECT 1 FROM notification.suppressions WHERE project_id = $1 AND email = $2)`
+	err := p.pool.QueryRow(ctx, sql, projectID, email.Value()).Scan(&exists)
+	return exists, err
+}
+
+// COMMAND
+func (p *pgSuppressionRepository) Save(ctx context.Context, s *domain.Suppression) error {
+	query := `
+		INSERT INTO notification.suppressions (
+			project_id,
+			email,
+			reason,
+			diagnostic,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (project_id, email) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			diagnostic = EXCLUDED.diagnostic`
+
+	dto := SuppressionDTO{}
+	_, err := p.pool.Exec(ctx, query, dto.ToDTO(s).GetValues()...)
+	if err != nil {
+		return fmt.Errorf("failed to save suppression: %w", err)
+	}
+	return nil
+}
+
+func (p *pgSuppressionRepository) Delete(ctx context.Context, email vo.Email) error {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return shared.ErrInvalidContext
+	}
+
+	// STEP-2: Delete from database
+	sql := "DELETE FROM notification.suppressions WHERE project_id = $1 AND email = $2"
+	_, err := p.pool.Exec(ctx, sql, projectID, email.Value())
+	if err != nil {
+		return fmt.Errorf("failed to delete suppression: %w", err)
+	}
+	return nil
+}
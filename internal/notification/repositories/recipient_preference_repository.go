@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	vo "platform/pkg/domain/value_object"
+)
+
+type RecipientPreferenceRepository interface {
+	// QUERY
+	GetByEmail(ctx context.Context, email vo.Email) (*domain.RecipientPreference, error)
+
+	// COMMAND
+	Save(ctx context.Context, preference *domain.RecipientPreference) error
+}
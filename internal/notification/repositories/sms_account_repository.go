@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+
+	"github.com/google/uuid"
+)
+
+type SmsAccountRepository interface {
+	// QUERY
+	GetAll(ctx context.Context) ([]*domain.SmsAccount, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.SmsAccount, error)
+	GetDefault(ctx context.Context) (*domain.SmsAccount, error)
+	GetTemplates(ctx context.Context, smsAccountID uuid.UUID, name domain.SmsTemplateName) ([]domain.SmsTemplate, error)
+
+	// COMMAND
+	Create(ctx context.Context, account *domain.SmsAccount) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	vo "platform/pkg/domain/value_object"
+)
+
+type SubscriptionRepository interface {
+	// QUERY
+	GetAll(ctx context.Context) ([]*domain.Subscription, error)
+	GetByEmail(ctx context.Context, email vo.Email) (*domain.Subscription, error)
+
+	// COMMAND
+	Save(ctx context.Context, subscription *domain.Subscription) error
+	Delete(ctx context.Context, email vo.Email) error
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	vo "platform/pkg/domain/value_object"
+)
+
+type SuppressionRepository interface {
+	// QUERY
+	GetAll(ctx context.Context) ([]*domain.Suppression, error)
+	IsSuppressed(ctx context.Context, email vo.Email) (bool, error)
+
+	// COMMAND
+	Save(ctx context.Context, suppression *domain.Suppression) error
+	Delete(ctx context.Context, email vo.Email) error
+}
@@ -0,0 +1,88 @@
+// Package bounce_handler keeps the suppression list of a project up to date from the recipients rejected
+// while sending and from the delivery status notifications received later.
+package bounce_handler
+
+import (
+	"context"
+	"io"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	email_sender "platform/internal/notification/services/emailSender"
+	"platform/internal/shared"
+	vo "platform/pkg/domain/value_object"
+
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	repository repositories.SuppressionRepository
+}
+
+func NewHandler(repository repositories.SuppressionRepository) *Handler {
+	return &Handler{repository: repository}
+}
+
+// HandleResult suppresses the recipients the server rejected for good while sending
+func (h *Handler) HandleResult(ctx context.Context, result *email_sender.SendResult) error {
+	if result == nil {
+		return nil
+	}
+	for _, rejection := range result.Rejected {
+		if !rejection.HardBounce() {
+			continue
+		}
+		if err := h.suppress(ctx, rejection.Recipient, rejection.Err.Error()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleDSN parses an asynchronous bounce and suppresses its hard-bounced recipients, the returned list
+// holds the suppressed addresses
+func (h *Handler) HandleDSN(ctx context.Context, message io.Reader) (*Report, []string, error) {
+	report, err := ParseDSN(message)
+	if err != nil {
+		return nil, nil, err
+	}
+	suppressed, err := h.HandleReport(ctx, report)
+	if err != nil {
+		return nil, nil, err
+	}
+	return report, suppressed, nil
+}
+
+// HandleReport suppresses the hard-bounced recipients of a parsed report and returns their addresses
+func (h *Handler) HandleReport(ctx context.Context, report *Report) ([]string, error) {
+	suppressed := make([]string, 0)
+	for _, recipient := range report.Recipients {
+		if !recipient.HardBounce() {
+			continue
+		}
+		diagnostic := recipient.DiagnosticCode
+		if diagnostic == "" {
+			diagnostic = recipient.Status
+		}
+		if err := h.suppress(ctx, recipient.Recipient, diagnostic); err != nil {
+			return nil, err
+		}
+		suppressed = append(suppressed, recipient.Recipient)
+	}
+	return suppressed, nil
+}
+
+func (h *Handler) suppress(ctx context.Context, address, diagnostic string) error {
+	// STEP-1: Get project identifier and validate
+	pidVal := ctx.Value(shared.ProjectIDContextKey)
+	projectID, ok := pidVal.(uuid.UUID)
+	if !ok {
+		return shared.ErrInvalidContext
+	}
+
+	// STEP-2: Addresses the server gives back are not always valid, they cannot be sent to anyway
+	email, err := vo.NewEmail(address)
+	if err != nil {
+		return nil
+	}
+	return h.repository.Save(ctx, domain.NewSuppression(projectID, email, domain.SuppressionHardBounce, diagnostic))
+}
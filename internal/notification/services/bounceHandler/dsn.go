@@ -0,0 +1,169 @@
+package bounce_handler
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	email_sender "platform/internal/notification/services/emailSender"
+)
+
+var ErrNotDeliveryReport = errors.New("message is not a delivery status notification")
+
+// Report is a delivery status notification (RFC 3464) sent back by a mail server
+type Report struct {
+	ReportingMTA      string
+	OriginalMessageID string
+	Recipients        []RecipientStatus
+}
+
+// For returns the report limited to the status of address
+func (r *Report) For(address string) *Report {
+	filtered := &Report{ReportingMTA: r.ReportingMTA, OriginalMessageID: r.OriginalMessageID}
+	for _, recipient := range r.Recipients {
+		if strings.EqualFold(recipient.Recipient, address) {
+			filtered.Recipients = append(filtered.Recipients, recipient)
+		}
+	}
+	return filtered
+}
+
+// RecipientStatus is the outcome of the delivery to one recipient
+type RecipientStatus struct {
+	Recipient      string
+	Action         string
+	Status         string
+	DiagnosticCode string
+}
+
+// Failed reports whether the message could not be delivered to the recipient
+func (r RecipientStatus) Failed() bool {
+	return r.Action == "failed"
+}
+
+// HardBounce reports whether the address of the recipient is invalid for good
+func (r RecipientStatus) HardBounce() bool {
+	return r.Failed() && email_sender.IsHardBounce(r.replyCode(), r.Status)
+}
+
+// replyCode returns the SMTP reply code of a diagnostic such as "smtp; 550 5.1.1 user unknown"
+func (r RecipientStatus) replyCode() int {
+	_, diagnostic, _ := strings.Cut(r.DiagnosticCode, ";")
+	fields := strings.Fields(diagnostic)
+	if len(fields) == 0 {
+		return 0
+	}
+	code, _ := strconv.Atoi(fields[0])
+	return code
+}
+
+// ParseDSN reads a multipart/report message with a message/delivery-status part
+func ParseDSN(r io.Reader) (*Report, error) {
+	message, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/report" || !strings.EqualFold(params["report-type"], "delivery-status") {
+		return nil, ErrNotDeliveryReport
+	}
+
+	report := &Report{}
+	found := false
+	parts := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		body := decodePart(part)
+		switch partType {
+		case "message/delivery-status", "message/global-delivery-status":
+			if err := parseDeliveryStatus(body, report); err != nil {
+				return nil, err
+			}
+			found = true
+		case "message/rfc822", "text/rfc822-headers", "message/global-headers":
+			// Only the headers of the original message are needed
+			original, _ := textproto.NewReader(bufio.NewReader(body)).ReadMIMEHeader()
+			report.OriginalMessageID = strings.Trim(original.Get("Message-Id"), "<> ")
+		}
+	}
+
+	if !found {
+		return nil, ErrNotDeliveryReport
+	}
+	return report, nil
+}
+
+// parseDeliveryStatus reads the per-message fields then a block of fields for every recipient
+func parseDeliveryStatus(body io.Reader, report *Report) error {
+	reader := textproto.NewReader(bufio.NewReader(body))
+
+	perMessage, err := reader.ReadMIMEHeader()
+	if err != nil && err != io.EOF {
+		return err
+	}
+	report.ReportingMTA = fieldValue(perMessage.Get("Reporting-MTA"))
+
+	for err == nil {
+		var perRecipient textproto.MIMEHeader
+		perRecipient, err = reader.ReadMIMEHeader()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if len(perRecipient) == 0 {
+			continue
+		}
+
+		recipient := perRecipient.Get("Final-Recipient")
+		if recipient == "" {
+			recipient = perRecipient.Get("Original-Recipient")
+		}
+		report.Recipients = append(report.Recipients, RecipientStatus{
+			Recipient:      fieldValue(recipient),
+			Action:         strings.ToLower(strings.TrimSpace(perRecipient.Get("Action"))),
+			Status:         statusCode(perRecipient.Get("Status")),
+			DiagnosticCode: strings.TrimSpace(perRecipient.Get("Diagnostic-Code")),
+		})
+	}
+	return nil
+}
+
+// fieldValue removes the type of a field such as "rfc822; john@example.com"
+func fieldValue(value string) string {
+	if _, v, ok := strings.Cut(value, ";"); ok {
+		return strings.TrimSpace(v)
+	}
+	return strings.TrimSpace(value)
+}
+
+// statusCode removes the comment of a status such as "5.1.1 (unknown user)"
+func statusCode(value string) string {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// decodePart undoes the base64 transfer encoding, multipart already decodes quoted-printable parts
+func decodePart(part *multipart.Part) io.Reader {
+	if strings.EqualFold(part.Header.Get("Content-Transfer-Encoding"), "base64") {
+		return base64.NewDecoder(base64.StdEncoding, part)
+	}
+	return part
+}
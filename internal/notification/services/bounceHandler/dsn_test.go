@@ -0,0 +1,92 @@
+package bounce_handler
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDSN(t *testing.T) {
+	tests := []struct {
+		name       string
+		want       *Report
+		hardBounce []bool
+	}{
+		{"postfix", &Report{
+			ReportingMTA:      "mail.example.org",
+			OriginalMessageID: "4b0f4c1e-4d2a-4a52-9d6c-1b2b1f7c0a11@example.com",
+			Recipients: []RecipientStatus{
+				{Recipient: "nobody@example.org", Action: "failed", Status: "5.1.1", DiagnosticCode: "smtp; 550 5.1.1 <nobody@example.org>: Recipient address rejected: User unknown in virtual mailbox table"},
+				{Recipient: "full@example.org", Action: "delayed", Status: "4.2.2", DiagnosticCode: "smtp; 452 4.2.2 Mailbox full"},
+			},
+		}, []bool{true, false}},
+		{"gmail_base64", &Report{
+			ReportingMTA:      "googlemail.com",
+			OriginalMessageID: "a1e0c9f2-77d3-4b7e-9a55-0e6f7d1c2b33@example.com",
+			Recipients: []RecipientStatus{
+				{Recipient: "ghost@gmail.com", Action: "failed", Status: "5.1.1", DiagnosticCode: "smtp; 550-5.1.1 The email account that you tried to reach does not exist."},
+			},
+		}, []bool{true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := os.Open(filepath.Join("testdata", tt.name+".eml"))
+			if err != nil {
+				t.Fatalf("open: %v", err)
+			}
+			defer message.Close()
+
+			report, err := ParseDSN(message)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if !reflect.DeepEqual(report, tt.want) {
+				t.Fatalf("report = %+v, want %+v", report, tt.want)
+			}
+			for i, recipient := range report.Recipients {
+				if recipient.HardBounce() != tt.hardBounce[i] {
+					t.Errorf("%s hard bounce = %v, want %v", recipient.Recipient, recipient.HardBounce(), tt.hardBounce[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseDSNRejectsOtherMessages(t *testing.T) {
+	autoReply, err := os.ReadFile(filepath.Join("testdata", "auto_reply.eml"))
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	reportWithoutStatus := "Content-Type: multipart/report; report-type=delivery-status; boundary=b\r\n\r\n" +
+		"--b\r\nContent-Type: text/plain\r\n\r\nYour message was not delivered.\r\n--b--\r\n"
+	feedbackReport := "Content-Type: multipart/report; report-type=feedback-report; boundary=b\r\n\r\n" +
+		"--b\r\nContent-Type: message/feedback-report\r\n\r\nFeedback-Type: abuse\r\n--b--\r\n"
+
+	for name, message := range map[string]string{
+		"auto reply":            string(autoReply),
+		"report without status": reportWithoutStatus,
+		"feedback report":       feedbackReport,
+		"no content type":       "Subject: Hello\r\n\r\nHello\r\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseDSN(strings.NewReader(message)); !errors.Is(err, ErrNotDeliveryReport) {
+				t.Errorf("err = %v, want %v", err, ErrNotDeliveryReport)
+			}
+		})
+	}
+}
+
+func TestReportFor(t *testing.T) {
+	report := &Report{OriginalMessageID: "sent@example.com", Recipients: []RecipientStatus{{Recipient: "John@Example.org"}, {Recipient: "jane@example.org"}}}
+
+	filtered := report.For("john@example.org")
+	if len(filtered.Recipients) != 1 || filtered.Recipients[0].Recipient != "John@Example.org" || filtered.OriginalMessageID != "sent@example.com" {
+		t.Errorf("report = %+v", filtered)
+	}
+	if len(report.For("ceo@example.org").Recipients) != 0 {
+		t.Error("the report of an unknown recipient has recipients")
+	}
+}
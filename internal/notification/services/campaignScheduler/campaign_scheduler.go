@@ -0,0 +1,313 @@
+// Package campaign_scheduler expands scheduled campaigns into queued emails and sends them without going
+// over the send limits of the email accounts.
+package campaign_scheduler
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	bounce_handler "platform/internal/notification/services/bounceHandler"
+	delivery_tracker "platform/internal/notification/services/deliveryTracker"
+	email_sender "platform/internal/notification/services/emailSender"
+	"platform/internal/notification/services/encryption"
+	subscription_token "platform/internal/notification/services/subscriptionToken"
+	template_renderer "platform/internal/notification/services/templateRenderer"
+	"platform/internal/shared"
+	vo "platform/pkg/domain/value_object"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// Interval is the time between two runs of the scheduler
+	Interval = 10 * time.Second
+
+	// batchSize is the maximum number of emails an account sends in a run when it has no minute limit
+	batchSize = 100
+)
+
+var ErrEmailAccountNotFound = errors.New("email account of the campaign not found")
+
+type Scheduler struct {
+	encryption             encryption.EncryptionService
+	signer                 *subscription_token.Signer
+	bounces                *bounce_handler.Handler
+	tracker                *delivery_tracker.Tracker
+	campaignRepository     repositories.CampaignRepository
+	queuedEmailRepository  repositories.QueuedEmailRepository
+	subscriptionRepository repositories.SubscriptionRepository
+	suppressionRepository  repositories.SuppressionRepository
+	emailAccountRepository repositories.EmailAccountRepository
+}
+
+func NewScheduler(
+	encryption encryption.EncryptionService,
+	signer *subscription_token.Signer,
+	bounces *bounce_handler.Handler,
+	tracker *delivery_tracker.Tracker,
+	campaignRepository repositories.CampaignRepository,
+	queuedEmailRepository repositories.QueuedEmailRepository,
+	subscriptionRepository repositories.SubscriptionRepository,
+	suppressionRepository repositories.SuppressionRepository,
+	emailAccountRepository repositories.EmailAccountRepository,
+) *Scheduler {
+	return &Scheduler{
+		encryption:             encryption,
+		signer:                 signer,
+		bounces:                bounces,
+		tracker:                tracker,
+		campaignRepository:     campaignRepository,
+		queuedEmailRepository:  queuedEmailRepository,
+		subscriptionRepository: subscriptionRepository,
+		suppressionRepository:  suppressionRepository,
+		emailAccountRepository: emailAccountRepository,
+	}
+}
+
+// Run processes the campaigns every Interval until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick(ctx)
+		}
+	}
+}
+
+// Tick queues the emails of the campaigns whose schedule has come, then sends the queued emails
+func (s *Scheduler) Tick(ctx context.Context) {
+	due, err := s.campaignRepository.GetDue(ctx, time.Now())
+	if err != nil {
+		zap.L().Error("failed to get due campaigns", zap.Error(err))
+		return
+	}
+	for _, campaign := range due {
+		if err := s.expand(projectContext(ctx, campaign.GetProjectID()), campaign); err != nil {
+			zap.L().Error("failed to start campaign", zap.String("campaign_id", campaign.GetID().String()), zap.Error(err))
+		}
+	}
+
+	running, err := s.campaignRepository.GetRunning(ctx)
+	if err != nil {
+		zap.L().Error("failed to get running campaigns", zap.Error(err))
+		return
+	}
+
+	// Campaigns sharing an email account share its limits, so the queue is processed per account
+	accounts := make(map[uuid.UUID]uuid.UUID)
+	for _, campaign := range running {
+		accounts[campaign.GetEmailAccountID()] = campaign.GetProjectID()
+	}
+	for accountID, projectID := range accounts {
+		if err := s.send(projectContext(ctx, projectID), accountID); err != nil {
+			zap.L().Error("failed to send queued emails", zap.String("email_account_id", accountID.String()), zap.Error(err))
+		}
+	}
+
+	for _, campaign := range running {
+		if err := s.complete(ctx, campaign); err != nil {
+			zap.L().Error("failed to complete campaign", zap.String("campaign_id", campaign.GetID().String()), zap.Error(err))
+		}
+	}
+}
+
+// expand queues an email for every confirmed subscriber of the audience who is not suppressed and starts
+// the campaign
+func (s *Scheduler) expand(ctx context.Context, campaign *domain.Campaign) error {
+	// STEP-1: Get the templates of the campaign
+	templates, err := s.emailAccountRepository.GetTemplates(ctx, campaign.GetEmailAccountID(), campaign.GetTemplateName())
+	if err != nil {
+		return err
+	}
+	if len(templates) == 0 {
+		return template_renderer.ErrTemplateNotFound
+	}
+
+	// STEP-2: Get the addresses which must not receive emails
+	suppressions, err := s.suppressionRepository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	suppressed := make(map[string]bool, len(suppressions))
+	for _, suppression := range suppressions {
+		suppressed[suppression.GetEmail().Value()] = true
+	}
+
+	// STEP-3: Render the template for every subscriber in their language
+	subscriptions, err := s.subscriptionRepository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	emails := make([]*domain.QueuedEmail, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		language := subscription.GetLanguage().GetCulture()
+		if !subscription.IsConfirmed() || !campaign.Targets(language) || suppressed[subscription.GetEmail().Value()] {
+			continue
+		}
+
+		template, _ := template_renderer.Select(templates, language)
+		email := subscription.GetEmail().Value()
+		tokens := map[string]string{
+			"Email":                  email,
+			domain.UnsubscribeURLKey: s.signer.UnsubscribeURL(campaign.GetProjectID(), email),
+		}
+		subject := template_renderer.Render(template.GetSubject(), tokens)
+		body := template_renderer.Render(template.GetBody(), tokens)
+		emails = append(emails, domain.NewCampaignEmail(campaign, email, subject, body, tokens[domain.UnsubscribeURLKey]))
+	}
+
+	// STEP-4: Queue the emails and start the campaign. The emails already queued by a run which failed to
+	// start the campaign are skipped, so the campaign is expanded again on the next run.
+	if len(emails) > 0 {
+		if err := s.queuedEmailRepository.CreateBatch(ctx, emails); err != nil {
+			return err
+		}
+	}
+	if err := campaign.Start(len(emails)); err != nil {
+		return err
+	}
+	return s.campaignRepository.Update(ctx, campaign)
+}
+
+// send sends the queued emails of the account which fit in its per minute and per day limits
+func (s *Scheduler) send(ctx context.Context, accountID uuid.UUID) error {
+	// STEP-1: Get the email account
+	account, err := s.getEmailAccount(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	// STEP-2: Compute how many emails the account can still send
+	budget, err := s.budget(ctx, account)
+	if err != nil || budget == 0 {
+		return err
+	}
+
+	// STEP-3: Send the emails
+	emails, err := s.queuedEmailRepository.GetPending(ctx, accountID, budget)
+	if err != nil {
+		return err
+	}
+	from := vo.NewAddress(account.GetDisplayName(), account.GetEmail())
+	for _, email := range emails {
+		sent, failed := 0, 0
+		result, err := s.sendEmail(ctx, account, from, email)
+		if bounceErr := s.bounces.HandleResult(ctx, result); bounceErr != nil {
+			zap.L().Error("failed to record bounced recipients", zap.Error(bounceErr))
+		}
+
+		var sendErr *email_sender.SendError
+		if err != nil {
+			zap.L().Warn("failed to send queued email", zap.String("id", email.GetID().String()), zap.Error(err))
+			// Rejected messages are not tried again, failures of the account itself (auth, tls) are
+			if email.MarkFailed(err) || (errors.As(err, &sendErr) && isMessageRejected(sendErr)) {
+				failed = 1
+				email.GiveUp()
+			}
+		} else {
+			email.MarkSent()
+			sent = 1
+		}
+
+		if err := s.queuedEmailRepository.Update(ctx, email); err != nil {
+			return err
+		}
+		if campaignID := email.GetCampaignID(); campaignID != nil && sent+failed > 0 {
+			if err := s.campaignRepository.IncrementCounters(ctx, *campaignID, sent, failed); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sendEmail sends a queued email, its delivery log has the ID of the queued email so the retries share it
+func (s *Scheduler) sendEmail(ctx context.Context, account *domain.EmailAccount, from vo.Address, email *domain.QueuedEmail) (*email_sender.SendResult, error) {
+	to, err := vo.ParseAddress(email.GetTo())
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := s.tracker.Start(ctx, email.GetID(), account, email.GetCampaignID(), to.Email().Value(), email.GetSubject())
+	if err != nil {
+		return nil, err
+	}
+	detail, err := email_sender.BaseEmailDetail(email.GetSubject(), s.tracker.Instrument(account, delivery, email.GetBody()), from, to)
+	if err != nil {
+		return nil, err
+	}
+	detail.WithMessageID(delivery_tracker.MessageIDHeader(delivery))
+	if email.GetUnsubscribeURL() != "" {
+		detail.WithListUnsubscribe(email.GetUnsubscribeURL())
+	}
+
+	result, err := email_sender.SendEmail(ctx, s.encryption, account, detail)
+	if trackErr := s.tracker.Finish(ctx, delivery, result, err); trackErr != nil {
+		zap.L().Error("failed to record email delivery", zap.Error(trackErr))
+	}
+	return result, err
+}
+
+func isMessageRejected(err *email_sender.SendError) bool {
+	return (err.Category == email_sender.ErrorPermanent || err.Category == email_sender.ErrorRecipient) && !err.Temporary()
+}
+
+// budget returns the number of emails the account can send now, the messages it sent for the campaigns and
+// the notifications are counted over the last minute and the last 24 hours
+func (s *Scheduler) budget(ctx context.Context, account *domain.EmailAccount) (int, error) {
+	perMinute, perDay := account.SendLimits()
+	budget := batchSize
+	now := time.Now()
+
+	for _, limit := range []struct {
+		max    int
+		window time.Duration
+	}{{perMinute, time.Minute}, {perDay, 24 * time.Hour}} {
+		if limit.max == 0 {
+			continue
+		}
+		sent, err := s.tracker.CountSentSince(ctx, account.GetID(), now.Add(-limit.window))
+		if err != nil {
+			return 0, err
+		}
+		budget = min(budget, max(limit.max-sent, 0))
+	}
+	return budget, nil
+}
+
+// complete marks the campaign as completed once it has nothing left to send
+func (s *Scheduler) complete(ctx context.Context, campaign *domain.Campaign) error {
+	pending, err := s.queuedEmailRepository.CountPending(ctx, campaign.GetID())
+	if err != nil || pending > 0 {
+		return err
+	}
+	if err := campaign.Complete(); err != nil {
+		return err
+	}
+	return s.campaignRepository.Update(ctx, campaign)
+}
+
+func (s *Scheduler) getEmailAccount(ctx context.Context, accountID uuid.UUID) (*domain.EmailAccount, error) {
+	accounts, err := s.emailAccountRepository.GetAll(ctx, shared.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts.Items {
+		if account.GetID() == accountID {
+			return account, nil
+		}
+	}
+	return nil, ErrEmailAccountNotFound
+}
+
+// projectContext scopes the repositories to the project of a campaign
+func projectContext(ctx context.Context, projectID uuid.UUID) context.Context {
+	return context.WithValue(ctx, shared.ProjectIDContextKey, projectID)
+}
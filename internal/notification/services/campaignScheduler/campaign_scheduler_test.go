@@ -0,0 +1,210 @@
+package campaign_scheduler
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	delivery_tracker "platform/internal/notification/services/deliveryTracker"
+	subscription_token "platform/internal/notification/services/subscriptionToken"
+	"platform/internal/shared"
+	vo "platform/pkg/domain/value_object"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type testCampaigns struct {
+	repositories.CampaignRepository
+	updateErr error
+	updated   int
+}
+
+func (r *testCampaigns) Update(context.Context, *domain.Campaign) error {
+	if r.updateErr != nil {
+		return r.updateErr
+	}
+	r.updated++
+	return nil
+}
+
+// testQueue skips the emails of a campaign already queued for their recipient, like the unique index of the table
+type testQueue struct {
+	repositories.QueuedEmailRepository
+	emails []*domain.QueuedEmail
+}
+
+func (r *testQueue) CreateBatch(_ context.Context, emails []*domain.QueuedEmail) error {
+	for _, email := range emails {
+		queued := false
+		for _, other := range r.emails {
+			queued = queued || (*other.GetCampaignID() == *email.GetCampaignID() && other.GetTo() == email.GetTo())
+		}
+		if !queued {
+			r.emails = append(r.emails, email)
+		}
+	}
+	return nil
+}
+
+func (r *testQueue) recipients() []string {
+	var to []string
+	for _, email := range r.emails {
+		to = append(to, email.GetTo())
+	}
+	sort.Strings(to)
+	return to
+}
+
+type testSubscriptions struct {
+	repositories.SubscriptionRepository
+	subscriptions []*domain.Subscription
+}
+
+func (r testSubscriptions) GetAll(context.Context) ([]*domain.Subscription, error) {
+	return r.subscriptions, nil
+}
+
+type testSuppressions struct {
+	repositories.SuppressionRepository
+	suppressions []*domain.Suppression
+}
+
+func (r testSuppressions) GetAll(context.Context) ([]*domain.Suppression, error) {
+	return r.suppressions, nil
+}
+
+type testAccounts struct {
+	repositories.EmailAccountRepository
+}
+
+func (testAccounts) GetTemplates(_ context.Context, accountID uuid.UUID, name domain.EmailTemplateName) ([]domain.EmailTemplate, error) {
+	return []domain.EmailTemplate{*domain.NewEmailTemplate(accountID, name, "en-US", "News for {{.Email}}", "Hello", "", false)}, nil
+}
+
+// testDeliveries counts the messages sent at the given times, whatever their account
+type testDeliveries struct {
+	repositories.EmailDeliveryRepository
+	sent []time.Time
+}
+
+func (r testDeliveries) CountSentSince(_ context.Context, _ uuid.UUID, since time.Time) (int, error) {
+	count := 0
+	for _, at := range r.sent {
+		if !at.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func newTestScheduler(t *testing.T, campaigns *testCampaigns, queue *testQueue, subscriptions []*domain.Subscription, suppressions []*domain.Suppression, sent []time.Time) *Scheduler {
+	t.Helper()
+	signer, err := subscription_token.NewSigner([]byte("secret"), "https://api.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker, err := delivery_tracker.NewTracker([]byte("secret"), "https://api.example.com", testDeliveries{sent: sent})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewScheduler(nil, signer, nil, tracker, campaigns, queue,
+		testSubscriptions{subscriptions: subscriptions}, testSuppressions{suppressions: suppressions}, testAccounts{})
+}
+
+func TestExpand(t *testing.T) {
+	projectID := uuid.New()
+	languages := shared.GetLanguageManager()
+	subscriber := func(address, culture string, confirmed bool) *domain.Subscription {
+		email, _ := vo.NewEmail(address)
+		subscription := domain.NewSubscription(projectID, email, *languages.GetLanguageByCulture(culture))
+		if confirmed {
+			_ = subscription.Confirm()
+		}
+		return subscription
+	}
+	suppressedEmail, _ := vo.NewEmail("bounced@example.org")
+
+	campaigns := &testCampaigns{updateErr: errors.New("connection reset")}
+	queue := &testQueue{}
+	scheduler := newTestScheduler(t, campaigns, queue, []*domain.Subscription{
+		subscriber("john@example.org", "en-US", true),
+		subscriber("jane@example.org", "tr-TR", true),
+		subscriber("pending@example.org", "en-US", false),
+		subscriber("bounced@example.org", "en-US", true),
+		subscriber("hans@example.org", "de-DE", true),
+	}, []*domain.Suppression{
+		domain.NewSuppression(projectID, suppressedEmail, domain.SuppressionHardBounce, ""),
+	}, nil)
+
+	campaign := domain.NewCampaign(projectID, uuid.New(), "Weekly", "weekly", []string{"en-US", "tr-TR"}, time.Now())
+	ctx := projectContext(context.Background(), projectID)
+	want := []string{"jane@example.org", "john@example.org"}
+
+	// A run failing to start the campaign leaves it scheduled, the next run queues nobody twice
+	if err := scheduler.expand(ctx, campaign); err == nil {
+		t.Fatal("expand did not report the failed update")
+	}
+	campaign.SetStatus(domain.CampaignScheduled)
+	campaigns.updateErr = nil
+	if err := scheduler.expand(ctx, campaign); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := queue.recipients(); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("queued = %v, want %v", got, want)
+	}
+	if campaign.GetStatus() != domain.CampaignRunning || campaign.GetTotalCount() != len(want) || campaigns.updated != 1 {
+		t.Errorf("campaign = %s with %d emails, %d updates", campaign.GetStatus(), campaign.GetTotalCount(), campaigns.updated)
+	}
+	for _, email := range queue.emails {
+		if email.GetUnsubscribeURL() == "" {
+			t.Errorf("email to %s has no unsubscribe link", email.GetTo())
+		}
+	}
+}
+
+func TestBudget(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration, count int) []time.Time {
+		sent := make([]time.Time, count)
+		for i := range sent {
+			sent[i] = now.Add(-d)
+		}
+		return sent
+	}
+
+	tests := []struct {
+		name      string
+		perMinute int
+		perDay    int
+		sent      []time.Time
+		want      int
+	}{
+		{"no limits", 0, 0, ago(time.Second, 500), batchSize},
+		{"minute limit", 30, 0, ago(10*time.Second, 12), 18},
+		{"minute limit of an older minute", 30, 0, ago(2*time.Minute, 12), 30},
+		{"day limit", 0, 1000, append(ago(time.Hour, 950), ago(25*time.Hour, 500)...), 50},
+		{"both limits", 30, 1000, append(ago(time.Second, 5), ago(time.Hour, 990)...), 5},
+		{"limit reached", 30, 0, ago(time.Second, 40), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email, _ := vo.NewEmail("news@example.org")
+			account := domain.NewEmailAccount(uuid.New(), uuid.New(), 0, email, "News", "smtp.example.org", 587, true)
+			account.SetMaxPerMinute(tt.perMinute)
+			account.SetMaxPerDay(tt.perDay)
+			scheduler := newTestScheduler(t, &testCampaigns{}, &testQueue{}, nil, nil, tt.sent)
+
+			budget, err := scheduler.budget(context.Background(), account)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if budget != tt.want {
+				t.Errorf("budget = %d, want %d", budget, tt.want)
+			}
+		})
+	}
+}
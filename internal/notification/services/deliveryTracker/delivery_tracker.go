@@ -0,0 +1,192 @@
+// Package delivery_tracker keeps the delivery log of the sent emails: the outcome of the sending, the
+// events the providers report with their webhooks, the bounces, and the opens and clicks recorded by the
+// tracking pixel and the rewritten links of the HTML body.
+package delivery_tracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	bounce_handler "platform/internal/notification/services/bounceHandler"
+	email_sender "platform/internal/notification/services/emailSender"
+	"platform/internal/shared"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDeliveryNotFound = errors.New("email delivery not found")
+	ErrSecretRequired   = errors.New("delivery tracking secret is required")
+)
+
+type Tracker struct {
+	secret     []byte
+	baseURL    string
+	repository repositories.EmailDeliveryRepository
+}
+
+// NewTracker creates a tracker building the tracking links on baseURL, e.g. https://api.example.com.
+// An empty secret is refused, anyone could sign tracking links with it.
+func NewTracker(secret []byte, baseURL string, repository repositories.EmailDeliveryRepository) (*Tracker, error) {
+	if len(secret) == 0 {
+		return nil, ErrSecretRequired
+	}
+	return &Tracker{secret: secret, baseURL: strings.TrimSuffix(baseURL, "/"), repository: repository}, nil
+}
+
+// Start returns the delivery log of a message about to be sent, a message sent again keeps the log of its
+// first try. The message must be sent with the Message-ID header of MessageIDHeader.
+func (t *Tracker) Start(ctx context.Context, id uuid.UUID, account *domain.EmailAccount, campaignID *uuid.UUID, recipient, subject string) (*domain.EmailDelivery, error) {
+	delivery, err := t.repository.GetByID(ctx, id)
+	if err != nil || delivery != nil {
+		return delivery, err
+	}
+
+	messageID := fmt.Sprintf("%s@%s", id, account.GetEmail().Domain())
+	delivery = domain.NewEmailDelivery(id, account.GetProjectID(), account.GetID(), campaignID, recipient, subject, messageID)
+	if err := t.repository.Save(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// CountSentSince counts the messages the email account sent since the time, the send limits of the account
+// apply to all of them
+func (t *Tracker) CountSentSince(ctx context.Context, emailAccountID uuid.UUID, since time.Time) (int, error) {
+	return t.repository.CountSentSince(ctx, emailAccountID, since)
+}
+
+// MessageIDHeader returns the value of the Message-ID header of the message of the delivery
+func MessageIDHeader(delivery *domain.EmailDelivery) string {
+	return "<" + delivery.GetMessageID() + ">"
+}
+
+// Finish records the outcome of the sending: sent, deferred when it can be tried again, or bounced when the
+// recipient is refused for good
+func (t *Tracker) Finish(ctx context.Context, delivery *domain.EmailDelivery, result *email_sender.SendResult, sendErr error) error {
+	now := time.Now()
+	switch {
+	case sendErr == nil:
+		if result != nil && result.MessageID != "" {
+			delivery.SetProviderMessageID(normalizeMessageID(result.MessageID))
+		}
+		delivery.Record(domain.EmailSent, "", "", now)
+	case isRejected(sendErr):
+		delivery.Record(domain.EmailBounced, sendErr.Error(), "", now)
+	default:
+		delivery.Record(domain.EmailDeferred, sendErr.Error(), "", now)
+	}
+	return t.repository.Save(ctx, delivery)
+}
+
+func isRejected(err error) bool {
+	var sendErr *email_sender.SendError
+	if !errors.As(err, &sendErr) || sendErr.Temporary() {
+		return false
+	}
+	return sendErr.Category == email_sender.ErrorRecipient || sendErr.Category == email_sender.ErrorPermanent
+}
+
+// Open records the opening of the message of a tracking pixel token
+func (t *Tracker) Open(ctx context.Context, token string) error {
+	c, err := t.verify(token, purposeOpen)
+	if err != nil {
+		return err
+	}
+	return t.record(projectContext(ctx, c.ProjectID), c.DeliveryID, domain.EmailOpened, "", "")
+}
+
+// Click records the click on a rewritten link and returns the original URL
+func (t *Tracker) Click(ctx context.Context, token string) (string, error) {
+	c, err := t.verify(token, purposeClick)
+	if err != nil {
+		return "", err
+	}
+	return c.URL, t.record(projectContext(ctx, c.ProjectID), c.DeliveryID, domain.EmailClicked, "", c.URL)
+}
+
+func (t *Tracker) record(ctx context.Context, id uuid.UUID, status domain.EmailDeliveryStatus, detail, url string) error {
+	delivery, err := t.repository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if delivery == nil {
+		return ErrDeliveryNotFound
+	}
+	delivery.Record(status, detail, url, time.Now())
+	return t.repository.Save(ctx, delivery)
+}
+
+// Record adds an event reported by a provider or a bounce to the delivery log of the message. When ctx has
+// a project, only the messages of the project are found.
+func (t *Tracker) Record(ctx context.Context, event Event) error {
+	delivery, err := t.repository.GetByMessageID(ctx, normalizeMessageID(event.MessageID))
+	if err != nil {
+		return err
+	}
+	if projectID, ok := ctx.Value(shared.ProjectIDContextKey).(uuid.UUID); delivery == nil || (ok && projectID != delivery.GetProjectID()) {
+		return ErrDeliveryNotFound
+	}
+
+	occurredAt := event.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+	delivery.Record(event.Status, event.Detail, event.URL, occurredAt)
+	return t.repository.Save(projectContext(ctx, delivery.GetProjectID()), delivery)
+}
+
+// RecordDSN adds the outcome of the recipient of a delivery status notification to the delivery log of the
+// original message and returns it. The delivery is nil when the report is not about a message sent by the
+// project of ctx: anyone can send a report, it must not change the log of another message.
+func (t *Tracker) RecordDSN(ctx context.Context, report *bounce_handler.Report) (*domain.EmailDelivery, error) {
+	if report == nil || report.OriginalMessageID == "" {
+		return nil, nil
+	}
+	delivery, err := t.repository.GetByMessageID(ctx, normalizeMessageID(report.OriginalMessageID))
+	if err != nil {
+		return nil, err
+	}
+	if projectID, ok := ctx.Value(shared.ProjectIDContextKey).(uuid.UUID); delivery == nil || (ok && projectID != delivery.GetProjectID()) {
+		return nil, nil
+	}
+
+	recorded := false
+	for _, recipient := range report.For(delivery.GetRecipient()).Recipients {
+		var status domain.EmailDeliveryStatus
+		switch recipient.Action {
+		case "failed":
+			status = domain.EmailBounced
+		case "delayed":
+			status = domain.EmailDeferred
+		case "delivered", "relayed":
+			status = domain.EmailSent
+		default:
+			continue
+		}
+		detail := recipient.DiagnosticCode
+		if detail == "" {
+			detail = recipient.Status
+		}
+		delivery.Record(status, detail, "", time.Now())
+		recorded = true
+	}
+	if !recorded {
+		return delivery, nil
+	}
+	return delivery, t.repository.Save(projectContext(ctx, delivery.GetProjectID()), delivery)
+}
+
+// normalizeMessageID removes the angle brackets of a Message-ID, the providers give it with or without them
+func normalizeMessageID(messageID string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(messageID), "<"), ">")
+}
+
+// projectContext scopes the repositories to the project of a delivery
+func projectContext(ctx context.Context, projectID uuid.UUID) context.Context {
+	return context.WithValue(ctx, shared.ProjectIDContextKey, projectID)
+}
@@ -0,0 +1,53 @@
+package delivery_tracker
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	bounce_handler "platform/internal/notification/services/bounceHandler"
+	"platform/internal/shared"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestRecordDSN(t *testing.T) {
+	projectID := uuid.New()
+	failed := bounce_handler.RecipientStatus{Recipient: "John@Example.org", Action: "failed", Status: "5.1.1", DiagnosticCode: "smtp; 550 5.1.1 user unknown"}
+	other := bounce_handler.RecipientStatus{Recipient: "ceo@example.org", Action: "failed", Status: "5.1.1"}
+
+	tests := []struct {
+		name       string
+		projectID  uuid.UUID
+		report     *bounce_handler.Report
+		wantFound  bool
+		wantStatus domain.EmailDeliveryStatus
+	}{
+		{"known message", projectID, &bounce_handler.Report{OriginalMessageID: "<sent@example.com>", Recipients: []bounce_handler.RecipientStatus{failed}}, true, domain.EmailBounced},
+		{"other recipient of a known message", projectID, &bounce_handler.Report{OriginalMessageID: "sent@example.com", Recipients: []bounce_handler.RecipientStatus{other}}, true, domain.EmailSent},
+		{"unknown message", projectID, &bounce_handler.Report{OriginalMessageID: "forged@example.com", Recipients: []bounce_handler.RecipientStatus{failed}}, false, ""},
+		{"message of another project", uuid.New(), &bounce_handler.Report{OriginalMessageID: "sent@example.com", Recipients: []bounce_handler.RecipientStatus{failed}}, false, ""},
+		{"no original message", projectID, &bounce_handler.Report{Recipients: []bounce_handler.RecipientStatus{failed}}, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := domain.NewEmailDelivery(uuid.New(), projectID, uuid.New(), nil, "john@example.org", "Hello", "sent@example.com")
+			sent.Record(domain.EmailSent, "", "", sent.GetCreatedAt())
+			tracker, _ := NewTracker([]byte("secret"), "https://api.example.com", testRepository{deliveries: []*domain.EmailDelivery{sent}})
+
+			ctx := context.WithValue(context.Background(), shared.ProjectIDContextKey, tt.projectID)
+			delivery, err := tracker.RecordDSN(ctx, tt.report)
+			if err != nil {
+				t.Fatalf("RecordDSN: %v", err)
+			}
+			if found := delivery != nil; found != tt.wantFound {
+				t.Fatalf("found = %v, want %v", found, tt.wantFound)
+			}
+			if tt.wantFound && delivery.GetStatus() != tt.wantStatus {
+				t.Errorf("status = %s, want %s", delivery.GetStatus(), tt.wantStatus)
+			}
+			if !tt.wantFound && sent.GetStatus() != domain.EmailSent {
+				t.Errorf("status of the sent message = %s, want %s", sent.GetStatus(), domain.EmailSent)
+			}
+		})
+	}
+}
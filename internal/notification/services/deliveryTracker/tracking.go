@@ -0,0 +1,98 @@
+package delivery_tracker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"html"
+	"platform/internal/notification/domain"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidToken = errors.New("invalid tracking token")
+
+type purpose string
+
+const (
+	purposeOpen  purpose = "open"
+	purposeClick purpose = "click"
+)
+
+// claims identify the delivery of a tracking link, the URL of a click is signed so the endpoint cannot be
+// used as an open redirect
+type claims struct {
+	Purpose    purpose   `json:"p"`
+	DeliveryID uuid.UUID `json:"d"`
+	ProjectID  uuid.UUID `json:"pid"`
+	URL        string    `json:"u,omitempty"`
+}
+
+var (
+	// linkPattern matches the href attribute of the links to a web page
+	linkPattern = regexp.MustCompile(`(?i)(<a\s[^>]*?\bhref\s*=\s*)("https?://[^"]*"|'https?://[^']*')`)
+
+	// bodyEndPattern matches the closing tag of the body, the pixel is added right before it
+	bodyEndPattern = regexp.MustCompile(`(?i)</body\s*>`)
+)
+
+// Instrument rewrites the links of the HTML body to the click tracking endpoint and adds the tracking pixel,
+// according to the tracking settings of the email account
+func (t *Tracker) Instrument(account *domain.EmailAccount, delivery *domain.EmailDelivery, body string) string {
+	if account.GetTrackClicks() {
+		body = linkPattern.ReplaceAllStringFunc(body, func(match string) string {
+			parts := linkPattern.FindStringSubmatch(match)
+			target := html.UnescapeString(strings.Trim(parts[2], `"'`))
+			token := t.sign(claims{Purpose: purposeClick, DeliveryID: delivery.GetID(), ProjectID: delivery.GetProjectID(), URL: target})
+			return parts[1] + `"` + t.baseURL + "/v1/notification/track/click/" + token + `"`
+		})
+	}
+
+	if account.GetTrackOpens() {
+		token := t.sign(claims{Purpose: purposeOpen, DeliveryID: delivery.GetID(), ProjectID: delivery.GetProjectID()})
+		pixel := `<img src="` + t.baseURL + "/v1/notification/track/open/" + token + `" width="1" height="1" alt="" style="display:none">`
+		if loc := bodyEndPattern.FindStringIndex(body); loc != nil {
+			body = body[:loc[0]] + pixel + body[loc[0]:]
+		} else {
+			body += pixel
+		}
+	}
+	return body
+}
+
+func (t *Tracker) sign(c claims) string {
+	payload, _ := json.Marshal(c)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(t.mac(encoded))
+}
+
+func (t *Tracker) verify(token string, p purpose) (*claims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, t.mac(encoded)) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil || c.Purpose != p {
+		return nil, ErrInvalidToken
+	}
+	return &c, nil
+}
+
+func (t *Tracker) mac(payload string) []byte {
+	h := hmac.New(sha256.New, t.secret)
+	h.Write([]byte("tracking:" + payload))
+	return h.Sum(nil)
+}
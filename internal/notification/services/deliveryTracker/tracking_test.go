@@ -0,0 +1,69 @@
+package delivery_tracker
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// testRepository finds the deliveries it holds by Message-ID and nothing by ID
+type testRepository struct {
+	deliveries []*domain.EmailDelivery
+}
+
+func (testRepository) GetAll(context.Context, repositories.EmailDeliveryFilter, int, int) ([]*domain.EmailDelivery, int, error) {
+	return nil, 0, nil
+}
+func (testRepository) GetByID(context.Context, uuid.UUID) (*domain.EmailDelivery, error) {
+	return nil, nil
+}
+func (r testRepository) GetByMessageID(_ context.Context, messageID string) (*domain.EmailDelivery, error) {
+	for _, delivery := range r.deliveries {
+		if delivery.GetMessageID() == messageID {
+			return delivery, nil
+		}
+	}
+	return nil, nil
+}
+func (testRepository) CountSentSince(context.Context, uuid.UUID, time.Time) (int, error) {
+	return 0, nil
+}
+func (testRepository) Save(context.Context, *domain.EmailDelivery) error { return nil }
+
+func TestNewTrackerRejectsEmptySecret(t *testing.T) {
+	if _, err := NewTracker(nil, "https://api.example.com", testRepository{}); !errors.Is(err, ErrSecretRequired) {
+		t.Fatalf("err = %v, want %v", err, ErrSecretRequired)
+	}
+}
+
+func TestClick(t *testing.T) {
+	tracker, _ := NewTracker([]byte("secret"), "https://api.example.com", testRepository{})
+	other, _ := NewTracker([]byte("other"), "https://api.example.com", testRepository{})
+	click := claims{Purpose: purposeClick, DeliveryID: uuid.New(), ProjectID: uuid.New(), URL: "https://evil.example.com"}
+	open := claims{Purpose: purposeOpen, DeliveryID: uuid.New(), ProjectID: uuid.New()}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantURL string
+		wantErr error
+	}{
+		{"unknown delivery", tracker.sign(click), "https://evil.example.com", ErrDeliveryNotFound},
+		{"other secret", other.sign(click), "", ErrInvalidToken},
+		{"open token", tracker.sign(open), "", ErrInvalidToken},
+		{"garbage", "garbage", "", ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, err := tracker.Click(context.Background(), tt.token)
+			if !errors.Is(err, tt.wantErr) || url != tt.wantURL {
+				t.Fatalf("got %q, %v, want %q, %v", url, err, tt.wantURL, tt.wantErr)
+			}
+		})
+	}
+}
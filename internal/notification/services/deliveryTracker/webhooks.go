@@ -0,0 +1,302 @@
+package delivery_tracker
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"platform/internal/notification/domain"
+	email_provider "platform/internal/notification/services/emailProvider"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnknownProvider  = errors.New("unknown webhook provider")
+	ErrInvalidWebhook   = errors.New("invalid webhook payload")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// Event is a step of the delivery of a message reported by a provider, MessageID is either the Message-ID
+// header of the message or the identifier the provider gave it
+type Event struct {
+	MessageID  string
+	Status     domain.EmailDeliveryStatus
+	Detail     string
+	URL        string
+	OccurredAt time.Time
+}
+
+// Webhooks reads the event notifications of SendGrid, Mailgun and Amazon SES
+type Webhooks struct {
+	mailgunSigningKey string
+	client            *http.Client
+}
+
+// NewWebhooks creates the webhook reader, the Mailgun signatures are verified when mailgunSigningKey is set
+func NewWebhooks(mailgunSigningKey string) *Webhooks {
+	return &Webhooks{mailgunSigningKey: mailgunSigningKey, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// Parse returns the delivery events of the notification of a provider, the events not related to the
+// delivery of a message are skipped
+func (w *Webhooks) Parse(ctx context.Context, provider string, body []byte) ([]Event, error) {
+	switch provider {
+	case email_provider.TransportSendGrid:
+		return parseSendGrid(body)
+	case email_provider.TransportMailgun:
+		return w.parseMailgun(body)
+	case email_provider.TransportSES:
+		return w.parseSNS(ctx, body)
+	default:
+		return nil, ErrUnknownProvider
+	}
+}
+
+// SENDGRID
+
+type sendgridEvent struct {
+	Event       string `json:"event"`
+	Timestamp   int64  `json:"timestamp"`
+	SMTPID      string `json:"smtp-id"`
+	SGMessageID string `json:"sg_message_id"`
+	URL         string `json:"url"`
+	Reason      string `json:"reason"`
+	Response    string `json:"response"`
+	BounceType  string `json:"type"`
+}
+
+// parseSendGrid reads the batch of events of the Event Webhook
+func parseSendGrid(body []byte) ([]Event, error) {
+	var payload []sendgridEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+
+	events := make([]Event, 0, len(payload))
+	for _, e := range payload {
+		event := Event{MessageID: e.SMTPID, URL: e.URL}
+		if e.Timestamp != 0 {
+			event.OccurredAt = time.Unix(e.Timestamp, 0)
+		}
+		if event.MessageID == "" {
+			// sg_message_id is the X-Message-Id of the response followed by ".filter..." or ".recvd-..."
+			event.MessageID, _, _ = strings.Cut(e.SGMessageID, ".")
+		}
+		switch e.Event {
+		case "processed":
+			continue
+		case "delivered":
+			event.Status, event.Detail = domain.EmailSent, "delivered"
+		case "deferred":
+			event.Status, event.Detail = domain.EmailDeferred, e.Response
+		case "bounce":
+			event.Status, event.Detail = domain.EmailBounced, e.Reason
+			if e.BounceType == "blocked" {
+				event.Status = domain.EmailDeferred
+			}
+		case "dropped":
+			event.Status, event.Detail = domain.EmailBounced, e.Reason
+		case "open":
+			event.Status = domain.EmailOpened
+		case "click":
+			event.Status = domain.EmailClicked
+		default:
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// MAILGUN
+
+type mailgunWebhook struct {
+	Signature struct {
+		Timestamp string `json:"timestamp"`
+		Token     string `json:"token"`
+		Signature string `json:"signature"`
+	} `json:"signature"`
+	EventData struct {
+		Event     string  `json:"event"`
+		Timestamp float64 `json:"timestamp"`
+		Severity  string  `json:"severity"`
+		Reason    string  `json:"reason"`
+		URL       string  `json:"url"`
+		Message   struct {
+			Headers struct {
+				MessageID string `json:"message-id"`
+			} `json:"headers"`
+		} `json:"message"`
+		DeliveryStatus struct {
+			Message     string `json:"message"`
+			Description string `json:"description"`
+		} `json:"delivery-status"`
+	} `json:"event-data"`
+}
+
+// parseMailgun reads a webhook of the events API, each request carries a single event
+func (w *Webhooks) parseMailgun(body []byte) ([]Event, error) {
+	var payload mailgunWebhook
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	if w.mailgunSigningKey != "" {
+		h := hmac.New(sha256.New, []byte(w.mailgunSigningKey))
+		h.Write([]byte(payload.Signature.Timestamp + payload.Signature.Token))
+		signature, err := hex.DecodeString(payload.Signature.Signature)
+		if err != nil || !hmac.Equal(signature, h.Sum(nil)) {
+			return nil, ErrInvalidSignature
+		}
+	}
+
+	data := payload.EventData
+	event := Event{MessageID: data.Message.Headers.MessageID, URL: data.URL}
+	if data.Timestamp != 0 {
+		seconds := int64(data.Timestamp)
+		event.OccurredAt = time.Unix(seconds, int64((data.Timestamp-float64(seconds))*1e9))
+	}
+	detail := data.DeliveryStatus.Message
+	if detail == "" {
+		detail = data.DeliveryStatus.Description
+	}
+	switch data.Event {
+	case "delivered":
+		event.Status, event.Detail = domain.EmailSent, "delivered"
+	case "failed":
+		event.Status, event.Detail = domain.EmailDeferred, detail
+		if data.Severity == "permanent" {
+			event.Status = domain.EmailBounced
+		}
+	case "rejected":
+		event.Status, event.Detail = domain.EmailBounced, data.Reason
+	case "opened":
+		event.Status = domain.EmailOpened
+	case "clicked":
+		event.Status = domain.EmailClicked
+	default:
+		return nil, nil
+	}
+	return []Event{event}, nil
+}
+
+// AMAZON SES
+
+type snsMessage struct {
+	Type         string `json:"Type"`
+	Message      string `json:"Message"`
+	SubscribeURL string `json:"SubscribeURL"`
+}
+
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Mail             struct {
+		MessageID string `json:"messageId"`
+	} `json:"mail"`
+	Bounce struct {
+		BounceType        string    `json:"bounceType"`
+		BounceSubType     string    `json:"bounceSubType"`
+		Timestamp         time.Time `json:"timestamp"`
+		BouncedRecipients []struct {
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Delivery struct {
+		Timestamp time.Time `json:"timestamp"`
+	} `json:"delivery"`
+	DeliveryDelay struct {
+		Timestamp time.Time `json:"timestamp"`
+		DelayType string    `json:"delayType"`
+	} `json:"deliveryDelay"`
+	Open struct {
+		Timestamp time.Time `json:"timestamp"`
+	} `json:"open"`
+	Click struct {
+		Timestamp time.Time `json:"timestamp"`
+		Link      string    `json:"link"`
+	} `json:"click"`
+}
+
+// parseSNS reads the notifications of SES published to an SNS topic, either the Notification or the Event
+// Publishing format. The subscription of the topic is confirmed.
+func (w *Webhooks) parseSNS(ctx context.Context, body []byte) ([]Event, error) {
+	var message snsMessage
+	if err := json.Unmarshal(body, &message); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+
+	switch message.Type {
+	case "SubscriptionConfirmation":
+		return nil, w.confirmSubscription(ctx, message.SubscribeURL)
+	case "Notification":
+	default:
+		return nil, nil
+	}
+
+	var n sesNotification
+	if err := json.Unmarshal([]byte(message.Message), &n); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	kind := n.EventType
+	if kind == "" {
+		kind = n.NotificationType
+	}
+
+	event := Event{MessageID: n.Mail.MessageID}
+	switch kind {
+	case "Bounce":
+		event.Status, event.OccurredAt = domain.EmailDeferred, n.Bounce.Timestamp
+		if n.Bounce.BounceType == "Permanent" {
+			event.Status = domain.EmailBounced
+		}
+		event.Detail = n.Bounce.BounceType + "/" + n.Bounce.BounceSubType
+		if len(n.Bounce.BouncedRecipients) > 0 && n.Bounce.BouncedRecipients[0].DiagnosticCode != "" {
+			event.Detail = n.Bounce.BouncedRecipients[0].DiagnosticCode
+		}
+	case "Delivery":
+		event.Status, event.Detail, event.OccurredAt = domain.EmailSent, "delivered", n.Delivery.Timestamp
+	case "DeliveryDelay":
+		event.Status, event.Detail, event.OccurredAt = domain.EmailDeferred, n.DeliveryDelay.DelayType, n.DeliveryDelay.Timestamp
+	case "Open":
+		event.Status, event.OccurredAt = domain.EmailOpened, n.Open.Timestamp
+	case "Click":
+		event.Status, event.URL, event.OccurredAt = domain.EmailClicked, n.Click.Link, n.Click.Timestamp
+	default:
+		return nil, nil
+	}
+	return []Event{event}, nil
+}
+
+// snsHost matches the regional endpoints of SNS, e.g. sns.us-east-1.amazonaws.com, and not the other hosts
+// of amazonaws.com anyone can create such as the S3 bucket sns.s3.amazonaws.com
+var snsHost = regexp.MustCompile(`^sns\.[a-z]{2}(-gov)?-[a-z]+-[0-9]+\.amazonaws\.com(\.cn)?$`)
+
+// confirmSubscription opens the SubscribeURL of the confirmation, only the endpoints of SNS are trusted
+func (w *Webhooks) confirmSubscription(ctx context.Context, subscribeURL string) error {
+	u, err := url.Parse(subscribeURL)
+	if err != nil || u.Scheme != "https" || u.Port() != "" || !snsHost.MatchString(u.Hostname()) {
+		return fmt.Errorf("%w: untrusted subscribe URL", ErrInvalidWebhook)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.New("failed to confirm SNS subscription: " + strconv.Itoa(resp.StatusCode))
+	}
+	return nil
+}
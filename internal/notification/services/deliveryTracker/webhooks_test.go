@@ -0,0 +1,193 @@
+package delivery_tracker
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"platform/internal/notification/domain"
+	email_provider "platform/internal/notification/services/emailProvider"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readPayload(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read payload: %v", err)
+	}
+	return body
+}
+
+func TestParseSendGrid(t *testing.T) {
+	events, err := NewWebhooks("").Parse(context.Background(), email_provider.TransportSendGrid, readPayload(t, "sendgrid_events.json"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	// The processed and group_unsubscribe events are not about the delivery
+	want := []Event{
+		{MessageID: "<14c5d75ce93.dfd.64b469@ismtpd-555>", Status: domain.EmailDeferred, Detail: "400 try again later"},
+		{MessageID: "<14c5d75ce93.dfd.64b469@ismtpd-555>", Status: domain.EmailSent, Detail: "delivered"},
+		{MessageID: "14c5d75ce93", Status: domain.EmailBounced, Detail: "500 unknown recipient"},
+		{MessageID: "<14c5d75ce93.dfd.64b470@ismtpd-555>", Status: domain.EmailDeferred, Detail: "550 blocked by the spam filter"},
+		{MessageID: "<14c5d75ce93.dfd.64b469@ismtpd-555>", Status: domain.EmailClicked, URL: "http://www.sendgrid.com/"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, event := range events {
+		if !event.OccurredAt.Equal(time.Unix(1513299569, 0)) {
+			t.Errorf("event %d occurred at %s", i, event.OccurredAt)
+		}
+		event.OccurredAt = time.Time{}
+		if event != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, event, want[i])
+		}
+	}
+
+	if _, err := parseSendGrid([]byte(`{"event":"delivered"}`)); !errors.Is(err, ErrInvalidWebhook) {
+		t.Errorf("err = %v, want %v", err, ErrInvalidWebhook)
+	}
+}
+
+func TestParseMailgun(t *testing.T) {
+	const signingKey = "key-3ax6xnjp29jd6fds4gc373sgvjxteol0"
+	h := hmac.New(sha256.New, []byte(signingKey))
+	h.Write([]byte("1529006854a8ce0edb2dd8301dee6c2405235584e45aa91d1e9f979f3de0"))
+	payload := readPayload(t, "mailgun_failed.json")
+	signed := []byte(strings.Replace(string(payload), "SIGNATURE", hex.EncodeToString(h.Sum(nil)), 1))
+	tampered := []byte(strings.Replace(string(signed), `"severity": "permanent"`, `"severity": "temporary"`, 1))
+	forged := []byte(strings.Replace(string(signed), "1529006854", "1529006855", 1))
+
+	tests := []struct {
+		name       string
+		signingKey string
+		body       []byte
+		want       *Event
+		wantErr    error
+	}{
+		{"signed", signingKey, signed, &Event{MessageID: "20130503192659.13651.20287@sandbox.mailgun.org", Status: domain.EmailBounced, Detail: "Not delivering to previously bounced address"}, nil},
+		// The signature covers the timestamp and the token only, the event data is trusted with them
+		{"signed with other event data", signingKey, tampered, &Event{MessageID: "20130503192659.13651.20287@sandbox.mailgun.org", Status: domain.EmailDeferred, Detail: "Not delivering to previously bounced address"}, nil},
+		{"forged timestamp", signingKey, forged, nil, ErrInvalidSignature},
+		{"other key", "key-other", signed, nil, ErrInvalidSignature},
+		{"no signature", signingKey, payload, nil, ErrInvalidSignature},
+		{"signature not checked", "", payload, &Event{MessageID: "20130503192659.13651.20287@sandbox.mailgun.org", Status: domain.EmailBounced, Detail: "Not delivering to previously bounced address"}, nil},
+		{"not json", signingKey, []byte("event=failed"), nil, ErrInvalidWebhook},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := NewWebhooks(tt.signingKey).Parse(context.Background(), email_provider.TransportMailgun, tt.body)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.want == nil {
+				return
+			}
+			if len(events) != 1 {
+				t.Fatalf("got %d events, want 1", len(events))
+			}
+			event := events[0]
+			if event.OccurredAt.Unix() != 1521233195 {
+				t.Errorf("occurred at %s", event.OccurredAt)
+			}
+			event.OccurredAt = time.Time{}
+			if event != *tt.want {
+				t.Errorf("event = %+v, want %+v", event, *tt.want)
+			}
+		})
+	}
+}
+
+// roundTripper answers every request of the tests without opening a connection
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestParseSNS(t *testing.T) {
+	tests := []struct {
+		name string
+		body []byte
+		want Event
+	}{
+		{"bounce event", readPayload(t, "sns_bounce.json"), Event{
+			MessageID:  "EXAMPLE7c191be45-e9aedb9a-02f9-4d12-a87d-dd0099a07f8a-000000",
+			Status:     domain.EmailBounced,
+			Detail:     "smtp; 550 5.1.1 user unknown",
+			OccurredAt: time.Date(2017, 8, 5, 0, 41, 2, 669e6, time.UTC),
+		}},
+		{"delivery notification", readPayload(t, "sns_delivery.json"), Event{
+			MessageID:  "0000014644fe5ef6-9a483358-9170-4cb4-a269-f5dcdf415321-000000",
+			Status:     domain.EmailSent,
+			Detail:     "delivered",
+			OccurredAt: time.Date(2016, 1, 27, 14, 59, 38, 237e6, time.UTC),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := NewWebhooks("").Parse(context.Background(), email_provider.TransportSES, tt.body)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if len(events) != 1 || !events[0].OccurredAt.Equal(tt.want.OccurredAt) {
+				t.Fatalf("events = %+v, want %+v", events, tt.want)
+			}
+			events[0].OccurredAt = tt.want.OccurredAt
+			if events[0] != tt.want {
+				t.Errorf("event = %+v, want %+v", events[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSNSSubscriptionConfirmation(t *testing.T) {
+	payload := string(readPayload(t, "sns_subscription_confirmation.json"))
+
+	tests := []struct {
+		name         string
+		subscribeURL string
+		wantErr      error
+	}{
+		{"regional endpoint", "https://sns.us-west-2.amazonaws.com/?Action=ConfirmSubscription&TopicArn=arn:aws:sns:us-west-2:123456789012:MyTopic&Token=2336412f37", nil},
+		{"gov cloud endpoint", "https://sns.us-gov-west-1.amazonaws.com/?Action=ConfirmSubscription", nil},
+		{"china endpoint", "https://sns.cn-north-1.amazonaws.com.cn/?Action=ConfirmSubscription", nil},
+		{"plain http", "http://sns.us-west-2.amazonaws.com/?Action=ConfirmSubscription", ErrInvalidWebhook},
+		{"s3 bucket named sns", "https://sns.s3.amazonaws.com/confirm", ErrInvalidWebhook},
+		{"other host", "https://sns.us-west-2.amazonaws.com.evil.example.com/", ErrInvalidWebhook},
+		{"user info", "https://sns.us-west-2.amazonaws.com@evil.example.com/", ErrInvalidWebhook},
+		{"other port", "https://sns.us-west-2.amazonaws.com:8443/", ErrInvalidWebhook},
+		{"internal address", "https://169.254.169.254/latest/meta-data/", ErrInvalidWebhook},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested []string
+			webhooks := NewWebhooks("")
+			webhooks.client = &http.Client{Transport: roundTripper(func(req *http.Request) (*http.Response, error) {
+				requested = append(requested, req.URL.String())
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+			})}
+
+			body := []byte(strings.Replace(payload, "SUBSCRIBE_URL", tt.subscribeURL, 1))
+			events, err := webhooks.Parse(context.Background(), email_provider.TransportSES, body)
+			if !errors.Is(err, tt.wantErr) || len(events) != 0 {
+				t.Fatalf("got %v, %v, want no event and %v", events, err, tt.wantErr)
+			}
+			if tt.wantErr != nil && len(requested) != 0 {
+				t.Errorf("requested %v, want no request", requested)
+			}
+			if tt.wantErr == nil && (len(requested) != 1 || requested[0] != tt.subscribeURL) {
+				t.Errorf("requested %v, want %s", requested, tt.subscribeURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Package dispatcher fans a notification out to the channels of its recipient and records the outcome of
+// each of them on the notification.
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/domain"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+var ErrChannelNotSupported = errors.New("channel is not supported")
+
+// ChannelSender delivers a notification on one channel and returns the identifier given by the provider
+type ChannelSender interface {
+	Send(ctx context.Context, notification *domain.Notification, recipient *domain.RecipientPreference) (string, error)
+}
+
+type Dispatcher struct {
+	senders map[domain.Channel]ChannelSender
+}
+
+func NewDispatcher(senders map[domain.Channel]ChannelSender) *Dispatcher {
+	return &Dispatcher{senders: senders}
+}
+
+// Dispatch sends the pending deliveries of the notification in parallel. A failing channel does not stop the
+// others, its error is kept on the delivery.
+func (d *Dispatcher) Dispatch(ctx context.Context, notification *domain.Notification, recipient *domain.RecipientPreference) {
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for _, delivery := range notification.GetDeliveries() {
+		if delivery.Status != domain.DeliveryPending {
+			continue
+		}
+
+		sender, ok := d.senders[delivery.Channel]
+		if !ok {
+			notification.MarkFailed(delivery.Channel, ErrChannelNotSupported)
+			continue
+		}
+
+		wg.Add(1)
+		go func(channel domain.Channel) {
+			defer wg.Done()
+			messageID, err := sender.Send(ctx, notification, recipient)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				zap.L().Warn("notification delivery failed",
+					zap.String("notification_id", notification.GetID().String()),
+					zap.String("channel", string(channel)),
+					zap.Error(err))
+				notification.MarkFailed(channel, err)
+				return
+			}
+			notification.MarkSent(channel, messageID)
+		}(delivery.Channel)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,80 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/domain"
+	vo "platform/pkg/domain/value_object"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// testSender counts its calls and fails with err when it is set
+type testSender struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (s *testSender) Send(context.Context, *domain.Notification, *domain.RecipientPreference) (string, error) {
+	s.calls.Add(1)
+	if s.err != nil {
+		return "", s.err
+	}
+	return "message-1", nil
+}
+
+func TestDispatch(t *testing.T) {
+	email, _ := vo.NewEmail("john@example.org")
+	recipient := domain.NewRecipientPreference(uuid.New(), email, "", domain.Channels)
+	emailSender := &testSender{}
+	smsSender := &testSender{err: errors.New("provider is down")}
+	dispatcher := NewDispatcher(map[domain.Channel]ChannelSender{domain.EmailChannel: emailSender, domain.SmsChannel: smsSender})
+
+	notification := domain.NewNotification(recipient.GetProjectID(), email.Value(), "welcome", "en-US", nil)
+	notification.AddDelivery(domain.EmailChannel)
+	notification.AddDelivery(domain.SmsChannel)
+	notification.AddDelivery(domain.WebhookChannel)
+	dispatcher.Dispatch(context.Background(), notification, recipient)
+
+	want := map[domain.Channel]struct {
+		status domain.DeliveryStatus
+		detail string
+	}{
+		domain.EmailChannel:   {domain.DeliverySent, "message-1"},
+		domain.SmsChannel:     {domain.DeliveryFailed, "provider is down"},
+		domain.WebhookChannel: {domain.DeliveryFailed, ErrChannelNotSupported.Error()},
+	}
+	for channel, w := range want {
+		delivery := notification.GetDelivery(channel)
+		detail := delivery.Error
+		if delivery.Status == domain.DeliverySent {
+			detail = delivery.ProviderMessageID
+		}
+		if delivery.Status != w.status || detail != w.detail {
+			t.Errorf("%s = %s %q, want %s %q", channel, delivery.Status, detail, w.status, w.detail)
+		}
+	}
+
+	// The deliveries which are not pending anymore are not sent again
+	dispatcher.Dispatch(context.Background(), notification, recipient)
+	if emailSender.calls.Load() != 1 || smsSender.calls.Load() != 1 {
+		t.Errorf("calls = %d email, %d sms, want 1 each", emailSender.calls.Load(), smsSender.calls.Load())
+	}
+}
+
+func TestDispatchSkippedChannel(t *testing.T) {
+	email, _ := vo.NewEmail("john@example.org")
+	recipient := domain.DefaultRecipientPreference(uuid.New(), email)
+	sender := &testSender{}
+	dispatcher := NewDispatcher(map[domain.Channel]ChannelSender{domain.SmsChannel: sender})
+
+	notification := domain.NewNotification(recipient.GetProjectID(), email.Value(), "welcome", "en-US", nil)
+	notification.Skip(domain.SmsChannel, "recipient opted out")
+	dispatcher.Dispatch(context.Background(), notification, recipient)
+
+	if sender.calls.Load() != 0 || notification.GetDelivery(domain.SmsChannel).Status != domain.DeliverySkipped {
+		t.Errorf("skipped channel was sent: %+v", notification.GetDeliveries())
+	}
+}
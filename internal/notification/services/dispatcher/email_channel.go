@@ -0,0 +1,89 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	bounce_handler "platform/internal/notification/services/bounceHandler"
+	delivery_tracker "platform/internal/notification/services/deliveryTracker"
+	email_sender "platform/internal/notification/services/emailSender"
+	"platform/internal/notification/services/encryption"
+	template_renderer "platform/internal/notification/services/templateRenderer"
+	vo "platform/pkg/domain/value_object"
+
+	"go.uber.org/zap"
+)
+
+var ErrNoEmailAccount = errors.New("project has no email account")
+
+// EmailChannel sends notifications with the default email account of the project, recipients rejected for
+// good are added to the suppression list. The delivery log of the message has the ID of the notification.
+type EmailChannel struct {
+	encryption encryption.EncryptionService
+	repository repositories.EmailAccountRepository
+	bounces    *bounce_handler.Handler
+	tracker    *delivery_tracker.Tracker
+}
+
+func NewEmailChannel(encryption encryption.EncryptionService, repository repositories.EmailAccountRepository, bounces *bounce_handler.Handler, tracker *delivery_tracker.Tracker) *EmailChannel {
+	return &EmailChannel{
+		encryption: encryption,
+		repository: repository,
+		bounces:    bounces,
+		tracker:    tracker,
+	}
+}
+
+func (c *EmailChannel) Send(ctx context.Context, n *domain.Notification, recipient *domain.RecipientPreference) (string, error) {
+	// STEP-1: Get the email account of the project
+	ea, err := c.repository.GetDefault(ctx)
+	if err != nil {
+		return "", err
+	}
+	if ea == nil {
+		return "", ErrNoEmailAccount
+	}
+
+	// STEP-2: Render the template in the language of the recipient
+	templates, err := c.repository.GetTemplates(ctx, ea.GetID(), domain.EmailTemplateName(n.GetTemplateName()))
+	if err != nil {
+		return "", err
+	}
+	template, err := template_renderer.Select(templates, n.GetLanguage())
+	if err != nil {
+		return "", err
+	}
+	subject := template_renderer.Render(template.GetSubject(), n.GetData())
+	body := template_renderer.Render(template.GetBody(), n.GetData())
+
+	// STEP-3: Start the delivery log and add the tracking of the opens and clicks
+	delivery, err := c.tracker.Start(ctx, n.GetID(), ea, nil, recipient.GetEmail().Value(), subject)
+	if err != nil {
+		return "", err
+	}
+	body = c.tracker.Instrument(ea, delivery, body)
+
+	// STEP-4: Send the email
+	from := vo.NewAddress(ea.GetDisplayName(), ea.GetEmail())
+	to := vo.NewAddress("", recipient.GetEmail())
+	email, err := email_sender.BaseEmailDetail(subject, body, from, to)
+	if err != nil {
+		return "", err
+	}
+	email.WithMessageID(delivery_tracker.MessageIDHeader(delivery))
+	if unsubscribeURL, ok := n.GetData()[domain.UnsubscribeURLKey]; ok {
+		email.WithListUnsubscribe(unsubscribeURL)
+	}
+	result, err := email_sender.SendEmail(ctx, c.encryption, ea, email)
+	if bounceErr := c.bounces.HandleResult(ctx, result); bounceErr != nil {
+		zap.L().Error("failed to record bounced recipients", zap.Error(bounceErr))
+	}
+	if trackErr := c.tracker.Finish(ctx, delivery, result, err); trackErr != nil {
+		zap.L().Error("failed to record email delivery", zap.Error(trackErr))
+	}
+	if err != nil {
+		return "", err
+	}
+	return delivery.GetMessageID(), nil
+}
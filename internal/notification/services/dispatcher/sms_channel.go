@@ -0,0 +1,50 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	"platform/internal/notification/services/encryption"
+	sms_sender "platform/internal/notification/services/smsSender"
+)
+
+var ErrNoSmsAccount = errors.New("project has no sms account")
+
+// SmsChannel sends notifications with the default sms account of the project
+type SmsChannel struct {
+	encryption encryption.EncryptionService
+	repository repositories.SmsAccountRepository
+}
+
+func NewSmsChannel(encryption encryption.EncryptionService, repository repositories.SmsAccountRepository) *SmsChannel {
+	return &SmsChannel{
+		encryption: encryption,
+		repository: repository,
+	}
+}
+
+func (c *SmsChannel) Send(ctx context.Context, n *domain.Notification, recipient *domain.RecipientPreference) (string, error) {
+	// STEP-1: Get the sms account of the project
+	sa, err := c.repository.GetDefault(ctx)
+	if err != nil {
+		return "", err
+	}
+	if sa == nil {
+		return "", ErrNoSmsAccount
+	}
+
+	// STEP-2: Render the template in the language of the recipient
+	templates, err := c.repository.GetTemplates(ctx, sa.GetID(), domain.SmsTemplateName(n.GetTemplateName()))
+	if err != nil {
+		return "", err
+	}
+	body, err := sms_sender.RenderTemplate(templates, n.GetLanguage(), n.GetData())
+	if err != nil {
+		return "", err
+	}
+
+	// STEP-3: Send the message
+	message := sms_sender.SmsMessage{To: *recipient.GetPhone(), Body: body}
+	return sms_sender.SendSms(ctx, c.encryption, sa, &message)
+}
@@ -0,0 +1,61 @@
+package dispatcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"platform/internal/notification/domain"
+	"time"
+)
+
+// WebhookChannel posts notifications as JSON to the webhook URL of the recipient
+type WebhookChannel struct {
+	client *http.Client
+}
+
+type webhookPayload struct {
+	ID        string            `json:"id"`
+	Event     string            `json:"event"`
+	Recipient string            `json:"recipient"`
+	Language  string            `json:"language"`
+	Data      map[string]string `json:"data"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+func NewWebhookChannel() *WebhookChannel {
+	return &WebhookChannel{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (c *WebhookChannel) Send(ctx context.Context, n *domain.Notification, recipient *domain.RecipientPreference) (string, error) {
+	payload, err := json.Marshal(webhookPayload{
+		ID:        n.GetID().String(),
+		Event:     n.GetTemplateName(),
+		Recipient: n.GetRecipient(),
+		Language:  n.GetLanguage(),
+		Data:      n.GetData(),
+		CreatedAt: n.GetCreatedAt(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, recipient.GetWebhookURL(), bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Notification-ID", n.GetID().String())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return "", nil
+}
@@ -0,0 +1,204 @@
+// Package email_linter finds the mistakes of a rendered message before it is sent: placeholders left
+// without a value, links and images which cannot work in a mailbox, images too large to be displayed and
+// the missing one-click unsubscribe header.
+package email_linter
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	email_sender "platform/internal/notification/services/emailSender"
+	template_renderer "platform/internal/notification/services/templateRenderer"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// MaxImageSize is the size above which an image is reported, large images are slow to load or not shown
+	MaxImageSize = 1 << 20
+
+	// MaxHTMLSize is the size above which Gmail clips the HTML body behind a "View entire message" link
+	MaxHTMLSize = 102 * 1024
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+const (
+	RuleMissingToken       = "missing_token"
+	RuleBrokenLink         = "broken_link"
+	RuleInsecureLink       = "insecure_link"
+	RuleBrokenImage        = "broken_image"
+	RuleOversizedImage     = "oversized_image"
+	RuleOversizedHTML      = "oversized_html"
+	RuleMissingUnsubscribe = "missing_unsubscribe"
+)
+
+// Issue is a problem found in a message, the errors must be fixed while the warnings depend on the kind of
+// message, e.g. a transactional message has no unsubscribe link
+type Issue struct {
+	Rule     string
+	Severity Severity
+	Message  string
+}
+
+// Lint returns the issues of a previewed message, in the order of the rules. The links are not requested,
+// only their form is checked.
+func Lint(preview *email_sender.Preview) []Issue {
+	l := &linter{preview: preview, seen: make(map[string]bool)}
+	l.missingTokens()
+	l.html()
+	l.files()
+	if len(preview.HTML) > MaxHTMLSize {
+		l.add(RuleOversizedHTML, SeverityWarning, fmt.Sprintf("the HTML body has %d bytes, Gmail clips the bodies over %d bytes", len(preview.HTML), MaxHTMLSize))
+	}
+	if len(preview.Header["List-Unsubscribe"]) == 0 {
+		l.add(RuleMissingUnsubscribe, SeverityWarning, "the message has no List-Unsubscribe header, bulk senders must offer a one-click unsubscribe")
+	}
+	return l.issues
+}
+
+type linter struct {
+	preview *email_sender.Preview
+	issues  []Issue
+	seen    map[string]bool
+}
+
+// add records an issue once, a link used twice is reported once
+func (l *linter) add(rule string, severity Severity, message string) {
+	key := rule + "\x00" + message
+	if l.seen[key] {
+		return
+	}
+	l.seen[key] = true
+	l.issues = append(l.issues, Issue{Rule: rule, Severity: severity, Message: message})
+}
+
+func (l *linter) missingTokens() {
+	for _, part := range []struct{ name, text string }{
+		{"subject", l.preview.Subject},
+		{"HTML body", l.preview.HTML},
+		{"text body", l.preview.Text},
+	} {
+		for _, name := range template_renderer.Placeholders(part.text) {
+			l.add(RuleMissingToken, SeverityError, fmt.Sprintf("%%%s%% of the %s has no value", name, part.name))
+		}
+	}
+}
+
+// html checks the links and the images of the HTML body
+func (l *linter) html() {
+	tokenizer := html.NewTokenizer(strings.NewReader(l.preview.HTML))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.DataAtom {
+			case atom.A, atom.Area:
+				if href, ok := attribute(token, "href"); ok {
+					l.link(href)
+				}
+			case atom.Img:
+				src, _ := attribute(token, "src")
+				l.image(src)
+			}
+		}
+	}
+}
+
+func (l *linter) link(href string) {
+	href = strings.TrimSpace(href)
+	if href == "" || href == "#" {
+		l.add(RuleBrokenLink, SeverityError, "a link has no target")
+		return
+	}
+	if len(template_renderer.Placeholders(href)) > 0 {
+		// Reported as a missing token
+		return
+	}
+
+	u, err := url.Parse(href)
+	switch {
+	case err != nil:
+		l.add(RuleBrokenLink, SeverityError, fmt.Sprintf("the link %q is not a valid URL", href))
+	case u.Scheme == "mailto" || u.Scheme == "tel":
+		if u.Opaque == "" {
+			l.add(RuleBrokenLink, SeverityError, fmt.Sprintf("the link %q has no address", href))
+		}
+	case u.Scheme == "":
+		l.add(RuleBrokenLink, SeverityError, fmt.Sprintf("the link %q is relative, it has no page to be relative to in a mailbox", href))
+	case u.Scheme != "http" && u.Scheme != "https":
+		l.add(RuleBrokenLink, SeverityError, fmt.Sprintf("the link %q uses the %s scheme, mail clients only open http and https links", href, u.Scheme))
+	case u.Host == "":
+		l.add(RuleBrokenLink, SeverityError, fmt.Sprintf("the link %q has no host", href))
+	case u.Scheme == "http":
+		l.add(RuleInsecureLink, SeverityWarning, fmt.Sprintf("the link %q is not https", href))
+	}
+}
+
+func (l *linter) image(src string) {
+	src = strings.TrimSpace(src)
+	if src == "" {
+		l.add(RuleBrokenImage, SeverityError, "an image has no source")
+		return
+	}
+	if len(template_renderer.Placeholders(src)) > 0 {
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(src, "cid:"):
+		contentID := strings.TrimPrefix(src, "cid:")
+		for _, file := range l.preview.Files {
+			if file.ContentID == contentID {
+				return
+			}
+		}
+		l.add(RuleBrokenImage, SeverityError, fmt.Sprintf("the image %q has no inline part with this content ID", src))
+	case strings.HasPrefix(src, "data:"):
+		l.add(RuleBrokenImage, SeverityWarning, "an image is a data URI, Gmail and Outlook do not show them, send it as an inline image")
+		if size := dataURISize(src); size > MaxImageSize {
+			l.add(RuleOversizedImage, SeverityWarning, fmt.Sprintf("a data URI image has %d bytes, over the limit of %d", size, MaxImageSize))
+		}
+	default:
+		u, err := url.Parse(src)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			l.add(RuleBrokenImage, SeverityError, fmt.Sprintf("the image %q is not an absolute http or https URL", src))
+		}
+	}
+}
+
+// files checks the size of the inline images and the attached images
+func (l *linter) files() {
+	for _, file := range l.preview.Files {
+		if strings.HasPrefix(file.ContentType, "image/") && file.Size > MaxImageSize {
+			l.add(RuleOversizedImage, SeverityWarning, fmt.Sprintf("the image %q has %d bytes, over the limit of %d", file.Name, file.Size, MaxImageSize))
+		}
+	}
+}
+
+func attribute(token html.Token, name string) (string, bool) {
+	for _, attr := range token.Attr {
+		if attr.Key == name {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// dataURISize returns the size of the content of a data URI once decoded
+func dataURISize(uri string) int {
+	meta, data, _ := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if strings.HasSuffix(meta, ";base64") {
+		return base64.RawStdEncoding.DecodedLen(len(strings.TrimRight(data, "=")))
+	}
+	return len(data)
+}
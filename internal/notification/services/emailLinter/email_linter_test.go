@@ -0,0 +1,110 @@
+package email_linter
+
+import (
+	"strings"
+	"testing"
+
+	email_sender "platform/internal/notification/services/emailSender"
+)
+
+func TestLint(t *testing.T) {
+	unsubscribe := map[string][]string{"List-Unsubscribe": {"<https://example.org/unsubscribe>"}}
+	logo := email_sender.PreviewFile{Name: "logo.png", ContentType: "image/png", ContentID: "logo", Inline: true, Size: 2048}
+	oversizedDataURI := "data:image/png;base64," + strings.Repeat("A", MaxImageSize*2)
+
+	tests := []struct {
+		name    string
+		preview email_sender.Preview
+		want    []Issue
+	}{
+		{
+			name: "valid message",
+			preview: email_sender.Preview{
+				Header:  unsubscribe,
+				Subject: "Weekly digest",
+				HTML:    `<a href="https://example.org/news">News</a> <a href="mailto:news@example.org">Reply</a> <img src="cid:logo">`,
+				Files:   []email_sender.PreviewFile{logo},
+			},
+		},
+		{
+			name: "missing token",
+			preview: email_sender.Preview{
+				Header:  unsubscribe,
+				Subject: "Hello %FirstName%",
+				Text:    "Your code is %Code%",
+			},
+			want: []Issue{
+				{RuleMissingToken, SeverityError, "%FirstName% of the subject has no value"},
+				{RuleMissingToken, SeverityError, "%Code% of the text body has no value"},
+			},
+		},
+		{
+			name:    "relative link",
+			preview: email_sender.Preview{Header: unsubscribe, HTML: `<a href="/news">News</a>`},
+			want: []Issue{
+				{RuleBrokenLink, SeverityError, `the link "/news" is relative, it has no page to be relative to in a mailbox`},
+			},
+		},
+		{
+			name:    "javascript link",
+			preview: email_sender.Preview{Header: unsubscribe, HTML: `<a href="javascript:alert(1)">News</a>`},
+			want: []Issue{
+				{RuleBrokenLink, SeverityError, `the link "javascript:alert(1)" uses the javascript scheme, mail clients only open http and https links`},
+			},
+		},
+		{
+			name:    "link without target",
+			preview: email_sender.Preview{Header: unsubscribe, HTML: `<a href="#">News</a> <a href=" ">Blog</a>`},
+			want: []Issue{
+				{RuleBrokenLink, SeverityError, "a link has no target"},
+			},
+		},
+		{
+			name:    "http link used twice",
+			preview: email_sender.Preview{Header: unsubscribe, HTML: `<a href="http://example.org">Home</a> <a href="http://example.org">Home</a>`},
+			want: []Issue{
+				{RuleInsecureLink, SeverityWarning, `the link "http://example.org" is not https`},
+			},
+		},
+		{
+			name: "cid without part",
+			preview: email_sender.Preview{
+				Header: unsubscribe,
+				HTML:   `<img src="cid:banner">`,
+				Files:  []email_sender.PreviewFile{logo},
+			},
+			want: []Issue{
+				{RuleBrokenImage, SeverityError, `the image "cid:banner" has no inline part with this content ID`},
+			},
+		},
+		{
+			name:    "oversized data URI",
+			preview: email_sender.Preview{Header: unsubscribe, HTML: `<img src="` + oversizedDataURI + `">`},
+			want: []Issue{
+				{RuleBrokenImage, SeverityWarning, "an image is a data URI, Gmail and Outlook do not show them, send it as an inline image"},
+				{RuleOversizedImage, SeverityWarning, "a data URI image has 1572864 bytes, over the limit of 1048576"},
+				{RuleOversizedHTML, SeverityWarning, "the HTML body has 2097186 bytes, Gmail clips the bodies over 104448 bytes"},
+			},
+		},
+		{
+			name:    "missing List-Unsubscribe header",
+			preview: email_sender.Preview{Subject: "Weekly digest", HTML: `<a href="https://example.org/news">News</a>`},
+			want: []Issue{
+				{RuleMissingUnsubscribe, SeverityWarning, "the message has no List-Unsubscribe header, bulk senders must offer a one-click unsubscribe"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Lint(&tt.preview)
+			if len(got) != len(tt.want) {
+				t.Fatalf("issues = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("issue %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Package email_provider keeps the registry of the email account types. A provider knows how its accounts
+// authenticate, where the OAuth2 grants are requested and which server settings and send limits apply by
+// default, so that supporting a new provider is a single registration.
+package email_provider
+
+import (
+	"errors"
+	"platform/internal/notification/domain"
+	"sort"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrUnknownProvider          = errors.New("unknown email account type")
+	ErrProviderRegistered       = errors.New("email account type already registered")
+	ErrNotOAuth2Account         = errors.New("email account does not authenticate with OAuth2")
+	ErrOAuth2CredentialsMissing = errors.New("ClientID and ClientSecret are required, Microsoft accounts need a TenantID too")
+)
+
+// Mechanism is the SASL mechanism used to log in to the SMTP server
+type Mechanism string
+
+const (
+	MechanismPlain       Mechanism = "PLAIN"
+	MechanismXOAuth2     Mechanism = "XOAUTH2"
+	MechanismOAuthBearer Mechanism = "OAUTHBEARER"
+
+	// The HTTP APIs authenticate with the secret of the account or with an OAuth2 bearer token
+	MechanismAPIKey Mechanism = "API_KEY"
+	MechanismBearer Mechanism = "BEARER"
+)
+
+// OAuth2 reports whether the mechanism logs in with an access token
+func (m Mechanism) OAuth2() bool {
+	return m == MechanismXOAuth2 || m == MechanismOAuthBearer || m == MechanismBearer
+}
+
+// Transports delivering the messages of the accounts, see email_sender.Transport
+const (
+	TransportSMTP     = "smtp"
+	TransportSendGrid = "sendgrid"
+	TransportMailgun  = "mailgun"
+	TransportSES      = "ses"
+	TransportGmailAPI = "gmail_api"
+	TransportGraph    = "graph"
+)
+
+// Defaults are the settings used when an account does not set them. Zero limits mean unlimited.
+type Defaults struct {
+	Host         string `json:"host,omitempty"`
+	Port         int    `json:"port,omitempty"`
+	EnableSSL    bool   `json:"enable_ssl"`
+	MaxPerMinute int    `json:"max_per_minute"`
+	MaxPerDay    int    `json:"max_per_day"`
+
+	// IMAP server reading the mailbox of the accounts, empty when the provider has no known IMAP server
+	IMAPHost string `json:"imap_host,omitempty"`
+	IMAPPort int    `json:"imap_port,omitempty"`
+}
+
+type EmailProvider interface {
+	// TypeID is the type_id stored with the email accounts of the provider
+	TypeID() int
+	Name() string
+	Mechanism() Mechanism
+	Defaults() Defaults
+
+	// Transport is the name of the transport delivering the messages of the accounts
+	Transport() string
+
+	// OAuth2Config returns the configuration of the authorization flow, redirectURL is only needed to get a
+	// grant. Providers which do not use OAuth2 return ErrNotOAuth2Account.
+	OAuth2Config(clientID, tenantID, clientSecret, redirectURL string) (*oauth2.Config, error)
+}
+
+var (
+	mu        sync.RWMutex
+	providers = make(map[int]EmailProvider)
+)
+
+// Register adds a provider to the registry, it is meant to be called during the initialization
+func Register(provider EmailProvider) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := providers[provider.TypeID()]; ok {
+		return ErrProviderRegistered
+	}
+	providers[provider.TypeID()] = provider
+
+	defaults := provider.Defaults()
+	domain.SetDefaultSendLimits(provider.TypeID(), defaults.MaxPerMinute, defaults.MaxPerDay)
+	return nil
+}
+
+// MustRegister is like Register but panics when the type is already registered
+func MustRegister(provider EmailProvider) {
+	if err := Register(provider); err != nil {
+		panic(err)
+	}
+}
+
+func Get(typeID int) (EmailProvider, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	provider, ok := providers[typeID]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return provider, nil
+}
+
+// All returns the registered providers ordered by type
+func All() []EmailProvider {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	list := make([]EmailProvider, 0, len(providers))
+	for _, provider := range providers {
+		list = append(list, provider)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].TypeID() < list[j].TypeID() })
+	return list
+}
+
+// UsesOAuth2 reports whether the accounts of the type log in with an access token
+func UsesOAuth2(typeID int) bool {
+	provider, err := Get(typeID)
+	return err == nil && provider.Mechanism().OAuth2()
+}
+
+// OAuth2TypeIDs returns the types whose accounts log in with an access token
+func OAuth2TypeIDs() []int {
+	var ids []int
+	for _, provider := range All() {
+		if provider.Mechanism().OAuth2() {
+			ids = append(ids, provider.TypeID())
+		}
+	}
+	return ids
+}
+
+// OAuth2Config returns the OAuth2 configuration of the provider of the account type
+func OAuth2Config(typeID int, clientID, tenantID, clientSecret, redirectURL string) (*oauth2.Config, error) {
+	provider, err := Get(typeID)
+	if err != nil {
+		return nil, err
+	}
+	return provider.OAuth2Config(clientID, tenantID, clientSecret, redirectURL)
+}
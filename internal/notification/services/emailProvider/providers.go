@@ -0,0 +1,167 @@
+package email_provider
+
+import (
+	"fmt"
+	"platform/internal/notification/domain"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+func init() {
+	MustRegister(&PasswordProvider{
+		ID:          domain.Login,
+		DisplayName: "SMTP",
+		Settings:    Defaults{Port: 587, MaxPerMinute: 60},
+	})
+	MustRegister(&OAuth2Provider{
+		ID:            domain.GmailOAuth2,
+		DisplayName:   "Gmail",
+		AuthMechanism: MechanismXOAuth2,
+		Scopes:        []string{"https://mail.google.com/"},
+		Endpoint:      StaticEndpoint(google.Endpoint),
+		Settings:      Defaults{Host: "smtp.gmail.com", Port: 587, MaxPerMinute: 20, MaxPerDay: 2000, IMAPHost: "imap.gmail.com", IMAPPort: 993},
+	})
+	MustRegister(&OAuth2Provider{
+		ID:            domain.MicrosoftOAuth2,
+		DisplayName:   "Microsoft 365",
+		AuthMechanism: MechanismXOAuth2,
+		Scopes:        []string{"https://outlook.office365.com/SMTP.Send", "https://outlook.office365.com/IMAP.AccessAsUser.All", "offline_access"},
+		Endpoint:      microsoftEndpoint,
+		Settings:      Defaults{Host: "smtp.office365.com", Port: 587, MaxPerMinute: 30, MaxPerDay: 10000, IMAPHost: "outlook.office365.com", IMAPPort: 993},
+	})
+
+	// HTTP APIs, the host of the account is the one of the API
+	MustRegister(&APIKeyProvider{
+		ID:            domain.SendGridAPI,
+		DisplayName:   "SendGrid",
+		TransportName: TransportSendGrid,
+		Settings:      Defaults{Host: "api.sendgrid.com", Port: 443, EnableSSL: true},
+	})
+	MustRegister(&APIKeyProvider{
+		ID:            domain.MailgunAPI,
+		DisplayName:   "Mailgun",
+		TransportName: TransportMailgun,
+		Settings:      Defaults{Host: "api.mailgun.net", Port: 443, EnableSSL: true},
+	})
+	MustRegister(&APIKeyProvider{
+		ID:            domain.AmazonSES,
+		DisplayName:   "Amazon SES",
+		TransportName: TransportSES,
+		Settings:      Defaults{Host: "email.us-east-1.amazonaws.com", Port: 443, EnableSSL: true},
+	})
+	MustRegister(&OAuth2Provider{
+		ID:            domain.GmailAPI,
+		DisplayName:   "Gmail API",
+		AuthMechanism: MechanismBearer,
+		TransportName: TransportGmailAPI,
+		Scopes:        []string{"https://www.googleapis.com/auth/gmail.send"},
+		Endpoint:      StaticEndpoint(google.Endpoint),
+		Settings:      Defaults{Host: "gmail.googleapis.com", Port: 443, EnableSSL: true, MaxPerMinute: 20, MaxPerDay: 2000},
+	})
+	MustRegister(&OAuth2Provider{
+		ID:            domain.MicrosoftGraph,
+		DisplayName:   "Microsoft Graph",
+		AuthMechanism: MechanismBearer,
+		TransportName: TransportGraph,
+		Scopes:        []string{"https://graph.microsoft.com/Mail.Send", "offline_access"},
+		Endpoint:      microsoftEndpoint,
+		Settings:      Defaults{Host: "graph.microsoft.com", Port: 443, EnableSSL: true, MaxPerMinute: 30, MaxPerDay: 10000},
+	})
+}
+
+// PasswordProvider logs in with the username and the password of the account
+type PasswordProvider struct {
+	ID          int
+	DisplayName string
+	Settings    Defaults
+}
+
+func (p *PasswordProvider) TypeID() int          { return p.ID }
+func (p *PasswordProvider) Name() string         { return p.DisplayName }
+func (p *PasswordProvider) Mechanism() Mechanism { return MechanismPlain }
+func (p *PasswordProvider) Defaults() Defaults   { return p.Settings }
+func (p *PasswordProvider) Transport() string    { return TransportSMTP }
+
+func (p *PasswordProvider) OAuth2Config(clientID, tenantID, clientSecret, redirectURL string) (*oauth2.Config, error) {
+	return nil, ErrNotOAuth2Account
+}
+
+// APIKeyProvider sends with an HTTP API authenticated with the password of the account, the username holds
+// the identifier the API needs with it (the sending domain of Mailgun, the access key ID of Amazon SES)
+type APIKeyProvider struct {
+	ID            int
+	DisplayName   string
+	TransportName string
+	Settings      Defaults
+}
+
+func (p *APIKeyProvider) TypeID() int          { return p.ID }
+func (p *APIKeyProvider) Name() string         { return p.DisplayName }
+func (p *APIKeyProvider) Mechanism() Mechanism { return MechanismAPIKey }
+func (p *APIKeyProvider) Defaults() Defaults   { return p.Settings }
+func (p *APIKeyProvider) Transport() string    { return p.TransportName }
+
+func (p *APIKeyProvider) OAuth2Config(clientID, tenantID, clientSecret, redirectURL string) (*oauth2.Config, error) {
+	return nil, ErrNotOAuth2Account
+}
+
+// OAuth2Provider logs in with an access token granted by the authorization server of Endpoint
+type OAuth2Provider struct {
+	ID            int
+	DisplayName   string
+	AuthMechanism Mechanism
+	Scopes        []string
+
+	// TransportName is empty for the providers sending over SMTP
+	TransportName string
+
+	// Endpoint returns the endpoints of the authorization server, tenantID is empty for the providers
+	// which do not have tenants
+	Endpoint func(tenantID string) (oauth2.Endpoint, error)
+	Settings Defaults
+}
+
+func (p *OAuth2Provider) TypeID() int          { return p.ID }
+func (p *OAuth2Provider) Name() string         { return p.DisplayName }
+func (p *OAuth2Provider) Mechanism() Mechanism { return p.AuthMechanism }
+func (p *OAuth2Provider) Defaults() Defaults   { return p.Settings }
+
+func (p *OAuth2Provider) Transport() string {
+	if p.TransportName == "" {
+		return TransportSMTP
+	}
+	return p.TransportName
+}
+
+func (p *OAuth2Provider) OAuth2Config(clientID, tenantID, clientSecret, redirectURL string) (*oauth2.Config, error) {
+	if clientID == "" || clientSecret == "" {
+		return nil, ErrOAuth2CredentialsMissing
+	}
+	endpoint, err := p.Endpoint(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       append([]string(nil), p.Scopes...),
+		Endpoint:     endpoint,
+	}, nil
+}
+
+// StaticEndpoint is the Endpoint of the providers whose authorization server does not depend on a tenant
+func StaticEndpoint(endpoint oauth2.Endpoint) func(string) (oauth2.Endpoint, error) {
+	return func(string) (oauth2.Endpoint, error) { return endpoint, nil }
+}
+
+func microsoftEndpoint(tenantID string) (oauth2.Endpoint, error) {
+	if tenantID == "" {
+		return oauth2.Endpoint{}, ErrOAuth2CredentialsMissing
+	}
+	return oauth2.Endpoint{
+		AuthURL:  fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/authorize", tenantID),
+		TokenURL: fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", tenantID),
+	}, nil
+}
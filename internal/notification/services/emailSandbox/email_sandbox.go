@@ -0,0 +1,72 @@
+// Package email_sandbox keeps the messages of local and staging environments from reaching real
+// recipients: they are captured, like a built-in SMTP catcher, or redirected to an allowed address.
+package email_sandbox
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	"platform/internal/shared"
+
+	"github.com/google/uuid"
+)
+
+// Service gives the sandbox settings to the email sender and stores the captured messages. The settings of
+// a project override the default of the environment, but only to make it stricter: a sandboxed environment
+// cannot be turned off by a project.
+type Service struct {
+	defaults                *domain.EmailSandbox
+	sandboxRepository       repositories.EmailSandboxRepository
+	capturedEmailRepository repositories.CapturedEmailRepository
+}
+
+// NewService creates the sandbox service, mode is the default of the environment for the projects which have
+// no settings of their own
+func NewService(
+	mode domain.SandboxMode,
+	allowList []string,
+	redirectTo string,
+	sandboxRepository repositories.EmailSandboxRepository,
+	capturedEmailRepository repositories.CapturedEmailRepository,
+) *Service {
+	if mode == "" {
+		mode = domain.SandboxOff
+	}
+	return &Service{
+		defaults:                domain.NewEmailSandbox(uuid.Nil, mode, allowList, redirectTo),
+		sandboxRepository:       sandboxRepository,
+		capturedEmailRepository: capturedEmailRepository,
+	}
+}
+
+// Default returns the settings of the projects which have none
+func (s *Service) Default() *domain.EmailSandbox {
+	return s.defaults
+}
+
+// Get returns the sandbox settings of the project of the account, or nil when its messages are delivered
+func (s *Service) Get(ctx context.Context, account *domain.EmailAccount) (*domain.EmailSandbox, error) {
+	sandbox, err := s.sandboxRepository.Get(projectContext(ctx, account.GetProjectID()))
+	if err != nil {
+		return nil, err
+	}
+	if sandbox == nil {
+		sandbox = s.defaults
+	} else {
+		sandbox = sandbox.Tighten(s.defaults)
+	}
+	if sandbox.GetMode() == domain.SandboxOff {
+		return nil, nil
+	}
+	return sandbox, nil
+}
+
+// Capture stores a message of the project instead of delivering it
+func (s *Service) Capture(ctx context.Context, email *domain.CapturedEmail) error {
+	return s.capturedEmailRepository.Save(projectContext(ctx, email.GetProjectID()), email)
+}
+
+// projectContext scopes the repositories to the project of an account
+func projectContext(ctx context.Context, projectID uuid.UUID) context.Context {
+	return context.WithValue(ctx, shared.ProjectIDContextKey, projectID)
+}
@@ -18,7 +18,7 @@ type Base64LineWriter struct {
 // NewBase64LineWriter creates a new Base64LineWriter that writes to the given
 // io.Writer. It ensures that lines do not exceed "lineBreakByteLimit" bytes by inserting
 // a CRLF after each full chunk.
-func NewBase64LineWriter(w io.Writer) io.Writer {
+func NewBase64LineWriter(w io.Writer) io.WriteCloser {
 	return &Base64LineWriter{
 		w:      w,
 		buffer: make([]byte, 0, lineBreakByteLimit),
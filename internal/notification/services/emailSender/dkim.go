@@ -0,0 +1,304 @@
+package email_sender
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	voInternal "platform/internal/notification/domain/value_object"
+	"platform/internal/notification/services/encryption"
+)
+
+var (
+	ErrInvalidDkimKey        = errors.New("DKIM private key must be a PEM encoded RSA or Ed25519 key")
+	ErrUnsupportedDkimKey    = errors.New("DKIM algorithm must be rsa or ed25519")
+	ErrDkimAlgorithmMismatch = errors.New("DKIM private key does not match the algorithm")
+	ErrInvalidDkimDomain     = errors.New("DKIM domain must be a domain name")
+	ErrInvalidDkimSelector   = errors.New("DKIM selector must be dot separated labels of letters, digits and hyphens")
+)
+
+// dkimName matches the domain and the selector written in the tags of the signature and in the DNS record name,
+// anything else could end the tag or the header
+var dkimName = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`)
+
+// dkimSignedHeaders are the headers covered by the signature when they are present in the message
+var dkimSignedHeaders = []string{
+	"from", "reply-to", "to", "cc", "subject", "date", "message-id",
+	"mime-version", "content-type", "list-unsubscribe", "list-unsubscribe-post",
+}
+
+// DKIMSigner adds a DKIM-Signature header (RFC 6376) to the messages, the header and the body are
+// canonicalized with the relaxed algorithm
+type DKIMSigner struct {
+	domain    string
+	selector  string
+	algorithm string
+	key       crypto.Signer
+}
+
+func NewDKIMSigner(domain, selector string, key crypto.Signer) (*DKIMSigner, error) {
+	if err := checkDKIMNames(domain, selector); err != nil {
+		return nil, err
+	}
+	algorithm, err := dkimAlgorithm(key)
+	if err != nil {
+		return nil, err
+	}
+	return &DKIMSigner{domain: domain, selector: selector, algorithm: algorithm, key: key}, nil
+}
+
+// Sign returns the message with its DKIM-Signature header, the message must use CRLF line endings
+func (s *DKIMSigner) Sign(message []byte) ([]byte, error) {
+	header, body, _ := bytes.Cut(message, []byte("\r\n\r\n"))
+	fields := splitHeaderFields(string(header))
+
+	// STEP-1: Hash the canonical body
+	bodyHash := sha256.Sum256(canonicalBodyRelaxed(body))
+
+	// STEP-2: Pick the signed headers, a field occurring several times is listed once per occurrence and
+	// verifiers take them from the bottom up
+	var names []string
+	var signed strings.Builder
+	for _, name := range dkimSignedHeaders {
+		for i := len(fields) - 1; i >= 0; i-- {
+			if fieldName(fields[i]) == name {
+				names = append(names, name)
+				signed.WriteString(canonicalHeaderRelaxed(fields[i]))
+			}
+		}
+	}
+
+	// STEP-3: Sign the headers followed by the signature header with an empty b= tag
+	signatureField := fmt.Sprintf("DKIM-Signature: v=1; a=%s-sha256; c=relaxed/relaxed; d=%s; s=%s;\r\n\tt=%d; h=%s;\r\n\tbh=%s;\r\n\tb=",
+		s.algorithm, s.domain, s.selector, time.Now().Unix(), strings.Join(names, ":"), base64.StdEncoding.EncodeToString(bodyHash[:]))
+	signed.WriteString(strings.TrimSuffix(canonicalHeaderRelaxed(signatureField), "\r\n"))
+	hashed := sha256.Sum256([]byte(signed.String()))
+
+	var opts crypto.SignerOpts = crypto.SHA256
+	if s.algorithm == voInternal.DkimEd25519 {
+		// RFC 8463 signs the SHA-256 hash with PureEdDSA
+		opts = crypto.Hash(0)
+	}
+	signature, err := s.key.Sign(rand.Reader, hashed[:], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-4: Prepend the signature header to the message
+	var signedMessage bytes.Buffer
+	signedMessage.Grow(len(signatureField) + len(message) + 256)
+	signedMessage.WriteString(signatureField)
+	signedMessage.WriteString(base64.StdEncoding.EncodeToString(signature))
+	signedMessage.WriteString("\r\n")
+	signedMessage.Write(message)
+	return signedMessage.Bytes(), nil
+}
+
+// ParseDKIMPrivateKey reads a PEM encoded PKCS #8 or PKCS #1 private key
+func ParseDKIMPrivateKey(pemKey string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, ErrInvalidDkimKey
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		switch key := key.(type) {
+		case *rsa.PrivateKey:
+			return key, nil
+		case ed25519.PrivateKey:
+			return key, nil
+		}
+		return nil, ErrInvalidDkimKey
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, ErrInvalidDkimKey
+}
+
+// GenerateDKIMKey creates a PEM encoded PKCS #8 private key, RSA keys are 2048 bits long
+func GenerateDKIMKey(algorithm string) (string, error) {
+	var key any
+	var err error
+	switch algorithm {
+	case voInternal.DkimRSA:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case voInternal.DkimEd25519:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return "", ErrUnsupportedDkimKey
+	}
+	if err != nil {
+		return "", err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// CheckDKIMKey reports an error when the PEM key cannot be parsed or does not match the algorithm
+func CheckDKIMKey(algorithm, pemKey string) error {
+	key, err := ParseDKIMPrivateKey(pemKey)
+	if err != nil {
+		return err
+	}
+	keyAlgorithm, err := dkimAlgorithm(key)
+	if err != nil {
+		return err
+	}
+	if keyAlgorithm != algorithm {
+		return ErrDkimAlgorithmMismatch
+	}
+	return nil
+}
+
+// DKIMRecord returns the name and the value of the DNS TXT record publishing the public key
+func DKIMRecord(domain, selector, pemKey string) (name, value string, err error) {
+	if err := checkDKIMNames(domain, selector); err != nil {
+		return "", "", err
+	}
+	key, err := ParseDKIMPrivateKey(pemKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	var publicKey []byte
+	switch pub := key.Public().(type) {
+	case *rsa.PublicKey:
+		publicKey, err = x509.MarshalPKIXPublicKey(pub)
+		if err != nil {
+			return "", "", err
+		}
+	case ed25519.PublicKey:
+		publicKey = pub
+	}
+
+	algorithm, err := dkimAlgorithm(key)
+	if err != nil {
+		return "", "", err
+	}
+	name = fmt.Sprintf("%s._domainkey.%s", selector, domain)
+	value = fmt.Sprintf("v=DKIM1; k=%s; p=%s", algorithm, base64.StdEncoding.EncodeToString(publicKey))
+	return name, value, nil
+}
+
+// newDKIMSigner returns the signer of the account, or nil when DKIM is not configured
+func newDKIMSigner(encryption encryption.EncryptionService, settings *voInternal.DkimSettings) (*DKIMSigner, error) {
+	if settings == nil {
+		return nil, nil
+	}
+	domain, selector, _, encryptedKey := settings.Settings()
+	if domain == "" || encryptedKey == "" {
+		return nil, nil
+	}
+
+	pemKey, err := encryption.Decrypt(encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ParseDKIMPrivateKey(pemKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewDKIMSigner(domain, selector, key)
+}
+
+func checkDKIMNames(domain, selector string) error {
+	if len(domain) > 253 || !dkimName.MatchString(domain) {
+		return ErrInvalidDkimDomain
+	}
+	if !dkimName.MatchString(selector) {
+		return ErrInvalidDkimSelector
+	}
+	return nil
+}
+
+func dkimAlgorithm(key crypto.Signer) (string, error) {
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		return voInternal.DkimRSA, nil
+	case ed25519.PrivateKey:
+		return voInternal.DkimEd25519, nil
+	}
+	return "", ErrUnsupportedDkimKey
+}
+
+// splitHeaderFields returns the header fields with their folded lines
+func splitHeaderFields(header string) []string {
+	var fields []string
+	for _, line := range strings.Split(header, "\r\n") {
+		if len(fields) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			fields[len(fields)-1] += "\r\n" + line
+			continue
+		}
+		fields = append(fields, line)
+	}
+	return fields
+}
+
+func fieldName(field string) string {
+	name, _, _ := strings.Cut(field, ":")
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// canonicalHeaderRelaxed lowercases the name, unfolds the value and reduces its whitespaces to one space
+func canonicalHeaderRelaxed(field string) string {
+	name, value, _ := strings.Cut(field, ":")
+	value = strings.ReplaceAll(value, "\r\n", "")
+	value = strings.Join(strings.FieldsFunc(value, isWSP), " ")
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + value + "\r\n"
+}
+
+// canonicalBodyRelaxed reduces the whitespaces of the lines to one space, drops the trailing whitespaces and
+// the empty lines at the end of the body
+func canonicalBodyRelaxed(body []byte) []byte {
+	lines := strings.Split(string(body), "\r\n")
+	for i, line := range lines {
+		trimmed := strings.TrimRight(line, " \t")
+		if strings.ContainsAny(trimmed, " \t") {
+			// Keep a leading whitespace, it is reduced to one space like the other ones
+			lead := ""
+			if trimmed != strings.TrimLeft(trimmed, " \t") {
+				lead = " "
+			}
+			trimmed = lead + strings.Join(strings.FieldsFunc(trimmed, isWSP), " ")
+		}
+		lines[i] = trimmed
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+func isWSP(r rune) bool {
+	return r == ' ' || r == '\t'
+}
+
+// DKIMZoneEntry formats the TXT record for a zone file, the value is split in strings of 255 characters at
+// most since RSA keys do not fit in a single one
+func DKIMZoneEntry(name, value string) string {
+	var chunks []string
+	for len(value) > 255 {
+		chunks = append(chunks, fmt.Sprintf("%q", value[:255]))
+		value = value[255:]
+	}
+	chunks = append(chunks, fmt.Sprintf("%q", value))
+	return fmt.Sprintf("%s. IN TXT ( %s )", name, strings.Join(chunks, " "))
+}
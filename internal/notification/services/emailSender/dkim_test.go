@@ -0,0 +1,221 @@
+package email_sender
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	voInternal "platform/internal/notification/domain/value_object"
+)
+
+const dkimMessage = "From: Beecraft <news@example.org>\r\n" +
+	"To: john@example.com\r\n" +
+	"Subject:   Weekly\r\n  digest\r\n" +
+	"Date: Mon, 19 Oct 2026 10:00:00 +0000\r\n" +
+	"X-Mailer: beecraft\r\n" +
+	"\r\n" +
+	"Hello  John, \r\n" +
+	"\r\n" +
+	"See you next week.\r\n" +
+	"\r\n"
+
+func TestDKIMSignVerify(t *testing.T) {
+	for _, algorithm := range []string{voInternal.DkimRSA, voInternal.DkimEd25519} {
+		t.Run(algorithm, func(t *testing.T) {
+			pemKey, err := GenerateDKIMKey(algorithm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, err := ParseDKIMPrivateKey(pemKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			signer, err := NewDKIMSigner("example.org", "s2026", key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			signed, err := signer.Sign([]byte(dkimMessage))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, record, err := DKIMRecord("example.org", "s2026", pemKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := verifyDKIM(signed, record); err != nil {
+				t.Fatalf("signature does not verify: %v\n%s", err, signed)
+			}
+
+			// A changed body or signed header breaks the signature, an unsigned header does not
+			if err := verifyDKIM(bytes.Replace(signed, []byte("next week"), []byte("tomorrow"), 1), record); err == nil {
+				t.Error("changed body verifies")
+			}
+			if err := verifyDKIM(bytes.Replace(signed, []byte("Weekly"), []byte("Daily"), 1), record); err == nil {
+				t.Error("changed subject verifies")
+			}
+			if err := verifyDKIM(bytes.Replace(signed, []byte("X-Mailer: beecraft"), []byte("X-Mailer: other"), 1), record); err != nil {
+				t.Errorf("changed unsigned header does not verify: %v", err)
+			}
+		})
+	}
+}
+
+// verifyDKIM checks the DKIM-Signature header prepended by the signer against the public key of the TXT record
+func verifyDKIM(message []byte, record string) error {
+	header, body, _ := bytes.Cut(message, []byte("\r\n\r\n"))
+	fields := splitHeaderFields(string(header))
+	signatureField := fields[0]
+	_, tagList, _ := strings.Cut(signatureField, ":")
+	tags := dkimTags(tagList)
+
+	bodyHash := sha256.Sum256(canonicalBodyRelaxed(body))
+	if tags["bh"] != base64.StdEncoding.EncodeToString(bodyHash[:]) {
+		return errors.New("body hash mismatch")
+	}
+
+	// The signed fields are taken from the bottom up, each one once
+	used := make(map[int]bool)
+	var signed strings.Builder
+	for _, name := range strings.Split(tags["h"], ":") {
+		for i := len(fields) - 1; i > 0; i-- {
+			if !used[i] && fieldName(fields[i]) == name {
+				used[i] = true
+				signed.WriteString(canonicalHeaderRelaxed(fields[i]))
+				break
+			}
+		}
+	}
+	withoutSignature := signatureField[:strings.LastIndex(signatureField, "b=")+2]
+	signed.WriteString(strings.TrimSuffix(canonicalHeaderRelaxed(withoutSignature), "\r\n"))
+	hashed := sha256.Sum256([]byte(signed.String()))
+
+	signature, err := base64.StdEncoding.DecodeString(tags["b"])
+	if err != nil {
+		return err
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(dkimTags(record)["p"])
+	if err != nil {
+		return err
+	}
+	switch tags["a"] {
+	case "rsa-sha256":
+		pub, err := x509.ParsePKIXPublicKey(publicKey)
+		if err != nil {
+			return err
+		}
+		return rsa.VerifyPKCS1v15(pub.(*rsa.PublicKey), crypto.SHA256, hashed[:], signature)
+	case "ed25519-sha256":
+		if !ed25519.Verify(publicKey, hashed[:], signature) {
+			return errors.New("ed25519 signature mismatch")
+		}
+		return nil
+	}
+	return errors.New("unknown algorithm " + tags["a"])
+}
+
+// dkimTags reads a tag list of a signature or of a record, its whitespaces are ignored
+func dkimTags(tagList string) map[string]string {
+	value := strings.Join(strings.Fields(tagList), "")
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(value, ";") {
+		name, tagValue, _ := strings.Cut(tag, "=")
+		tags[name] = tagValue
+	}
+	return tags
+}
+
+func TestDKIMRelaxedCanonicalization(t *testing.T) {
+	// The example of RFC 6376 section 3.4.6
+	var header strings.Builder
+	for _, field := range splitHeaderFields("A: X\r\nB : Y\t\r\n\tZ  ") {
+		header.WriteString(canonicalHeaderRelaxed(field))
+	}
+	if got, want := header.String(), "a:X\r\nb:Y Z\r\n"; got != want {
+		t.Errorf("header = %q, want %q", got, want)
+	}
+
+	bodies := []struct {
+		body string
+		want string
+	}{
+		{" C \r\nD \t E\r\n\r\n\r\n", " C\r\nD E\r\n"},
+		{"", ""},
+		{"\r\n\r\n", ""},
+		{"line", "line\r\n"},
+		{"\t\tindented  text\t\r\n", " indented text\r\n"},
+	}
+	for _, tt := range bodies {
+		if got := string(canonicalBodyRelaxed([]byte(tt.body))); got != tt.want {
+			t.Errorf("body %q = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestDKIMRecord(t *testing.T) {
+	for _, algorithm := range []string{voInternal.DkimRSA, voInternal.DkimEd25519} {
+		t.Run(algorithm, func(t *testing.T) {
+			pemKey, err := GenerateDKIMKey(algorithm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			name, value, err := DKIMRecord("mail.example.org", "s1", pemKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if name != "s1._domainkey.mail.example.org" {
+				t.Errorf("name = %q", name)
+			}
+			if !strings.HasPrefix(value, "v=DKIM1; k="+algorithm+"; p=") {
+				t.Errorf("value = %q", value)
+			}
+			if err := CheckDKIMKey(algorithm, pemKey); err != nil {
+				t.Errorf("CheckDKIMKey = %v", err)
+			}
+		})
+	}
+
+	pemKey, err := GenerateDKIMKey(voInternal.DkimEd25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckDKIMKey(voInternal.DkimRSA, pemKey); !errors.Is(err, ErrDkimAlgorithmMismatch) {
+		t.Errorf("CheckDKIMKey with another algorithm = %v", err)
+	}
+	if _, _, err := DKIMRecord("example.org", "s1", "not a key"); !errors.Is(err, ErrInvalidDkimKey) {
+		t.Errorf("DKIMRecord with an invalid key = %v", err)
+	}
+
+	invalid := []struct {
+		domain   string
+		selector string
+		err      error
+	}{
+		{"example.org; h=from", "s1", ErrInvalidDkimDomain},
+		{"example.org\r\nBcc: eve@example.com", "s1", ErrInvalidDkimDomain},
+		{"-example.org", "s1", ErrInvalidDkimDomain},
+		{"example.org", "s1; d=evil.example", ErrInvalidDkimSelector},
+		{"example.org", "s1\r\n", ErrInvalidDkimSelector},
+		{"example.org", "", ErrInvalidDkimSelector},
+		{"example.org", "a..b", ErrInvalidDkimSelector},
+	}
+	key, err := ParseDKIMPrivateKey(pemKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range invalid {
+		if _, _, err := DKIMRecord(tt.domain, tt.selector, pemKey); !errors.Is(err, tt.err) {
+			t.Errorf("DKIMRecord(%q, %q) = %v, want %v", tt.domain, tt.selector, err, tt.err)
+		}
+		if _, err := NewDKIMSigner(tt.domain, tt.selector, key); !errors.Is(err, tt.err) {
+			t.Errorf("NewDKIMSigner(%q, %q) = %v, want %v", tt.domain, tt.selector, err, tt.err)
+		}
+	}
+}
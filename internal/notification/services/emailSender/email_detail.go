@@ -2,6 +2,7 @@ package email_sender
 
 import (
 	"errors"
+	"io"
 	vo "platform/pkg/domain/value_object"
 )
 
@@ -10,21 +11,35 @@ var (
 	ErrBodyRequired    = errors.New("body is required")
 )
 
+// Attachment is a file sent with a message, Content is read once when the message is built. Inline images
+// have a ContentID which the HTML body references as "cid:<ContentID>".
+type Attachment struct {
+	Name        string
+	ContentType string
+	ContentID   string
+	Content     io.Reader
+}
+
 type EmailDetail struct {
 	subject            string
 	body               string
-	from               vo.Email
-	to                 vo.Email
-	replyTo            *vo.Email
-	cc                 []string
-	bcc                []string
+	textBody           string
+	from               vo.Address
+	to                 vo.Address
+	replyTo            *vo.Address
+	cc                 []vo.Address
+	bcc                []vo.Address
+	attachments        []Attachment
+	inlineImages       []Attachment
 	attachmentFilePath *string
 	attachmentFileName *string
 	attachedDownloadId *int
 	headers            map[string]string
+	listUnsubscribeURL string
+	messageID          string
 }
 
-func BaseEmailDetail(subject, body string, from, to vo.Email) (*EmailDetail, error) {
+func BaseEmailDetail(subject, body string, from, to vo.Address) (*EmailDetail, error) {
 	if subject == "" {
 		return nil, ErrSubjectRequired
 	}
@@ -37,27 +52,44 @@ func BaseEmailDetail(subject, body string, from, to vo.Email) (*EmailDetail, err
 		body:    body,
 		from:    from,
 		to:      to,
-		cc:      []string{},
-		bcc:     []string{},
+		cc:      []vo.Address{},
+		bcc:     []vo.Address{},
 		headers: make(map[string]string),
 	}, nil
 }
 
-func (ed *EmailDetail) WithReplyTo(replyTo *vo.Email) *EmailDetail {
+func (ed *EmailDetail) WithReplyTo(replyTo *vo.Address) *EmailDetail {
 	ed.replyTo = replyTo
 	return ed
 }
 
-func (ed *EmailDetail) WithCc(cc []string) *EmailDetail {
+func (ed *EmailDetail) WithCc(cc []vo.Address) *EmailDetail {
 	ed.cc = cc
 	return ed
 }
 
-func (ed *EmailDetail) WithBcc(bcc []string) *EmailDetail {
+func (ed *EmailDetail) WithBcc(bcc []vo.Address) *EmailDetail {
 	ed.bcc = bcc
 	return ed
 }
 
+// WithTextBody sets the plain text alternative of the HTML body, it is generated from the HTML otherwise
+func (ed *EmailDetail) WithTextBody(text string) *EmailDetail {
+	ed.textBody = text
+	return ed
+}
+
+func (ed *EmailDetail) WithAttachment(attachment Attachment) *EmailDetail {
+	ed.attachments = append(ed.attachments, attachment)
+	return ed
+}
+
+// WithInlineImage adds an image displayed in the HTML body, the attachment must have a ContentID
+func (ed *EmailDetail) WithInlineImage(image Attachment) *EmailDetail {
+	ed.inlineImages = append(ed.inlineImages, image)
+	return ed
+}
+
 func (ed *EmailDetail) WithAttachmentFilePath(path *string) *EmailDetail {
 	ed.attachmentFilePath = path
 	return ed
@@ -77,3 +109,28 @@ func (ed *EmailDetail) WithHeaders(headers map[string]string) *EmailDetail {
 	ed.headers = headers
 	return ed
 }
+
+// WithListUnsubscribe adds the RFC 8058 one-click unsubscribe headers, url must accept POST requests
+func (ed *EmailDetail) WithListUnsubscribe(url string) *EmailDetail {
+	ed.listUnsubscribeURL = url
+	return ed
+}
+
+// WithMessageID sets the Message-ID header, e.g. "<id@example.com>", a random one is generated otherwise
+func (ed *EmailDetail) WithMessageID(messageID string) *EmailDetail {
+	ed.messageID = messageID
+	return ed
+}
+
+// recipients returns the envelope recipients, Bcc addresses are not written in the headers
+func (ed *EmailDetail) recipients() []string {
+	list := make([]string, 0, 1+len(ed.cc)+len(ed.bcc))
+	list = append(list, ed.to.Email().Value())
+	for _, addr := range ed.cc {
+		list = append(list, addr.Email().Value())
+	}
+	for _, addr := range ed.bcc {
+		list = append(list, addr.Email().Value())
+	}
+	return list
+}
@@ -0,0 +1,68 @@
+package email_sender
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/smtp"
+	"platform/internal/notification/domain"
+	"testing"
+)
+
+// greetingServer answers the greeting and EHLO of a client without extensions, then waits for the client to
+// hang up
+func greetingServer(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	conn.Write([]byte("220 mail.example.com ESMTP\r\n"))
+	if _, err := reader.ReadString('\n'); err != nil {
+		return
+	}
+	conn.Write([]byte("250 mail.example.com\r\n"))
+	reader.ReadString('\n')
+}
+
+func checkConfigurationError(t *testing.T, err error) {
+	t.Helper()
+	var sendErr *SendError
+	if !errors.As(err, &sendErr) || sendErr.Category != ErrorConfiguration || !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("error = %v, want a %s error", err, ErrorConfiguration)
+	}
+	if sendErr.Temporary() {
+		t.Error("a configuration error is temporary")
+	}
+}
+
+func TestSendWithoutCredentials(t *testing.T) {
+	t.Run("smtp", func(t *testing.T) {
+		account := testAccount(t, domain.Login, "mail.example.com", "", "")
+		account.SetTraditionalCredentials(nil)
+
+		server, conn := net.Pipe()
+		go greetingServer(server)
+		client, err := smtp.NewClient(conn, "mail.example.com")
+		if err != nil {
+			t.Fatalf("client: %v", err)
+		}
+		defer client.Close()
+
+		checkConfigurationError(t, authenticate(context.Background(), client, plainEncryption{}, memoryTokenSource{}, account, nil))
+	})
+
+	t.Run("api key", func(t *testing.T) {
+		account := testAccount(t, domain.SendGridAPI, "api.sendgrid.com", "", "")
+		account.SetTraditionalCredentials(nil)
+
+		_, err := NewSendGridTransport().Deliver(context.Background(), testDelivery(t, account))
+		checkConfigurationError(t, err)
+	})
+
+	t.Run("oauth2", func(t *testing.T) {
+		account := testAccount(t, domain.GmailOAuth2, "smtp.gmail.com", "", "")
+		account.SetTraditionalCredentials(nil)
+
+		_, err := memoryTokenSource{}.Token(context.Background(), account)
+		checkConfigurationError(t, err)
+	})
+}
@@ -0,0 +1,161 @@
+package email_sender
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"regexp"
+	"strings"
+)
+
+type ErrorCategory string
+
+const (
+	// ErrorTransient is a 4xx reply, a network failure or a timeout, sending again later may succeed
+	ErrorTransient ErrorCategory = "transient"
+
+	// ErrorPermanent is a 5xx reply, sending the same message again will fail
+	ErrorPermanent ErrorCategory = "permanent"
+
+	// ErrorAuth means the credentials or the token of the email account were refused
+	ErrorAuth ErrorCategory = "auth"
+
+	// ErrorTLS means the TLS handshake failed, e.g. because of an invalid certificate
+	ErrorTLS ErrorCategory = "tls"
+
+	// ErrorRecipient means every recipient of the message was rejected, see SendResult.Rejected
+	ErrorRecipient ErrorCategory = "recipient"
+
+	// ErrorConfiguration means the email account lacks a setting its provider needs, such as its credentials,
+	// sending fails until the account is changed
+	ErrorConfiguration ErrorCategory = "configuration"
+)
+
+var ErrNoCredentials = errors.New("the email account has no credentials")
+
+// enhancedCodePattern matches the RFC 3463 status code written at the beginning of a reply, e.g. 5.1.1
+var enhancedCodePattern = regexp.MustCompile(`^([245]\.\d{1,3}\.\d{1,3})\b`)
+
+// SendError is the error returned when a message cannot be sent
+type SendError struct {
+	Category     ErrorCategory
+	Code         int
+	EnhancedCode string
+	Message      string
+	err          error
+}
+
+func (e *SendError) Error() string {
+	if e.Code == 0 {
+		return fmt.Sprintf("%s: %s", e.Category, e.Message)
+	}
+	return fmt.Sprintf("%s: %d %s", e.Category, e.Code, e.Message)
+}
+
+func (e *SendError) Unwrap() error {
+	return e.err
+}
+
+// Temporary reports whether sending the message again later may succeed
+func (e *SendError) Temporary() bool {
+	if e.Code != 0 {
+		return e.Code >= 400 && e.Code < 500
+	}
+	return e.Category == ErrorTransient
+}
+
+// Rejection is a recipient refused by the server
+type Rejection struct {
+	Recipient string
+	Err       *SendError
+}
+
+// HardBounce reports whether the address does not exist or cannot receive messages anymore
+func (r Rejection) HardBounce() bool {
+	return IsHardBounce(r.Err.Code, r.Err.EnhancedCode)
+}
+
+// SendResult reports the recipients the server accepted, a message is sent when at least one of them is.
+// MessageID is the identifier the HTTP APIs assign to the message.
+type SendResult struct {
+	Accepted  []string
+	Rejected  []Rejection
+	MessageID string
+}
+
+// IsHardBounce reports whether the reply means the address is invalid for good: bad mailbox, domain or
+// syntax (5.1.x) and disabled mailbox (5.2.1). Replies without an enhanced code rely on the reply code.
+func IsHardBounce(code int, enhancedCode string) bool {
+	if enhancedCode != "" {
+		return strings.HasPrefix(enhancedCode, "5.1.") || enhancedCode == "5.2.1"
+	}
+	return code == 550 || code == 551 || code == 553
+}
+
+// classifyError converts err into a *SendError, nil stays nil
+func classifyError(err error, fallback ErrorCategory) error {
+	if err == nil {
+		return nil
+	}
+	return toSendError(err, fallback)
+}
+
+// toSendError converts err into a SendError, fallback is used for the errors which are not SMTP replies
+// nor network failures, such as the errors of the authentication step
+func toSendError(err error, fallback ErrorCategory) *SendError {
+	var sendErr *SendError
+	if errors.As(err, &sendErr) {
+		return sendErr
+	}
+
+	// SMTP replies
+	var replyErr *textproto.Error
+	if errors.As(err, &replyErr) {
+		result := &SendError{Code: replyErr.Code, Message: replyErr.Msg, err: err}
+		if match := enhancedCodePattern.FindStringSubmatch(replyErr.Msg); match != nil {
+			result.EnhancedCode = match[1]
+			result.Message = strings.TrimSpace(replyErr.Msg[len(match[0]):])
+		}
+		switch {
+		case fallback == ErrorAuth || fallback == ErrorTLS:
+			result.Category = fallback
+		case replyErr.Code >= 400 && replyErr.Code < 500:
+			result.Category = ErrorTransient
+		default:
+			result.Category = ErrorPermanent
+		}
+		return result
+	}
+
+	// TLS handshake and certificates
+	if isTLSError(err) {
+		return &SendError{Category: ErrorTLS, Message: err.Error(), err: err}
+	}
+
+	// Network failures, timeouts and cancellation
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return &SendError{Category: ErrorTransient, Message: err.Error(), err: err}
+	}
+
+	return &SendError{Category: fallback, Message: err.Error(), err: err}
+}
+
+func isTLSError(err error) bool {
+	var recordErr tls.RecordHeaderError
+	var alertErr tls.AlertError
+	var verificationErr *tls.CertificateVerificationError
+	var hostnameErr x509.HostnameError
+	var authorityErr x509.UnknownAuthorityError
+	var certificateErr x509.CertificateInvalidError
+	return errors.As(err, &recordErr) ||
+		errors.As(err, &alertErr) ||
+		errors.As(err, &verificationErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &authorityErr) ||
+		errors.As(err, &certificateErr)
+}
@@ -0,0 +1,44 @@
+package email_sender
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+)
+
+// GmailAPITransport sends the raw message with users.messages.send of the Gmail API
+type GmailAPITransport struct {
+	baseURL string
+}
+
+func NewGmailAPITransport() *GmailAPITransport {
+	return &GmailAPITransport{}
+}
+
+// WithBaseURL points the transport to another endpoint, e.g. a test server
+func (t *GmailAPITransport) WithBaseURL(baseURL string) *GmailAPITransport {
+	t.baseURL = strings.TrimSuffix(baseURL, "/")
+	return t
+}
+
+func (t *GmailAPITransport) Deliver(ctx context.Context, delivery *Delivery) (*SendResult, error) {
+	token, err := delivery.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]string{"raw": base64.URLEncoding.EncodeToString(delivery.withBcc())}
+	req, _, err := newJSONRequest(ctx, baseURL(t.baseURL, delivery.Account)+"/gmail/v1/users/me/messages/send", payload)
+	if err != nil {
+		return nil, classifyError(err, ErrorPermanent)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	if _, err := doJSON(req, "gmail", &result); err != nil {
+		return nil, err
+	}
+	return delivery.accepted(result.ID), nil
+}
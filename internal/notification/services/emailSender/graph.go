@@ -0,0 +1,45 @@
+package email_sender
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+)
+
+// GraphTransport sends the raw message with sendMail of Microsoft Graph, the API does not return an
+// identifier of the message
+type GraphTransport struct {
+	baseURL string
+}
+
+func NewGraphTransport() *GraphTransport {
+	return &GraphTransport{}
+}
+
+// WithBaseURL points the transport to another endpoint, e.g. a test server
+func (t *GraphTransport) WithBaseURL(baseURL string) *GraphTransport {
+	t.baseURL = strings.TrimSuffix(baseURL, "/")
+	return t
+}
+
+func (t *GraphTransport) Deliver(ctx context.Context, delivery *Delivery) (*SendResult, error) {
+	token, err := delivery.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The MIME message is sent base64 encoded as a text/plain body
+	body := base64.StdEncoding.EncodeToString(delivery.withBcc())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL(t.baseURL, delivery.Account)+"/v1.0/me/sendMail", strings.NewReader(body))
+	if err != nil {
+		return nil, classifyError(err, ErrorPermanent)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "text/plain")
+
+	if _, err := doJSON(req, "graph", nil); err != nil {
+		return nil, err
+	}
+	return delivery.accepted(""), nil
+}
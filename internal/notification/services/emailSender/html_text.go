@@ -0,0 +1,139 @@
+package email_sender
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// htmlToText returns the plain text alternative of an HTML body: blocks are separated by blank lines, list
+// items start with a dash and the targets of the links follow their text
+func htmlToText(htmlContent string) string {
+	var sb strings.Builder
+	var links []string
+	var linkStarts []int
+	skip, pre := 0, 0
+
+	z := html.NewTokenizer(strings.NewReader(htmlContent))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return tidyText(sb.String())
+
+		case html.TextToken:
+			if skip > 0 {
+				continue
+			}
+			raw := string(z.Raw())
+			text := string(z.Text())
+			if pre == 0 {
+				// Whitespaces are collapsed like browsers do
+				text = strings.Join(strings.Fields(text), " ")
+				if strings.TrimLeft(raw, " \t\r\n") != raw {
+					text = " " + text
+				}
+				if text != " " && strings.TrimRight(raw, " \t\r\n") != raw {
+					text += " "
+				}
+				if written := sb.String(); written == "" || strings.HasSuffix(written, " ") || strings.HasSuffix(written, "\n") {
+					text = strings.TrimLeft(text, " ")
+				}
+			}
+			sb.WriteString(text)
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			switch tok.DataAtom {
+			case atom.Script, atom.Style, atom.Head, atom.Title:
+				if tt == html.StartTagToken {
+					skip++
+				}
+			case atom.Pre:
+				pre++
+				sb.WriteString("\n\n")
+			case atom.Br:
+				sb.WriteString("\n")
+			case atom.Li:
+				sb.WriteString("\n- ")
+			case atom.Td, atom.Th:
+				sb.WriteString(" ")
+			case atom.Hr:
+				sb.WriteString("\n\n---\n\n")
+			case atom.A:
+				href := ""
+				for _, attr := range tok.Attr {
+					if attr.Key == "href" {
+						href = attr.Val
+					}
+				}
+				links = append(links, href)
+				linkStarts = append(linkStarts, sb.Len())
+			default:
+				if isBlock(tok.DataAtom) {
+					sb.WriteString("\n\n")
+				}
+			}
+
+		case html.EndTagToken:
+			tok := z.Token()
+			switch tok.DataAtom {
+			case atom.Script, atom.Style, atom.Head, atom.Title:
+				if skip > 0 {
+					skip--
+				}
+			case atom.Pre:
+				if pre > 0 {
+					pre--
+				}
+				sb.WriteString("\n\n")
+			case atom.Tr:
+				sb.WriteString("\n")
+			case atom.A:
+				if len(links) == 0 {
+					continue
+				}
+				href, start := links[len(links)-1], linkStarts[len(linkStarts)-1]
+				links, linkStarts = links[:len(links)-1], linkStarts[:len(linkStarts)-1]
+				text := strings.TrimSpace(sb.String()[start:])
+				if href != "" && href != text && !strings.HasPrefix(href, "#") && !strings.HasPrefix(href, "mailto:") {
+					sb.WriteString(" (" + href + ")")
+				}
+			default:
+				if isBlock(tok.DataAtom) {
+					sb.WriteString("\n\n")
+				}
+			}
+		}
+	}
+}
+
+func isBlock(a atom.Atom) bool {
+	switch a {
+	case atom.P, atom.Div, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Ul, atom.Ol,
+		atom.Table, atom.Blockquote, atom.Section, atom.Article, atom.Header, atom.Footer:
+		return true
+	}
+	return false
+}
+
+// tidyText trims the lines and keeps one blank line at most between the paragraphs
+func tidyText(text string) string {
+	lines := strings.Split(text, "\n")
+	result := make([]string, 0, len(lines))
+	blank := true
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if !blank {
+				result = append(result, "")
+			}
+			blank = true
+			continue
+		}
+		result = append(result, line)
+		blank = false
+	}
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
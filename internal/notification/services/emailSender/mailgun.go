@@ -0,0 +1,74 @@
+package email_sender
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// MailgunTransport sends the raw message with the messages.mime API, the host of the account selects the
+// region (api.mailgun.net or api.eu.mailgun.net) and its username the sending domain
+type MailgunTransport struct {
+	baseURL string
+}
+
+func NewMailgunTransport() *MailgunTransport {
+	return &MailgunTransport{}
+}
+
+// WithBaseURL points the transport to another endpoint, e.g. a test server
+func (t *MailgunTransport) WithBaseURL(baseURL string) *MailgunTransport {
+	t.baseURL = strings.TrimSuffix(baseURL, "/")
+	return t
+}
+
+func (t *MailgunTransport) Deliver(ctx context.Context, delivery *Delivery) (*SendResult, error) {
+	// STEP-1: The sending domain is the one of the sender unless the account sets it
+	sendingDomain, key, err := delivery.apiKey()
+	if err != nil {
+		return nil, err
+	}
+	if sendingDomain == "" {
+		sendingDomain = delivery.Request.from.Email().Domain()
+	}
+
+	// STEP-2: Write the envelope recipients, Bcc included, and the message as a form
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	for _, recipient := range delivery.Request.recipients() {
+		if err := form.WriteField("to", recipient); err != nil {
+			return nil, classifyError(err, ErrorPermanent)
+		}
+	}
+	file, err := form.CreateFormFile("message", "message.mime")
+	if err != nil {
+		return nil, classifyError(err, ErrorPermanent)
+	}
+	if _, err := file.Write(delivery.Message); err != nil {
+		return nil, classifyError(err, ErrorPermanent)
+	}
+	if err := form.Close(); err != nil {
+		return nil, classifyError(err, ErrorPermanent)
+	}
+
+	// STEP-3: Send it
+	endpoint := fmt.Sprintf("%s/v3/%s/messages.mime", baseURL(t.baseURL, delivery.Account), url.PathEscape(sendingDomain))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+	if err != nil {
+		return nil, classifyError(err, ErrorPermanent)
+	}
+	req.SetBasicAuth("api", key)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	if _, err := doJSON(req, "mailgun", &result); err != nil {
+		return nil, err
+	}
+	return delivery.accepted(result.ID), nil
+}
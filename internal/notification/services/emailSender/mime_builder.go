@@ -0,0 +1,314 @@
+package email_sender
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrDownloadStoreMissing = errors.New("attachments from downloads need a download store")
+	ErrContentIDRequired    = errors.New("inline images need a content ID")
+)
+
+// DownloadStore opens the stored files referenced by WithAttachmentDownloadID
+type DownloadStore interface {
+	OpenDownload(ctx context.Context, id int) (name, contentType string, content io.ReadCloser, err error)
+}
+
+// MIMEBuilder writes the messages as a tree of parts, the levels which are not needed are left out:
+//
+//	multipart/mixed          attachments
+//	  multipart/related      inline images
+//	    multipart/alternative
+//	      text/plain
+//	      text/html
+//	    image/...
+//	  application/...
+type MIMEBuilder struct {
+	downloads DownloadStore
+
+	// Replaced by the tests to get reproducible messages
+	now       func() time.Time
+	boundary  func() string
+	messageID func(domain string) string
+}
+
+func NewMIMEBuilder(downloads DownloadStore) *MIMEBuilder {
+	return &MIMEBuilder{
+		downloads: downloads,
+		now:       time.Now,
+		boundary:  randomBoundary,
+		messageID: randomMessageID,
+	}
+}
+
+// mimePart is a leaf written by write or a multipart part with children
+type mimePart struct {
+	header   textproto.MIMEHeader
+	boundary string
+	children []*mimePart
+	write    func(w io.Writer) error
+}
+
+// Build returns the message with CRLF line endings
+func (b *MIMEBuilder) Build(ctx context.Context, request *EmailDetail) ([]byte, error) {
+	// STEP-1: Collect the attachments, the files are closed once the message is written
+	attachments, closers, err := b.attachments(ctx, request)
+	defer func() {
+		for _, c := range closers {
+			c.Close()
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range request.inlineImages {
+		if image.ContentID == "" {
+			return nil, ErrContentIDRequired
+		}
+	}
+
+	// STEP-2: Build the tree of parts
+	textBody := request.textBody
+	if textBody == "" {
+		textBody = htmlToText(request.body)
+	}
+	root := b.multipart("alternative",
+		textPart("text/plain; charset=UTF-8", textBody),
+		textPart("text/html; charset=UTF-8", request.body),
+	)
+	if len(request.inlineImages) > 0 {
+		parts := []*mimePart{root}
+		for _, image := range request.inlineImages {
+			parts = append(parts, filePart(image, "inline"))
+		}
+		root = b.multipart("related", parts...)
+	}
+	if len(attachments) > 0 {
+		parts := []*mimePart{root}
+		for _, attachment := range attachments {
+			parts = append(parts, filePart(attachment, "attachment"))
+		}
+		root = b.multipart("mixed", parts...)
+	}
+
+	// STEP-3: Write the headers of the message followed by the root part
+	var buf bytes.Buffer
+	b.writeHeaders(&buf, request)
+	if err := writeMIMEHeader(&buf, root.header); err != nil {
+		return nil, err
+	}
+	if err := writeBody(&buf, root); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (b *MIMEBuilder) attachments(ctx context.Context, request *EmailDetail) ([]Attachment, []io.Closer, error) {
+	attachments := append([]Attachment{}, request.attachments...)
+	var closers []io.Closer
+
+	if request.attachmentFilePath != nil {
+		file, err := os.Open(*request.attachmentFilePath)
+		if err != nil {
+			return nil, closers, err
+		}
+		closers = append(closers, file)
+
+		name := filepath.Base(*request.attachmentFilePath)
+		if request.attachmentFileName != nil && *request.attachmentFileName != "" {
+			name = *request.attachmentFileName
+		}
+		attachments = append(attachments, Attachment{Name: name, Content: file})
+	}
+
+	if request.attachedDownloadId != nil {
+		if b.downloads == nil {
+			return nil, closers, ErrDownloadStoreMissing
+		}
+		name, contentType, content, err := b.downloads.OpenDownload(ctx, *request.attachedDownloadId)
+		if err != nil {
+			return nil, closers, err
+		}
+		closers = append(closers, content)
+		attachments = append(attachments, Attachment{Name: name, ContentType: contentType, Content: content})
+	}
+
+	return attachments, closers, nil
+}
+
+func (b *MIMEBuilder) writeHeaders(buf *bytes.Buffer, request *EmailDetail) {
+	writeHeader := func(key, value string) {
+		buf.WriteString(key + ": " + value + "\r\n")
+	}
+
+	writeHeader("Date", b.now().Format(time.RFC1123Z))
+	messageID := request.messageID
+	if messageID == "" {
+		messageID = b.messageID(request.from.Email().Domain())
+	}
+	writeHeader("Message-ID", messageID)
+	writeHeader("Subject", encodeHeader(request.subject))
+	writeHeader("From", request.from.String())
+	writeHeader("To", request.to.String())
+	if len(request.cc) > 0 {
+		cc := make([]string, len(request.cc))
+		for i, addr := range request.cc {
+			cc[i] = addr.String()
+		}
+		writeHeader("Cc", strings.Join(cc, ", "))
+	}
+	if request.replyTo != nil {
+		writeHeader("Reply-To", request.replyTo.String())
+	}
+	if request.listUnsubscribeURL != "" {
+		writeHeader("List-Unsubscribe", "<"+request.listUnsubscribeURL+">")
+		writeHeader("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
+
+	headers := make(map[string]string, len(request.headers))
+	keys := make([]string, 0, len(request.headers))
+	for k, v := range request.headers {
+		k = textproto.CanonicalMIMEHeaderKey(k)
+		headers[k] = v
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writeHeader(k, encodeHeader(headers[k]))
+	}
+	writeHeader("MIME-Version", "1.0")
+}
+
+func (b *MIMEBuilder) multipart(subtype string, children ...*mimePart) *mimePart {
+	boundary := b.boundary()
+	return &mimePart{
+		header:   textproto.MIMEHeader{"Content-Type": {mime.FormatMediaType("multipart/"+subtype, map[string]string{"boundary": boundary})}},
+		boundary: boundary,
+		children: children,
+	}
+}
+
+func writeBody(w io.Writer, part *mimePart) error {
+	if part.write != nil {
+		return part.write(w)
+	}
+
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(part.boundary); err != nil {
+		return err
+	}
+	for _, child := range part.children {
+		pw, err := mw.CreatePart(child.header)
+		if err != nil {
+			return err
+		}
+		if err := writeBody(pw, child); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+func textPart(contentType, content string) *mimePart {
+	return &mimePart{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		},
+		write: func(w io.Writer) error {
+			qw := quotedprintable.NewWriter(w)
+			if _, err := qw.Write([]byte(content)); err != nil {
+				return err
+			}
+			return qw.Close()
+		},
+	}
+}
+
+func filePart(attachment Attachment, disposition string) *mimePart {
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(attachment.Name))
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "application/octet-stream", make(map[string]string)
+	}
+	params["name"] = attachment.Name
+
+	header := textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType(mediaType, params)},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Name})},
+	}
+	if attachment.ContentID != "" {
+		header.Set("Content-ID", "<"+attachment.ContentID+">")
+	}
+
+	return &mimePart{
+		header: header,
+		write: func(w io.Writer) error {
+			// Base64 encoded with a line break every 76 characters
+			lw := NewBase64LineWriter(w)
+			encoder := base64.NewEncoder(base64.StdEncoding, lw)
+			if _, err := io.Copy(encoder, attachment.Content); err != nil {
+				return err
+			}
+			if err := encoder.Close(); err != nil {
+				return err
+			}
+			return lw.Close()
+		},
+	}
+}
+
+// writeMIMEHeader writes the header in a stable order followed by the blank line ending it
+func writeMIMEHeader(w io.Writer, header textproto.MIMEHeader) error {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range header[k] {
+			if _, err := fmt.Fprintf(w, "%s: %s\r\n", k, v); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := io.WriteString(w, "\r\n")
+	return err
+}
+
+// encodeHeader encodes the non-ASCII values as RFC 2047 encoded words
+func encodeHeader(value string) string {
+	return mime.QEncoding.Encode("UTF-8", value)
+}
+
+func randomBoundary() string {
+	var buf [15]byte
+	_, _ = rand.Read(buf[:])
+	return hex.EncodeToString(buf[:])
+}
+
+func randomMessageID(domain string) string {
+	var buf [16]byte
+	_, _ = rand.Read(buf[:])
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(buf[:]), domain)
+}
@@ -0,0 +1,155 @@
+package email_sender
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	vo "platform/pkg/domain/value_object"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+type fakeDownloads map[int]string
+
+func (f fakeDownloads) OpenDownload(_ context.Context, id int) (string, string, io.ReadCloser, error) {
+	content, ok := f[id]
+	if !ok {
+		return "", "", nil, errors.New("download not found")
+	}
+	return fmt.Sprintf("report-%d.csv", id), "text/csv", io.NopCloser(strings.NewReader(content)), nil
+}
+
+func testBuilder(downloads DownloadStore) *MIMEBuilder {
+	b := NewMIMEBuilder(downloads)
+	count := 0
+	b.now = func() time.Time { return time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC) }
+	b.boundary = func() string {
+		count++
+		return fmt.Sprintf("boundary-%d", count)
+	}
+	b.messageID = func(domain string) string { return "<message-1@" + domain + ">" }
+	return b
+}
+
+func testAddress(t *testing.T, value string) vo.Address {
+	t.Helper()
+	address, err := vo.ParseAddress(value)
+	if err != nil {
+		t.Fatalf("%q: %v", value, err)
+	}
+	return address
+}
+
+func TestMIMEBuilderGolden(t *testing.T) {
+	downloadID := 7
+	cases := map[string]func() *EmailDetail{
+		"html_only": func() *EmailDetail {
+			ed, _ := BaseEmailDetail("Welcome", "<h1>Hello</h1><p>Thanks for joining, <a href=\"https://example.com/start\">get started</a>.</p>",
+				testAddress(t, "Beecraft <noreply@example.com>"), testAddress(t, "john@example.org"))
+			return ed
+		},
+		"encoded_headers": func() *EmailDetail {
+			ed, _ := BaseEmailDetail("Sipariş onayı – №42", "<p>Teşekkürler!</p>",
+				testAddress(t, "Müşteri Hizmetleri <destek@example.com>"), testAddress(t, "Ayşe Yılmaz <ayse@example.org>"))
+			replyTo := testAddress(t, "reply@example.com")
+			return ed.WithReplyTo(&replyTo).
+				WithCc([]vo.Address{testAddress(t, "cc@example.org")}).
+				WithBcc([]vo.Address{testAddress(t, "bcc@example.org")}).
+				WithHeaders(map[string]string{"x-campaign": "Güz", "X-Priority": "3"}).
+				WithListUnsubscribe("https://example.com/unsubscribe?token=abc")
+		},
+		"inline_and_attachments": func() *EmailDetail {
+			ed, _ := BaseEmailDetail("Your invoice", `<p><img src="cid:logo" alt="Logo"></p><p>Invoice attached.</p>`,
+				testAddress(t, "billing@example.com"), testAddress(t, "john@example.org"))
+			return ed.WithTextBody("Invoice attached.").
+				WithInlineImage(Attachment{Name: "logo.png", ContentType: "image/png", ContentID: "logo", Content: strings.NewReader("\x89PNG fake image")}).
+				WithAttachment(Attachment{Name: "invoice.pdf", ContentType: "application/pdf", Content: strings.NewReader("%PDF-1.7 fake invoice with a body long enough to need a second base64 line")}).
+				WithAttachment(Attachment{Name: "özet.txt", ContentType: "text/plain; charset=utf-8", Content: strings.NewReader("summary")}).
+				WithAttachmentDownloadID(&downloadID)
+		},
+	}
+
+	for name, detail := range cases {
+		t.Run(name, func(t *testing.T) {
+			message, err := testBuilder(fakeDownloads{7: "a,b\n1,2\n"}).Build(context.Background(), detail())
+			if err != nil {
+				t.Fatalf("build: %v", err)
+			}
+
+			golden := filepath.Join("testdata", name+".eml")
+			if *update {
+				if err := os.WriteFile(golden, message, 0o644); err != nil {
+					t.Fatalf("write golden: %v", err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden: %v", err)
+			}
+			if !bytes.Equal(message, want) {
+				t.Fatalf("message differs from %s, run the tests with -update after checking the output:\n%s", golden, message)
+			}
+
+			// The message must be readable by a MIME parser
+			parsed, err := mail.ReadMessage(bytes.NewReader(message))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+			if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+				t.Fatalf("bad content type %q: %v", mediaType, err)
+			}
+			reader := multipart.NewReader(parsed.Body, params["boundary"])
+			for {
+				if _, err := reader.NextPart(); err == io.EOF {
+					break
+				} else if err != nil {
+					t.Fatalf("read part: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestMIMEBuilderErrors(t *testing.T) {
+	downloadID := 1
+	ed, _ := BaseEmailDetail("Subject", "<p>Body</p>", testAddress(t, "a@example.com"), testAddress(t, "b@example.com"))
+	if _, err := testBuilder(nil).Build(context.Background(), ed.WithAttachmentDownloadID(&downloadID)); !errors.Is(err, ErrDownloadStoreMissing) {
+		t.Fatalf("expected ErrDownloadStoreMissing, got %v", err)
+	}
+
+	ed, _ = BaseEmailDetail("Subject", "<p>Body</p>", testAddress(t, "a@example.com"), testAddress(t, "b@example.com"))
+	ed.WithInlineImage(Attachment{Name: "logo.png", Content: strings.NewReader("x")})
+	if _, err := testBuilder(nil).Build(context.Background(), ed); !errors.Is(err, ErrContentIDRequired) {
+		t.Fatalf("expected ErrContentIDRequired, got %v", err)
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	cases := map[string]string{
+		"<p>Hello   <b>world</b></p><p>Bye</p>":                           "Hello world\n\nBye",
+		"<style>p{color:red}</style><p>Styled</p>":                        "Styled",
+		`<p>Read <a href="https://example.com">the docs</a> now</p>`:      "Read the docs (https://example.com) now",
+		`<a href="https://example.com">https://example.com</a>`:           "https://example.com",
+		"<ul><li>One</li><li>Two</li></ul>":                               "- One\n- Two",
+		"Line one<br>Line two &amp; more":                                 "Line one\nLine two & more",
+		"<h1>Title</h1>\n\n\n<div>Text</div>":                             "Title\n\nText",
+		`<table><tr><td>A</td><td>B</td></tr><tr><td>C</td></tr></table>`: "A B\nC",
+	}
+	for input, want := range cases {
+		if got := htmlToText(input); got != want {
+			t.Fatalf("%q: got %q want %q", input, got, want)
+		}
+	}
+}
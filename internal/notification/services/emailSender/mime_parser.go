@@ -0,0 +1,101 @@
+package email_sender
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+)
+
+// parsedMessage is the content of a message built by MIMEBuilder, for the APIs which do not take a raw
+// message
+type parsedMessage struct {
+	header      mail.Header
+	text        string
+	html        string
+	attachments []parsedAttachment
+}
+
+type parsedAttachment struct {
+	name        string
+	contentType string
+	contentID   string
+	inline      bool
+	content     []byte
+}
+
+func parseMessage(message []byte) (*parsedMessage, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(message))
+	if err != nil {
+		return nil, err
+	}
+	parsed := &parsedMessage{header: msg.Header}
+	err = parsed.walk(textproto.MIMEHeader(msg.Header), msg.Body)
+	return parsed, err
+}
+
+// walk collects the text parts and the files of the tree of parts
+func (m *parsedMessage) walk(header textproto.MIMEHeader, body io.Reader) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := m.walk(part.Header, part); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(decodeTransfer(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return err
+	}
+
+	disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	switch {
+	case disposition == "" && mediaType == "text/plain" && m.text == "":
+		m.text = string(content)
+	case disposition == "" && mediaType == "text/html" && m.html == "":
+		m.html = string(content)
+	default:
+		name := dispositionParams["filename"]
+		if name == "" {
+			name = params["name"]
+		}
+		m.attachments = append(m.attachments, parsedAttachment{
+			name:        name,
+			contentType: mediaType,
+			contentID:   strings.Trim(header.Get("Content-ID"), "<>"),
+			inline:      disposition == "inline",
+			content:     content,
+		})
+	}
+	return nil
+}
+
+func decodeTransfer(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(encoding) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
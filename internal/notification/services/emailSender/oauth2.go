@@ -42,3 +42,32 @@ func (a *OAuth2) Next(fromServer []byte, more bool) ([]byte, error) {
 	}
 	return nil, nil
 }
+
+// OAuthBearer implements the smtp.Auth interface with the OAUTHBEARER mechanism of RFC 7628.
+type OAuthBearer struct {
+	username    string
+	host        string
+	port        int
+	accessToken string
+}
+
+// NewOAuthBearerAuth returns a new smtp.Auth implementation using the OAUTHBEARER mechanism, host and port
+// are the ones of the SMTP server.
+func NewOAuthBearerAuth(username, host string, port int, accessToken string) smtp.Auth {
+	return &OAuthBearer{username: username, host: host, port: port, accessToken: accessToken}
+}
+
+// Start returns the mechanism name ("OAUTHBEARER") and the GS2 header followed by the key/value pairs.
+func (a *OAuthBearer) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	authStr := fmt.Sprintf("n,a=%s,\x01host=%s\x01port=%d\x01auth=Bearer %s\x01\x01", a.username, a.host, a.port, a.accessToken)
+	return "OAUTHBEARER", []byte(authStr), nil
+}
+
+// Next answers the error challenge of the server with the dummy response %x01 required by RFC 7628, the
+// server then fails the exchange.
+func (a *OAuthBearer) Next(fromServer []byte, more bool) ([]byte, error) {
+	if more {
+		return []byte{0x01}, nil
+	}
+	return nil, nil
+}
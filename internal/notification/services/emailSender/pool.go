@@ -0,0 +1,512 @@
+package email_sender
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"platform/internal/notification/domain"
+	email_provider "platform/internal/notification/services/emailProvider"
+	"platform/internal/notification/services/encryption"
+	"sync"
+	"time"
+)
+
+var ErrPoolClosed = errors.New("smtp connection pool is closed")
+
+// aLongTimeAgo is used as a deadline to abort the pending operations of a connection
+var aLongTimeAgo = time.Unix(1, 0)
+
+type PoolConfig struct {
+	// DialTimeout limits the TCP (and implicit TLS) handshake
+	DialTimeout time.Duration
+
+	// IOTimeout limits each exchange with the server, the deadline of the context is used when it is earlier
+	IOTimeout time.Duration
+
+	// IdleTimeout closes connections which are not used for a while, servers drop them anyway
+	IdleTimeout time.Duration
+
+	// HealthCheckAfter sends a NOOP before reusing a connection which is idle for longer than this
+	HealthCheckAfter time.Duration
+
+	MaxIdlePerAccount  int
+	MaxMessagesPerConn int
+}
+
+var DefaultPoolConfig = PoolConfig{
+	DialTimeout:        10 * time.Second,
+	IOTimeout:          30 * time.Second,
+	IdleTimeout:        60 * time.Second,
+	HealthCheckAfter:   5 * time.Second,
+	MaxIdlePerAccount:  4,
+	MaxMessagesPerConn: 100,
+}
+
+// DefaultPool is used by SendEmail
+var DefaultPool = NewPool(DefaultPoolConfig)
+
+// Pool keeps authenticated SMTP connections per email account, so that sending a message does not dial,
+// negotiate TLS and authenticate again. Connections are reset with RSET between messages.
+type Pool struct {
+	config     PoolConfig
+	builder    *MIMEBuilder
+	tokens     TokenSource
+	transports map[string]Transport
+	sandbox    Sandbox
+	capture    Transport
+	mu         sync.Mutex
+	idle       map[string][]*pooledConn
+	closed     bool
+	done       chan struct{}
+}
+
+type pooledConn struct {
+	key      string
+	conn     net.Conn
+	client   *smtp.Client
+	sent     int
+	lastUsed time.Time
+}
+
+// NewPool returns a pool using config, an idle timeout which is not set uses the one of DefaultPoolConfig
+func NewPool(config PoolConfig) *Pool {
+	if config.IdleTimeout <= 0 {
+		config.IdleTimeout = DefaultPoolConfig.IdleTimeout
+	}
+	p := &Pool{
+		config:  config,
+		builder: NewMIMEBuilder(nil),
+		tokens:  memoryTokenSource{},
+		idle:    make(map[string][]*pooledConn),
+		done:    make(chan struct{}),
+	}
+	p.transports = defaultTransports()
+	p.transports[email_provider.TransportSMTP] = p
+	go p.janitor()
+	return p
+}
+
+// Send builds the message and delivers it with the transport of the provider of the account, pooled SMTP
+// connections or an HTTP API. Errors are *SendError values, the result lists the rejected recipients when
+// the message is sent to some of them only.
+func (p *Pool) Send(ctx context.Context, encryption encryption.EncryptionService, account *domain.EmailAccount, request *EmailDetail) (*SendResult, error) {
+	// STEP-1: Capture the message or rewrite its recipients when the project is sandboxed
+	capture := false
+	if p.sandbox != nil {
+		sandbox, err := p.sandbox.Get(ctx, account)
+		if err != nil {
+			return nil, classifyError(err, ErrorTransient)
+		}
+		if sandbox != nil {
+			if sandboxed, ok := applySandbox(sandbox, request); ok {
+				request = sandboxed
+			} else {
+				capture = true
+			}
+		}
+	}
+
+	// STEP-2: Build the MIME message before holding a connection
+	message, err := p.builder.Build(ctx, request)
+	if err != nil {
+		return nil, classifyError(err, ErrorPermanent)
+	}
+
+	// Sign the message when the account has a DKIM key
+	signer, err := newDKIMSigner(encryption, account.GetDkimSettings())
+	if err != nil {
+		return nil, classifyError(err, ErrorPermanent)
+	}
+	if signer != nil {
+		if message, err = signer.Sign(message); err != nil {
+			return nil, classifyError(err, ErrorPermanent)
+		}
+	}
+
+	// STEP-3: Deliver the message with the transport of the provider
+	transport := p.capture
+	if !capture {
+		provider, err := email_provider.Get(account.GetSmtpType())
+		if err != nil {
+			return nil, classifyError(err, ErrorPermanent)
+		}
+		var ok bool
+		if transport, ok = p.transports[provider.Transport()]; !ok {
+			return nil, classifyError(ErrTransportNotFound, ErrorPermanent)
+		}
+	}
+	return transport.Deliver(ctx, &Delivery{
+		Account:    account,
+		Request:    request,
+		Message:    message,
+		Encryption: encryption,
+		Tokens:     p.tokens,
+	})
+}
+
+// Deliver sends the message over a pooled SMTP connection of the account
+func (p *Pool) Deliver(ctx context.Context, delivery *Delivery) (*SendResult, error) {
+	// STEP-1: Get a healthy connection of the account
+	pc, err := p.get(ctx, delivery.Encryption, delivery.Account)
+	if err != nil {
+		return nil, classifyError(err, ErrorTransient)
+	}
+
+	// STEP-2: Send the message and reset the session for the next one
+	stop := pc.watch(ctx, p.config.IOTimeout)
+	result, err := pc.send(delivery.Request, delivery.Message)
+	reusable := isReplyError(err) || err == nil
+	if reusable && pc.client.Reset() != nil {
+		reusable = false
+	}
+	if !stop() {
+		// The context was cancelled during the exchange, the state of the connection is unknown
+		reusable = false
+	}
+
+	p.put(pc, reusable)
+	return result, err
+}
+
+// SetDownloadStore resolves the attachments referenced by a download identifier, it must be called before
+// sending messages
+func (p *Pool) SetDownloadStore(downloads DownloadStore) {
+	p.builder = NewMIMEBuilder(downloads)
+}
+
+// SetTokenSource sets the source of the access tokens of the OAuth2 accounts, it must be called before
+// sending messages
+func (p *Pool) SetTokenSource(tokens TokenSource) {
+	p.tokens = tokens
+}
+
+// SetSandbox captures or redirects the messages of the sandboxed projects, it must be called before sending
+// messages
+func (p *Pool) SetSandbox(sandbox Sandbox) {
+	p.sandbox = sandbox
+	p.capture = NewCaptureTransport(sandbox)
+}
+
+// SetTransport replaces the transport of the providers with the given transport name, e.g. with a test
+// double, it must be called before sending messages
+func (p *Pool) SetTransport(name string, transport Transport) {
+	p.transports[name] = transport
+}
+
+// Close closes the idle connections, the connections in use are closed when they are released
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	idle := p.idle
+	p.idle = make(map[string][]*pooledConn)
+	p.mu.Unlock()
+
+	close(p.done)
+	for _, list := range idle {
+		for _, pc := range list {
+			pc.quit()
+		}
+	}
+}
+
+func (p *Pool) get(ctx context.Context, encryption encryption.EncryptionService, account *domain.EmailAccount) (*pooledConn, error) {
+	key := poolKey(account)
+	for {
+		pc, err := p.popIdle(key)
+		if err != nil {
+			return nil, err
+		}
+		if pc == nil {
+			return p.dial(ctx, encryption, account, key)
+		}
+
+		idleFor := time.Since(pc.lastUsed)
+		if idleFor > p.config.IdleTimeout {
+			pc.quit()
+			continue
+		}
+		if idleFor > p.config.HealthCheckAfter {
+			stop := pc.watch(ctx, p.config.IOTimeout)
+			err := pc.client.Noop()
+			if !stop() || err != nil {
+				pc.close()
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				continue
+			}
+		}
+		return pc, nil
+	}
+}
+
+// popIdle returns the most recently used connection, so that the others can reach their idle timeout
+func (p *Pool) popIdle(key string) (*pooledConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+	list := p.idle[key]
+	if len(list) == 0 {
+		return nil, nil
+	}
+	pc := list[len(list)-1]
+	p.idle[key] = list[:len(list)-1]
+	return pc, nil
+}
+
+func (p *Pool) put(pc *pooledConn, reusable bool) {
+	if !reusable {
+		pc.close()
+		return
+	}
+	if pc.sent >= p.config.MaxMessagesPerConn {
+		pc.quit()
+		return
+	}
+
+	_ = pc.conn.SetDeadline(time.Time{})
+	pc.lastUsed = time.Now()
+
+	p.mu.Lock()
+	if p.closed || len(p.idle[pc.key]) >= p.config.MaxIdlePerAccount {
+		p.mu.Unlock()
+		pc.quit()
+		return
+	}
+	p.idle[pc.key] = append(p.idle[pc.key], pc)
+	p.mu.Unlock()
+}
+
+// janitor closes the connections which reached their idle timeout
+func (p *Pool) janitor() {
+	ticker := time.NewTicker(max(p.config.IdleTimeout/2, time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+
+		expired := make([]*pooledConn, 0)
+		p.mu.Lock()
+		for key, list := range p.idle {
+			kept := list[:0]
+			for _, pc := range list {
+				if time.Since(pc.lastUsed) > p.config.IdleTimeout {
+					expired = append(expired, pc)
+				} else {
+					kept = append(kept, pc)
+				}
+			}
+			if len(kept) == 0 {
+				delete(p.idle, key)
+			} else {
+				p.idle[key] = kept
+			}
+		}
+		p.mu.Unlock()
+
+		for _, pc := range expired {
+			pc.quit()
+		}
+	}
+}
+
+// dial opens an authenticated connection, honoring the deadline and the cancellation of ctx
+func (p *Pool) dial(ctx context.Context, encryption encryption.EncryptionService, ea *domain.EmailAccount, key string) (*pooledConn, error) {
+	tlsConfig := &tls.Config{ServerName: ea.GetHost()}
+	addr := net.JoinHostPort(ea.GetHost(), fmt.Sprintf("%d", ea.GetPort()))
+	dialer := &net.Dialer{Timeout: p.config.DialTimeout}
+
+	// If SSL is enabled (implicit TLS), use a TLS dialer. Otherwise use a plain TCP one.
+	var conn net.Conn
+	var err error
+	if ea.GetEnableSSL() {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, classifyError(err, ErrorTransient)
+	}
+
+	pc := &pooledConn{key: key, conn: conn}
+	stop := pc.watch(ctx, p.config.IOTimeout)
+	pc.client, err = smtp.NewClient(conn, ea.GetHost())
+	if err != nil {
+		err = classifyError(err, ErrorTransient)
+	} else {
+		err = authenticate(ctx, pc.client, encryption, p.tokens, ea, tlsConfig)
+	}
+	if !stop() && err == nil {
+		err = classifyError(ctx.Err(), ErrorTransient)
+	}
+	if err != nil {
+		pc.close()
+		return nil, err
+	}
+	return pc, nil
+}
+
+// watch sets the deadline of the next exchanges and aborts them when ctx is cancelled, the returned
+// function reports false if ctx was cancelled in the meantime
+func (pc *pooledConn) watch(ctx context.Context, timeout time.Duration) func() bool {
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = pc.conn.SetDeadline(deadline)
+	return context.AfterFunc(ctx, func() {
+		_ = pc.conn.SetDeadline(aLongTimeAgo)
+	})
+}
+
+// send writes the envelope and the data of the message, the envelope is pipelined (RFC 2920) when the
+// server supports it. The message is sent to the accepted recipients when some of them are rejected.
+func (pc *pooledConn) send(request *EmailDetail, message []byte) (*SendResult, error) {
+	from := request.from.Email().Value()
+	recipients := request.recipients()
+
+	// STEP-1: Send the envelope
+	var mailErr error
+	rcptErrs := make([]error, len(recipients))
+	if ok, _ := pc.client.Extension("PIPELINING"); ok {
+		mailErr, rcptErrs = pc.pipelineEnvelope(from, recipients)
+	} else if mailErr = pc.client.Mail(from); mailErr == nil {
+		for i, recipient := range recipients {
+			rcptErrs[i] = pc.client.Rcpt(recipient)
+		}
+	}
+	if mailErr != nil {
+		return nil, classifyError(mailErr, ErrorPermanent)
+	}
+
+	// STEP-2: Sort out the recipients
+	result := &SendResult{Accepted: make([]string, 0, len(recipients)), Rejected: make([]Rejection, 0)}
+	for i, recipient := range recipients {
+		if rcptErrs[i] != nil {
+			result.Rejected = append(result.Rejected, Rejection{Recipient: recipient, Err: toSendError(rcptErrs[i], ErrorPermanent)})
+		} else {
+			result.Accepted = append(result.Accepted, recipient)
+		}
+	}
+	if len(result.Accepted) == 0 {
+		first := result.Rejected[0].Err
+		return result, &SendError{
+			Category:     ErrorRecipient,
+			Code:         first.Code,
+			EnhancedCode: first.EnhancedCode,
+			Message:      fmt.Sprintf("every recipient was rejected: %s", first.Message),
+			err:          first,
+		}
+	}
+
+	// STEP-3: Send the message
+	dataWriter, err := pc.client.Data()
+	if err != nil {
+		return result, classifyError(err, ErrorPermanent)
+	}
+	if _, err = dataWriter.Write(message); err != nil {
+		return result, classifyError(err, ErrorTransient)
+	}
+	if err = dataWriter.Close(); err != nil {
+		return result, classifyError(err, ErrorPermanent)
+	}
+
+	pc.sent++
+	return result, nil
+}
+
+// pipelineEnvelope sends MAIL FROM and every RCPT TO at once, then reads the replies in order
+func (pc *pooledConn) pipelineEnvelope(from string, recipients []string) (error, []error) {
+	text := pc.client.Text
+	rcptErrs := make([]error, len(recipients))
+	mailCommand := "MAIL FROM:<%s>"
+	if ok, _ := pc.client.Extension("8BITMIME"); ok {
+		mailCommand += " BODY=8BITMIME"
+	}
+
+	ids := make([]uint, 0, len(recipients)+1)
+	id, err := text.Cmd(mailCommand, from)
+	if err != nil {
+		return err, rcptErrs
+	}
+	ids = append(ids, id)
+	for _, recipient := range recipients {
+		if id, err = text.Cmd("RCPT TO:<%s>", recipient); err != nil {
+			return err, rcptErrs
+		}
+		ids = append(ids, id)
+	}
+
+	// Every reply has to be read to keep the connection in sync
+	var mailErr error
+	for i, id := range ids {
+		expectCode := 250
+		if i > 0 {
+			expectCode = 25
+		}
+		text.StartResponse(id)
+		_, _, err := text.ReadResponse(expectCode)
+		text.EndResponse(id)
+
+		if i == 0 {
+			mailErr = err
+		} else {
+			rcptErrs[i-1] = err
+		}
+	}
+	return mailErr, rcptErrs
+}
+
+// quit ends the session politely, close is used when the connection is broken
+func (pc *pooledConn) quit() {
+	_ = pc.conn.SetDeadline(time.Now().Add(time.Second))
+	if err := pc.client.Quit(); err != nil {
+		pc.close()
+	}
+}
+
+func (pc *pooledConn) close() {
+	if pc.client != nil {
+		_ = pc.client.Close()
+		return
+	}
+	_ = pc.conn.Close()
+}
+
+// isReplyError reports whether the server rejected a command, the connection itself is still usable then
+func isReplyError(err error) bool {
+	var replyErr *textproto.Error
+	return errors.As(err, &replyErr)
+}
+
+// poolKey identifies the connections of an account, it changes with the settings of the account so that
+// connections opened with old settings are not reused. Refreshed OAuth2 tokens keep the same key.
+func poolKey(ea *domain.EmailAccount) string {
+	identity := ""
+	if credentials := ea.GetTraditionalCredentials(); credentials != nil {
+		username, password := credentials.Credentials()
+		identity = username + "|" + password
+	} else if credentials := ea.GetOAuth2Credentials(); credentials != nil {
+		clientID, tenantID, _ := credentials.Credentials()
+		identity = clientID + "|" + tenantID
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d|%t|%d|%s", ea.GetHost(), ea.GetPort(), ea.GetEnableSSL(), ea.GetSmtpType(), identity)))
+	return ea.GetID().String() + "|" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,23 @@
+package email_sender
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewPoolIdleTimeout(t *testing.T) {
+	for _, idleTimeout := range []time.Duration{0, -time.Second, time.Nanosecond} {
+		config := DefaultPoolConfig
+		config.IdleTimeout = idleTimeout
+		pool := NewPool(config)
+		pool.Close()
+
+		want := idleTimeout
+		if idleTimeout <= 0 {
+			want = DefaultPoolConfig.IdleTimeout
+		}
+		if pool.config.IdleTimeout != want {
+			t.Errorf("idle timeout %s = %s, want %s", idleTimeout, pool.config.IdleTimeout, want)
+		}
+	}
+}
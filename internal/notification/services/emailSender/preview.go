@@ -0,0 +1,66 @@
+package email_sender
+
+import (
+	"context"
+	"mime"
+)
+
+// Preview is a message as it would be sent, without the DKIM signature, with the content its parts decode to
+type Preview struct {
+	Message []byte
+	Header  map[string][]string
+	Subject string
+	Text    string
+	HTML    string
+	Files   []PreviewFile
+}
+
+// PreviewFile is an inline image or an attachment of a previewed message
+type PreviewFile struct {
+	Name        string
+	ContentType string
+	ContentID   string
+	Inline      bool
+	Size        int
+}
+
+// PreviewEmail builds the message with the default pool without sending it, see Pool.Preview
+func PreviewEmail(ctx context.Context, request *EmailDetail) (*Preview, error) {
+	return DefaultPool.Preview(ctx, request)
+}
+
+// Preview builds the message the pool would send for request and reads it back, the sandbox of the project
+// is not applied
+func (p *Pool) Preview(ctx context.Context, request *EmailDetail) (*Preview, error) {
+	message, err := p.builder.Build(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.header.Get("Subject"))
+	if err != nil {
+		subject = parsed.header.Get("Subject")
+	}
+	preview := &Preview{
+		Message: message,
+		Header:  parsed.header,
+		Subject: subject,
+		Text:    parsed.text,
+		HTML:    parsed.html,
+		Files:   make([]PreviewFile, 0, len(parsed.attachments)),
+	}
+	for _, a := range parsed.attachments {
+		preview.Files = append(preview.Files, PreviewFile{
+			Name:        a.name,
+			ContentType: a.contentType,
+			ContentID:   a.contentID,
+			Inline:      a.inline,
+			Size:        len(a.content),
+		})
+	}
+	return preview, nil
+}
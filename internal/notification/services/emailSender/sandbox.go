@@ -0,0 +1,128 @@
+package email_sender
+
+import (
+	"context"
+	"mime"
+	"platform/internal/notification/domain"
+	vo "platform/pkg/domain/value_object"
+	"strings"
+)
+
+// Sandbox gives the sandbox settings of the project of an account and stores the captured messages, see
+// Pool.SetSandbox. Get returns nil when the messages are delivered.
+type Sandbox interface {
+	Get(ctx context.Context, account *domain.EmailAccount) (*domain.EmailSandbox, error)
+	Capture(ctx context.Context, email *domain.CapturedEmail) error
+}
+
+// OriginalRecipientsHeader lists the recipients a sandbox replaced by its redirect address
+const OriginalRecipientsHeader = "X-Sandbox-Original-Recipients"
+
+// CaptureTransport stores the messages in the sandbox instead of delivering them, like a local SMTP catcher
+type CaptureTransport struct {
+	sandbox Sandbox
+}
+
+func NewCaptureTransport(sandbox Sandbox) *CaptureTransport {
+	return &CaptureTransport{sandbox: sandbox}
+}
+
+// Deliver stores the message with its parsed content, every recipient is accepted
+func (t *CaptureTransport) Deliver(ctx context.Context, delivery *Delivery) (*SendResult, error) {
+	account := delivery.Account
+	email := domain.NewCapturedEmail(account.GetProjectID(), account.GetID(), delivery.Request.from.String(), delivery.Request.recipients(), delivery.Message)
+
+	parsed, err := parseMessage(delivery.Message)
+	if err != nil {
+		return nil, classifyError(err, ErrorPermanent)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.header.Get("Subject"))
+	if err != nil {
+		subject = parsed.header.Get("Subject")
+	}
+	email.SetSubject(subject)
+	email.SetMessageID(strings.Trim(parsed.header.Get("Message-Id"), "<>"))
+	email.SetHeaders(parsed.header)
+	email.SetText(parsed.text)
+	email.SetHTML(parsed.html)
+	attachments := make([]domain.CapturedAttachment, 0, len(parsed.attachments))
+	for _, a := range parsed.attachments {
+		attachments = append(attachments, domain.CapturedAttachment{
+			Name:        a.name,
+			ContentType: a.contentType,
+			ContentID:   a.contentID,
+			Size:        len(a.content),
+		})
+	}
+	email.SetAttachments(attachments)
+
+	if err := t.sandbox.Capture(ctx, email); err != nil {
+		return nil, classifyError(err, ErrorTransient)
+	}
+	return delivery.accepted(email.GetID().String()), nil
+}
+
+// applySandbox returns the request to deliver in allow-list mode, with the recipients which are not allowed
+// replaced by the redirect address. ok is false when the message must be captured instead.
+func applySandbox(sandbox *domain.EmailSandbox, request *EmailDetail) (result *EmailDetail, ok bool) {
+	switch sandbox.GetMode() {
+	case domain.SandboxCapture:
+		return nil, false
+	case domain.SandboxAllowList:
+	default:
+		return request, true
+	}
+
+	var redirect *vo.Address
+	if sandbox.GetRedirectTo() != "" {
+		email, err := vo.NewEmail(sandbox.GetRedirectTo())
+		if err != nil {
+			return nil, false
+		}
+		address := vo.NewAddress("", email)
+		redirect = &address
+	}
+
+	// Every recipient is kept or redirected once, the message is captured when one of them cannot be
+	var replaced []string
+	seen := make(map[string]bool)
+	rewrite := func(list []vo.Address) ([]vo.Address, bool) {
+		kept := make([]vo.Address, 0, len(list))
+		for _, addr := range list {
+			if !sandbox.Allows(addr.Email().Value()) {
+				if redirect == nil {
+					return nil, false
+				}
+				replaced = append(replaced, addr.Email().Value())
+				addr = *redirect
+			}
+			if key := strings.ToLower(addr.Email().Value()); !seen[key] {
+				seen[key] = true
+				kept = append(kept, addr)
+			}
+		}
+		return kept, true
+	}
+
+	rewritten := *request
+	to, ok := rewrite([]vo.Address{request.to})
+	if !ok {
+		return nil, false
+	}
+	rewritten.to = to[0]
+	if rewritten.cc, ok = rewrite(request.cc); !ok {
+		return nil, false
+	}
+	if rewritten.bcc, ok = rewrite(request.bcc); !ok {
+		return nil, false
+	}
+
+	if len(replaced) > 0 {
+		rewritten.headers = make(map[string]string, len(request.headers)+1)
+		for key, value := range request.headers {
+			rewritten.headers[key] = value
+		}
+		rewritten.headers[OriginalRecipientsHeader] = strings.Join(replaced, ", ")
+	}
+	return &rewritten, true
+}
@@ -0,0 +1,124 @@
+package email_sender
+
+import (
+	"context"
+	"platform/internal/notification/domain"
+	vo "platform/pkg/domain/value_object"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type testSandbox struct {
+	settings *domain.EmailSandbox
+	captured []*domain.CapturedEmail
+}
+
+func (s *testSandbox) Get(context.Context, *domain.EmailAccount) (*domain.EmailSandbox, error) {
+	return s.settings, nil
+}
+
+func (s *testSandbox) Capture(_ context.Context, email *domain.CapturedEmail) error {
+	s.captured = append(s.captured, email)
+	return nil
+}
+
+func TestPoolSendCapturesSandboxedMessage(t *testing.T) {
+	sandbox := &testSandbox{settings: domain.NewEmailSandbox(uuid.New(), domain.SandboxCapture, nil, "")}
+	pool := NewPool(DefaultPoolConfig)
+	defer pool.Close()
+	pool.SetSandbox(sandbox)
+
+	account := testAccount(t, domain.MailgunAPI, "api.mailgun.net", "mg.example.com", "mg-key")
+	delivery := testDelivery(t, account)
+	result, err := pool.Send(context.Background(), plainEncryption{}, account, delivery.Request)
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if len(sandbox.captured) != 1 {
+		t.Fatalf("captured %d messages, want 1", len(sandbox.captured))
+	}
+
+	email := sandbox.captured[0]
+	if result.MessageID != email.GetID().String() {
+		t.Errorf("message ID = %q, want %q", result.MessageID, email.GetID())
+	}
+	if email.GetSubject() != "Your invoice" || !strings.Contains(email.GetHTML(), "Invoice attached.") {
+		t.Errorf("subject = %q, html = %q", email.GetSubject(), email.GetHTML())
+	}
+	if got := strings.Join(email.GetRecipients(), ","); got != "john@example.org,cc@example.org,bcc@example.org" {
+		t.Errorf("recipients = %s", got)
+	}
+	if attachments := email.GetAttachments(); len(attachments) != 2 {
+		t.Errorf("attachments = %+v", attachments)
+	}
+	if got := email.GetHeaders()["X-Campaign"]; len(got) != 1 {
+		t.Errorf("X-Campaign = %v", got)
+	}
+}
+
+func TestApplySandboxRedirectsRecipients(t *testing.T) {
+	sandbox := domain.NewEmailSandbox(uuid.New(), domain.SandboxAllowList, []string{"@example.org"}, "qa@example.net")
+	detail, _ := BaseEmailDetail("Hello", "<p>Hello</p>", testAddress(t, "noreply@example.com"), testAddress(t, "John <john@example.org>"))
+	detail.WithCc([]vo.Address{testAddress(t, "jane@customer.com"), testAddress(t, "joe@customer.com")})
+
+	result, ok := applySandbox(sandbox, detail)
+	if !ok {
+		t.Fatal("message captured, want redirected")
+	}
+	if got := strings.Join(result.recipients(), ","); got != "john@example.org,qa@example.net" {
+		t.Errorf("recipients = %s", got)
+	}
+	if got := result.headers[OriginalRecipientsHeader]; got != "jane@customer.com, joe@customer.com" {
+		t.Errorf("%s = %q", OriginalRecipientsHeader, got)
+	}
+	if len(detail.cc) != 2 || detail.headers[OriginalRecipientsHeader] != "" {
+		t.Error("the original request was changed")
+	}
+
+	// Without a redirect address the messages to other recipients are captured
+	sandbox.Change(domain.SandboxAllowList, []string{"@example.org"}, "")
+	if _, ok := applySandbox(sandbox, detail); ok {
+		t.Error("message redirected, want captured")
+	}
+}
+
+func TestApplySandboxEnforcesEnvironmentFloor(t *testing.T) {
+	projectID := uuid.New()
+	floor := domain.NewEmailSandbox(uuid.Nil, domain.SandboxAllowList, []string{"@example.org"}, "qa@example.net")
+	detail, _ := BaseEmailDetail("Hello", "<p>Hello</p>", testAddress(t, "noreply@example.com"), testAddress(t, "John <john@example.org>"))
+	detail.WithCc([]vo.Address{testAddress(t, "jane@customer.com"), testAddress(t, "boss@example.org")})
+
+	tests := []struct {
+		name       string
+		project    *domain.EmailSandbox
+		recipients string
+		captured   bool
+	}{
+		{"off", domain.NewEmailSandbox(projectID, domain.SandboxOff, nil, ""), "john@example.org,qa@example.net,boss@example.org", false},
+		{"wider allow list", domain.NewEmailSandbox(projectID, domain.SandboxAllowList, []string{"@customer.com", "@example.org"}, ""), "john@example.org,qa@example.net,boss@example.org", false},
+		{"narrower allow list", domain.NewEmailSandbox(projectID, domain.SandboxAllowList, []string{"john@example.org"}, "dev@example.net"), "john@example.org,dev@example.net", false},
+		{"capture", domain.NewEmailSandbox(projectID, domain.SandboxCapture, nil, ""), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := applySandbox(tt.project.Tighten(floor), detail)
+			if ok == tt.captured {
+				t.Fatalf("delivered = %v, want %v", ok, !tt.captured)
+			}
+			if tt.captured {
+				return
+			}
+			if got := strings.Join(result.recipients(), ","); got != tt.recipients {
+				t.Errorf("recipients = %s, want %s", got, tt.recipients)
+			}
+		})
+	}
+
+	// Without an environment sandbox the project can turn it off
+	project := domain.NewEmailSandbox(projectID, domain.SandboxOff, nil, "")
+	if got := project.Tighten(domain.NewEmailSandbox(uuid.Nil, domain.SandboxOff, nil, "")); got != project {
+		t.Errorf("Tighten = %+v, want the project settings", got)
+	}
+}
@@ -0,0 +1,146 @@
+package email_sender
+
+import (
+	"context"
+	"encoding/base64"
+	"mime"
+	"net/textproto"
+	vo "platform/pkg/domain/value_object"
+	"strings"
+)
+
+// sendgridReservedHeaders are set from the fields of the request, SendGrid refuses them as custom headers
+var sendgridReservedHeaders = map[string]bool{
+	"Date": true, "From": true, "To": true, "Cc": true, "Bcc": true, "Reply-To": true, "Subject": true,
+	"Content-Type": true, "Content-Transfer-Encoding": true, "Mime-Version": true, "Dkim-Signature": true,
+}
+
+// SendGridTransport sends with the v3 Mail Send API, SendGrid signs the messages with the DKIM key of the
+// authenticated domain
+type SendGridTransport struct {
+	baseURL string
+}
+
+func NewSendGridTransport() *SendGridTransport {
+	return &SendGridTransport{}
+}
+
+// WithBaseURL points the transport to another endpoint, e.g. a test server
+func (t *SendGridTransport) WithBaseURL(baseURL string) *SendGridTransport {
+	t.baseURL = strings.TrimSuffix(baseURL, "/")
+	return t
+}
+
+type sendgridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendgridPersonalization struct {
+	To  []sendgridAddress `json:"to"`
+	Cc  []sendgridAddress `json:"cc,omitempty"`
+	Bcc []sendgridAddress `json:"bcc,omitempty"`
+}
+
+type sendgridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendgridAttachment struct {
+	Content     string `json:"content"`
+	Type        string `json:"type,omitempty"`
+	Filename    string `json:"filename"`
+	Disposition string `json:"disposition"`
+	ContentID   string `json:"content_id,omitempty"`
+}
+
+type sendgridMessage struct {
+	Personalizations []sendgridPersonalization `json:"personalizations"`
+	From             sendgridAddress           `json:"from"`
+	ReplyTo          *sendgridAddress          `json:"reply_to,omitempty"`
+	Subject          string                    `json:"subject"`
+	Content          []sendgridContent         `json:"content"`
+	Attachments      []sendgridAttachment      `json:"attachments,omitempty"`
+	Headers          map[string]string         `json:"headers,omitempty"`
+}
+
+func (t *SendGridTransport) Deliver(ctx context.Context, delivery *Delivery) (*SendResult, error) {
+	// STEP-1: Get the parts of the built message
+	_, key, err := delivery.apiKey()
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseMessage(delivery.Message)
+	if err != nil {
+		return nil, classifyError(err, ErrorPermanent)
+	}
+
+	// STEP-2: Convert the message to the request of the API
+	request := delivery.Request
+	payload := sendgridMessage{
+		Personalizations: []sendgridPersonalization{{
+			To:  sendgridAddresses([]vo.Address{request.to}),
+			Cc:  sendgridAddresses(request.cc),
+			Bcc: sendgridAddresses(request.bcc),
+		}},
+		From:    sendgridAddresses([]vo.Address{request.from})[0],
+		Subject: request.subject,
+		Content: []sendgridContent{
+			{Type: "text/plain", Value: parsed.text},
+			{Type: "text/html", Value: parsed.html},
+		},
+		Headers: make(map[string]string),
+	}
+	if request.replyTo != nil {
+		payload.ReplyTo = &sendgridAddresses([]vo.Address{*request.replyTo})[0]
+	}
+	for _, attachment := range parsed.attachments {
+		disposition := "attachment"
+		if attachment.inline {
+			disposition = "inline"
+		}
+		payload.Attachments = append(payload.Attachments, sendgridAttachment{
+			Content:     base64.StdEncoding.EncodeToString(attachment.content),
+			Type:        attachment.contentType,
+			Filename:    attachment.name,
+			Disposition: disposition,
+			ContentID:   attachment.contentID,
+		})
+	}
+	decoder := new(mime.WordDecoder)
+	for key, values := range parsed.header {
+		key = textproto.CanonicalMIMEHeaderKey(key)
+		if sendgridReservedHeaders[key] || len(values) == 0 {
+			continue
+		}
+		value, err := decoder.DecodeHeader(values[0])
+		if err != nil {
+			value = values[0]
+		}
+		payload.Headers[key] = value
+	}
+
+	// STEP-3: Send it, the identifier of the message is returned in a header
+	req, _, err := newJSONRequest(ctx, baseURL(t.baseURL, delivery.Account)+"/v3/mail/send", payload)
+	if err != nil {
+		return nil, classifyError(err, ErrorPermanent)
+	}
+	req.Header.Set("Authorization", "Bearer "+key)
+	header, err := doJSON(req, "sendgrid", nil)
+	if err != nil {
+		return nil, err
+	}
+	return delivery.accepted(header.Get("X-Message-Id")), nil
+}
+
+func sendgridAddresses(addresses []vo.Address) []sendgridAddress {
+	if len(addresses) == 0 {
+		return nil
+	}
+	list := make([]sendgridAddress, len(addresses))
+	for i, addr := range addresses {
+		list[i] = sendgridAddress{Email: addr.Email().Value(), Name: addr.Name()}
+	}
+	return list
+}
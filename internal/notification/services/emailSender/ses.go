@@ -0,0 +1,152 @@
+package email_sender
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SESTransport sends the raw message with SendEmail of the Amazon SES v2 API. The host of the account is
+// the regional endpoint (email.<region>.amazonaws.com), its username the access key ID and its password
+// the secret access key.
+type SESTransport struct {
+	baseURL string
+	now     func() time.Time
+}
+
+func NewSESTransport() *SESTransport {
+	return &SESTransport{now: time.Now}
+}
+
+// WithBaseURL points the transport to another endpoint, e.g. a test server
+func (t *SESTransport) WithBaseURL(baseURL string) *SESTransport {
+	t.baseURL = strings.TrimSuffix(baseURL, "/")
+	return t
+}
+
+type sesDestination struct {
+	ToAddresses  []string `json:"ToAddresses,omitempty"`
+	CcAddresses  []string `json:"CcAddresses,omitempty"`
+	BccAddresses []string `json:"BccAddresses,omitempty"`
+}
+
+type sesRawContent struct {
+	Raw struct {
+		Data string `json:"Data"`
+	} `json:"Raw"`
+}
+
+type sesSendEmail struct {
+	FromEmailAddress string         `json:"FromEmailAddress"`
+	Destination      sesDestination `json:"Destination"`
+	Content          sesRawContent  `json:"Content"`
+}
+
+func (t *SESTransport) Deliver(ctx context.Context, delivery *Delivery) (*SendResult, error) {
+	accessKeyID, secret, err := delivery.apiKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-1: The envelope is given with the raw message, Bcc addresses included
+	request := delivery.Request
+	payload := sesSendEmail{FromEmailAddress: request.from.String()}
+	payload.Destination.ToAddresses = []string{request.to.String()}
+	for _, addr := range request.cc {
+		payload.Destination.CcAddresses = append(payload.Destination.CcAddresses, addr.String())
+	}
+	for _, addr := range request.bcc {
+		payload.Destination.BccAddresses = append(payload.Destination.BccAddresses, addr.String())
+	}
+	payload.Content.Raw.Data = base64.StdEncoding.EncodeToString(delivery.Message)
+
+	// STEP-2: Sign the request with the access key of the account
+	req, body, err := newJSONRequest(ctx, baseURL(t.baseURL, delivery.Account)+"/v2/email/outbound-emails", payload)
+	if err != nil {
+		return nil, classifyError(err, ErrorPermanent)
+	}
+	signV4(req, body, accessKeyID, secret, sesRegion(delivery.Account.GetHost()), "ses", t.now())
+
+	var result struct {
+		MessageID string `json:"MessageId"`
+	}
+	if _, err := doJSON(req, "ses", &result); err != nil {
+		return nil, err
+	}
+	return delivery.accepted(result.MessageID), nil
+}
+
+// sesRegion returns the region of a regional endpoint such as email.eu-west-1.amazonaws.com
+func sesRegion(host string) string {
+	parts := strings.Split(host, ".")
+	if len(parts) >= 4 && parts[0] == "email" {
+		return parts[1]
+	}
+	return "us-east-1"
+}
+
+// signV4 adds the AWS Signature Version 4 of the request to its headers, the signed headers are the ones
+// of the request plus host and x-amz-date
+func signV4(req *http.Request, body []byte, accessKeyID, secret, region, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	// STEP-1: Canonical request
+	headers := map[string]string{"host": req.URL.Host}
+	for key, values := range req.Header {
+		headers[strings.ToLower(key)] = strings.Join(values, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	// STEP-2: String to sign and signing key derived from the secret
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", date, region, service)
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
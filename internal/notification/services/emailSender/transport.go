@@ -0,0 +1,153 @@
+package email_sender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"platform/internal/notification/domain"
+	email_provider "platform/internal/notification/services/emailProvider"
+	"platform/internal/notification/services/encryption"
+	"strings"
+	"time"
+)
+
+var ErrTransportNotFound = errors.New("no transport is registered for the email provider")
+
+// Delivery is a built message handed to a transport
+type Delivery struct {
+	Account    *domain.EmailAccount
+	Request    *EmailDetail
+	Message    []byte
+	Encryption encryption.EncryptionService
+	Tokens     TokenSource
+}
+
+// Transport delivers the messages of the accounts of a provider, the SMTP pool and the HTTP APIs are
+// transports. Errors are *SendError values.
+type Transport interface {
+	Deliver(ctx context.Context, delivery *Delivery) (*SendResult, error)
+}
+
+// httpClient is shared by the HTTP transports
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// defaultTransports are the HTTP transports of the pools, keyed by the transport names of the providers
+func defaultTransports() map[string]Transport {
+	return map[string]Transport{
+		email_provider.TransportSendGrid: NewSendGridTransport(),
+		email_provider.TransportMailgun:  NewMailgunTransport(),
+		email_provider.TransportSES:      NewSESTransport(),
+		email_provider.TransportGmailAPI: NewGmailAPITransport(),
+		email_provider.TransportGraph:    NewGraphTransport(),
+	}
+}
+
+// apiKey returns the username and the decrypted password of an account sending with an API key
+func (d *Delivery) apiKey() (username, key string, err error) {
+	credentials := d.Account.GetTraditionalCredentials()
+	if credentials == nil {
+		return "", "", classifyError(ErrNoCredentials, ErrorConfiguration)
+	}
+	username, encrypted := credentials.Credentials()
+	key, err = d.Encryption.Decrypt(encrypted)
+	if err != nil {
+		return "", "", classifyError(err, ErrorAuth)
+	}
+	return username, key, nil
+}
+
+// accessToken returns a valid OAuth2 access token of the account
+func (d *Delivery) accessToken(ctx context.Context) (string, error) {
+	token, err := d.Tokens.Token(ctx, d.Account)
+	if err != nil {
+		return "", classifyError(err, ErrorAuth)
+	}
+	return token.AccessToken, nil
+}
+
+// accepted is the result of the APIs which accept or refuse the message as a whole
+func (d *Delivery) accepted(messageID string) *SendResult {
+	return &SendResult{Accepted: d.Request.recipients(), MessageID: messageID}
+}
+
+// withBcc adds the Bcc header to the message, the APIs taking a raw message read the recipients from its
+// headers and remove the Bcc header before sending it
+func (d *Delivery) withBcc() []byte {
+	if len(d.Request.bcc) == 0 {
+		return d.Message
+	}
+	bcc := make([]string, len(d.Request.bcc))
+	for i, addr := range d.Request.bcc {
+		bcc[i] = addr.String()
+	}
+	return append([]byte("Bcc: "+strings.Join(bcc, ", ")+"\r\n"), d.Message...)
+}
+
+// baseURL returns the URL of the API on the host of the account unless the transport is given another one
+func baseURL(override string, account *domain.EmailAccount) string {
+	if override != "" {
+		return override
+	}
+	return "https://" + account.GetHost()
+}
+
+// doJSON sends the request and decodes the JSON response into out when it is not nil, the headers of the
+// response are returned for the APIs giving the message identifier in a header
+func doJSON(req *http.Request, provider string, out any) (http.Header, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, classifyError(err, ErrorTransient)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, classifyError(err, ErrorTransient)
+	}
+	if resp.StatusCode >= 300 {
+		return nil, httpSendError(provider, resp.StatusCode, body)
+	}
+	if out == nil || len(bytes.TrimSpace(body)) == 0 {
+		return resp.Header, nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return nil, classifyError(fmt.Errorf("%s: unexpected response: %w", provider, err), ErrorPermanent)
+	}
+	return resp.Header, nil
+}
+
+func newJSONRequest(ctx context.Context, url string, payload any) (*http.Request, []byte, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, body, nil
+}
+
+// httpSendError classifies the error responses of the APIs: refused credentials, throttling and server
+// errors which may go away, and the requests the API will never accept
+func httpSendError(provider string, status int, body []byte) *SendError {
+	message := strings.TrimSpace(string(body))
+	if len(message) > 512 {
+		message = message[:512]
+	}
+	result := &SendError{Message: fmt.Sprintf("%s: HTTP %d %s", provider, status, message)}
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		result.Category = ErrorAuth
+	case status == http.StatusTooManyRequests || status == http.StatusRequestTimeout || status >= 500:
+		result.Category = ErrorTransient
+	default:
+		result.Category = ErrorPermanent
+	}
+	return result
+}
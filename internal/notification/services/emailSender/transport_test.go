@@ -0,0 +1,303 @@
+package email_sender
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"platform/internal/notification/domain"
+	voInternal "platform/internal/notification/domain/value_object"
+	email_provider "platform/internal/notification/services/emailProvider"
+	vo "platform/pkg/domain/value_object"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+type plainEncryption struct{}
+
+func (plainEncryption) Encrypt(plainText string) (string, error)  { return plainText, nil }
+func (plainEncryption) Decrypt(cipherText string) (string, error) { return cipherText, nil }
+
+type staticTokens string
+
+func (s staticTokens) Token(context.Context, *domain.EmailAccount) (*oauth2.Token, error) {
+	return &oauth2.Token{AccessToken: string(s)}, nil
+}
+
+func testAccount(t *testing.T, typeID int, host, username, secret string) *domain.EmailAccount {
+	t.Helper()
+	email, err := vo.NewEmail("noreply@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ea := &domain.EmailAccount{}
+	ea.SetEmail(email)
+	ea.SetSmtpType(typeID)
+	ea.SetHost(host)
+	ea.SetTraditionalCredentials(voInternal.NewTraditionalCredentials(username, secret))
+	return ea
+}
+
+func testDelivery(t *testing.T, account *domain.EmailAccount) *Delivery {
+	t.Helper()
+	detail, _ := BaseEmailDetail("Your invoice", `<p><img src="cid:logo"></p><p>Invoice attached.</p>`,
+		testAddress(t, "Billing <noreply@example.com>"), testAddress(t, "John <john@example.org>"))
+	detail.WithCc([]vo.Address{testAddress(t, "cc@example.org")}).
+		WithBcc([]vo.Address{testAddress(t, "bcc@example.org")}).
+		WithHeaders(map[string]string{"X-Campaign": "Güz"}).
+		WithInlineImage(Attachment{Name: "logo.png", ContentType: "image/png", ContentID: "logo", Content: strings.NewReader("png")}).
+		WithAttachment(Attachment{Name: "invoice.pdf", ContentType: "application/pdf", Content: strings.NewReader("%PDF")})
+
+	message, err := testBuilder(nil).Build(context.Background(), detail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Delivery{Account: account, Request: detail, Message: message, Encryption: plainEncryption{}, Tokens: staticTokens("token-1")}
+}
+
+func checkAccepted(t *testing.T, result *SendResult, err error, messageID string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	if result.MessageID != messageID {
+		t.Errorf("message ID = %q, want %q", result.MessageID, messageID)
+	}
+	want := []string{"john@example.org", "cc@example.org", "bcc@example.org"}
+	if strings.Join(result.Accepted, ",") != strings.Join(want, ",") {
+		t.Errorf("accepted = %v, want %v", result.Accepted, want)
+	}
+}
+
+func TestSendGridTransport(t *testing.T) {
+	var got sendgridMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/mail/send" || r.Header.Get("Authorization") != "Bearer SG.key" {
+			t.Errorf("unexpected request %s %s", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		w.Header().Set("X-Message-Id", "sg-1")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	delivery := testDelivery(t, testAccount(t, domain.SendGridAPI, "api.sendgrid.com", "apikey", "SG.key"))
+	result, err := NewSendGridTransport().WithBaseURL(srv.URL).Deliver(context.Background(), delivery)
+	checkAccepted(t, result, err, "sg-1")
+
+	personalization := got.Personalizations[0]
+	if personalization.To[0].Email != "john@example.org" || personalization.To[0].Name != "John" ||
+		personalization.Cc[0].Email != "cc@example.org" || personalization.Bcc[0].Email != "bcc@example.org" {
+		t.Errorf("personalization = %+v", personalization)
+	}
+	if got.From.Email != "noreply@example.com" || got.Subject != "Your invoice" {
+		t.Errorf("from = %+v, subject = %q", got.From, got.Subject)
+	}
+	if len(got.Content) != 2 || got.Content[0].Value != "Invoice attached." || !strings.Contains(got.Content[1].Value, `cid:logo`) {
+		t.Errorf("content = %+v", got.Content)
+	}
+	if len(got.Attachments) != 2 {
+		t.Fatalf("attachments = %+v", got.Attachments)
+	}
+	logo, invoice := got.Attachments[0], got.Attachments[1]
+	if logo.Disposition != "inline" || logo.ContentID != "logo" || logo.Content != base64.StdEncoding.EncodeToString([]byte("png")) {
+		t.Errorf("inline image = %+v", logo)
+	}
+	if invoice.Disposition != "attachment" || invoice.Filename != "invoice.pdf" || invoice.Type != "application/pdf" {
+		t.Errorf("attachment = %+v", invoice)
+	}
+	if got.Headers["X-Campaign"] != "Güz" || got.Headers["Message-Id"] == "" {
+		t.Errorf("headers = %v", got.Headers)
+	}
+	if _, ok := got.Headers["Subject"]; ok {
+		t.Errorf("reserved header sent: %v", got.Headers)
+	}
+}
+
+func TestMailgunTransport(t *testing.T) {
+	delivery := testDelivery(t, testAccount(t, domain.MailgunAPI, "api.eu.mailgun.net", "", "mg-key"))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if r.URL.Path != "/v3/example.com/messages.mime" || user != "api" || pass != "mg-key" {
+			t.Errorf("unexpected request %s %s:%s", r.URL.Path, user, pass)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatal(err)
+		}
+		if to := strings.Join(r.MultipartForm.Value["to"], ","); to != "john@example.org,cc@example.org,bcc@example.org" {
+			t.Errorf("to = %q", to)
+		}
+		file, _, err := r.FormFile("message")
+		if err != nil {
+			t.Fatal(err)
+		}
+		message, _ := io.ReadAll(file)
+		if string(message) != string(delivery.Message) {
+			t.Error("message differs from the built one")
+		}
+		w.Write([]byte(`{"id":"<mg-1@example.com>","message":"Queued. Thank you."}`))
+	}))
+	defer srv.Close()
+
+	result, err := NewMailgunTransport().WithBaseURL(srv.URL).Deliver(context.Background(), delivery)
+	checkAccepted(t, result, err, "<mg-1@example.com>")
+}
+
+func TestSESTransport(t *testing.T) {
+	var got sesSendEmail
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if r.URL.Path != "/v2/email/outbound-emails" ||
+			!strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/20261019/eu-west-1/ses/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=") {
+			t.Errorf("unexpected request %s %s", r.URL.Path, auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		w.Write([]byte(`{"MessageId":"ses-1"}`))
+	}))
+	defer srv.Close()
+
+	transport := NewSESTransport().WithBaseURL(srv.URL)
+	transport.now = func() time.Time { return time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC) }
+	delivery := testDelivery(t, testAccount(t, domain.AmazonSES, "email.eu-west-1.amazonaws.com", "AKID", "secret"))
+	result, err := transport.Deliver(context.Background(), delivery)
+	checkAccepted(t, result, err, "ses-1")
+
+	raw, _ := base64.StdEncoding.DecodeString(got.Content.Raw.Data)
+	if string(raw) != string(delivery.Message) {
+		t.Error("raw message differs from the built one")
+	}
+	if got.Destination.BccAddresses[0] != "bcc@example.org" || got.FromEmailAddress != delivery.Request.from.String() {
+		t.Errorf("envelope = %+v", got)
+	}
+}
+
+// TestSignV4 checks the get-vanilla case of the AWS Signature Version 4 test suite
+func TestSignV4(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	signV4(req, nil, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service",
+		time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, " +
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("authorization = %s\nwant %s", got, want)
+	}
+}
+
+func TestGmailAPITransport(t *testing.T) {
+	delivery := testDelivery(t, testAccount(t, domain.GmailAPI, "gmail.googleapis.com", "", ""))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gmail/v1/users/me/messages/send" || r.Header.Get("Authorization") != "Bearer token-1" {
+			t.Errorf("unexpected request %s %s", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		var payload struct{ Raw string }
+		json.NewDecoder(r.Body).Decode(&payload)
+		raw, err := base64.URLEncoding.DecodeString(payload.Raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(raw) != "Bcc: bcc@example.org\r\n"+string(delivery.Message) {
+			t.Errorf("raw message starts with %q", string(raw[:40]))
+		}
+		w.Write([]byte(`{"id":"gm-1","threadId":"th-1"}`))
+	}))
+	defer srv.Close()
+
+	result, err := NewGmailAPITransport().WithBaseURL(srv.URL).Deliver(context.Background(), delivery)
+	checkAccepted(t, result, err, "gm-1")
+}
+
+func TestGraphTransport(t *testing.T) {
+	delivery := testDelivery(t, testAccount(t, domain.MicrosoftGraph, "graph.microsoft.com", "", ""))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1.0/me/sendMail" || r.Header.Get("Content-Type") != "text/plain" {
+			t.Errorf("unexpected request %s %s", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		raw, err := base64.StdEncoding.DecodeString(string(body))
+		if err != nil || !strings.HasPrefix(string(raw), "Bcc: ") {
+			t.Errorf("body is not the base64 encoded message: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	result, err := NewGraphTransport().WithBaseURL(srv.URL).Deliver(context.Background(), delivery)
+	checkAccepted(t, result, err, "")
+}
+
+func TestHTTPTransportErrors(t *testing.T) {
+	cases := []struct {
+		status   int
+		category ErrorCategory
+	}{
+		{http.StatusUnauthorized, ErrorAuth},
+		{http.StatusForbidden, ErrorAuth},
+		{http.StatusTooManyRequests, ErrorTransient},
+		{http.StatusServiceUnavailable, ErrorTransient},
+		{http.StatusBadRequest, ErrorPermanent},
+	}
+	for _, c := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(c.status)
+			w.Write([]byte(`{"errors":[{"message":"refused"}]}`))
+		}))
+		delivery := testDelivery(t, testAccount(t, domain.SendGridAPI, "api.sendgrid.com", "apikey", "SG.key"))
+		_, err := NewSendGridTransport().WithBaseURL(srv.URL).Deliver(context.Background(), delivery)
+		srv.Close()
+
+		var sendErr *SendError
+		if !errors.As(err, &sendErr) || sendErr.Category != c.category {
+			t.Errorf("status %d: error = %v, want category %s", c.status, err, c.category)
+			continue
+		}
+		if sendErr.Temporary() != (c.category == ErrorTransient) {
+			t.Errorf("status %d: temporary = %t", c.status, sendErr.Temporary())
+		}
+	}
+}
+
+func TestPoolSendSelectsTransport(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"id":"mg-2"}`))
+	}))
+	defer srv.Close()
+
+	pool := NewPool(DefaultPoolConfig)
+	defer pool.Close()
+	pool.SetTransport(email_provider.TransportMailgun, NewMailgunTransport().WithBaseURL(srv.URL))
+
+	detail, _ := BaseEmailDetail("Hello", "<p>Hello</p>", testAddress(t, "noreply@example.com"), testAddress(t, "john@example.org"))
+	account := testAccount(t, domain.MailgunAPI, "api.mailgun.net", "mg.example.com", "mg-key")
+	result, err := pool.Send(context.Background(), plainEncryption{}, account, detail)
+	if err != nil || result.MessageID != "mg-2" || calls != 1 {
+		t.Fatalf("result = %+v, err = %v, calls = %d", result, err, calls)
+	}
+}
+
+func TestPoolSendBuildErrorIsPermanent(t *testing.T) {
+	pool := NewPool(DefaultPoolConfig)
+	defer pool.Close()
+
+	detail, _ := BaseEmailDetail("Hello", "<p>Hello</p>", testAddress(t, "noreply@example.com"), testAddress(t, "john@example.org"))
+	detail.WithInlineImage(Attachment{Name: "logo.png", Content: strings.NewReader("x")})
+	account := testAccount(t, domain.MailgunAPI, "api.mailgun.net", "mg.example.com", "mg-key")
+	_, err := pool.Send(context.Background(), plainEncryption{}, account, detail)
+
+	var sendErr *SendError
+	if !errors.As(err, &sendErr) || sendErr.Category != ErrorPermanent || !errors.Is(err, ErrContentIDRequired) {
+		t.Fatalf("err = %v, want a permanent error wrapping ErrContentIDRequired", err)
+	}
+}
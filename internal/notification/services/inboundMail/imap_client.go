@@ -0,0 +1,377 @@
+package inbound_mail
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrIMAPGreeting = errors.New("imap server did not greet with OK")
+
+// IMAPError is a NO or BAD completion of a command
+type IMAPError struct {
+	Command string
+	Status  string
+	Text    string
+}
+
+func (e *IMAPError) Error() string {
+	return fmt.Sprintf("imap %s: %s %s", e.Command, e.Status, e.Text)
+}
+
+var (
+	literalPattern     = regexp.MustCompile(`\{(\d+)\+?\}$`)
+	uidValidityPattern = regexp.MustCompile(`\[UIDVALIDITY (\d+)\]`)
+	uidNextPattern     = regexp.MustCompile(`\[UIDNEXT (\d+)\]`)
+	existsPattern      = regexp.MustCompile(`^\* \d+ EXISTS$`)
+)
+
+// imapLine is an untagged response, the literals it contains are cut out of the line
+type imapLine struct {
+	text     string
+	literals [][]byte
+}
+
+// imapClient speaks the subset of IMAP4rev1 (RFC 3501) the inbound workers need: login, folder
+// selection, UID search and fetch, and IDLE (RFC 2177)
+type imapClient struct {
+	conn         net.Conn
+	reader       *bufio.Reader
+	tag          int
+	capabilities map[string]bool
+	timeout      time.Duration
+}
+
+// dialIMAP connects and greets the server, the connection is upgraded with STARTTLS when implicit TLS
+// is not used
+func dialIMAP(ctx context.Context, host string, port int, enableSSL bool, timeout time.Duration) (*imapClient, error) {
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	dialer := &net.Dialer{Timeout: timeout}
+	tlsConfig := &tls.Config{ServerName: host}
+
+	var conn net.Conn
+	var err error
+	if enableSSL {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := newIMAPClient(conn, timeout)
+	if err := c.greet(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if !enableSSL && c.capabilities["STARTTLS"] {
+		if _, err := c.command("STARTTLS"); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		c.conn, c.reader = tlsConn, bufio.NewReader(tlsConn)
+		if err := c.capability(); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func newIMAPClient(conn net.Conn, timeout time.Duration) *imapClient {
+	return &imapClient{conn: conn, reader: bufio.NewReader(conn), timeout: timeout}
+}
+
+func (c *imapClient) greet() error {
+	c.conn.SetDeadline(time.Now().Add(c.timeout))
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line.text, "* OK") && !strings.HasPrefix(line.text, "* PREAUTH") {
+		return ErrIMAPGreeting
+	}
+	return c.capability()
+}
+
+func (c *imapClient) capability() error {
+	lines, err := c.command("CAPABILITY")
+	if err != nil {
+		return err
+	}
+	c.capabilities = make(map[string]bool)
+	for _, line := range lines {
+		if fields := strings.Fields(line.text); len(fields) > 2 && strings.EqualFold(fields[1], "CAPABILITY") {
+			for _, capability := range fields[2:] {
+				c.capabilities[strings.ToUpper(capability)] = true
+			}
+		}
+	}
+	return nil
+}
+
+// Login authenticates with a password
+func (c *imapClient) Login(username, password string) error {
+	if _, err := c.command("LOGIN " + quote(username) + " " + quote(password)); err != nil {
+		return err
+	}
+	return c.capability()
+}
+
+// AuthenticateXOAuth2 authenticates with an OAuth2 access token
+func (c *imapClient) AuthenticateXOAuth2(username, accessToken string) error {
+	initial := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("user=%s\x01auth=Bearer %s\x01\x01", username, accessToken)))
+
+	tag := c.nextTag()
+	command := "AUTHENTICATE XOAUTH2"
+	if c.capabilities["SASL-IR"] {
+		command += " " + initial
+		initial = ""
+	}
+	if err := c.write(tag + " " + command); err != nil {
+		return err
+	}
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case strings.HasPrefix(line.text, "+"):
+			// The server asks for the initial response, or sends the details of a failure which are
+			// acknowledged with an empty response before the NO completion
+			if err := c.write(initial); err != nil {
+				return err
+			}
+			initial = ""
+		case strings.HasPrefix(line.text, tag+" "):
+			if err := completion("AUTHENTICATE", tag, line.text); err != nil {
+				return err
+			}
+			return c.capability()
+		}
+	}
+}
+
+// Select opens the folder and returns its UID validity and the UID the next message will have
+func (c *imapClient) Select(folder string) (uidValidity, uidNext uint32, err error) {
+	lines, err := c.command("SELECT " + quote(folder))
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, line := range lines {
+		if match := uidValidityPattern.FindStringSubmatch(line.text); match != nil {
+			uidValidity = parseUID(match[1])
+		}
+		if match := uidNextPattern.FindStringSubmatch(line.text); match != nil {
+			uidNext = parseUID(match[1])
+		}
+	}
+	return uidValidity, uidNext, nil
+}
+
+// SearchAfter returns the UIDs greater than uid in ascending order
+func (c *imapClient) SearchAfter(uid uint32) ([]uint32, error) {
+	lines, err := c.command(fmt.Sprintf("UID SEARCH UID %d:*", uid+1))
+	if err != nil {
+		return nil, err
+	}
+	var uids []uint32
+	for _, line := range lines {
+		fields := strings.Fields(line.text)
+		if len(fields) < 2 || !strings.EqualFold(fields[1], "SEARCH") {
+			continue
+		}
+		for _, field := range fields[2:] {
+			// "n:*" matches the last message even when its UID is lower than n
+			if value := parseUID(field); value > uid {
+				uids = append(uids, value)
+			}
+		}
+	}
+	return sortUIDs(uids), nil
+}
+
+// Fetch returns the raw message without setting its \Seen flag
+func (c *imapClient) Fetch(uid uint32) ([]byte, error) {
+	lines, err := c.command(fmt.Sprintf("UID FETCH %d BODY.PEEK[]", uid))
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		if strings.Contains(line.text, "FETCH") && len(line.literals) > 0 {
+			return line.literals[0], nil
+		}
+	}
+	return nil, fmt.Errorf("imap: message %d not found", uid)
+}
+
+// Idle waits until the server reports a new message, maxWait elapses or ctx is cancelled. It reports
+// whether a new message arrived.
+func (c *imapClient) Idle(ctx context.Context, maxWait time.Duration) (bool, error) {
+	tag := c.nextTag()
+	if err := c.write(tag + " IDLE"); err != nil {
+		return false, err
+	}
+	line, err := c.readLine()
+	if err != nil {
+		return false, err
+	}
+	if !strings.HasPrefix(line.text, "+") {
+		return false, completion("IDLE", tag, line.text)
+	}
+
+	// Wait for EXISTS, the deadline ends the wait and the cancellation of ctx moves it to now
+	c.conn.SetDeadline(time.Now().Add(maxWait))
+	stop := context.AfterFunc(ctx, func() { c.conn.SetDeadline(time.Now()) })
+	arrived := false
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			var netErr net.Error
+			if !errors.As(err, &netErr) || !netErr.Timeout() {
+				stop()
+				return false, err
+			}
+			break
+		}
+		if existsPattern.MatchString(line.text) {
+			arrived = true
+			break
+		}
+	}
+	stop()
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	// End the IDLE command
+	c.conn.SetDeadline(time.Now().Add(c.timeout))
+	if err := c.write("DONE"); err != nil {
+		return false, err
+	}
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return false, err
+		}
+		if strings.HasPrefix(line.text, tag+" ") {
+			return arrived, completion("IDLE", tag, line.text)
+		}
+	}
+}
+
+func (c *imapClient) SupportsIdle() bool {
+	return c.capabilities["IDLE"]
+}
+
+// Close logs out and closes the connection
+func (c *imapClient) Close() error {
+	c.conn.SetDeadline(time.Now().Add(time.Second))
+	c.write(c.nextTag() + " LOGOUT")
+	return c.conn.Close()
+}
+
+// command sends a command and returns its untagged responses, NO and BAD completions are errors
+func (c *imapClient) command(command string) ([]imapLine, error) {
+	c.conn.SetDeadline(time.Now().Add(c.timeout))
+	tag := c.nextTag()
+	if err := c.write(tag + " " + command); err != nil {
+		return nil, err
+	}
+
+	var lines []imapLine
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(line.text, tag+" ") {
+			name, _, _ := strings.Cut(command, " ")
+			return lines, completion(name, tag, line.text)
+		}
+		lines = append(lines, line)
+	}
+}
+
+func (c *imapClient) nextTag() string {
+	c.tag++
+	return fmt.Sprintf("a%d", c.tag)
+}
+
+func (c *imapClient) write(line string) error {
+	_, err := io.WriteString(c.conn, line+"\r\n")
+	return err
+}
+
+// readLine reads a response line, the literals announced with {n} are read into the line
+func (c *imapClient) readLine() (imapLine, error) {
+	var result imapLine
+	var text strings.Builder
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			return result, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		text.WriteString(line)
+
+		match := literalPattern.FindStringSubmatch(line)
+		if match == nil {
+			result.text = text.String()
+			return result, nil
+		}
+		size, err := strconv.Atoi(match[1])
+		if err != nil || size > maxMessageSize {
+			return result, fmt.Errorf("imap: literal of %s bytes refused", match[1])
+		}
+		literal := make([]byte, size)
+		if _, err := io.ReadFull(c.reader, literal); err != nil {
+			return result, err
+		}
+		result.literals = append(result.literals, literal)
+	}
+}
+
+func completion(command, tag, line string) error {
+	status, text, _ := strings.Cut(strings.TrimPrefix(line, tag+" "), " ")
+	if strings.EqualFold(status, "OK") {
+		return nil
+	}
+	return &IMAPError{Command: command, Status: strings.ToUpper(status), Text: text}
+}
+
+// quote writes a string as an IMAP quoted string
+func quote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+func parseUID(value string) uint32 {
+	uid, _ := strconv.ParseUint(value, 10, 32)
+	return uint32(uid)
+}
+
+func sortUIDs(uids []uint32) []uint32 {
+	for i := 1; i < len(uids); i++ {
+		for j := i; j > 0 && uids[j] < uids[j-1]; j-- {
+			uids[j], uids[j-1] = uids[j-1], uids[j]
+		}
+	}
+	return uids
+}
@@ -0,0 +1,287 @@
+package inbound_mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	email_provider "platform/internal/notification/services/emailProvider"
+	email_sender "platform/internal/notification/services/emailSender"
+	"platform/internal/notification/services/encryption"
+	"platform/internal/shared"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// Interval is the time between two loads of the configured mailboxes
+	Interval = time.Minute
+
+	// PollInterval is the time between two checks of a mailbox whose server does not support IDLE
+	PollInterval = 2 * time.Minute
+
+	// idleTimeout ends IDLE before the 30 minutes after which the servers may drop the connection
+	idleTimeout = 25 * time.Minute
+
+	// commandTimeout is the time a server has to answer a command
+	commandTimeout = time.Minute
+
+	// maxBackoff is the longest wait before connecting again after a failure
+	maxBackoff = 15 * time.Minute
+)
+
+var ErrEmailAccountNotFound = errors.New("email account of the inbound mailbox not found")
+
+// IMAPReceiver reads the new messages of the inbound mailboxes, every mailbox has its own connection which
+// waits with IDLE when the server supports it and polls otherwise
+type IMAPReceiver struct {
+	encryption             encryption.EncryptionService
+	tokens                 email_sender.TokenSource
+	processor              *Processor
+	mailboxRepository      repositories.InboundMailboxRepository
+	emailAccountRepository repositories.EmailAccountRepository
+
+	mu      sync.Mutex
+	workers map[uuid.UUID]*imapWorker
+}
+
+// imapWorker is the goroutine of a mailbox, it is restarted when the settings of the mailbox change
+type imapWorker struct {
+	settings string
+	cancel   context.CancelFunc
+}
+
+func NewIMAPReceiver(
+	encryption encryption.EncryptionService,
+	tokens email_sender.TokenSource,
+	processor *Processor,
+	mailboxRepository repositories.InboundMailboxRepository,
+	emailAccountRepository repositories.EmailAccountRepository,
+) *IMAPReceiver {
+	return &IMAPReceiver{
+		encryption:             encryption,
+		tokens:                 tokens,
+		processor:              processor,
+		mailboxRepository:      mailboxRepository,
+		emailAccountRepository: emailAccountRepository,
+		workers:                make(map[uuid.UUID]*imapWorker),
+	}
+}
+
+// Run starts a worker for every inbound mailbox and keeps them in line with the configured mailboxes every
+// Interval until ctx is cancelled
+func (r *IMAPReceiver) Run(ctx context.Context) {
+	ticker := time.NewTicker(Interval)
+	defer ticker.Stop()
+
+	r.Tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			r.stopAll()
+			return
+		case <-ticker.C:
+			r.Tick(ctx)
+		}
+	}
+}
+
+// Tick starts the workers of the new mailboxes, restarts the ones whose settings changed and stops the
+// ones of the deleted mailboxes
+func (r *IMAPReceiver) Tick(ctx context.Context) {
+	mailboxes, err := r.mailboxRepository.GetAll(ctx)
+	if err != nil {
+		zap.L().Error("failed to get inbound mailboxes", zap.Error(err))
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	configured := make(map[uuid.UUID]bool, len(mailboxes))
+	for _, mailbox := range mailboxes {
+		id := mailbox.GetEmailAccountID()
+		configured[id] = true
+
+		settings := fmt.Sprintf("%s:%d/%t/%s/%t", mailbox.GetHost(), mailbox.GetPort(), mailbox.GetEnableSSL(), mailbox.GetFolder(), mailbox.GetUseIdle())
+		if worker, ok := r.workers[id]; ok {
+			if worker.settings == settings {
+				continue
+			}
+			worker.cancel()
+		}
+
+		workerCtx, cancel := context.WithCancel(ctx)
+		r.workers[id] = &imapWorker{settings: settings, cancel: cancel}
+		go r.watch(workerCtx, mailbox)
+	}
+
+	for id, worker := range r.workers {
+		if !configured[id] {
+			worker.cancel()
+			delete(r.workers, id)
+		}
+	}
+}
+
+func (r *IMAPReceiver) stopAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, worker := range r.workers {
+		worker.cancel()
+		delete(r.workers, id)
+	}
+}
+
+// watch reads the mailbox until ctx is cancelled, it connects again after a failure with an exponential
+// backoff and records the error on the mailbox
+func (r *IMAPReceiver) watch(ctx context.Context, mailbox *domain.InboundMailbox) {
+	ctx = context.WithValue(ctx, shared.ProjectIDContextKey, mailbox.GetProjectID())
+	backoff := time.Duration(0)
+
+	for ctx.Err() == nil {
+		started := time.Now()
+		err := r.session(ctx, mailbox)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// A connection which lasted is not a failing server, the backoff starts over
+		if time.Since(started) > maxBackoff {
+			backoff = 0
+		}
+		backoff = min(max(2*backoff, 10*time.Second), maxBackoff)
+		zap.L().Warn("inbound mailbox disconnected", zap.String("email_account_id", mailbox.GetEmailAccountID().String()), zap.Error(err), zap.Duration("retry_in", backoff))
+		if err != nil {
+			mailbox.SetLastError(err.Error())
+			if err := r.mailboxRepository.UpdateCursor(ctx, mailbox); err != nil {
+				zap.L().Error("failed to save inbound mailbox", zap.Error(err))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// session connects to the server and processes the new messages until the connection fails
+func (r *IMAPReceiver) session(ctx context.Context, mailbox *domain.InboundMailbox) error {
+	// STEP-1: Get the email account
+	account, err := r.getEmailAccount(ctx, mailbox.GetEmailAccountID())
+	if err != nil {
+		return err
+	}
+
+	// STEP-2: Connect and log in
+	client, err := dialIMAP(ctx, mailbox.GetHost(), mailbox.GetPort(), mailbox.GetEnableSSL(), commandTimeout)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := r.login(ctx, client, account); err != nil {
+		return err
+	}
+
+	// STEP-3: Open the folder, the messages already there when it is first read are skipped
+	uidValidity, uidNext, err := client.Select(mailbox.GetFolder())
+	if err != nil {
+		return err
+	}
+	if uidValidity != mailbox.GetUIDValidity() {
+		mailbox.SetCursor(uidValidity, max(uidNext, 1)-1)
+	}
+	mailbox.SetLastError("")
+	if err := r.mailboxRepository.UpdateCursor(ctx, mailbox); err != nil {
+		return err
+	}
+
+	// STEP-4: Process the new messages, then wait for the next ones
+	for {
+		if err := r.fetchNew(ctx, client, account, mailbox); err != nil {
+			return err
+		}
+
+		if mailbox.GetUseIdle() && client.SupportsIdle() {
+			if _, err := client.Idle(ctx, idleTimeout); err != nil {
+				return err
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(PollInterval):
+		}
+	}
+}
+
+func (r *IMAPReceiver) login(ctx context.Context, client *imapClient, account *domain.EmailAccount) error {
+	provider, err := email_provider.Get(account.GetSmtpType())
+	if err != nil {
+		return err
+	}
+	if !provider.Mechanism().OAuth2() {
+		credentials := account.GetTraditionalCredentials()
+		if credentials == nil {
+			return email_sender.ErrNoCredentials
+		}
+		username, password := credentials.Credentials()
+		rawPassword, err := r.encryption.Decrypt(password)
+		if err != nil {
+			return err
+		}
+		return client.Login(username, rawPassword)
+	}
+
+	// The access token granted for sending is used, the grant must include the IMAP scope of the provider
+	token, err := r.tokens.Token(ctx, account)
+	if err != nil {
+		return err
+	}
+	return client.AuthenticateXOAuth2(account.GetEmail().Value(), token.AccessToken)
+}
+
+// fetchNew processes the messages after the cursor, the cursor is saved after every message so a message
+// is processed again only when the connection fails while it is processed
+func (r *IMAPReceiver) fetchNew(ctx context.Context, client *imapClient, account *domain.EmailAccount, mailbox *domain.InboundMailbox) error {
+	uids, err := client.SearchAfter(mailbox.GetLastUID())
+	if err != nil {
+		return err
+	}
+	for _, uid := range uids {
+		raw, err := client.Fetch(uid)
+		if err != nil {
+			return err
+		}
+		if err := r.processor.Process(ctx, domain.InboundSourceIMAP, account, raw); err != nil {
+			// A message which cannot be parsed would block the mailbox, it is skipped
+			zap.L().Warn("failed to process inbound email", zap.String("email_account_id", account.GetID().String()), zap.Uint32("uid", uid), zap.Error(err))
+		}
+
+		mailbox.SetCursor(mailbox.GetUIDValidity(), uid)
+		if err := r.mailboxRepository.UpdateCursor(ctx, mailbox); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *IMAPReceiver) getEmailAccount(ctx context.Context, accountID uuid.UUID) (*domain.EmailAccount, error) {
+	accounts, err := r.emailAccountRepository.GetAll(ctx, shared.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts.Items {
+		if account.GetID() == accountID {
+			return account, nil
+		}
+	}
+	return nil, ErrEmailAccountNotFound
+}
@@ -0,0 +1,170 @@
+package inbound_mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"platform/internal/notification/domain"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+// wordDecoder decodes the encoded words (RFC 2047) of the headers in any charset
+var wordDecoder = &mime.WordDecoder{CharsetReader: charset.NewReaderLabel}
+
+// Parse reads a raw message into an inbound email, the identifiers and the source are left to the caller
+func Parse(raw []byte) (*domain.InboundEmail, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	email := &domain.InboundEmail{
+		MessageID:  messageID(msg.Header.Get("Message-Id")),
+		InReplyTo:  messageID(msg.Header.Get("In-Reply-To")),
+		References: messageIDs(msg.Header.Get("References")),
+		To:         addresses(msg.Header, "To"),
+		Cc:         addresses(msg.Header, "Cc"),
+		Subject:    decodeHeader(msg.Header.Get("Subject")),
+		ReceivedAt: time.Now(),
+	}
+	if from := addresses(msg.Header, "From"); len(from) > 0 {
+		email.From = from[0]
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		email.ReceivedAt = date
+	}
+
+	if err := walk(email, textproto.MIMEHeader(msg.Header), msg.Body); err != nil {
+		return nil, err
+	}
+	return email, nil
+}
+
+// walk collects the text parts and describes the files of the tree of parts
+func walk(email *domain.InboundEmail, header textproto.MIMEHeader, body io.Reader) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		// Bounces are delivery status notifications (RFC 3464)
+		if mediaType == "multipart/report" && strings.EqualFold(params["report-type"], "delivery-status") {
+			email.Bounce = true
+		}
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := walk(email, part.Header, part); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(decodeTransfer(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return err
+	}
+
+	disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	switch {
+	case disposition != "attachment" && mediaType == "text/plain" && email.Text == "":
+		email.Text = decodeCharset(content, params["charset"])
+	case disposition != "attachment" && mediaType == "text/html" && email.HTML == "":
+		email.HTML = decodeCharset(content, params["charset"])
+	default:
+		name := dispositionParams["filename"]
+		if name == "" {
+			name = params["name"]
+		}
+		email.Attachments = append(email.Attachments, domain.InboundAttachment{
+			Name:        decodeHeader(name),
+			ContentType: mediaType,
+			ContentID:   strings.Trim(header.Get("Content-Id"), "<>"),
+			Size:        len(content),
+		})
+	}
+	return nil
+}
+
+func decodeTransfer(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+// decodeCharset converts a text part to UTF-8, the content is kept as is when the charset is unknown
+func decodeCharset(content []byte, label string) string {
+	if label == "" || strings.EqualFold(label, "utf-8") || strings.EqualFold(label, "us-ascii") {
+		return string(content)
+	}
+	reader, err := charset.NewReaderLabel(label, bytes.NewReader(content))
+	if err != nil {
+		return string(content)
+	}
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return string(content)
+	}
+	return string(decoded)
+}
+
+func decodeHeader(value string) string {
+	decoded, err := wordDecoder.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+// addresses returns the addresses of a header, the display names are dropped
+func addresses(header mail.Header, key string) []string {
+	parser := mail.AddressParser{WordDecoder: wordDecoder}
+	list, err := parser.ParseList(header.Get(key))
+	if err != nil {
+		return nil
+	}
+	result := make([]string, 0, len(list))
+	for _, address := range list {
+		result = append(result, strings.ToLower(address.Address))
+	}
+	return result
+}
+
+func messageID(value string) string {
+	ids := messageIDs(value)
+	if len(ids) == 0 {
+		return ""
+	}
+	return ids[0]
+}
+
+// messageIDs returns the identifiers of a header such as References without their angle brackets
+func messageIDs(value string) []string {
+	var ids []string
+	for _, field := range strings.Fields(value) {
+		if id := strings.Trim(field, "<>,"); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
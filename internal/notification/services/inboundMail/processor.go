@@ -0,0 +1,95 @@
+// Package inbound_mail receives the messages sent to the email accounts, either by reading their IMAP
+// mailbox or with an embedded SMTP server for a receiving domain, and publishes them on the event bus as
+// InboundEmail events. The delivery status notifications among them go to the bounce handler.
+package inbound_mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/domain/domain_event"
+	bounce_handler "platform/internal/notification/services/bounceHandler"
+	delivery_tracker "platform/internal/notification/services/deliveryTracker"
+	"platform/internal/shared"
+	event_bus "platform/pkg/services/eventbus"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxMessageSize is the size of the largest message received
+const maxMessageSize = 25 << 20
+
+var ErrInvalidMessage = errors.New("invalid message")
+
+type Processor struct {
+	bus     event_bus.EventBus
+	bounces *bounce_handler.Handler
+	tracker *delivery_tracker.Tracker
+}
+
+func NewProcessor(bus event_bus.EventBus, bounces *bounce_handler.Handler, tracker *delivery_tracker.Tracker) *Processor {
+	return &Processor{bus: bus, bounces: bounces, tracker: tracker}
+}
+
+// Process parses a received message and publishes it, account is nil when the message is addressed to no
+// email account
+func (p *Processor) Process(ctx context.Context, source string, account *domain.EmailAccount, raw []byte) error {
+	// STEP-1: Parse the message
+	email, err := Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+	if err := email.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+	email.ID = uuid.New()
+	email.Source = source
+	if account != nil {
+		email.ProjectID = account.GetProjectID()
+		email.EmailAccountID = account.GetID()
+	}
+
+	// STEP-2: Suppress the hard-bounced recipients, the project of the account owns the suppression list
+	if email.Bounce && account != nil {
+		report, err := bounce_handler.ParseDSN(bytes.NewReader(raw))
+		switch {
+		case errors.Is(err, bounce_handler.ErrNotDeliveryReport):
+			email.Bounce = false
+		case err != nil:
+			return err
+		default:
+			bounceCtx := context.WithValue(ctx, shared.ProjectIDContextKey, account.GetProjectID())
+			if err := p.handleDSN(bounceCtx, account, report); err != nil {
+				return err
+			}
+		}
+	}
+
+	// STEP-3: Publish the inbound email
+	return p.bus.Publish(ctx, domain_event.NewInboundEmailReceivedEvent(*email))
+}
+
+// handleDSN suppresses the recipient of a report only when it is about a message the project sent to it,
+// otherwise anyone could get any address suppressed by mailing a forged report to the account
+func (p *Processor) handleDSN(ctx context.Context, account *domain.EmailAccount, report *bounce_handler.Report) error {
+	delivery, err := p.tracker.RecordDSN(ctx, report)
+	if err != nil {
+		return err
+	}
+	if delivery == nil {
+		zap.L().Info("ignored delivery status notification of an unknown message", zap.String("email_account_id", account.GetID().String()), zap.String("original_message_id", report.OriginalMessageID))
+		return nil
+	}
+
+	suppressed, err := p.bounces.HandleReport(ctx, report.For(delivery.GetRecipient()))
+	if err != nil {
+		return err
+	}
+	if len(suppressed) > 0 {
+		zap.L().Info("suppressed bounced recipients", zap.String("email_account_id", account.GetID().String()), zap.Strings("recipients", suppressed))
+	}
+	return nil
+}
@@ -0,0 +1,392 @@
+package inbound_mail
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	vo "platform/pkg/domain/value_object"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// maxRecipients is the number of recipients a message can have (RFC 5321 4.5.3.1.8)
+	maxRecipients = 100
+
+	// sessionTimeout is the time a client has to send its next command
+	sessionTimeout = 5 * time.Minute
+
+	// maxCommandLine is the length of a command line with its CRLF (RFC 5321 4.5.3.1.4)
+	maxCommandLine = 512
+
+	// DefaultMaxSessions is the number of connections served at once, the others are refused
+	DefaultMaxSessions = 100
+)
+
+var errLineTooLong = errors.New("command line too long")
+
+// SMTPReceiver is an SMTP server accepting the messages sent to its receiving domains, it does not relay.
+// A message is processed once for every email account among its recipients, or once without an account
+// when none matches so the bounces sent to a VERP or catch-all address still reach the event bus.
+type SMTPReceiver struct {
+	addr                   string
+	hostname               string
+	domains                map[string]bool
+	tlsConfig              *tls.Config
+	maxSessions            int
+	emailAccountRepository repositories.EmailAccountRepository
+	processor              *Processor
+
+	wg sync.WaitGroup
+}
+
+func NewSMTPReceiver(addr, hostname string, domains []string, emailAccountRepository repositories.EmailAccountRepository, processor *Processor) *SMTPReceiver {
+	if hostname == "" {
+		hostname = "localhost"
+	}
+	accepted := make(map[string]bool, len(domains))
+	for _, name := range domains {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			accepted[name] = true
+		}
+	}
+	return &SMTPReceiver{
+		addr:                   addr,
+		hostname:               hostname,
+		domains:                accepted,
+		maxSessions:            DefaultMaxSessions,
+		emailAccountRepository: emailAccountRepository,
+		processor:              processor,
+	}
+}
+
+// WithTLS offers STARTTLS with the given certificates
+func (s *SMTPReceiver) WithTLS(config *tls.Config) *SMTPReceiver {
+	s.tlsConfig = config
+	return s
+}
+
+// WithMaxSessions changes the number of connections served at once
+func (s *SMTPReceiver) WithMaxSessions(maxSessions int) *SMTPReceiver {
+	s.maxSessions = maxSessions
+	return s
+}
+
+// Run listens on the address of the receiver until ctx is cancelled
+func (s *SMTPReceiver) Run(ctx context.Context) {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		zap.L().Error("failed to start inbound SMTP server", zap.String("addr", s.addr), zap.Error(err))
+		return
+	}
+	zap.L().Info("inbound SMTP server started", zap.String("addr", s.addr))
+	if err := s.Serve(ctx, listener); err != nil {
+		zap.L().Error("inbound SMTP server stopped", zap.Error(err))
+	}
+}
+
+// Serve accepts the connections of the listener until ctx is cancelled, it waits for the open sessions
+// before returning. The connections over the session limit are told to try again later.
+func (s *SMTPReceiver) Serve(ctx context.Context, listener net.Listener) error {
+	stop := context.AfterFunc(ctx, func() { listener.Close() })
+	defer stop()
+
+	sessions := make(chan struct{}, s.maxSessions)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			s.wg.Wait()
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		select {
+		case sessions <- struct{}{}:
+		default:
+			conn.SetDeadline(time.Now().Add(time.Second))
+			fmt.Fprintf(conn, "421 4.3.2 %s Too many connections, try again later\r\n", s.hostname)
+			conn.Close()
+			continue
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() { <-sessions }()
+			s.serveConn(ctx, conn)
+		}()
+	}
+}
+
+// smtpSession is the state of a connection, it is reset after every message
+type smtpSession struct {
+	conn       net.Conn
+	text       *textproto.Conn
+	tls        bool
+	helo       string
+	from       string
+	recipients []string
+}
+
+func (s *smtpSession) reply(code int, format string, args ...any) {
+	s.text.PrintfLine("%d %s", code, fmt.Sprintf(format, args...))
+}
+
+// readCommand reads a command line, a line longer than maxCommandLine is read to its end and refused.
+// The lines are not read with textproto.Reader.ReadLine, which keeps the whole line in memory.
+func (s *smtpSession) readCommand() (string, error) {
+	var line []byte
+	tooLong := false
+	for {
+		chunk, err := s.text.R.ReadSlice('\n')
+		if !tooLong {
+			line = append(line, chunk...)
+			tooLong = len(line) > maxCommandLine
+		}
+		switch {
+		case errors.Is(err, bufio.ErrBufferFull):
+			continue
+		case err != nil:
+			return "", err
+		case tooLong:
+			return "", errLineTooLong
+		}
+		return strings.TrimSuffix(strings.TrimSuffix(string(line), "\n"), "\r"), nil
+	}
+}
+
+func (s *smtpSession) reset() {
+	s.from = ""
+	s.recipients = nil
+}
+
+func (s *SMTPReceiver) serveConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	session := &smtpSession{conn: conn, text: textproto.NewConn(conn)}
+	session.reply(220, "%s ESMTP ready", s.hostname)
+
+	for {
+		conn.SetDeadline(time.Now().Add(sessionTimeout))
+		line, err := session.readCommand()
+		if errors.Is(err, errLineTooLong) {
+			session.reply(500, "5.5.2 Line too long")
+			continue
+		}
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		arg = strings.TrimSpace(arg)
+
+		switch strings.ToUpper(verb) {
+		case "HELO":
+			session.helo = arg
+			session.reset()
+			session.reply(250, "%s", s.hostname)
+		case "EHLO":
+			session.helo = arg
+			session.reset()
+			s.replyEhlo(session)
+		case "STARTTLS":
+			if s.tlsConfig == nil || session.tls {
+				session.reply(502, "5.5.1 STARTTLS not available")
+				continue
+			}
+			session.reply(220, "2.0.0 Ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.HandshakeContext(ctx); err != nil {
+				return
+			}
+			conn = tlsConn
+			session.conn, session.text, session.tls, session.helo = tlsConn, textproto.NewConn(tlsConn), true, ""
+			session.reset()
+		case "MAIL":
+			s.mail(session, arg)
+		case "RCPT":
+			s.rcpt(session, arg)
+		case "DATA":
+			if err := s.data(ctx, session); err != nil {
+				return
+			}
+		case "RSET":
+			session.reset()
+			session.reply(250, "2.0.0 OK")
+		case "NOOP":
+			session.reply(250, "2.0.0 OK")
+		case "VRFY":
+			session.reply(252, "2.5.0 Cannot verify the user, the message will be accepted")
+		case "QUIT":
+			session.reply(221, "2.0.0 Bye")
+			return
+		default:
+			session.reply(502, "5.5.2 Command not recognized")
+		}
+	}
+}
+
+func (s *SMTPReceiver) replyEhlo(session *smtpSession) {
+	extensions := []string{s.hostname, "PIPELINING", "8BITMIME", "SIZE " + strconv.Itoa(maxMessageSize)}
+	if s.tlsConfig != nil && !session.tls {
+		extensions = append(extensions, "STARTTLS")
+	}
+	for i, extension := range extensions {
+		separator := "-"
+		if i == len(extensions)-1 {
+			separator = " "
+		}
+		session.text.PrintfLine("250%s%s", separator, extension)
+	}
+}
+
+func (s *SMTPReceiver) mail(session *smtpSession, arg string) {
+	if session.helo == "" {
+		session.reply(503, "5.5.1 Send EHLO first")
+		return
+	}
+	if session.from != "" {
+		session.reply(503, "5.5.1 Sender already given")
+		return
+	}
+	if !strings.HasPrefix(strings.ToUpper(arg), "FROM:") {
+		session.reply(501, "5.5.4 Syntax: MAIL FROM:<address>")
+		return
+	}
+	path, params := splitPath(arg[len("FROM:"):])
+	for _, param := range params {
+		key, value, _ := strings.Cut(param, "=")
+		if strings.EqualFold(key, "SIZE") {
+			if size, err := strconv.Atoi(value); err == nil && size > maxMessageSize {
+				session.reply(552, "5.3.4 Message too big")
+				return
+			}
+		}
+	}
+
+	// The null reverse-path of the bounces is kept as "<>"
+	session.from = "<" + path + ">"
+	session.reply(250, "2.1.0 OK")
+}
+
+func (s *SMTPReceiver) rcpt(session *smtpSession, arg string) {
+	if session.from == "" {
+		session.reply(503, "5.5.1 Send MAIL first")
+		return
+	}
+	if !strings.HasPrefix(strings.ToUpper(arg), "TO:") {
+		session.reply(501, "5.5.4 Syntax: RCPT TO:<address>")
+		return
+	}
+	if len(session.recipients) >= maxRecipients {
+		session.reply(452, "4.5.3 Too many recipients")
+		return
+	}
+
+	path, _ := splitPath(arg[len("TO:"):])
+	email, err := vo.NewEmail(path)
+	if err != nil {
+		session.reply(501, "5.1.3 Bad recipient address syntax")
+		return
+	}
+	_, host, _ := strings.Cut(strings.ToLower(email.Value()), "@")
+	if !s.domains[host] {
+		session.reply(550, "5.7.1 Relaying denied")
+		return
+	}
+	session.recipients = append(session.recipients, email.Value())
+	session.reply(250, "2.1.5 OK")
+}
+
+// data reads the message and processes it, the error is returned when the connection cannot be used anymore
+func (s *SMTPReceiver) data(ctx context.Context, session *smtpSession) error {
+	if len(session.recipients) == 0 {
+		session.reply(503, "5.5.1 Send RCPT first")
+		return nil
+	}
+	session.reply(354, "Start mail input; end with <CRLF>.<CRLF>")
+
+	// The message is read to its end even when it is too big, so the session stays in sync
+	reader := session.text.DotReader()
+	message, err := io.ReadAll(io.LimitReader(reader, maxMessageSize+1))
+	if err != nil {
+		return err
+	}
+	if len(message) > maxMessageSize {
+		if _, err := io.Copy(io.Discard, reader); err != nil {
+			return err
+		}
+		session.reset()
+		session.reply(552, "5.3.4 Message too big")
+		return nil
+	}
+
+	// Keep the trace of the delivery as the first header (RFC 5321 4.4)
+	received := fmt.Sprintf("Received: from %s (%s)\r\n\tby %s with ESMTP;\r\n\t%s\r\n",
+		session.helo, session.conn.RemoteAddr(), s.hostname, time.Now().Format(time.RFC1123Z))
+	raw := append([]byte(received), message...)
+
+	err = s.deliver(ctx, session.recipients, raw)
+	session.reset()
+	switch {
+	case errors.Is(err, ErrInvalidMessage):
+		session.reply(554, "5.6.0 %v", err)
+		return nil
+	case err != nil:
+		zap.L().Error("failed to process inbound email", zap.Error(err))
+		session.reply(451, "4.3.0 Message not processed, try again later")
+		return nil
+	}
+	session.reply(250, "2.0.0 OK queued")
+	return nil
+}
+
+// deliver processes the message for every email account among the recipients
+func (s *SMTPReceiver) deliver(ctx context.Context, recipients []string, raw []byte) error {
+	processed := make(map[string]bool)
+	for _, recipient := range recipients {
+		email, err := vo.NewEmail(recipient)
+		if err != nil {
+			continue
+		}
+		accounts, err := s.emailAccountRepository.GetByAddress(ctx, email)
+		if err != nil {
+			return err
+		}
+		for _, account := range accounts {
+			if processed[account.GetID().String()] {
+				continue
+			}
+			processed[account.GetID().String()] = true
+			if err := s.processor.Process(ctx, domain.InboundSourceSMTP, account, raw); err != nil {
+				return err
+			}
+		}
+	}
+	if len(processed) > 0 {
+		return nil
+	}
+	return s.processor.Process(ctx, domain.InboundSourceSMTP, nil, raw)
+}
+
+// splitPath returns the address of a "<address> PARAM=value" argument and its parameters
+func splitPath(arg string) (string, []string) {
+	arg = strings.TrimSpace(arg)
+	fields := strings.Fields(arg)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return strings.Trim(fields[0], "<>"), fields[1:]
+}
@@ -0,0 +1,347 @@
+package inbound_mail
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/repositories"
+	bounce_handler "platform/internal/notification/services/bounceHandler"
+	delivery_tracker "platform/internal/notification/services/deliveryTracker"
+	pkgDomain "platform/pkg/domain"
+	vo "platform/pkg/domain/value_object"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// testAccounts knows a single email account, the other methods are not used by the receiver
+type testAccounts struct {
+	repositories.EmailAccountRepository
+	account *domain.EmailAccount
+}
+
+func (r testAccounts) GetByAddress(_ context.Context, email vo.Email) ([]*domain.EmailAccount, error) {
+	if strings.EqualFold(email.Value(), r.account.GetEmail().Value()) {
+		return []*domain.EmailAccount{r.account}, nil
+	}
+	return nil, nil
+}
+
+// testDeliveries knows the deliveries it holds by Message-ID
+type testDeliveries struct {
+	repositories.EmailDeliveryRepository
+	deliveries []*domain.EmailDelivery
+}
+
+func (r testDeliveries) GetByMessageID(_ context.Context, messageID string) (*domain.EmailDelivery, error) {
+	for _, delivery := range r.deliveries {
+		if delivery.GetMessageID() == messageID {
+			return delivery, nil
+		}
+	}
+	return nil, nil
+}
+
+func (testDeliveries) Save(context.Context, *domain.EmailDelivery) error { return nil }
+
+// testRecorder keeps the published events and the suppressed addresses, the sessions run in their own
+// goroutines
+type testRecorder struct {
+	repositories.SuppressionRepository
+	mu         sync.Mutex
+	emails     []domain.InboundEmail
+	suppressed []string
+}
+
+func (r *testRecorder) Publish(_ context.Context, event pkgDomain.DomainEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.emails = append(r.emails, event.(*pkgDomain.BaseDomainEvent).Payload.(domain.InboundEmail))
+	return nil
+}
+
+func (r *testRecorder) Subscribe(string, string, func(context.Context, pkgDomain.DomainEvent) error) (string, error) {
+	return "", nil
+}
+func (r *testRecorder) Unsubscribe(string) error { return nil }
+func (r *testRecorder) Close()                   {}
+
+func (r *testRecorder) Save(_ context.Context, suppression *domain.Suppression) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.suppressed = append(r.suppressed, suppression.GetEmail().Value())
+	return nil
+}
+
+func (r *testRecorder) received() ([]domain.InboundEmail, []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.emails, r.suppressed
+}
+
+// startReceiver serves a receiver for in.example.com on a local port, support@in.example.com is the address
+// of an email account which sent the delivery to john@example.org
+func startReceiver(t *testing.T, options ...func(*SMTPReceiver)) (string, *domain.EmailAccount, *testRecorder) {
+	t.Helper()
+	address, _ := vo.NewEmail("support@in.example.com")
+	account := domain.NewEmailAccount(uuid.New(), uuid.New(), 1, address, "Support", "", 0, false)
+	delivery := domain.NewEmailDelivery(uuid.New(), account.GetProjectID(), account.GetID(), nil, "john@example.org", "Your invoice", "sent@in.example.com")
+
+	recorder := &testRecorder{}
+	tracker, err := delivery_tracker.NewTracker([]byte("secret"), "https://api.example.com", testDeliveries{deliveries: []*domain.EmailDelivery{delivery}})
+	if err != nil {
+		t.Fatalf("tracker: %v", err)
+	}
+	processor := NewProcessor(recorder, bounce_handler.NewHandler(recorder), tracker)
+	receiver := NewSMTPReceiver("", "mx.in.example.com", []string{"In.Example.com"}, testAccounts{account: account}, processor)
+	for _, option := range options {
+		option(receiver)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- receiver.Serve(ctx, listener) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("serve: %v", err)
+		}
+	})
+	return listener.Addr().String(), account, recorder
+}
+
+func sendMail(t *testing.T, addr, from string, to []string, message string) error {
+	t.Helper()
+	client, err := smtp.Dial(addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+	if err := client.Hello("mail.example.org"); err != nil {
+		return err
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte(message)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func TestSMTPReceiverDeliversToAccount(t *testing.T) {
+	addr, account, recorder := startReceiver(t)
+
+	message := "From: John <john@example.org>\r\n" +
+		"To: Support <support@in.example.com>\r\n" +
+		"Subject: Invoice 42\r\n" +
+		"Message-ID: <question-1@example.org>\r\n" +
+		"\r\n" +
+		"Where is my invoice?\r\n" +
+		".A line starting with a dot\r\n"
+	if err := sendMail(t, addr, "john@example.org", []string{"SUPPORT@in.example.com", "support@in.example.com"}, message); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	emails, _ := recorder.received()
+	if len(emails) != 1 {
+		t.Fatalf("published %d emails, want 1", len(emails))
+	}
+	email := emails[0]
+	if email.EmailAccountID != account.GetID() || email.ProjectID != account.GetProjectID() || email.Source != domain.InboundSourceSMTP {
+		t.Errorf("email = %+v, want the account %s", email, account.GetID())
+	}
+	if email.Subject != "Invoice 42" || email.Bounce || !strings.Contains(email.Text, "\n.A line starting with a dot") {
+		t.Errorf("subject = %q, bounce = %v, text = %q", email.Subject, email.Bounce, email.Text)
+	}
+}
+
+func TestSMTPReceiverPublishesMessagesOfNoAccount(t *testing.T) {
+	addr, _, recorder := startReceiver(t)
+
+	message := "From: john@example.org\r\nTo: bounces+abc@in.example.com\r\nSubject: Hello\r\n\r\nHello\r\n"
+	if err := sendMail(t, addr, "john@example.org", []string{"bounces+abc@in.example.com"}, message); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	emails, _ := recorder.received()
+	if len(emails) != 1 || emails[0].EmailAccountID != uuid.Nil {
+		t.Fatalf("emails = %+v, want one without account", emails)
+	}
+}
+
+// dsn is a bounce of the mail server of example.org, the Message-ID of the original message is given
+func dsn(messageID string) string {
+	return "From: MAILER-DAEMON@mail.example.org (Mail Delivery System)\r\n" +
+		"To: support@in.example.com\r\n" +
+		"Subject: Undelivered Mail Returned to Sender\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/report; report-type=delivery-status; boundary=\"b\"\r\n" +
+		"\r\n" +
+		"--b\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"Your message could not be delivered.\r\n" +
+		"--b\r\n" +
+		"Content-Type: message/delivery-status\r\n" +
+		"\r\n" +
+		"Reporting-MTA: dns; mail.example.org\r\n" +
+		"\r\n" +
+		"Final-Recipient: rfc822; john@example.org\r\n" +
+		"Action: failed\r\n" +
+		"Status: 5.1.1\r\n" +
+		"Diagnostic-Code: smtp; 550 5.1.1 User unknown\r\n" +
+		"\r\n" +
+		"Final-Recipient: rfc822; ceo@example.org\r\n" +
+		"Action: failed\r\n" +
+		"Status: 5.1.1\r\n" +
+		"\r\n" +
+		"--b\r\n" +
+		"Content-Type: text/rfc822-headers\r\n" +
+		"\r\n" +
+		"Message-ID: <" + messageID + ">\r\n" +
+		"\r\n" +
+		"--b--\r\n"
+}
+
+func TestSMTPReceiverHandlesBounces(t *testing.T) {
+	tests := []struct {
+		name           string
+		messageID      string
+		wantSuppressed string
+	}{
+		// Only the recipient of the delivery is suppressed, the report names another address
+		{"bounce of a sent message", "sent@in.example.com", "john@example.org"},
+		{"forged bounce", "forged@example.net", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, _, recorder := startReceiver(t)
+			if err := sendMail(t, addr, "", []string{"support@in.example.com"}, dsn(tt.messageID)); err != nil {
+				t.Fatalf("send: %v", err)
+			}
+
+			emails, suppressed := recorder.received()
+			if len(emails) != 1 || !emails[0].Bounce {
+				t.Fatalf("emails = %+v, want one bounce", emails)
+			}
+			if got := strings.Join(suppressed, ","); got != tt.wantSuppressed {
+				t.Errorf("suppressed = %q, want %q", got, tt.wantSuppressed)
+			}
+		})
+	}
+}
+
+func TestSMTPReceiverReplies(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		want     int
+	}{
+		{"mail before hello", []string{"MAIL FROM:<john@example.org>"}, 503},
+		{"recipient before mail", []string{"EHLO mail.example.org", "RCPT TO:<support@in.example.com>"}, 503},
+		{"data before recipient", []string{"EHLO mail.example.org", "MAIL FROM:<john@example.org>", "DATA"}, 503},
+		{"relaying", []string{"EHLO mail.example.org", "MAIL FROM:<john@example.org>", "RCPT TO:<john@example.net>"}, 550},
+		{"bad recipient", []string{"EHLO mail.example.org", "MAIL FROM:<john@example.org>", "RCPT TO:<not an address>"}, 501},
+		{"bad mail syntax", []string{"HELO mail.example.org", "MAIL <john@example.org>"}, 501},
+		{"second sender", []string{"HELO mail.example.org", "MAIL FROM:<john@example.org>", "MAIL FROM:<jane@example.org>"}, 503},
+		{"too big", []string{"EHLO mail.example.org", "MAIL FROM:<john@example.org> SIZE=999999999"}, 552},
+		{"starttls without certificate", []string{"EHLO mail.example.org", "STARTTLS"}, 502},
+		{"unknown command", []string{"EXPN staff"}, 502},
+		{"reset", []string{"EHLO mail.example.org", "MAIL FROM:<john@example.org>", "RSET", "RCPT TO:<support@in.example.com>"}, 503},
+		{"null sender", []string{"EHLO mail.example.org", "MAIL FROM:<>", "RCPT TO:<support@in.example.com>"}, 250},
+		{"longest line", []string{"NOOP " + strings.Repeat("x", maxCommandLine-len("NOOP \r\n"))}, 250},
+		{"line too long", []string{"NOOP " + strings.Repeat("x", maxCommandLine)}, 500},
+		{"line too long for the buffer", []string{"NOOP " + strings.Repeat("x", 100000)}, 500},
+		{"command after a long line", []string{"HELO " + strings.Repeat("x", 100000), "NOOP"}, 250},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, _, _ := startReceiver(t)
+			conn, err := textproto.Dial("tcp", addr)
+			if err != nil {
+				t.Fatalf("dial: %v", err)
+			}
+			defer conn.Close()
+			if _, _, err := conn.ReadResponse(220); err != nil {
+				t.Fatalf("greeting: %v", err)
+			}
+
+			var code int
+			for _, command := range tt.commands {
+				if err := conn.PrintfLine("%s", command); err != nil {
+					t.Fatalf("write: %v", err)
+				}
+				code, _, _ = conn.ReadResponse(0)
+			}
+			if code != tt.want {
+				t.Errorf("reply = %d, want %d", code, tt.want)
+			}
+			conn.PrintfLine("QUIT")
+			conn.ReadResponse(221)
+		})
+	}
+}
+
+func TestSMTPReceiverLimitsSessions(t *testing.T) {
+	addr, _, _ := startReceiver(t, func(receiver *SMTPReceiver) { receiver.WithMaxSessions(1) })
+
+	first, err := textproto.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer first.Close()
+	if _, _, err := first.ReadResponse(220); err != nil {
+		t.Fatalf("greeting: %v", err)
+	}
+
+	second, err := textproto.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer second.Close()
+	if code, _, _ := second.ReadResponse(0); code != 421 {
+		t.Errorf("reply = %d, want 421", code)
+	}
+
+	// The session is released when the client quits
+	first.PrintfLine("QUIT")
+	first.ReadResponse(221)
+	first.Close()
+	for i := 0; ; i++ {
+		third, err := textproto.Dial("tcp", addr)
+		if err != nil {
+			t.Fatalf("dial: %v", err)
+		}
+		code, _, _ := third.ReadResponse(0)
+		third.Close()
+		if code == 220 {
+			break
+		}
+		if i == 50 {
+			t.Fatalf("reply = %d after the first session ended, want 220", code)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,117 @@
+// Package oauth2_state issues the state parameter of the OAuth2 authorization flow of the email accounts.
+// The state is a random nonce signed with HMAC-SHA256, the project, the email and the PKCE verifier it
+// was issued for are kept server-side and removed once the callback uses them.
+package oauth2_state
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"platform/pkg/services/cache"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrSecretRequired = errors.New("oauth2 state secret is required")
+	ErrInvalidState   = errors.New("invalid oauth2 state")
+	ErrStateExpired   = errors.New("oauth2 state expired")
+)
+
+// StateTTL is the time the user has to grant the access on the consent screen of the provider
+const StateTTL = 10 * time.Minute
+
+// Grant is the authorization request a state was issued for
+type Grant struct {
+	ProjectID uuid.UUID `json:"pid"`
+	Email     string    `json:"e"`
+	Verifier  string    `json:"v"`
+	ExpireAt  int64     `json:"exp"`
+}
+
+type Store struct {
+	secret []byte
+	cache  cache.CacheManager
+}
+
+// NewStore creates a store signing the states with secret, an empty secret is refused since anyone could
+// sign states with it
+func NewStore(secret []byte, cache cache.CacheManager) (*Store, error) {
+	if len(secret) == 0 {
+		return nil, ErrSecretRequired
+	}
+	return &Store{secret: secret, cache: cache}, nil
+}
+
+// Issue returns a new state and the PKCE verifier of the authorization request
+func (s *Store) Issue(ctx context.Context, projectID uuid.UUID, email string) (state string, verifier string, err error) {
+	var nonce [32]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(nonce[:])
+
+	grant := Grant{
+		ProjectID: projectID,
+		Email:     email,
+		Verifier:  oauth2.GenerateVerifier(),
+		ExpireAt:  time.Now().Add(StateTTL).Unix(),
+	}
+	payload, err := json.Marshal(grant)
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.cache.Set(ctx, cache.CacheKey{Key: cacheKey(encoded), Time: StateTTL}, string(payload)); err != nil {
+		return "", "", err
+	}
+
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded)), grant.Verifier, nil
+}
+
+// Consume checks the signature of the state and returns its grant, a state can be consumed once
+func (s *Store) Consume(ctx context.Context, state string) (*Grant, error) {
+	// STEP-1: Check the signature before touching the cache
+	encoded, signature, ok := strings.Cut(state, ".")
+	if !ok {
+		return nil, ErrInvalidState
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(encoded)) {
+		return nil, ErrInvalidState
+	}
+
+	// STEP-2: Take the grant out of the cache, so that the state cannot be replayed even by concurrent callbacks
+	payload, err := s.cache.Take(ctx, cacheKey(encoded))
+	if errors.Is(err, cache.ErrKeyNotFound) || (err == nil && payload == "") {
+		return nil, ErrInvalidState
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var grant Grant
+	if err := json.Unmarshal([]byte(payload), &grant); err != nil {
+		return nil, ErrInvalidState
+	}
+	if time.Now().Unix() > grant.ExpireAt {
+		return nil, ErrStateExpired
+	}
+	return &grant, nil
+}
+
+func (s *Store) mac(encoded string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte("oauth2-state." + encoded))
+	return h.Sum(nil)
+}
+
+func cacheKey(nonce string) string {
+	return "notification:oauth2_state:" + nonce
+}
@@ -0,0 +1,142 @@
+package oauth2_state
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"platform/pkg/services/cache"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// testCache keeps the values in a map, Take removes them under the lock like the atomic take of a cache server
+type testCache struct {
+	cache.CacheManager
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newTestCache() *testCache {
+	return &testCache{values: make(map[string]string)}
+}
+
+func (c *testCache) Set(_ context.Context, key cache.CacheKey, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key.Key] = value
+	return nil
+}
+
+func (c *testCache) Take(_ context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.values[key]
+	if !ok {
+		return "", cache.ErrKeyNotFound
+	}
+	delete(c.values, key)
+	return value, nil
+}
+
+func TestNewStoreRejectsEmptySecret(t *testing.T) {
+	if _, err := NewStore(nil, newTestCache()); !errors.Is(err, ErrSecretRequired) {
+		t.Fatalf("err = %v, want %v", err, ErrSecretRequired)
+	}
+}
+
+func TestConsume(t *testing.T) {
+	projectID := uuid.New()
+	store, _ := NewStore([]byte("secret"), newTestCache())
+	other, _ := NewStore([]byte("other"), newTestCache())
+
+	state, verifier, err := store.Issue(context.Background(), projectID, "john@example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce, signature, _ := strings.Cut(state, ".")
+	otherState, _, err := other.Issue(context.Background(), projectID, "john@example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := []struct {
+		name  string
+		state string
+	}{
+		{"no signature", nonce},
+		{"empty signature", nonce + "."},
+		{"changed signature", nonce + "." + strings.ToUpper(signature)},
+		{"signature of another nonce", "AAAA." + signature},
+		{"signed with another secret", otherState},
+	}
+	for _, tt := range tampered {
+		if _, err := store.Consume(context.Background(), tt.state); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, ErrInvalidState)
+		}
+	}
+
+	// The tampered states did not consume the state
+	grant, err := store.Consume(context.Background(), state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grant.ProjectID != projectID || grant.Email != "john@example.org" || grant.Verifier != verifier {
+		t.Errorf("grant = %+v", grant)
+	}
+
+	if _, err := store.Consume(context.Background(), state); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("replayed state: err = %v, want %v", err, ErrInvalidState)
+	}
+}
+
+func TestConsumeExpired(t *testing.T) {
+	testCache := newTestCache()
+	store, _ := NewStore([]byte("secret"), testCache)
+	state, _, err := store.Issue(context.Background(), uuid.New(), "john@example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The cache may keep the grant longer than its expiry
+	nonce, _, _ := strings.Cut(state, ".")
+	var grant Grant
+	json.Unmarshal([]byte(testCache.values[cacheKey(nonce)]), &grant)
+	grant.ExpireAt = time.Now().Add(-time.Second).Unix()
+	payload, _ := json.Marshal(grant)
+	testCache.values[cacheKey(nonce)] = string(payload)
+
+	if _, err := store.Consume(context.Background(), state); !errors.Is(err, ErrStateExpired) {
+		t.Errorf("err = %v, want %v", err, ErrStateExpired)
+	}
+}
+
+func TestConsumeConcurrentReplay(t *testing.T) {
+	store, _ := NewStore([]byte("secret"), newTestCache())
+	state, _, err := store.Issue(context.Background(), uuid.New(), "john@example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	consumed := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := store.Consume(context.Background(), state); err == nil {
+				mu.Lock()
+				consumed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if consumed != 1 {
+		t.Errorf("state consumed %d times, want once", consumed)
+	}
+}
@@ -0,0 +1,98 @@
+package sms_sender
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// FakeSentLimit is the number of messages kept for each project by the fake provider of the sms accounts
+const FakeSentLimit = 100
+
+var ErrFakeProviderDisabled = errors.New("fake sms accounts are only available in development and staging environments")
+
+// fakeProvider is shared by the fake sms accounts, so their messages can be read back. It is nil until
+// EnableFakeProvider is called, the fake accounts are refused then.
+var fakeProvider *FakeProvider
+
+// EnableFakeProvider allows the fake sms accounts, for local and staging environments. It is called once at
+// startup.
+func EnableFakeProvider() {
+	fakeProvider = NewFakeProvider(FakeSentLimit)
+}
+
+func FakeProviderEnabled() bool {
+	return fakeProvider != nil
+}
+
+// FakeSentMessages returns the last messages sent by the fake sms accounts of the project, the oldest first
+func FakeSentMessages(projectID uuid.UUID) ([]FakeSms, error) {
+	if fakeProvider == nil {
+		return nil, ErrFakeProviderDisabled
+	}
+	return fakeProvider.SentMessages(projectID), nil
+}
+
+// FakeSms is a message kept by the fake provider
+type FakeSms struct {
+	SmsMessage
+	ID     string
+	SentAt time.Time
+}
+
+// FakeProvider keeps the last messages of each project instead of sending them, for local development.
+// The bodies are not logged, they hold verification codes.
+type FakeProvider struct {
+	mu    sync.Mutex
+	limit int
+	sent  map[uuid.UUID][]FakeSms
+}
+
+func NewFakeProvider(limit int) *FakeProvider {
+	return &FakeProvider{limit: limit, sent: make(map[uuid.UUID][]FakeSms)}
+}
+
+// For returns the provider of the fake accounts of the project
+func (p *FakeProvider) For(projectID uuid.UUID) Provider {
+	return &fakeProjectProvider{provider: p, projectID: projectID}
+}
+
+// SentMessages returns the last messages sent by the project, the oldest first
+func (p *FakeProvider) SentMessages(projectID uuid.UUID) []FakeSms {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	messages := make([]FakeSms, len(p.sent[projectID]))
+	copy(messages, p.sent[projectID])
+	return messages
+}
+
+func (p *FakeProvider) keep(projectID uuid.UUID, message FakeSms) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	sent := append(p.sent[projectID], message)
+	if len(sent) > p.limit {
+		sent = append([]FakeSms(nil), sent[len(sent)-p.limit:]...)
+	}
+	p.sent[projectID] = sent
+}
+
+type fakeProjectProvider struct {
+	provider  *FakeProvider
+	projectID uuid.UUID
+}
+
+func (p *fakeProjectProvider) Send(ctx context.Context, message *SmsMessage) (string, error) {
+	id := uuid.NewString()
+	p.provider.keep(p.projectID, FakeSms{SmsMessage: *message, ID: id, SentAt: time.Now()})
+
+	zap.L().Info("fake sms sent",
+		zap.String("id", id),
+		zap.String("project_id", p.projectID.String()),
+		zap.String("from", message.From),
+		zap.String("to", message.To.Value()))
+	return id, nil
+}
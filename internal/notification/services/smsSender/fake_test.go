@@ -0,0 +1,35 @@
+package sms_sender
+
+import (
+	"context"
+	vo "platform/pkg/domain/value_object"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFakeProviderKeepsMessagesPerProject(t *testing.T) {
+	provider := NewFakeProvider(2)
+	to, _ := vo.NewPhoneNumber("+905551112233")
+	projectA, projectB := uuid.New(), uuid.New()
+
+	for _, body := range []string{"code 1", "code 2", "code 3"} {
+		if _, err := provider.For(projectA).Send(context.Background(), &SmsMessage{From: "Beecraft", To: to, Body: body}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := provider.For(projectB).Send(context.Background(), &SmsMessage{From: "Beecraft", To: to, Body: "code 4"}); err != nil {
+		t.Fatal(err)
+	}
+
+	sent := provider.SentMessages(projectA)
+	if len(sent) != 2 || sent[0].Body != "code 2" || sent[1].Body != "code 3" {
+		t.Errorf("project A messages = %+v, want the last two", sent)
+	}
+	if sent := provider.SentMessages(projectB); len(sent) != 1 || sent[0].Body != "code 4" {
+		t.Errorf("project B messages = %+v", sent)
+	}
+	if sent := provider.SentMessages(uuid.New()); len(sent) != 0 {
+		t.Errorf("other project messages = %+v", sent)
+	}
+}
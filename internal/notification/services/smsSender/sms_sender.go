@@ -0,0 +1,78 @@
+// Package sms_sender sends text messages through pluggable providers such as Twilio and Vonage.
+package sms_sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"platform/internal/notification/domain"
+	"platform/internal/notification/services/encryption"
+	vo "platform/pkg/domain/value_object"
+	"time"
+)
+
+var (
+	ErrUnsupportedProvider = errors.New("unsupported sms provider")
+	ErrEmptyMessage        = errors.New("sms message body is empty")
+)
+
+// SmsMessage is a text message ready to be handed to a provider
+type SmsMessage struct {
+	From string
+	To   vo.PhoneNumber
+	Body string
+}
+
+// Provider sends a message and returns the identifier the provider assigned to it
+type Provider interface {
+	Send(ctx context.Context, message *SmsMessage) (string, error)
+}
+
+// httpClient is shared by the HTTP adapters, providers answer quickly or not at all
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// NewProvider builds the provider of the account, decrypting its secret
+func NewProvider(encryption encryption.EncryptionService, account *domain.SmsAccount) (Provider, error) {
+	if account.GetProviderID() == domain.FakeSms {
+		if fakeProvider == nil {
+			return nil, ErrFakeProviderDisabled
+		}
+		return fakeProvider.For(account.GetProjectID()), nil
+	}
+
+	credentials := account.GetCredentials()
+	if credentials == nil {
+		return nil, fmt.Errorf("sms account %s has no credentials", account.GetID())
+	}
+	username, encrypted := credentials.Credentials()
+	secret, err := encryption.Decrypt(encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	switch account.GetProviderID() {
+	case domain.Twilio:
+		return NewTwilioProvider(username, secret), nil
+	case domain.Vonage:
+		return NewVonageProvider(username, secret), nil
+	default:
+		return nil, ErrUnsupportedProvider
+	}
+}
+
+// SendSms validates the message and sends it with the provider of the account
+func SendSms(ctx context.Context, encryption encryption.EncryptionService, account *domain.SmsAccount, message *SmsMessage) (string, error) {
+	if message.Body == "" {
+		return "", ErrEmptyMessage
+	}
+	if message.From == "" {
+		message.From = account.GetSender()
+	}
+
+	provider, err := NewProvider(encryption, account)
+	if err != nil {
+		return "", err
+	}
+	return provider.Send(ctx, message)
+}
@@ -0,0 +1,15 @@
+package sms_sender
+
+import (
+	"platform/internal/notification/domain"
+	template_renderer "platform/internal/notification/services/templateRenderer"
+)
+
+// RenderTemplate picks the template in the requested language and replaces its %Token% placeholders
+func RenderTemplate(templates []domain.SmsTemplate, language string, tokens map[string]string) (string, error) {
+	template, err := template_renderer.Select(templates, language)
+	if err != nil {
+		return "", err
+	}
+	return template_renderer.Render(template.GetBody(), tokens), nil
+}
@@ -0,0 +1,63 @@
+package sms_sender
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const twilioBaseURL = "https://api.twilio.com/2010-04-01"
+
+// TwilioProvider sends messages with the Programmable Messaging API
+type TwilioProvider struct {
+	accountSid string
+	authToken  string
+	baseURL    string
+}
+
+func NewTwilioProvider(accountSid, authToken string) *TwilioProvider {
+	return &TwilioProvider{accountSid: accountSid, authToken: authToken, baseURL: twilioBaseURL}
+}
+
+// WithBaseURL points the provider to another endpoint, e.g. a test server
+func (p *TwilioProvider) WithBaseURL(baseURL string) *TwilioProvider {
+	p.baseURL = strings.TrimSuffix(baseURL, "/")
+	return p
+}
+
+func (p *TwilioProvider) Send(ctx context.Context, message *SmsMessage) (string, error) {
+	form := url.Values{}
+	form.Set("From", message.From)
+	form.Set("To", message.To.Value())
+	form.Set("Body", message.Body)
+
+	endpoint := fmt.Sprintf("%s/Accounts/%s/Messages.json", p.baseURL, url.PathEscape(p.accountSid))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(p.accountSid, p.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Sid     string `json:"sid"`
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("twilio: unexpected response with status %d: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("twilio: %s (code %d)", result.Message, result.Code)
+	}
+	return result.Sid, nil
+}
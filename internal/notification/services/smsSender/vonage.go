@@ -0,0 +1,73 @@
+package sms_sender
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const vonageBaseURL = "https://rest.nexmo.com"
+
+// VonageProvider sends messages with the Vonage (Nexmo) SMS API
+type VonageProvider struct {
+	apiKey    string
+	apiSecret string
+	baseURL   string
+}
+
+func NewVonageProvider(apiKey, apiSecret string) *VonageProvider {
+	return &VonageProvider{apiKey: apiKey, apiSecret: apiSecret, baseURL: vonageBaseURL}
+}
+
+// WithBaseURL points the provider to another endpoint, e.g. a test server
+func (p *VonageProvider) WithBaseURL(baseURL string) *VonageProvider {
+	p.baseURL = strings.TrimSuffix(baseURL, "/")
+	return p
+}
+
+func (p *VonageProvider) Send(ctx context.Context, message *SmsMessage) (string, error) {
+	form := url.Values{}
+	form.Set("api_key", p.apiKey)
+	form.Set("api_secret", p.apiSecret)
+	form.Set("from", message.From)
+	// Vonage expects the E.164 number without the leading +
+	form.Set("to", strings.TrimPrefix(message.To.Value(), "+"))
+	form.Set("text", message.Body)
+	form.Set("type", "unicode")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/sms/json", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// A long text is split into several parts, each of them has its own status
+	var result struct {
+		Messages []struct {
+			Status    string `json:"status"`
+			MessageID string `json:"message-id"`
+			ErrorText string `json:"error-text"`
+		} `json:"messages"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("vonage: unexpected response with status %d: %w", resp.StatusCode, err)
+	}
+	if len(result.Messages) == 0 {
+		return "", fmt.Errorf("vonage: empty response with status %d", resp.StatusCode)
+	}
+	for _, part := range result.Messages {
+		if part.Status != "0" {
+			return "", fmt.Errorf("vonage: %s (status %s)", part.ErrorText, part.Status)
+		}
+	}
+	return result.Messages[0].MessageID, nil
+}
@@ -0,0 +1,108 @@
+// Package subscription_token signs the links sent to subscribers, so they can confirm or cancel a
+// subscription without logging in. Tokens are stateless: the project, the email and the purpose are
+// protected by an HMAC-SHA256 signature.
+package subscription_token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSecretRequired = errors.New("subscription token secret is required")
+	ErrInvalidToken   = errors.New("invalid subscription token")
+	ErrTokenExpired   = errors.New("subscription token expired")
+)
+
+type Purpose string
+
+const (
+	Confirm     Purpose = "confirm"
+	Unsubscribe Purpose = "unsubscribe"
+)
+
+// ConfirmationTTL is the lifetime of confirmation links, unsubscribe links never expire
+const ConfirmationTTL = 7 * 24 * time.Hour
+
+type claims struct {
+	Purpose   Purpose   `json:"p"`
+	ProjectID uuid.UUID `json:"pid"`
+	Email     string    `json:"e"`
+	ExpireAt  int64     `json:"exp,omitempty"`
+}
+
+type Signer struct {
+	secret  []byte
+	baseURL string
+	now     func() time.Time
+}
+
+// NewSigner creates a signer building links on baseURL, e.g. https://api.example.com.
+// An empty secret is refused, anyone could sign the links of any subscriber with it.
+func NewSigner(secret []byte, baseURL string) (*Signer, error) {
+	if len(secret) == 0 {
+		return nil, ErrSecretRequired
+	}
+	return &Signer{secret: secret, baseURL: strings.TrimSuffix(baseURL, "/"), now: time.Now}, nil
+}
+
+func (s *Signer) Sign(purpose Purpose, projectID uuid.UUID, email string) string {
+	c := claims{Purpose: purpose, ProjectID: projectID, Email: email}
+	if purpose == Confirm {
+		c.ExpireAt = s.now().Add(ConfirmationTTL).Unix()
+	}
+
+	payload, _ := json.Marshal(c)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded))
+}
+
+// Verify checks the token was signed for purpose and returns the project and email it was issued for
+func (s *Signer) Verify(token string, purpose Purpose) (uuid.UUID, string, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, "", ErrInvalidToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(encoded)) {
+		return uuid.Nil, "", ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return uuid.Nil, "", ErrInvalidToken
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil || c.Purpose != purpose {
+		return uuid.Nil, "", ErrInvalidToken
+	}
+	if c.ExpireAt != 0 && s.now().Unix() > c.ExpireAt {
+		return uuid.Nil, "", ErrTokenExpired
+	}
+
+	return c.ProjectID, c.Email, nil
+}
+
+// ConfirmationURL returns the link of the double opt-in email
+func (s *Signer) ConfirmationURL(projectID uuid.UUID, email string) string {
+	return s.baseURL + "/v1/notification/subscriptions/confirm?token=" + url.QueryEscape(s.Sign(Confirm, projectID, email))
+}
+
+// UnsubscribeURL returns the one-click unsubscribe link, it accepts both GET and RFC 8058 POST requests
+func (s *Signer) UnsubscribeURL(projectID uuid.UUID, email string) string {
+	return s.baseURL + "/v1/notification/subscriptions/unsubscribe?token=" + url.QueryEscape(s.Sign(Unsubscribe, projectID, email))
+}
+
+func (s *Signer) mac(payload string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte("subscription:" + payload))
+	return h.Sum(nil)
+}
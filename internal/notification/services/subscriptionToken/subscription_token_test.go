@@ -0,0 +1,84 @@
+package subscription_token
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewSignerRejectsEmptySecret(t *testing.T) {
+	if _, err := NewSigner(nil, "https://api.example.com"); !errors.Is(err, ErrSecretRequired) {
+		t.Fatalf("err = %v, want %v", err, ErrSecretRequired)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	signer, _ := NewSigner([]byte("secret"), "https://api.example.com")
+	other, _ := NewSigner([]byte("other"), "https://api.example.com")
+	projectID := uuid.New()
+
+	token := signer.Sign(Unsubscribe, projectID, "user@example.com")
+	encoded, signature, _ := strings.Cut(token, ".")
+	forged, _, _ := strings.Cut(signer.Sign(Unsubscribe, projectID, "victim@example.com"), ".")
+
+	tests := []struct {
+		name    string
+		token   string
+		purpose Purpose
+		wantErr error
+	}{
+		{"valid", token, Unsubscribe, nil},
+		{"wrong purpose", token, Confirm, ErrInvalidToken},
+		{"tampered payload", forged + "." + signature, Unsubscribe, ErrInvalidToken},
+		{"other secret", other.Sign(Unsubscribe, projectID, "user@example.com"), Unsubscribe, ErrInvalidToken},
+		{"no signature", encoded, Unsubscribe, ErrInvalidToken},
+		{"invalid signature", encoded + ".!!", Unsubscribe, ErrInvalidToken},
+		{"garbage payload", "e30." + signature, Unsubscribe, ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotProjectID, email, err := signer.Verify(tt.token, tt.purpose)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (gotProjectID != projectID || email != "user@example.com") {
+				t.Fatalf("got %s %s, want %s user@example.com", gotProjectID, email, projectID)
+			}
+		})
+	}
+}
+
+func TestVerifyExpiry(t *testing.T) {
+	signer, _ := NewSigner([]byte("secret"), "https://api.example.com")
+	projectID := uuid.New()
+	confirm := signer.Sign(Confirm, projectID, "user@example.com")
+	unsubscribe := signer.Sign(Unsubscribe, projectID, "user@example.com")
+
+	signer.now = func() time.Time { return time.Now().Add(ConfirmationTTL + time.Minute) }
+	if _, _, err := signer.Verify(confirm, Confirm); !errors.Is(err, ErrTokenExpired) {
+		t.Fatalf("confirm err = %v, want %v", err, ErrTokenExpired)
+	}
+	if _, _, err := signer.Verify(unsubscribe, Unsubscribe); err != nil {
+		t.Fatalf("unsubscribe links never expire, err = %v", err)
+	}
+}
+
+func TestUnsubscribeURL(t *testing.T) {
+	signer, _ := NewSigner([]byte("secret"), "https://api.example.com/")
+	projectID := uuid.New()
+
+	link, err := url.Parse(signer.UnsubscribeURL(projectID, "user@example.com"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if link.Host != "api.example.com" || link.Path != "/v1/notification/subscriptions/unsubscribe" {
+		t.Fatalf("link = %s", link)
+	}
+	if _, email, err := signer.Verify(link.Query().Get("token"), Unsubscribe); err != nil || email != "user@example.com" {
+		t.Fatalf("verify = %q, %v", email, err)
+	}
+}
@@ -0,0 +1,70 @@
+// Package template_renderer picks the language of a template and replaces its %Token% placeholders.
+// It is shared by every channel, so a recipient gets the same language on email and sms.
+package template_renderer
+
+import (
+	"errors"
+	"platform/internal/shared"
+	"regexp"
+	"strings"
+)
+
+var ErrTemplateNotFound = errors.New("template not found")
+
+// placeholder matches the %Token% placeholders, the percent signs of the text do not enclose a name
+var placeholder = regexp.MustCompile(`%([A-Za-z][A-Za-z0-9_]*)%`)
+
+// Localized is implemented by the templates of every channel
+type Localized interface {
+	GetLanguage() string
+}
+
+// Select returns the template in the requested language. Unknown or missing languages fall back to the
+// default language of the platform, then to the first template.
+func Select[T Localized](templates []T, language string) (T, error) {
+	var selected T
+	if len(templates) == 0 {
+		return selected, ErrTemplateNotFound
+	}
+
+	// GetLanguageByCulture returns the default language for unknown cultures
+	languageManager := shared.GetLanguageManager()
+	requested := languageManager.GetLanguageByCulture(language).GetCulture()
+	fallback := languageManager.GetLanguages()[0].GetCulture()
+
+	selected = templates[0]
+	for _, culture := range []string{fallback, requested} {
+		for _, t := range templates {
+			if t.GetLanguage() == culture {
+				selected = t
+			}
+		}
+	}
+	return selected, nil
+}
+
+// Render replaces the %Token% placeholders of text, unknown placeholders are kept as they are
+func Render(text string, tokens map[string]string) string {
+	if len(tokens) == 0 {
+		return text
+	}
+
+	pairs := make([]string, 0, len(tokens)*2)
+	for key, value := range tokens {
+		pairs = append(pairs, "%"+key+"%", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// Placeholders returns the names of the %Token% placeholders of text once each, in order of appearance
+func Placeholders(text string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range placeholder.FindAllStringSubmatch(text, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
@@ -0,0 +1,192 @@
+// Package token_manager keeps the access tokens of the OAuth2 email accounts valid. Refreshed tokens are
+// saved to the email account, so that a rotated refresh token is not lost, and the tokens close to expiry
+// are refreshed in the background before a message needs them.
+package token_manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"platform/internal/notification/domain"
+	voInternal "platform/internal/notification/domain/value_object"
+	event_notification "platform/internal/notification/mediatr/notifications"
+	"platform/internal/notification/repositories"
+	email_provider "platform/internal/notification/services/emailProvider"
+	email_sender "platform/internal/notification/services/emailSender"
+	"platform/internal/shared"
+	"platform/pkg/services/mediator"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// Interval is the time between two runs of the background refresh
+	Interval = time.Minute
+
+	// RefreshBefore is how long before their expiry the tokens are refreshed in the background
+	RefreshBefore = 10 * time.Minute
+
+	// expiryDelta is the margin under which a token is refreshed before it is used
+	expiryDelta = time.Minute
+)
+
+var ErrReconsentRequired = errors.New("the grant of the email account was revoked, it must be authorized again")
+
+type Manager struct {
+	emailAccountRepository repositories.EmailAccountRepository
+
+	// Refreshes of the same account are serialized, a second refresh would use a rotated refresh token
+	mu    sync.Mutex
+	locks map[uuid.UUID]*sync.Mutex
+}
+
+func NewManager(emailAccountRepository repositories.EmailAccountRepository) *Manager {
+	return &Manager{
+		emailAccountRepository: emailAccountRepository,
+		locks:                  make(map[uuid.UUID]*sync.Mutex),
+	}
+}
+
+// Token returns a valid access token of the account, the token is refreshed and saved when it expires soon
+func (m *Manager) Token(ctx context.Context, emailAccount *domain.EmailAccount) (*oauth2.Token, error) {
+	if emailAccount.GetNeedsReconsent() {
+		return nil, ErrReconsentRequired
+	}
+	if token := tokenOf(emailAccount); valid(token, expiryDelta) {
+		return token, nil
+	}
+	return m.refresh(ctx, emailAccount, expiryDelta)
+}
+
+// Run refreshes the tokens expiring soon every Interval until ctx is cancelled
+func (m *Manager) Run(ctx context.Context) {
+	ticker := time.NewTicker(Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Tick(ctx)
+		}
+	}
+}
+
+// Tick refreshes the tokens of all projects which expire within RefreshBefore
+func (m *Manager) Tick(ctx context.Context) {
+	accounts, err := m.emailAccountRepository.GetExpiringTokens(ctx, time.Now().Add(RefreshBefore))
+	if err != nil {
+		zap.L().Error("failed to get expiring oauth2 tokens", zap.Error(err))
+		return
+	}
+	for _, account := range accounts {
+		if _, err := m.refresh(ctx, account, RefreshBefore); err != nil {
+			zap.L().Error("failed to refresh oauth2 token",
+				zap.String("email_account_id", account.GetID().String()), zap.Error(err))
+		}
+	}
+}
+
+// refresh gets a new access token unless another refresh saved one valid for at least delta in the meantime
+func (m *Manager) refresh(ctx context.Context, emailAccount *domain.EmailAccount, delta time.Duration) (*oauth2.Token, error) {
+	lock := m.lock(emailAccount.GetID())
+	lock.Lock()
+	defer lock.Unlock()
+
+	// STEP-1: Reload the account, the token may have been refreshed while waiting for the lock
+	ctx = context.WithValue(ctx, shared.ProjectIDContextKey, emailAccount.GetProjectID())
+	current, err := m.emailAccountRepository.GetByEmail(ctx, emailAccount.GetEmail())
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("email account %s was deleted: %w", emailAccount.GetEmail(), shared.ErrNotFound)
+	}
+	if current.GetNeedsReconsent() {
+		emailAccount.SetNeedsReconsent(true)
+		return nil, ErrReconsentRequired
+	}
+	if token := tokenOf(current); valid(token, delta) {
+		emailAccount.SetTokenInformation(current.GetTokenInformation())
+		return token, nil
+	}
+
+	// STEP-2: Exchange the refresh token
+	credentials := current.GetOAuth2Credentials()
+	if credentials == nil {
+		return nil, email_sender.ErrNoCredentials
+	}
+	clientID, tenantID, clientSecret := credentials.Credentials()
+	conf, err := email_provider.OAuth2Config(current.GetSmtpType(), clientID, tenantID, clientSecret, "")
+	if err != nil {
+		return nil, err
+	}
+	old := tokenOf(current)
+	token, err := conf.TokenSource(ctx, &oauth2.Token{RefreshToken: old.RefreshToken}).Token()
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "invalid_grant" {
+		return nil, m.requireReconsent(ctx, current, emailAccount, retrieveErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// STEP-3: Save the new token, the providers which do not rotate the refresh token do not return it
+	if token.RefreshToken == "" {
+		token.RefreshToken = old.RefreshToken
+	}
+	current.SetTokenInformation(voInternal.NewTokenInformation(token.AccessToken, token.RefreshToken, token.Expiry))
+	if err := m.emailAccountRepository.Update(ctx, current); err != nil {
+		return nil, err
+	}
+	emailAccount.SetTokenInformation(current.GetTokenInformation())
+	return token, nil
+}
+
+// requireReconsent flags the account and publishes the event telling the user to authorize it again
+func (m *Manager) requireReconsent(ctx context.Context, current, emailAccount *domain.EmailAccount, cause *oauth2.RetrieveError) error {
+	current.SetNeedsReconsent(true)
+	if err := m.emailAccountRepository.Update(ctx, current); err != nil {
+		return err
+	}
+	emailAccount.SetNeedsReconsent(true)
+
+	reason := cause.ErrorCode
+	if cause.ErrorDescription != "" {
+		reason = fmt.Sprintf("%s: %s", cause.ErrorCode, cause.ErrorDescription)
+	}
+	notification := event_notification.NewEmailAccountReconsentRequiredEvent(current.GetProjectID(), current.GetEmail().Value(), reason)
+	mediator.Publish(ctx, &notification)
+	return ErrReconsentRequired
+}
+
+func (m *Manager) lock(id uuid.UUID) *sync.Mutex {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lock, ok := m.locks[id]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.locks[id] = lock
+	}
+	return lock
+}
+
+func tokenOf(emailAccount *domain.EmailAccount) *oauth2.Token {
+	accessToken, refreshToken, expireAt := emailAccount.GetTokenInformation().TokenInformation()
+	return &oauth2.Token{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Expiry:       expireAt,
+		TokenType:    "Bearer",
+	}
+}
+
+func valid(token *oauth2.Token, delta time.Duration) bool {
+	return token.AccessToken != "" && (token.Expiry.IsZero() || time.Now().Add(delta).Before(token.Expiry))
+}
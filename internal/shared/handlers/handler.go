@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"platform/internal/shared"
 	"platform/internal/shared/validators"
 
 	"github.com/go-playground/validator/v10"
@@ -44,7 +46,9 @@ func Serve[I, O any](h Handler[I, O]) fiber.Handler {
 			return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
 		}
 
-		if err := c.BodyParser(&req); err != nil && !errors.Is(err, fiber.ErrUnprocessableEntity) {
+		if raw, ok := any(&req).(RawBodyRequest); ok {
+			raw.SetRawBody(bytes.Clone(c.Body()))
+		} else if err := c.BodyParser(&req); err != nil && !errors.Is(err, fiber.ErrUnprocessableEntity) {
 			// TODO: log here
 			return fiber.NewError(fiber.StatusBadRequest, "Invalid JSON body")
 		}
@@ -59,12 +63,18 @@ func Serve[I, O any](h Handler[I, O]) fiber.Handler {
 			for _, fieldError := range validationErrors {
 				// For each validation error, you can handle it here and send a custom error message
 				switch fieldError.Tag() {
-				case "required":
+				case "required", "required_unless":
 					errorMessages = append(errorMessages, fieldError.Field()+" is required")
 				case "min":
 					errorMessages = append(errorMessages, fieldError.Field()+" must have at least "+fieldError.Param()+" characters")
 				case "max":
 					errorMessages = append(errorMessages, fieldError.Field()+" must have max "+fieldError.Param()+" characters")
+				case "len":
+					errorMessages = append(errorMessages, fieldError.Field()+" must have "+fieldError.Param()+" characters")
+				case "url":
+					errorMessages = append(errorMessages, fieldError.Field()+" must be a valid URL")
+				case "numeric":
+					errorMessages = append(errorMessages, fieldError.Field()+" must be numeric")
 				case "email":
 					errorMessages = append(errorMessages, "Please enter a valid email address")
 				case "password":
@@ -79,6 +89,8 @@ func Serve[I, O any](h Handler[I, O]) fiber.Handler {
 					errorMessages = append(errorMessages, fieldError.Field()+" must be less than "+fieldError.Param())
 				case "lte":
 					errorMessages = append(errorMessages, fieldError.Field()+" must be less than or equal to "+fieldError.Param())
+				case "datetime":
+					errorMessages = append(errorMessages, fieldError.Field()+" must be a date in the format "+fieldError.Param())
 				case "oneof":
 					errorMessages = append(errorMessages, fieldError.Field()+" must be one of the following values: "+fieldError.Param())
 				}
@@ -102,6 +114,22 @@ func Serve[I, O any](h Handler[I, O]) fiber.Handler {
 			return c.Status(fiber.StatusOK).JSON(fiber.Map{"error": "An unexpected error occurred. Please try again later."})
 		}
 
+		for key, value := range resp.Headers {
+			c.Set(key, value)
+		}
+		switch {
+		case resp.Location != "":
+			return c.Redirect(resp.Location, resp.ResponseStatus)
+		case resp.ContentType != "":
+			c.Set(fiber.HeaderContentType, resp.ContentType)
+			return c.Status(resp.ResponseStatus).Send(resp.Body)
+		}
+
+		// The document is the same, clients asking for HAL get it with its media type
+		c.Vary(fiber.HeaderAccept)
+		if c.Accepts(fiber.MIMEApplicationJSON, shared.HALContentType) == shared.HALContentType {
+			return c.Status(resp.ResponseStatus).JSON(resp, shared.HALContentType)
+		}
 		return c.Status(resp.ResponseStatus).JSON(resp)
 	}
 }
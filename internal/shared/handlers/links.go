@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+	"platform/internal/shared"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Authorizer reports whether the caller in ctx can call the method on the path
+type Authorizer func(ctx context.Context, method, path string) bool
+
+// routes resolves the hrefs of the links from the named routes, so they follow the paths registered in the router
+var routes struct {
+	app       *fiber.App
+	authorize Authorizer
+}
+
+// UseRoutes sets the application whose named routes are linked, authorize may be nil when every caller
+// can follow every link. It is called once at startup, the routes can be registered afterwards.
+func UseRoutes(app *fiber.App, authorize Authorizer) {
+	routes.app = app
+	routes.authorize = authorize
+}
+
+// RoutePath returns the path of the named route, params replace its ":param" segments in order.
+// The segments without a value are written as URI template variables, e.g. "{email}".
+func RoutePath(name string, params ...string) (path string, templated bool) {
+	if routes.app == nil {
+		return "", false
+	}
+	route := routes.app.GetRoute(name)
+	if route.Path == "" {
+		return "", false
+	}
+
+	segments := strings.Split(route.Path, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+		if len(params) == 0 {
+			segments[i] = "{" + strings.TrimSuffix(segment[1:], "?") + "}"
+			templated = true
+			continue
+		}
+		segments[i] = url.PathEscape(params[0])
+		params = params[1:]
+	}
+	return strings.Join(segments, "/"), templated
+}
+
+// Links builds the HAL links of a response, the links the caller is not allowed to follow are left out
+type Links struct {
+	ctx   context.Context
+	links shared.HALLinks
+}
+
+func NewLinks(ctx context.Context) *Links {
+	return &Links{ctx: ctx, links: make(shared.HALLinks)}
+}
+
+// Route adds the link to the named route, see RoutePath for the params
+func (l *Links) Route(rel, name, title string, params ...string) *Links {
+	return l.RouteWithQuery(rel, name, title, nil, params...)
+}
+
+// RouteWithQuery adds the link to the named route with the query string
+func (l *Links) RouteWithQuery(rel, name, title string, query url.Values, params ...string) *Links {
+	path, templated := RoutePath(name, params...)
+	if path == "" {
+		zap.L().Warn("Link to an unknown route is left out", zap.String("route", name), zap.String("rel", rel))
+		return l
+	}
+
+	method := routes.app.GetRoute(name).Method
+	if routes.authorize != nil && !routes.authorize(l.ctx, method, path) {
+		return l
+	}
+
+	href := path
+	if len(query) > 0 {
+		href += "?" + query.Encode()
+	}
+	l.links[rel] = shared.HALLink{Href: href, Method: method, Title: title, Templated: templated}
+	return l
+}
+
+// Href adds a link which is not served by this application, e.g. the authorization page of a provider
+func (l *Links) Href(rel string, link shared.HALLink) *Links {
+	l.links[rel] = link
+	return l
+}
+
+func (l *Links) Build() shared.HALLinks {
+	return l.links
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"platform/internal/shared"
+	"platform/internal/shared/middlewares"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type linksTestRequest struct {
+	Email string `params:"email"`
+}
+
+type linksTestHandler struct{}
+
+func (linksTestHandler) Handle(ctx context.Context, req *linksTestRequest) (*Response[linksTestRequest], error) {
+	response := SuccessResponse(req)
+	response.Links = NewLinks(ctx).
+		Route("self", "accounts.get", "View this account", req.Email).
+		Route("delete", "accounts.delete", "Delete this account", req.Email).
+		Route("account", "accounts.get", "View an account").
+		Build()
+	return response, nil
+}
+
+func TestLinksFollowRoutesAndRoles(t *testing.T) {
+	authorize, err := middlewares.RouteAuthorizer([]middlewares.RoutePolicy{
+		{Path: "/v1/accounts/:email", Methods: []string{"DELETE"}, Roles: []string{"ADMIN"}},
+	})
+	if err != nil {
+		t.Fatalf("authorizer: %v", err)
+	}
+
+	// The authentication in front of the API puts the role of the caller in the context
+	caller := func(c *fiber.Ctx) error {
+		if role := c.Get("X-Test-Role"); role != "" {
+			c.SetUserContext(context.WithValue(c.UserContext(), shared.RolesContextKey, []string{role}))
+		}
+		return c.Next()
+	}
+
+	app := fiber.New()
+	UseRoutes(app, authorize)
+	defer UseRoutes(nil, nil)
+	group := app.Group("/v1", caller).Name("accounts.")
+	group.Get("/accounts/:email", Serve[linksTestRequest, linksTestRequest](linksTestHandler{})).Name("get")
+	group.Delete("/accounts/:email", Serve[linksTestRequest, linksTestRequest](linksTestHandler{})).Name("delete")
+
+	tests := []struct {
+		name       string
+		role       string
+		accept     string
+		wantDelete bool
+		wantType   string
+	}{
+		{"allowed role", "ADMIN", "", true, fiber.MIMEApplicationJSON},
+		{"denied role", "REGISTERED", "", false, fiber.MIMEApplicationJSON},
+		{"anonymous", "", shared.HALContentType, false, shared.HALContentType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/v1/accounts/a@example.com", nil)
+			req.Header.Set("X-Test-Role", tt.role)
+			if tt.accept != "" {
+				req.Header.Set(fiber.HeaderAccept, tt.accept)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+			if got := resp.Header.Get(fiber.HeaderContentType); got != tt.wantType {
+				t.Fatalf("content type = %q, want %q", got, tt.wantType)
+			}
+
+			var body struct {
+				Links shared.HALLinks `json:"_links"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if self := body.Links["self"]; self.Href != "/v1/accounts/a@example.com" || self.Method != "GET" {
+				t.Fatalf("self = %+v", self)
+			}
+			if account := body.Links["account"]; account.Href != "/v1/accounts/{email}" || !account.Templated {
+				t.Fatalf("account = %+v", account)
+			}
+			if _, ok := body.Links["delete"]; ok != tt.wantDelete {
+				t.Fatalf("delete link present = %v, want %v", ok, tt.wantDelete)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/url"
+	"platform/internal/shared"
+	"strconv"
+)
+
+// ListRequest is embedded in the requests of the list endpoints, it reads the page number or the cursor of
+// the page, its size and the sort, e.g. ?p=2&ps=10&sort=-created_at or ?ps=10&cursor=...
+type ListRequest struct {
+	Page     int    `reqHeader:"-" params:"-" query:"p" json:"-" validate:"omitempty,gt=0"`
+	PageSize int    `reqHeader:"-" params:"-" query:"ps" json:"-" validate:"gt=0,lte=100"`
+	Cursor   string `reqHeader:"-" params:"-" query:"cursor" json:"-" validate:"max=1024"`
+	Sort     string `reqHeader:"-" params:"-" query:"sort" json:"-" validate:"max=128"`
+}
+
+// ListOptions returns the options of the request, the empty filters are left out. The list is sorted on
+// defaultSort when the request has no sort, only the sortable fields are accepted.
+func (r *ListRequest) ListOptions(defaultSort string, filters map[string]string, sortable ...string) (shared.ListOptions, error) {
+	value := r.Sort
+	if value == "" {
+		value = defaultSort
+	}
+	fields, err := shared.ParseSort(value, sortable...)
+	if err != nil {
+		return shared.ListOptions{}, err
+	}
+
+	options := shared.ListOptions{
+		Page:     max(r.Page, 1),
+		PageSize: r.PageSize,
+		Sort:     fields,
+		Filters:  make(map[string]string, len(filters)),
+	}
+	for key, value := range filters {
+		if value != "" {
+			options.Filters[key] = value
+		}
+	}
+	if r.Cursor != "" {
+		if options.After, err = shared.DecodeCursor(r.Cursor, options.SortString()); err != nil {
+			return shared.ListOptions{}, err
+		}
+		options.Page = 0
+	}
+	return options, nil
+}
+
+// PageInfo describes the page of a list response, Number is left out for the pages read with a cursor
+type PageInfo struct {
+	Number     int    `json:"number,omitempty"`
+	Size       int    `json:"size"`
+	TotalCount int    `json:"total_count"`
+	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// PagedResponse returns a page of the list at path with its page metadata and the first, prev, next and
+// last links, which keep the filters and the sort of the request. The pages read with a cursor only link
+// to the first and the next page.
+func PagedResponse[T any](data *T, path string, options shared.ListOptions, result PageInfo) *Response[T] {
+	result.Size = options.PageSize
+	result.Number = options.Page
+	if options.PageSize > 0 {
+		result.TotalPages = (result.TotalCount + options.PageSize - 1) / options.PageSize
+	}
+
+	response := SuccessResponse(data)
+	response.Page = &result
+	response.Links = shared.HALLinks{
+		"first": {Href: pageHref(path, options, "p", "1"), Method: "GET", Title: "First page"},
+	}
+	if options.After != nil {
+		if result.NextCursor != "" {
+			response.Links["next"] = shared.HALLink{Href: pageHref(path, options, "cursor", result.NextCursor), Method: "GET", Title: "Next page"}
+		}
+		return response
+	}
+
+	if options.Page > 1 {
+		prev := min(options.Page-1, max(result.TotalPages, 1))
+		response.Links["prev"] = shared.HALLink{Href: pageHref(path, options, "p", strconv.Itoa(prev)), Method: "GET", Title: "Previous page"}
+	}
+	if options.Page < result.TotalPages {
+		response.Links["next"] = shared.HALLink{Href: pageHref(path, options, "p", strconv.Itoa(options.Page+1)), Method: "GET", Title: "Next page"}
+	}
+	response.Links["last"] = shared.HALLink{Href: pageHref(path, options, "p", strconv.Itoa(max(result.TotalPages, 1))), Method: "GET", Title: "Last page"}
+	return response
+}
+
+// pageHref returns the address of a page of the list, Encode sorts the parameters so the links are stable
+func pageHref(path string, options shared.ListOptions, key, value string) string {
+	query := url.Values{}
+	for name, filter := range options.Filters {
+		query.Set(name, filter)
+	}
+	if sort := options.SortString(); sort != "" {
+		query.Set("sort", sort)
+	}
+	query.Set("ps", strconv.Itoa(options.PageSize))
+	query.Set(key, value)
+	return path + "?" + query.Encode()
+}
@@ -1,3 +1,9 @@
 package handlers
 
 type Request any
+
+// RawBodyRequest is a request reading its body as it is instead of parsing it, e.g. a webhook whose
+// signature covers the exact bytes sent
+type RawBodyRequest interface {
+	SetRawBody(body []byte)
+}
@@ -3,11 +3,18 @@ package handlers
 import "platform/internal/shared"
 
 type Response[T any] struct {
-	ResponseStatus int    `json:"-"`
-	ErrorMessage   string `json:"error_message,omitempty"`
-	Message        string `json:"message,omitempty"`
-	Data           *T     `json:"data,omitempty"`
+	ResponseStatus int       `json:"-"`
+	ErrorMessage   string    `json:"error_message,omitempty"`
+	Message        string    `json:"message,omitempty"`
+	Data           *T        `json:"data,omitempty"`
+	Page           *PageInfo `json:"page,omitempty"`
 	shared.HALResource
+
+	// Location, ContentType and Body replace the JSON document, see RedirectResponse and ContentResponse
+	Location    string            `json:"-"`
+	ContentType string            `json:"-"`
+	Body        []byte            `json:"-"`
+	Headers     map[string]string `json:"-"`
 }
 
 func SuccessResponse[T any](data *T) *Response[T] {
@@ -49,3 +56,27 @@ func ConflictResponse[T any](err error) *Response[T] {
 		ErrorMessage:   err.Error(),
 	}
 }
+
+func TooManyRequestsResponse[T any](err error) *Response[T] {
+	return &Response[T]{
+		ResponseStatus: 429,
+		ErrorMessage:   err.Error(),
+	}
+}
+
+// RedirectResponse sends the client to url with 302 Found
+func RedirectResponse[T any](url string) *Response[T] {
+	return &Response[T]{
+		ResponseStatus: 302,
+		Location:       url,
+	}
+}
+
+// ContentResponse returns body as it is instead of a JSON document, e.g. an image
+func ContentResponse[T any](contentType string, body []byte) *Response[T] {
+	return &Response[T]{
+		ResponseStatus: 200,
+		ContentType:    contentType,
+		Body:           body,
+	}
+}
@@ -1,5 +1,15 @@
 package shared
 
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// HALContentType is returned instead of application/json to the clients accepting it
+const HALContentType = "application/hal+json"
+
 type HALLink struct {
 	Href      string `json:"href"`
 	Method    string `json:"method,omitempty"`    // GET, POST, PUT, DELETE, vs.
@@ -9,7 +19,95 @@ type HALLink struct {
 }
 
 type HALResource struct {
-	Links HALLinks `json:"_links"`
+	Links    HALLinks    `json:"_links"`
+	Embedded HALEmbedded `json:"_embedded,omitempty"`
+}
+
+// Embed adds a resource, or a list of resources, under the relation
+func (r *HALResource) Embed(rel string, resource any) {
+	if r.Embedded == nil {
+		r.Embedded = make(HALEmbedded)
+	}
+	r.Embedded[rel] = resource
 }
 
 type HALLinks map[string]HALLink
+
+// HALEmbedded holds the resources embedded in a resource by relation
+type HALEmbedded map[string]any
+
+// curies maps the registered CURIE names to their documentation href, e.g. "nf" => "https://docs/rels/{rel}"
+var curies = struct {
+	sync.RWMutex
+	hrefs map[string]string
+}{hrefs: make(map[string]string)}
+
+// RegisterCurie documents the relations named "<name>:<rel>", href must contain the "{rel}" template
+func RegisterCurie(name, href string) {
+	curies.Lock()
+	defer curies.Unlock()
+	curies.hrefs[name] = href
+}
+
+type curieLink struct {
+	Name      string `json:"name"`
+	Href      string `json:"href"`
+	Templated bool   `json:"templated"`
+}
+
+// MarshalJSON adds the "curies" link of the CURIEs used by the relations.
+// The relations of a CURIE which is not registered fall back to their plain name, e.g. "nf:dkim" => "dkim".
+func (l HALLinks) MarshalJSON() ([]byte, error) {
+	if l == nil {
+		return []byte("null"), nil
+	}
+
+	curies.RLock()
+	links := make(map[string]any, len(l)+1)
+	var used []curieLink
+	for rel, link := range l {
+		rel, name, href := resolveRel(rel)
+		links[rel] = link
+		if href != "" && !slices.ContainsFunc(used, func(c curieLink) bool { return c.Name == name }) {
+			used = append(used, curieLink{Name: name, Href: href, Templated: true})
+		}
+	}
+	curies.RUnlock()
+
+	if len(used) > 0 {
+		slices.SortFunc(used, func(a, b curieLink) int { return strings.Compare(a.Name, b.Name) })
+		links["curies"] = used
+	}
+	return json.Marshal(links)
+}
+
+// MarshalJSON names the relations like HALLinks, the CURIEs are only listed in the links
+func (e HALEmbedded) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+
+	// The lock is released before marshalling, the embedded resources marshal their own links
+	curies.RLock()
+	embedded := make(map[string]any, len(e))
+	for rel, resource := range e {
+		rel, _, _ := resolveRel(rel)
+		embedded[rel] = resource
+	}
+	curies.RUnlock()
+	return json.Marshal(embedded)
+}
+
+// resolveRel returns the relation to write with the CURIE it uses, href is empty when the relation has no
+// registered CURIE. The caller holds the read lock of curies.
+func resolveRel(rel string) (resolved, name, href string) {
+	name, plain, ok := strings.Cut(rel, ":")
+	if !ok {
+		return rel, "", ""
+	}
+	href, registered := curies.hrefs[name]
+	if !registered {
+		return plain, "", ""
+	}
+	return rel, name, href
+}
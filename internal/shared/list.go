@@ -0,0 +1,108 @@
+package shared
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidSort   = errors.New("invalid sort field")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// SortField orders a list on a field, in descending order when Desc is set
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ListOptions selects a page of a list. Pages are read by number with Page, or after the last item of the
+// previous page with After (keyset pagination), which stays stable while items are added. A zero PageSize
+// selects every item.
+type ListOptions struct {
+	Page     int
+	PageSize int
+	After    *Cursor
+	Sort     []SortField
+	Filters  map[string]string
+}
+
+// Offset returns the number of items before the page, it is 0 when the page follows a cursor
+func (o ListOptions) Offset() int {
+	if o.After != nil || o.Page < 1 {
+		return 0
+	}
+	return (o.Page - 1) * o.PageSize
+}
+
+// SortString returns the sort in the format of ParseSort
+func (o ListOptions) SortString() string {
+	fields := make([]string, 0, len(o.Sort))
+	for _, s := range o.Sort {
+		if s.Desc {
+			fields = append(fields, "-"+s.Field)
+		} else {
+			fields = append(fields, s.Field)
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
+// ParseSort reads a comma separated list of fields, a field starting with "-" is in descending order.
+// Only the sortable fields are accepted.
+func ParseSort(value string, sortable ...string) ([]SortField, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var fields []SortField
+	for _, part := range strings.Split(value, ",") {
+		field := SortField{Field: strings.TrimSpace(part)}
+		if strings.HasPrefix(field.Field, "-") {
+			field.Field, field.Desc = field.Field[1:], true
+		}
+		valid := false
+		for _, name := range sortable {
+			valid = valid || name == field.Field
+		}
+		if !valid {
+			return nil, ErrInvalidSort
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// Cursor is the position of the last item of a page: its values for the sort fields followed by its
+// identifier. It is only valid with the sort it was made with.
+type Cursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+// Encode returns the cursor as an opaque URL-safe string
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor reads a cursor of Encode, which must have been made with sort
+func DecodeCursor(value, sort string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != sort || len(cursor.Values) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// ListResult is a page of a list, NextCursor is empty on the last page
+type ListResult[T any] struct {
+	Items      []T
+	TotalCount int
+	NextCursor string
+}
@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"platform/internal/shared"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// RequireAuthentication rejects the requests without a caller in shared.UserIDContextKey
+func RequireAuthentication() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := c.UserContext().Value(shared.UserIDContextKey).(uuid.UUID); !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error_message": "Authentication is required to access this resource",
+			})
+		}
+		return c.Next()
+	}
+}
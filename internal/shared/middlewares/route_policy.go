@@ -0,0 +1,195 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"platform/internal/shared"
+	"platform/pkg/services/route_matcher"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/google/uuid"
+)
+
+// RateLimitRule allows at most Requests calls per Window for each caller
+type RateLimitRule struct {
+	Requests int    `json:"requests"`
+	Window   string `json:"window"` // time.ParseDuration format, e.g. "1m"
+}
+
+// RoutePolicy describes who can call a route and how often.
+// Path accepts ":param" and "*wildcard" segments, the most specific path matching a request wins.
+type RoutePolicy struct {
+	Path      string         `json:"path"`
+	Methods   []string       `json:"methods"` // empty means every method
+	Roles     []string       `json:"roles"`   // empty means no role is required
+	RateLimit *RateLimitRule `json:"rate_limit"`
+}
+
+type compiledPolicy struct {
+	methods []string
+	roles   []string
+	limiter fiber.Handler
+}
+
+// LoadRoutePolicies reads the policies from the JSON file set in ROUTE_POLICIES_FILE.
+// No policies are returned when the variable is not set.
+func LoadRoutePolicies() ([]RoutePolicy, error) {
+	path := os.Getenv("ROUTE_POLICIES_FILE")
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []RoutePolicy
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return nil, fmt.Errorf("invalid route policies file %s: %w", path, err)
+	}
+	return policies, nil
+}
+
+// RoutePolicyEnforcer applies the role and per-caller rate limit rules of the policy matching each request.
+// Requests without a matching policy are passed through. Roles are read from shared.RolesContextKey, they
+// are the roles of the caller in the project of the request.
+func RoutePolicyEnforcer(policies []RoutePolicy) (fiber.Handler, error) {
+	matcher, err := compileRoutePolicies(policies)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *fiber.Ctx) error {
+		match, ok := matcher.Match(c.Path())
+		if !ok {
+			return c.Next()
+		}
+
+		policy := findPolicy(match.Value, c.Method())
+		if policy == nil {
+			return c.Next()
+		}
+
+		if !policy.allows(c.UserContext()) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error_message": "You are not allowed to access this resource",
+			})
+		}
+
+		if policy.limiter != nil {
+			return policy.limiter(c)
+		}
+		return c.Next()
+	}, nil
+}
+
+// RouteAuthorizer reports whether the roles in ctx can call the method on the path, it is used to leave out
+// the links of the responses which the caller is not allowed to follow. Rate limits are not checked.
+func RouteAuthorizer(policies []RoutePolicy) (func(ctx context.Context, method, path string) bool, error) {
+	matcher, err := compileRoutePolicies(policies)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, method, path string) bool {
+		match, ok := matcher.Match(path)
+		if !ok {
+			return true
+		}
+		policy := findPolicy(match.Value, strings.ToUpper(method))
+		return policy == nil || policy.allows(ctx)
+	}, nil
+}
+
+func compileRoutePolicies(policies []RoutePolicy) (*route_matcher.Matcher[[]*compiledPolicy], error) {
+	matcher := route_matcher.New[[]*compiledPolicy]()
+
+	// Policies sharing a path are grouped, so each method can have its own rules
+	grouped := make(map[string][]*compiledPolicy)
+	var order []string
+	for _, policy := range policies {
+		compiled, err := compileRoutePolicy(policy)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := grouped[policy.Path]; !ok {
+			order = append(order, policy.Path)
+		}
+		grouped[policy.Path] = append(grouped[policy.Path], compiled)
+	}
+	for _, path := range order {
+		if err := matcher.Add(path, grouped[path]); err != nil {
+			return nil, err
+		}
+	}
+	return matcher, nil
+}
+
+func compileRoutePolicy(policy RoutePolicy) (*compiledPolicy, error) {
+	compiled := &compiledPolicy{roles: policy.Roles}
+	for _, method := range policy.Methods {
+		compiled.methods = append(compiled.methods, strings.ToUpper(method))
+	}
+
+	if policy.RateLimit != nil {
+		window, err := time.ParseDuration(policy.RateLimit.Window)
+		if err != nil || window <= 0 || policy.RateLimit.Requests <= 0 {
+			return nil, fmt.Errorf("invalid rate limit for route policy %s", policy.Path)
+		}
+
+		// Every policy has its own limiter, so the counters of different routes never collide
+		compiled.limiter = limiter.New(limiter.Config{
+			Max:          policy.RateLimit.Requests,
+			Expiration:   window,
+			KeyGenerator: callerRateLimitKey,
+			LimitReached: func(c *fiber.Ctx) error {
+				return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+					"error_message": "Rate limit exceeded, please try again later",
+				})
+			},
+		})
+	}
+	return compiled, nil
+}
+
+// allows reports whether one of the roles in ctx is required by the policy
+func (p *compiledPolicy) allows(ctx context.Context) bool {
+	if len(p.roles) == 0 {
+		return true
+	}
+	roles, _ := ctx.Value(shared.RolesContextKey).([]string)
+	return slices.ContainsFunc(roles, func(role string) bool { return slices.Contains(p.roles, role) })
+}
+
+// findPolicy returns the policy of the method, falling back to the one without methods
+func findPolicy(policies []*compiledPolicy, method string) *compiledPolicy {
+	var fallback *compiledPolicy
+	for _, policy := range policies {
+		if len(policy.methods) == 0 {
+			if fallback == nil {
+				fallback = policy
+			}
+			continue
+		}
+		if slices.Contains(policy.methods, method) {
+			return policy
+		}
+	}
+	return fallback
+}
+
+// callerRateLimitKey limits each authenticated user separately, the anonymous requests are limited by IP.
+// The X-Project-ID header is not used, a client could send a new project on every request to reset its limit.
+func callerRateLimitKey(c *fiber.Ctx) string {
+	if userID, ok := c.UserContext().Value(shared.UserIDContextKey).(uuid.UUID); ok {
+		return "user:" + userID.String()
+	}
+	return "ip:" + c.IP()
+}
@@ -0,0 +1,94 @@
+package middlewares
+
+import (
+	"context"
+	"net/http/httptest"
+	"platform/internal/shared"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// testCaller stands for the authentication in front of the API, the role of the caller is the token itself
+func testCaller(c *fiber.Ctx) error {
+	_, role, ok := strings.Cut(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if !ok {
+		return c.Next()
+	}
+	ctx := context.WithValue(c.UserContext(), shared.UserIDContextKey, uuid.New())
+	c.SetUserContext(context.WithValue(ctx, shared.RolesContextKey, []string{role}))
+	return c.Next()
+}
+
+func testApp(t *testing.T, policies []RoutePolicy) *fiber.App {
+	t.Helper()
+	enforcer, err := RoutePolicyEnforcer(policies)
+	if err != nil {
+		t.Fatalf("enforcer: %v", err)
+	}
+
+	app := fiber.New()
+	app.Use(testCaller, enforcer)
+	app.Get("/accounts/:id", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	app.Delete("/accounts/:id", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	app.Get("/me", RequireAuthentication(), func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	return app
+}
+
+func TestRoutePolicyEnforcerRoles(t *testing.T) {
+	app := testApp(t, []RoutePolicy{
+		{Path: "/accounts/:id", Methods: []string{"DELETE"}, Roles: []string{"ADMIN"}},
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		status int
+	}{
+		{"allowed role", "DELETE", "/accounts/1", "ADMIN", fiber.StatusOK},
+		{"denied role", "DELETE", "/accounts/1", "REGISTERED", fiber.StatusForbidden},
+		{"anonymous", "DELETE", "/accounts/1", "", fiber.StatusForbidden},
+		{"no role required", "GET", "/accounts/1", "", fiber.StatusOK},
+		{"authenticated", "GET", "/me", "REGISTERED", fiber.StatusOK},
+		{"authentication required", "GET", "/me", "", fiber.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set(fiber.HeaderAuthorization, "Bearer "+tt.token)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
+
+func TestRoutePolicyEnforcerRateLimitIgnoresProjectHeader(t *testing.T) {
+	app := testApp(t, []RoutePolicy{
+		{Path: "/accounts/:id", RateLimit: &RateLimitRule{Requests: 2, Window: "1m"}},
+	})
+
+	statuses := make([]int, 0, 3)
+	for range 3 {
+		req := httptest.NewRequest("GET", "/accounts/1", nil)
+		req.Header.Set("X-Project-ID", uuid.NewString())
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("request: %v", err)
+		}
+		statuses = append(statuses, resp.StatusCode)
+	}
+	if statuses[2] != fiber.StatusTooManyRequests {
+		t.Fatalf("statuses = %v, want the third request limited", statuses)
+	}
+}
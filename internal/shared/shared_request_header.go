@@ -5,4 +5,11 @@ type ctxKey string
 const (
 	ProjectIDHeader            = "X-Project-ID"
 	ProjectIDContextKey ctxKey = ctxKey(ProjectIDHeader)
+
+	// UserIDContextKey holds the identifier (uuid.UUID) of the authenticated caller, it is set by the
+	// authentication running in front of the API like RolesContextKey
+	UserIDContextKey ctxKey = "user_id"
+
+	// RolesContextKey holds the role names ([]string) of the authenticated caller in the project of the request
+	RolesContextKey ctxKey = "roles"
 )
@@ -2,22 +2,56 @@ package vo
 
 import (
 	"errors"
+	"net/mail"
 	"platform/pkg/domain"
-	"regexp"
+	"strings"
+
+	"golang.org/x/net/idna"
 )
 
 var (
-	emailRegex      = regexp.MustCompile(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}$`)
 	ErrEmailInvalid = errors.New("invalid email format")
 )
 
+// Email is an RFC 5322 addr-spec such as john.doe@example.com. The domain is lowercased and converted to its
+// ASCII (punycode) form, the local part is kept as written because it may be case-sensitive.
 type Email struct {
 	domain.BaseValueObject
 	value string
 }
 
 func NewEmail(value string) (Email, error) {
-	if !emailRegex.MatchString(value) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.ContainsAny(value, "<>") {
+		return Email{}, ErrEmailInvalid
+	}
+
+	// net/mail also accepts "Name <addr>", a bare address must not have a display name
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Name != "" {
+		return Email{}, ErrEmailInvalid
+	}
+
+	idx := strings.LastIndexByte(address.Address, '@')
+	if idx <= 0 {
+		return Email{}, ErrEmailInvalid
+	}
+	return newEmail(address.Address[:idx], address.Address[idx+1:])
+}
+
+func newEmail(localPart, domainPart string) (Email, error) {
+	asciiDomain, err := idna.Lookup.ToASCII(domainPart)
+	if err != nil || !strings.Contains(asciiDomain, ".") {
+		return Email{}, ErrEmailInvalid
+	}
+	if len(localPart) > 64 {
+		return Email{}, ErrEmailInvalid
+	}
+
+	// Let net/mail quote the local part when it needs it, e.g. "john doe"@example.com
+	formatted := (&mail.Address{Address: localPart + "@" + asciiDomain}).String()
+	value := formatted[1 : len(formatted)-1]
+	if len(value) > 254 {
 		return Email{}, ErrEmailInvalid
 	}
 	return Email{value: value}, nil
@@ -30,3 +64,119 @@ func (e Email) GetAtomicValues() []interface{} {
 func (e Email) Value() string {
 	return e.value
 }
+
+func (e Email) String() string {
+	return e.value
+}
+
+// LocalPart returns the part before the @, unquoted
+func (e Email) LocalPart() string {
+	address, err := mail.ParseAddress(e.value)
+	if err != nil {
+		return ""
+	}
+	return address.Address[:strings.LastIndexByte(address.Address, '@')]
+}
+
+// Domain returns the ASCII form of the domain, e.g. xn--mnchen-3ya.de
+func (e Email) Domain() string {
+	return e.value[strings.LastIndexByte(e.value, '@')+1:]
+}
+
+// UnicodeDomain returns the domain as people write it, e.g. münchen.de
+func (e Email) UnicodeDomain() string {
+	domain, err := idna.Lookup.ToUnicode(e.Domain())
+	if err != nil {
+		return e.Domain()
+	}
+	return domain
+}
+
+// Canonical returns the mailbox the address is delivered to, to detect the same person signing up twice:
+// the local part is lowercased and its +tag removed, and dots are dropped for Gmail which ignores them.
+// It must not be used to send messages.
+func (e Email) Canonical() Email {
+	local, domainPart := strings.ToLower(e.LocalPart()), e.Domain()
+	if idx := strings.IndexByte(local, '+'); idx > 0 {
+		local = local[:idx]
+	}
+	if domainPart == "gmail.com" || domainPart == "googlemail.com" {
+		local, domainPart = strings.ReplaceAll(local, ".", ""), "gmail.com"
+	}
+
+	canonical, err := newEmail(local, domainPart)
+	if err != nil {
+		return e
+	}
+	return canonical
+}
+
+// Address is an email address with an optional display name, as used in the From, To and Reply-To headers
+type Address struct {
+	domain.BaseValueObject
+	name  string
+	email Email
+}
+
+func NewAddress(name string, email Email) Address {
+	return Address{name: strings.TrimSpace(name), email: email}
+}
+
+// ParseAddress parses "John Doe <john@example.com>" as well as a bare address
+func ParseAddress(value string) (Address, error) {
+	address, err := mail.ParseAddress(strings.TrimSpace(value))
+	if err != nil {
+		return Address{}, ErrEmailInvalid
+	}
+	return fromMailAddress(address)
+}
+
+// ParseAddressList parses a comma separated list of addresses
+func ParseAddressList(value string) ([]Address, error) {
+	list, err := mail.ParseAddressList(value)
+	if err != nil {
+		return nil, ErrEmailInvalid
+	}
+
+	addresses := make([]Address, 0, len(list))
+	for _, address := range list {
+		parsed, err := fromMailAddress(address)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, parsed)
+	}
+	return addresses, nil
+}
+
+func fromMailAddress(address *mail.Address) (Address, error) {
+	idx := strings.LastIndexByte(address.Address, '@')
+	if idx <= 0 {
+		return Address{}, ErrEmailInvalid
+	}
+	email, err := newEmail(address.Address[:idx], address.Address[idx+1:])
+	if err != nil {
+		return Address{}, err
+	}
+	return NewAddress(address.Name, email), nil
+}
+
+func (a Address) GetAtomicValues() []interface{} {
+	return []any{a.name, a.email.value}
+}
+
+func (a Address) Name() string {
+	return a.name
+}
+
+func (a Address) Email() Email {
+	return a.email
+}
+
+// String formats the address for a message header, encoding non-ASCII display names with RFC 2047
+func (a Address) String() string {
+	if a.name == "" {
+		return a.email.value
+	}
+	return (&mail.Address{Name: a.name, Address: a.email.LocalPart() + "@" + a.email.Domain()}).String()
+}
@@ -0,0 +1,75 @@
+package vo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewEmail(t *testing.T) {
+	cases := map[string]string{
+		"john@example.com":         "john@example.com",
+		" John.Doe@Example.COM ":   "John.Doe@example.com",
+		`"john doe"@example.com`:   `"john doe"@example.com`,
+		"user+tag@sub.example.org": "user+tag@sub.example.org",
+		"info@Bücher.de":           "info@xn--bcher-kva.de",
+	}
+	for inp, out := range cases {
+		e, err := NewEmail(inp)
+		if err != nil {
+			t.Fatalf("%q: %v", inp, err)
+		}
+		if e.Value() != out {
+			t.Fatalf("%q: got %q want %q", inp, e.Value(), out)
+		}
+	}
+
+	for _, inp := range []string{"", "john", "john@", "@example.com", "john@localhost", "John <john@example.com>",
+		"<john@example.com>", "john@exa mple.com", "a@b@example.com"} {
+		if _, err := NewEmail(inp); !errors.Is(err, ErrEmailInvalid) {
+			t.Fatalf("%q: expected invalid email, got %v", inp, err)
+		}
+	}
+}
+
+func TestEmailParts(t *testing.T) {
+	e, err := NewEmail(`"John.Doe+News"@Bücher.de`)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if e.LocalPart() != "John.Doe+News" || e.Domain() != "xn--bcher-kva.de" || e.UnicodeDomain() != "bücher.de" {
+		t.Fatalf("bad parts: %q %q %q", e.LocalPart(), e.Domain(), e.UnicodeDomain())
+	}
+	if c := e.Canonical(); c.Value() != "john.doe@xn--bcher-kva.de" {
+		t.Fatalf("bad canonical: %q", c.Value())
+	}
+
+	gmail, _ := NewEmail("J.Doe+spam@googlemail.com")
+	if c := gmail.Canonical(); c.Value() != "jdoe@gmail.com" {
+		t.Fatalf("bad gmail canonical: %q", c.Value())
+	}
+}
+
+func TestParseAddress(t *testing.T) {
+	a, err := ParseAddress("Jöhn Doe <John@Example.com>")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if a.Name() != "Jöhn Doe" || a.Email().Value() != "John@example.com" {
+		t.Fatalf("bad address: %q %q", a.Name(), a.Email().Value())
+	}
+	if a.String() != "=?utf-8?q?J=C3=B6hn_Doe?= <John@example.com>" {
+		t.Fatalf("bad header value: %q", a.String())
+	}
+
+	list, err := ParseAddressList(`a@example.com, "Doe, John" <john@example.com>`)
+	if err != nil || len(list) != 2 || list[1].Name() != "Doe, John" {
+		t.Fatalf("bad list: %v %v", list, err)
+	}
+	if list[1].String() != `"Doe, John" <john@example.com>` {
+		t.Fatalf("bad header value: %q", list[1].String())
+	}
+
+	if _, err := ParseAddress("John <john>"); !errors.Is(err, ErrEmailInvalid) {
+		t.Fatalf("expected invalid email, got %v", err)
+	}
+}
@@ -1,44 +1,338 @@
 package vo
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"platform/pkg/domain"
+	"regexp"
 	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 var (
-	ErrInvalidCurrency = errors.New("invalid currency")
+	ErrCurrencyMismatch = errors.New("currencies do not match")
+	ErrMoneyOverflow    = errors.New("money amount out of range")
+	ErrPrecisionLoss    = errors.New("amount has more decimals than the currency allows")
+	ErrInvalidAmount    = errors.New("invalid money amount")
+	ErrInvalidRatios    = errors.New("invalid allocation ratios")
 )
 
+// decimalAmount is the form of the amounts read by ParseMoney, big.Rat alone also reads fractions, exponents
+// and hexadecimal numbers
+var decimalAmount = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$`)
+
+// Money is an exact amount in a currency, stored as an integer number of minor units (e.g. cents).
 type Money struct {
 	domain.BaseValueObject
-	Amount   float64
-	Currency string
+	amount   int64
+	currency Currency
 }
 
-var validCurrencies = map[string]bool{
-	"USD": true,
-	"EUR": true,
-	"TRY": true,
-	"GBP": true,
+// NewMoney returns an amount expressed in minor units, e.g. NewMoney(1050, "USD") is $10.50
+func NewMoney(minorUnits int64, currency string) (Money, error) {
+	c, err := ParseCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: minorUnits, currency: c}, nil
 }
 
-func NewMoney(amount float64, currency string) (Money, error) {
-	currency = strings.ToUpper(currency)
-	if !validCurrencies[currency] {
-		return Money{}, ErrInvalidCurrency
+// ParseMoney returns the amount written as a decimal string such as "10.50" or "-3". It fails if the amount
+// has more decimals than the currency allows instead of rounding it silently.
+func ParseMoney(amount, currency string) (Money, error) {
+	c, err := ParseCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	amount = strings.TrimSpace(amount)
+	if !decimalAmount.MatchString(amount) {
+		return Money{}, ErrInvalidAmount
+	}
+	r, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return Money{}, ErrInvalidAmount
+	}
+
+	minor := r.Mul(r, new(big.Rat).SetInt(pow10(c.exponent)))
+	if !minor.IsInt() {
+		return Money{}, ErrPrecisionLoss
 	}
-	return Money{
-		Amount:   amount,
-		Currency: currency,
-	}, nil
+	return fromBigInt(minor.Num(), c)
+}
+
+// Amount returns the amount in minor units
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.amount < 0
 }
 
 func (m Money) GetAtomicValues() []interface{} {
-	return []any{m.Amount, m.Currency}
+	return []any{m.amount, m.currency.code}
+}
+
+// Add returns m + other. Both amounts must be in the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	sum := m.amount + other.amount
+	if (sum > m.amount) != (other.amount > 0) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{amount: sum, currency: m.currency}, nil
+}
+
+// Subtract returns m - other. Both amounts must be in the same currency.
+func (m Money) Subtract(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	diff := m.amount - other.amount
+	if (diff < m.amount) != (other.amount > 0) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{amount: diff, currency: m.currency}, nil
+}
+
+// Negate returns -m
+func (m Money) Negate() (Money, error) {
+	return m.Multiply("-1")
+}
+
+// Multiply returns m multiplied by a decimal factor such as "1.18" or "0.5". The result is rounded to the
+// minor unit with banker's rounding, so that rounding errors do not drift in one direction over many operations.
+func (m Money) Multiply(factor string) (Money, error) {
+	f, ok := new(big.Rat).SetString(strings.TrimSpace(factor))
+	if !ok {
+		return Money{}, ErrInvalidAmount
+	}
+	product := f.Mul(f, new(big.Rat).SetInt64(m.amount))
+	return fromBigInt(roundHalfEven(product), m.currency)
+}
+
+// Allocate splits m between the given ratios without losing a minor unit: Allocate(1, 1, 1) of $1.00 gives
+// $0.34, $0.33 and $0.33. The remainder of the division is handed out one minor unit at a time, starting from
+// the first share with a non-zero ratio.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	var total int64
+	for _, ratio := range ratios {
+		if ratio < 0 || total+ratio < total {
+			return nil, ErrInvalidRatios
+		}
+		total += ratio
+	}
+	if total == 0 {
+		return nil, ErrInvalidRatios
+	}
+
+	amount := big.NewInt(m.amount)
+	bigTotal := big.NewInt(total)
+	shares := make([]Money, len(ratios))
+	remainder := m.amount
+	for i, ratio := range ratios {
+		// |amount * ratio / total| <= |amount|, so the share always fits into an int64
+		share := new(big.Int).Mul(amount, big.NewInt(ratio))
+		share.Quo(share, bigTotal)
+		shares[i] = Money{amount: share.Int64(), currency: m.currency}
+		remainder -= share.Int64()
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(shares) {
+		if ratios[i] == 0 {
+			continue
+		}
+		shares[i].amount += step
+		remainder -= step
+	}
+	return shares, nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether m is less than, equal to or greater than other
+func (m Money) Compare(other Money) (int, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Decimal returns the amount in major units with exactly as many decimals as the currency has, e.g. "-10.50"
+func (m Money) Decimal() string {
+	sign, digits := splitDigits(m.amount, m.currency.exponent)
+	integer, fraction := digits[:len(digits)-m.currency.exponent], digits[len(digits)-m.currency.exponent:]
+	if fraction == "" {
+		return sign + integer
+	}
+	return sign + integer + "." + fraction
 }
 
 func (m Money) String() string {
-	return fmt.Sprintf("%f %s", m.Amount, m.Currency)
+	return m.Decimal() + " " + m.currency.code
+}
+
+// MarshalJSON writes the amount as a decimal string, e.g. {"amount":"10.50","currency":"USD"}, so that it is
+// never turned into a float by the reader.
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m.currency.isZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{m.Decimal(), m.currency.code})
+}
+
+// UnmarshalJSON accepts the amount both as a decimal string and as a JSON number
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*m = Money{}
+		return nil
+	}
+
+	var raw struct {
+		Amount   json.RawMessage `json:"amount"`
+		Currency string          `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var amount json.Number
+	if err := json.Unmarshal(bytes.Trim(raw.Amount, `"`), &amount); err != nil {
+		return ErrInvalidAmount
+	}
+
+	parsed, err := ParseMoney(amount.String(), raw.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// NumericValue implements pgtype.NumericValuer, so Money can be written to a NUMERIC column.
+// The currency has to be stored in a column of its own.
+func (m Money) NumericValue() (pgtype.Numeric, error) {
+	if m.currency.isZero() {
+		return pgtype.Numeric{}, nil
+	}
+	return pgtype.Numeric{Int: big.NewInt(m.amount), Exp: int32(-m.currency.exponent), Valid: true}, nil
+}
+
+// ScanNumeric implements pgtype.NumericScanner. The currency must be set before scanning, either by starting
+// from a Money of the right currency or by using MoneyFromNumeric.
+func (m *Money) ScanNumeric(n pgtype.Numeric) error {
+	if m.currency.isZero() {
+		return ErrInvalidCurrency
+	}
+	if !n.Valid {
+		return ErrInvalidAmount
+	}
+	scanned, err := MoneyFromNumeric(n, m.currency.code)
+	if err != nil {
+		return err
+	}
+	*m = scanned
+	return nil
+}
+
+// MoneyFromNumeric converts a value read from a NUMERIC column
+func MoneyFromNumeric(n pgtype.Numeric, currency string) (Money, error) {
+	c, err := ParseCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	if !n.Valid || n.NaN || n.InfinityModifier != pgtype.Finite || n.Int == nil {
+		return Money{}, ErrInvalidAmount
+	}
+
+	// value = Int * 10^Exp, minor units = value * 10^exponent
+	minor := new(big.Int).Set(n.Int)
+	if scale := int(n.Exp) + c.exponent; scale >= 0 {
+		minor.Mul(minor, pow10(scale))
+	} else {
+		var rem big.Int
+		minor.QuoRem(minor, pow10(-scale), &rem)
+		if rem.Sign() != 0 {
+			return Money{}, ErrPrecisionLoss
+		}
+	}
+	return fromBigInt(minor, c)
+}
+
+func (m Money) sameCurrency(other Money) error {
+	if m.currency != other.currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency.code, other.currency.code)
+	}
+	return nil
+}
+
+func fromBigInt(minor *big.Int, c Currency) (Money, error) {
+	if !minor.IsInt64() {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{amount: minor.Int64(), currency: c}, nil
+}
+
+// roundHalfEven rounds to the nearest integer, ties go to the even neighbour
+func roundHalfEven(r *big.Rat) *big.Int {
+	quo, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	// Compare 2*|rem| with the denominator to find on which side of the half we are
+	twice := new(big.Int).Abs(rem)
+	twice.Lsh(twice, 1)
+	switch cmp := twice.Cmp(r.Denom()); {
+	case cmp > 0, cmp == 0 && quo.Bit(0) == 1:
+		if r.Sign() < 0 {
+			return quo.Sub(quo, big.NewInt(1))
+		}
+		return quo.Add(quo, big.NewInt(1))
+	}
+	return quo
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// splitDigits returns the sign and the absolute value of amount, left padded so that it has at least one digit
+// before the last exponent digits
+func splitDigits(amount int64, exponent int) (string, string) {
+	sign := ""
+	digits := new(big.Int).Abs(big.NewInt(amount)).String()
+	if amount < 0 {
+		sign = "-"
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return sign, digits
 }
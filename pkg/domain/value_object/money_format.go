@@ -0,0 +1,99 @@
+package vo
+
+import (
+	"strings"
+)
+
+// numberFormat describes how a locale writes amounts of money
+type numberFormat struct {
+	decimal      string
+	group        string
+	symbolBefore bool
+	symbolSpace  bool
+}
+
+var (
+	defaultNumberFormat = numberFormat{decimal: ".", group: ",", symbolBefore: true}
+
+	// numberFormats is keyed by language, or by language-region when the region changes the format
+	numberFormats = map[string]numberFormat{
+		"en":    defaultNumberFormat,
+		"en-IE": defaultNumberFormat,
+		"ja":    defaultNumberFormat,
+		"zh":    defaultNumberFormat,
+		"ko":    defaultNumberFormat,
+		"tr":    {decimal: ",", group: ".", symbolBefore: true},
+		"de":    {decimal: ",", group: ".", symbolSpace: true},
+		"de-CH": {decimal: ".", group: "’", symbolBefore: true, symbolSpace: true},
+		"es":    {decimal: ",", group: ".", symbolSpace: true},
+		"it":    {decimal: ",", group: ".", symbolSpace: true},
+		"pt":    {decimal: ",", group: ".", symbolSpace: true},
+		"pt-BR": {decimal: ",", group: ".", symbolBefore: true, symbolSpace: true},
+		"nl":    {decimal: ",", group: ".", symbolBefore: true, symbolSpace: true},
+		"fr":    {decimal: ",", group: " ", symbolSpace: true},
+		"fr-CH": {decimal: ".", group: " ", symbolSpace: true},
+		"ru":    {decimal: ",", group: " ", symbolSpace: true},
+		"pl":    {decimal: ",", group: " ", symbolSpace: true},
+		"sv":    {decimal: ",", group: " ", symbolSpace: true},
+		"ar":    {decimal: ".", group: ",", symbolSpace: true},
+	}
+
+	// currencySymbols lists the symbols of the common currencies, the others are written with their code
+	currencySymbols = map[string]string{
+		"USD": "$", "EUR": "€", "GBP": "£", "TRY": "₺", "JPY": "¥", "CNY": "¥", "KRW": "₩", "INR": "₹",
+		"RUB": "₽", "UAH": "₴", "ILS": "₪", "NGN": "₦", "PHP": "₱", "VND": "₫", "THB": "฿", "PLN": "zł",
+		"BRL": "R$", "CHF": "CHF", "CAD": "CA$", "AUD": "A$", "NZD": "NZ$", "HKD": "HK$", "MXN": "MX$",
+	}
+)
+
+// Format writes the amount the way the locale (a BCP 47 tag such as "en-US", "de" or "tr_TR") expects it,
+// e.g. "$1,234.50" for en-US or "1.234,50 €" for de-DE. Unknown locales fall back to the en-US format.
+func (m Money) Format(locale string) string {
+	format := findNumberFormat(locale)
+
+	sign, digits := splitDigits(m.amount, m.currency.exponent)
+	integer, fraction := digits[:len(digits)-m.currency.exponent], digits[len(digits)-m.currency.exponent:]
+
+	var sb strings.Builder
+	for i, d := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			sb.WriteString(format.group)
+		}
+		sb.WriteRune(d)
+	}
+	if fraction != "" {
+		sb.WriteString(format.decimal)
+		sb.WriteString(fraction)
+	}
+	number := sb.String()
+
+	symbol, ok := currencySymbols[m.currency.code]
+	if !ok {
+		symbol = m.currency.code
+	}
+	space := ""
+	if format.symbolSpace || !ok {
+		space = " "
+	}
+
+	if format.symbolBefore {
+		return sign + symbol + space + number
+	}
+	return sign + number + space + symbol
+}
+
+func findNumberFormat(locale string) numberFormat {
+	tag := strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	language, region, _ := strings.Cut(tag, "-")
+	language = strings.ToLower(language)
+
+	if region != "" {
+		if format, ok := numberFormats[language+"-"+strings.ToUpper(region)]; ok {
+			return format
+		}
+	}
+	if format, ok := numberFormats[language]; ok {
+		return format
+	}
+	return defaultNumberFormat
+}
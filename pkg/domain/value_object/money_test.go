@@ -0,0 +1,187 @@
+package vo
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func mustParseMoney(t *testing.T, amount, currency string) Money {
+	t.Helper()
+	m, err := ParseMoney(amount, currency)
+	if err != nil {
+		t.Fatalf("parse %s %s: %v", amount, currency, err)
+	}
+	return m
+}
+
+func TestParseMoney(t *testing.T) {
+	cases := []struct {
+		amount, currency string
+		minor            int64
+		err              error
+	}{
+		{"10.50", "usd", 1050, nil},
+		{"-0.05", "EUR", -5, nil},
+		{"1500", "JPY", 1500, nil},
+		{"1.234", "KWD", 1234, nil},
+		{"1.5", "JPY", 0, ErrPrecisionLoss},
+		{"1.005", "USD", 0, ErrPrecisionLoss},
+		{"1e3", "USD", 0, ErrInvalidAmount},
+		{"abc", "USD", 0, ErrInvalidAmount},
+		{"0x10", "USD", 0, ErrInvalidAmount},
+		{"0x1p4", "USD", 0, ErrInvalidAmount},
+		{"1/2", "USD", 0, ErrInvalidAmount},
+		{"1.", "USD", 0, ErrInvalidAmount},
+		{" 2.50 ", "USD", 250, nil},
+		{"1", "XYZ", 0, ErrInvalidCurrency},
+		{"100000000000000000", "USD", 0, ErrMoneyOverflow},
+	}
+	for _, test := range cases {
+		m, err := ParseMoney(test.amount, test.currency)
+		if !errors.Is(err, test.err) {
+			t.Fatalf("%s %s: got error %v want %v", test.amount, test.currency, err, test.err)
+		}
+		if err == nil && m.Amount() != test.minor {
+			t.Fatalf("%s %s: got %d want %d", test.amount, test.currency, m.Amount(), test.minor)
+		}
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a := mustParseMoney(t, "10.25", "USD")
+	b := mustParseMoney(t, "0.75", "USD")
+
+	sum, err := a.Add(b)
+	if err != nil || sum.Decimal() != "11.00" {
+		t.Fatalf("bad sum: %v %v", sum, err)
+	}
+	diff, err := b.Subtract(a)
+	if err != nil || diff.Decimal() != "-9.50" {
+		t.Fatalf("bad difference: %v %v", diff, err)
+	}
+	if _, err := a.Add(mustParseMoney(t, "1", "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("expected currency mismatch, got %v", err)
+	}
+
+	huge, _ := NewMoney(math.MaxInt64, "USD")
+	if _, err := huge.Add(b); !errors.Is(err, ErrMoneyOverflow) {
+		t.Fatalf("expected overflow, got %v", err)
+	}
+
+	// Banker's rounding: ties go to the even minor unit
+	cases := map[string]string{
+		"0.125":  "0.12",
+		"0.135":  "0.14",
+		"-0.125": "-0.12",
+		"-0.135": "-0.14",
+		"0.1251": "0.13",
+	}
+	one := mustParseMoney(t, "1.00", "USD")
+	for factor, want := range cases {
+		out, err := one.Multiply(factor)
+		if err != nil || out.Decimal() != want {
+			t.Fatalf("1.00 * %s: got %v want %s (%v)", factor, out.Decimal(), want, err)
+		}
+	}
+}
+
+func TestMoneyAllocate(t *testing.T) {
+	cases := []struct {
+		amount string
+		ratios []int64
+		out    []int64
+	}{
+		{"1.00", []int64{1, 1, 1}, []int64{34, 33, 33}},
+		{"0.05", []int64{3, 7}, []int64{2, 3}},
+		{"-1.00", []int64{1, 1, 1}, []int64{-34, -33, -33}},
+		{"0.10", []int64{0, 1, 1}, []int64{0, 5, 5}},
+		{"0.03", []int64{0, 1, 1}, []int64{0, 2, 1}},
+	}
+	for _, test := range cases {
+		shares, err := mustParseMoney(t, test.amount, "EUR").Allocate(test.ratios...)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		for i, share := range shares {
+			if share.Amount() != test.out[i] {
+				t.Fatalf("allocate %s %v: got %v want %v", test.amount, test.ratios, shares, test.out)
+			}
+		}
+	}
+
+	if _, err := mustParseMoney(t, "1", "USD").Allocate(0, 0); !errors.Is(err, ErrInvalidRatios) {
+		t.Fatalf("expected invalid ratios, got %v", err)
+	}
+	if _, err := mustParseMoney(t, "1", "USD").Allocate(1, -1); !errors.Is(err, ErrInvalidRatios) {
+		t.Fatalf("expected invalid ratios, got %v", err)
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	cases := []struct {
+		amount, currency, locale, out string
+	}{
+		{"1234567.5", "USD", "en-US", "$1,234,567.50"},
+		{"-1234.5", "EUR", "de-DE", "-1.234,50 €"},
+		{"1234.5", "TRY", "tr_TR", "₺1.234,50"},
+		{"1234", "JPY", "ja", "¥1,234"},
+		{"0.5", "CHF", "de-CH", "CHF 0.50"},
+		{"12.345", "KWD", "fr", "12,345 KWD"},
+		{"12", "GBP", "xx", "£12.00"},
+	}
+	for _, test := range cases {
+		out := mustParseMoney(t, test.amount, test.currency).Format(test.locale)
+		if out != test.out {
+			t.Fatalf("format %s %s %s: got %q want %q", test.amount, test.currency, test.locale, out, test.out)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	m := mustParseMoney(t, "-0.07", "USD")
+	data, err := json.Marshal(m)
+	if err != nil || string(data) != `{"amount":"-0.07","currency":"USD"}` {
+		t.Fatalf("bad json: %s %v", data, err)
+	}
+
+	var out Money
+	if err := json.Unmarshal(data, &out); err != nil || out.String() != m.String() {
+		t.Fatalf("bad round trip: %v %v", out, err)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":12.5,"currency":"EUR"}`), &out); err != nil || out.Amount() != 1250 {
+		t.Fatalf("bad number amount: %v %v", out, err)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":"1.5","currency":"JPY"}`), &out); !errors.Is(err, ErrPrecisionLoss) {
+		t.Fatalf("expected precision loss, got %v", err)
+	}
+}
+
+func TestMoneyNumeric(t *testing.T) {
+	m := mustParseMoney(t, "123.45", "USD")
+	n, err := m.NumericValue()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	scanned, _ := NewMoney(0, "USD")
+	if err := scanned.ScanNumeric(n); err != nil || scanned.String() != m.String() {
+		t.Fatalf("bad round trip: %v %v", scanned, err)
+	}
+
+	// NUMERIC(12,4) as returned by postgres
+	out, err := MoneyFromNumeric(pgtype.Numeric{Int: big.NewInt(1234500), Exp: -4, Valid: true}, "USD")
+	if err != nil || out.Amount() != 12345 {
+		t.Fatalf("bad scale conversion: %v %v", out, err)
+	}
+	if _, err := MoneyFromNumeric(pgtype.Numeric{Int: big.NewInt(1234501), Exp: -4, Valid: true}, "USD"); !errors.Is(err, ErrPrecisionLoss) {
+		t.Fatalf("expected precision loss, got %v", err)
+	}
+	if err := new(Money).ScanNumeric(n); !errors.Is(err, ErrInvalidCurrency) {
+		t.Fatalf("expected missing currency, got %v", err)
+	}
+}
@@ -3,26 +3,203 @@ package vo
 import (
 	"errors"
 	"platform/pkg/domain"
-	"regexp"
+	"strings"
 )
 
 var (
-	phoneRegex      = regexp.MustCompile(`^\+?[1-9]\d{1,14}$`)
-	ErrPhoneInvalid = errors.New("invalid phone number format")
+	ErrPhoneInvalid       = errors.New("invalid phone number format")
+	ErrPhoneRegionUnknown = errors.New("unknown phone number region")
 )
 
+// PhoneNumberType is the kind of line a number belongs to
+type PhoneNumberType int
+
+const (
+	UnknownPhoneType PhoneNumberType = iota
+
+	FixedLine
+
+	Mobile
+
+	// FixedLineOrMobile is used where both share the same numbering plan, e.g. in the US
+	FixedLineOrMobile
+
+	TollFree
+)
+
+// String method to return the string representation of the PhoneNumberType.
+func (t PhoneNumberType) String() string {
+	return [...]string{"unknown", "fixed_line", "mobile", "fixed_line_or_mobile", "toll_free"}[t]
+}
+
+// PhoneNumber is a phone number normalized to E.164, e.g. +905321234567
 type PhoneNumber struct {
 	domain.BaseValueObject
-	value string
+	callingCode    string
+	nationalNumber string
+	region         string
 }
 
+// NewPhoneNumber parses a number written in international format, such as "+90 532 123 45 67" or "0090532...".
 func NewPhoneNumber(value string) (PhoneNumber, error) {
-	if !phoneRegex.MatchString(value) {
+	return ParsePhoneNumber(value, "")
+}
+
+// ParsePhoneNumber parses a number written either in international format or in the national format of region
+// (an ISO 3166 code such as "TR"), e.g. "0532 123 45 67". Spaces, dots, dashes and parentheses are ignored.
+func ParsePhoneNumber(value, region string) (PhoneNumber, error) {
+	value = strings.TrimSpace(value)
+	international := strings.HasPrefix(value, "+")
+
+	digits := make([]byte, 0, len(value))
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case c == ' ' || c == '-' || c == '.' || c == '(' || c == ')' || (c == '+' && i == 0):
+		default:
+			return PhoneNumber{}, ErrPhoneInvalid
+		}
+	}
+	number := string(digits)
+
+	// 00 is the international call prefix of most countries
+	if !international && strings.HasPrefix(number, "00") {
+		international, number = true, number[2:]
+	}
+
+	if international {
+		return parseInternational(number)
+	}
+
+	meta, ok := phoneRegions[strings.ToUpper(region)]
+	if !ok {
+		return PhoneNumber{}, ErrPhoneRegionUnknown
+	}
+	return parseNational(meta, number)
+}
+
+func parseInternational(number string) (PhoneNumber, error) {
+	if len(number) < 8 || len(number) > 15 || number[0] == '0' {
 		return PhoneNumber{}, ErrPhoneInvalid
 	}
-	return PhoneNumber{value: value}, nil
+
+	// Calling codes are prefix-free, so at most one of the 1 to 3 digit prefixes is a known code
+	for l := 1; l <= 3; l++ {
+		regions, ok := callingCodes[number[:l]]
+		if !ok {
+			continue
+		}
+		national := number[l:]
+		for _, meta := range regions {
+			if p, err := parseNational(meta, national); err == nil {
+				return p, nil
+			}
+		}
+		return PhoneNumber{}, ErrPhoneInvalid
+	}
+
+	// Numbers of regions without metadata are kept as they are, they can still be used to send messages
+	return PhoneNumber{nationalNumber: number}, nil
+}
+
+func parseNational(meta *phoneRegion, number string) (PhoneNumber, error) {
+	candidates := []string{number}
+	if meta.trunkPrefix != "" && strings.HasPrefix(number, meta.trunkPrefix) {
+		// The trunk prefix is often written after the calling code too, e.g. +90 (0532) ...
+		candidates = []string{number[len(meta.trunkPrefix):], number}
+	}
+
+	for _, national := range candidates {
+		if meta.numberType(national) == UnknownPhoneType {
+			continue
+		}
+		region := meta.region
+		if meta.regionOf != nil {
+			region = meta.regionOf(national)
+		}
+		return PhoneNumber{callingCode: meta.callingCode, nationalNumber: national, region: region}, nil
+	}
+	return PhoneNumber{}, ErrPhoneInvalid
 }
 
 func (p PhoneNumber) GetAtomicValues() []any {
-	return []any{p.value}
+	return []any{p.Value()}
+}
+
+// Value returns the number in E.164 format
+func (p PhoneNumber) Value() string {
+	if p.nationalNumber == "" {
+		return ""
+	}
+	return "+" + p.callingCode + p.nationalNumber
+}
+
+func (p PhoneNumber) String() string {
+	return p.Value()
+}
+
+// CallingCode returns the country calling code, e.g. "90". It is empty for regions without metadata.
+func (p PhoneNumber) CallingCode() string {
+	return p.callingCode
+}
+
+// NationalNumber returns the national significant number, without trunk prefix
+func (p PhoneNumber) NationalNumber() string {
+	return p.nationalNumber
+}
+
+// Region returns the ISO 3166 code of the region the number belongs to, or "" if it is unknown
+func (p PhoneNumber) Region() string {
+	return p.region
+}
+
+// Type detects the kind of line from the numbering plan of the region
+func (p PhoneNumber) Type() PhoneNumberType {
+	meta, ok := phoneRegions[p.region]
+	if !ok {
+		return UnknownPhoneType
+	}
+	return meta.numberType(p.nationalNumber)
+}
+
+// FormatInternational returns the number grouped for humans, e.g. "+90 532 123 45 67"
+func (p PhoneNumber) FormatInternational() string {
+	if p.callingCode == "" {
+		return p.Value()
+	}
+	if meta, ok := phoneRegions[p.region]; ok {
+		if f, ok := meta.format(p.nationalNumber); ok {
+			return "+" + p.callingCode + " " + applyPattern(f.international, p.nationalNumber)
+		}
+	}
+	return "+" + p.callingCode + " " + p.nationalNumber
+}
+
+// FormatNational returns the number as dialed inside its region, e.g. "0532 123 45 67" or "(415) 555-2671"
+func (p PhoneNumber) FormatNational() string {
+	meta, ok := phoneRegions[p.region]
+	if !ok {
+		return p.Value()
+	}
+	if f, ok := meta.format(p.nationalNumber); ok {
+		return applyPattern(f.national, p.nationalNumber)
+	}
+	return meta.trunkPrefix + p.nationalNumber
+}
+
+// applyPattern replaces each # of the pattern with the next digit of number
+func applyPattern(pattern, number string) string {
+	var sb strings.Builder
+	i := 0
+	for _, c := range pattern {
+		if c == '#' {
+			sb.WriteByte(number[i])
+			i++
+			continue
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
 }
@@ -0,0 +1,196 @@
+package vo
+
+import (
+	"slices"
+	"strings"
+)
+
+// phoneFormat groups the national significant numbers starting with prefix and having the given length.
+// Each # of the patterns is replaced by a digit of the number.
+type phoneFormat struct {
+	prefix        string
+	length        int
+	national      string
+	international string
+}
+
+// phoneRegion is the numbering plan of a region. Prefixes apply to the national significant number, which is
+// the number without calling code and trunk prefix.
+type phoneRegion struct {
+	region        string
+	callingCode   string
+	trunkPrefix   string
+	lengths       []int
+	tollFree      []string
+	mobile        []string
+	fixed         []string
+	fixedOrMobile []string
+	formats       []phoneFormat
+
+	// regionOf picks the region of regions sharing this plan, e.g. the US and Canada
+	regionOf func(national string) string
+}
+
+func (r *phoneRegion) numberType(national string) PhoneNumberType {
+	if !slices.Contains(r.lengths, len(national)) {
+		return UnknownPhoneType
+	}
+
+	hasPrefix := func(prefixes []string) bool {
+		return slices.ContainsFunc(prefixes, func(prefix string) bool { return strings.HasPrefix(national, prefix) })
+	}
+	switch {
+	case hasPrefix(r.tollFree):
+		return TollFree
+	case hasPrefix(r.mobile):
+		return Mobile
+	case hasPrefix(r.fixed):
+		return FixedLine
+	case hasPrefix(r.fixedOrMobile):
+		return FixedLineOrMobile
+	}
+	return UnknownPhoneType
+}
+
+func (r *phoneRegion) format(national string) (phoneFormat, bool) {
+	for _, f := range r.formats {
+		if f.length == len(national) && strings.HasPrefix(national, f.prefix) {
+			return f, true
+		}
+	}
+	return phoneFormat{}, false
+}
+
+var nanp = &phoneRegion{
+	region:        "US",
+	callingCode:   "1",
+	trunkPrefix:   "1",
+	lengths:       []int{10},
+	tollFree:      []string{"800", "833", "844", "855", "866", "877", "888"},
+	fixedOrMobile: []string{"2", "3", "4", "5", "6", "7", "8", "9"},
+	formats:       []phoneFormat{{"", 10, "(###) ###-####", "###-###-####"}},
+	regionOf: func(national string) string {
+		if canadianAreaCodes[national[:3]] {
+			return "CA"
+		}
+		return "US"
+	},
+}
+
+var canadianAreaCodes = func() map[string]bool {
+	codes := strings.Fields(`204 226 236 249 250 257 263 289 306 343 354 365 367 368 382 403 416 418 428 431 437
+		438 450 468 474 506 514 519 548 579 581 584 587 604 613 639 647 672 683 705 709 742 753 778 780 782 807 819
+		825 867 873 879 902 905`)
+	m := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		m[code] = true
+	}
+	return m
+}()
+
+// phoneRegions holds the numbering plans of the regions we send messages to. Numbers of other regions are
+// accepted in international format but cannot be typed or formatted.
+var phoneRegions = map[string]*phoneRegion{
+	"US": nanp,
+	"CA": nanp,
+	"TR": {
+		region:      "TR",
+		callingCode: "90",
+		trunkPrefix: "0",
+		lengths:     []int{10},
+		tollFree:    []string{"800"},
+		mobile:      []string{"5"},
+		fixed:       []string{"2", "3", "4"},
+		formats:     []phoneFormat{{"", 10, "0### ### ## ##", "### ### ## ##"}},
+	},
+	"GB": {
+		region:      "GB",
+		callingCode: "44",
+		trunkPrefix: "0",
+		lengths:     []int{10},
+		tollFree:    []string{"800", "808"},
+		mobile:      []string{"71", "72", "73", "74", "75", "77", "78", "79"},
+		fixed:       []string{"1", "2"},
+		formats: []phoneFormat{
+			{"2", 10, "0## #### ####", "## #### ####"},
+			{"", 10, "0#### ######", "#### ######"},
+		},
+	},
+	"DE": {
+		region:      "DE",
+		callingCode: "49",
+		trunkPrefix: "0",
+		lengths:     []int{6, 7, 8, 9, 10, 11},
+		tollFree:    []string{"800"},
+		mobile:      []string{"15", "16", "17"},
+		fixed:       []string{"2", "3", "4", "5", "6", "7", "8", "9"},
+		formats: []phoneFormat{
+			{"1", 10, "0### #######", "### #######"},
+			{"1", 11, "0### ########", "### ########"},
+			{"30", 10, "030 ########", "30 ########"},
+		},
+	},
+	"FR": {
+		region:      "FR",
+		callingCode: "33",
+		trunkPrefix: "0",
+		lengths:     []int{9},
+		tollFree:    []string{"80"},
+		mobile:      []string{"6", "7"},
+		fixed:       []string{"1", "2", "3", "4", "5", "9"},
+		formats:     []phoneFormat{{"", 9, "0# ## ## ## ##", "# ## ## ## ##"}},
+	},
+	"ES": {
+		region:      "ES",
+		callingCode: "34",
+		lengths:     []int{9},
+		tollFree:    []string{"800", "900"},
+		mobile:      []string{"6", "7"},
+		fixed:       []string{"8", "9"},
+		formats:     []phoneFormat{{"", 9, "### ## ## ##", "### ## ## ##"}},
+	},
+	"IT": {
+		region:      "IT",
+		callingCode: "39",
+		lengths:     []int{6, 7, 8, 9, 10, 11},
+		tollFree:    []string{"80"},
+		mobile:      []string{"3"},
+		fixed:       []string{"0"},
+		formats: []phoneFormat{
+			{"3", 10, "### ### ####", "### ### ####"},
+			{"0", 10, "## #### ####", "## #### ####"},
+		},
+	},
+	"NL": {
+		region:      "NL",
+		callingCode: "31",
+		trunkPrefix: "0",
+		lengths:     []int{9},
+		mobile:      []string{"6"},
+		fixed:       []string{"1", "2", "3", "4", "5", "7"},
+		formats: []phoneFormat{
+			{"6", 9, "06 ########", "6 ########"},
+			{"", 9, "0## #######", "## #######"},
+		},
+	},
+	"IN": {
+		region:      "IN",
+		callingCode: "91",
+		trunkPrefix: "0",
+		lengths:     []int{10},
+		mobile:      []string{"6", "7", "8", "9"},
+		fixed:       []string{"1", "2", "3", "4", "5"},
+		formats:     []phoneFormat{{"", 10, "0##### #####", "##### #####"}},
+	},
+}
+
+// callingCodes maps each calling code to its numbering plans
+var callingCodes = func() map[string][]*phoneRegion {
+	m := make(map[string][]*phoneRegion)
+	for _, meta := range phoneRegions {
+		if !slices.Contains(m[meta.callingCode], meta) {
+			m[meta.callingCode] = append(m[meta.callingCode], meta)
+		}
+	}
+	return m
+}()
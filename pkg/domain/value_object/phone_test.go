@@ -0,0 +1,61 @@
+package vo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePhoneNumber(t *testing.T) {
+	cases := []struct {
+		input, region     string
+		e164, nationalFmt string
+		internationalFmt  string
+		numberRegion      string
+		numberType        PhoneNumberType
+	}{
+		{"0532 123 45 67", "TR", "+905321234567", "0532 123 45 67", "+90 532 123 45 67", "TR", Mobile},
+		{"+90 (0532) 123-45-67", "", "+905321234567", "0532 123 45 67", "+90 532 123 45 67", "TR", Mobile},
+		{"00902121234567", "", "+902121234567", "0212 123 45 67", "+90 212 123 45 67", "TR", FixedLine},
+		{"(415) 555-2671", "us", "+14155552671", "(415) 555-2671", "+1 415-555-2671", "US", FixedLineOrMobile},
+		{"1 800 555 0199", "US", "+18005550199", "(800) 555-0199", "+1 800-555-0199", "US", TollFree},
+		{"+1 416 555 0123", "", "+14165550123", "(416) 555-0123", "+1 416-555-0123", "CA", FixedLineOrMobile},
+		{"020 7946 0958", "GB", "+442079460958", "020 7946 0958", "+44 20 7946 0958", "GB", FixedLine},
+		{"+44 7700 900123", "", "+447700900123", "07700 900123", "+44 7700 900123", "GB", Mobile},
+		{"06 12 34 56 78", "FR", "+33612345678", "06 12 34 56 78", "+33 6 12 34 56 78", "FR", Mobile},
+		{"+39 06 1234 5678", "", "+390612345678", "06 1234 5678", "+39 06 1234 5678", "IT", FixedLine},
+		{"+971 50 123 4567", "", "+971501234567", "+971501234567", "+971501234567", "", UnknownPhoneType},
+	}
+	for _, test := range cases {
+		p, err := ParsePhoneNumber(test.input, test.region)
+		if err != nil {
+			t.Fatalf("%q: %v", test.input, err)
+		}
+		if p.Value() != test.e164 || p.FormatNational() != test.nationalFmt || p.FormatInternational() != test.internationalFmt {
+			t.Fatalf("%q: got %q %q %q", test.input, p.Value(), p.FormatNational(), p.FormatInternational())
+		}
+		if p.Region() != test.numberRegion || p.Type() != test.numberType {
+			t.Fatalf("%q: got region %q type %v", test.input, p.Region(), p.Type())
+		}
+	}
+}
+
+func TestParsePhoneNumberErrors(t *testing.T) {
+	cases := []struct {
+		input, region string
+		err           error
+	}{
+		{"0532 123 45 67", "", ErrPhoneRegionUnknown},
+		{"0532 123 45 67", "XX", ErrPhoneRegionUnknown},
+		{"0532 123 45", "TR", ErrPhoneInvalid},
+		{"0132 123 45 67", "TR", ErrPhoneInvalid},
+		{"+90 532 123 45 67 8", "", ErrPhoneInvalid},
+		{"+1 234", "", ErrPhoneInvalid},
+		{"+90 532 abc", "", ErrPhoneInvalid},
+		{"+0 532 123 45 67", "", ErrPhoneInvalid},
+	}
+	for _, test := range cases {
+		if _, err := ParsePhoneNumber(test.input, test.region); !errors.Is(err, test.err) {
+			t.Fatalf("%q %q: got %v want %v", test.input, test.region, err, test.err)
+		}
+	}
+}
@@ -6,6 +6,8 @@ type CacheManager interface {
 	Get(ctx context.Context, key CacheKey) (string, error)
 	Set(ctx context.Context, key CacheKey, value string) error
 	Remove(ctx context.Context, key string) error
+	// Take removes the key and returns its value, when several callers take a key only one gets its value
+	Take(ctx context.Context, key string) (string, error)
 	RemoveByPrefix(ctx context.Context, prefix string) error
 	Clear(ctx context.Context) error
 }
@@ -68,6 +68,18 @@ func (c *InMemoryCacheManager) Remove(ctx context.Context, key string) error {
 	return nil
 }
 
+func (c *InMemoryCacheManager) Take(ctx context.Context, key string) (any, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	item, ok := c.data[key]
+	delete(c.data, key)
+	if !ok || time.Now().After(item.expiresAt) {
+		return nil, ErrKeyNotFound
+	}
+	return item.value, nil
+}
+
 func (c *InMemoryCacheManager) RemoveByPrefix(ctx context.Context, prefix string) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
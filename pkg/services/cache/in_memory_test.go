@@ -65,6 +65,27 @@ func TestInMemoryCacheManager_Remove(t *testing.T) {
 	assert.Nil(t, got)
 }
 
+func TestInMemoryCacheManager_Take(t *testing.T) {
+	ctx := context.Background()
+	cache := NewInMemoryCacheManager()
+
+	key := CacheKey{
+		Key:  "take-key",
+		Time: 5,
+	}
+	value := "taken-once"
+
+	_ = cache.Set(ctx, key, value)
+
+	got, err := cache.Take(ctx, key.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, value, got)
+
+	got, err = cache.Take(ctx, key.Key)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	assert.Nil(t, got)
+}
+
 func TestInMemoryCacheManager_RemoveByPrefix(t *testing.T) {
 	ctx := context.Background()
 	cache := NewInMemoryCacheManager()
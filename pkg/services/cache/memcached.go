@@ -47,6 +47,22 @@ func (m *MemcacheManager) Remove(ctx context.Context, key string) error {
 	return err
 }
 
+// Take gets the value then deletes the key, the callers which lose the race to delete it get ErrKeyNotFound
+func (m *MemcacheManager) Take(ctx context.Context, key string) (string, error) {
+	item, err := m.client.Get(key)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return "", ErrKeyNotFound
+	} else if err != nil {
+		return "", err
+	}
+	if err := m.client.Delete(key); errors.Is(err, memcache.ErrCacheMiss) {
+		return "", ErrKeyNotFound
+	} else if err != nil {
+		return "", err
+	}
+	return string(item.Value), nil
+}
+
 // Memcached does not support key scanning or prefix deletes natively
 func (m *MemcacheManager) RemoveByPrefix(ctx context.Context, prefix string) error {
 	return fmt.Errorf("RemoveByPrefix is not supported in memcached")
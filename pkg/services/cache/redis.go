@@ -48,6 +48,16 @@ func (r *RedisCacheManager) Remove(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+func (r *RedisCacheManager) Take(ctx context.Context, key string) (string, error) {
+	val, err := r.client.GetDel(ctx, key).Result()
+	if err == redis.Nil {
+		return "", ErrKeyNotFound
+	} else if err != nil {
+		return "", err
+	}
+	return val, nil
+}
+
 func (r *RedisCacheManager) RemoveByPrefix(ctx context.Context, prefix string) error {
 	iter := r.client.Scan(ctx, 0, prefix+"*", 0).Iterator()
 	for iter.Next(ctx) {
@@ -0,0 +1,165 @@
+package radix_tree
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentTree is a radix tree that is safe for concurrent use. It has the same API as Tree but is backed by an
+// ImmutableTree: writers are serialized and publish a new version on every change, readers load the current
+// version atomically and never block.
+type ConcurrentTree[V any] struct {
+	mu   sync.Mutex
+	tree atomic.Pointer[ImmutableTree[V]]
+}
+
+// NewConcurrent returns an empty ConcurrentTree
+func NewConcurrent[V any]() *ConcurrentTree[V] {
+	return NewConcurrentFromMap[V](nil)
+}
+
+// NewConcurrentFromMap returns a new concurrent tree containing the keys from an existing map
+func NewConcurrentFromMap[V any](m map[string]V) *ConcurrentTree[V] {
+	t := &ConcurrentTree[V]{}
+	t.tree.Store(NewImmutableFromMap(m))
+	return t
+}
+
+// Snapshot returns the current version of the tree. It is never modified, so it can be read at leisure.
+func (t *ConcurrentTree[V]) Snapshot() *ImmutableTree[V] {
+	return t.tree.Load()
+}
+
+// Update applies many changes in a single transaction. Readers see either none or all of them.
+func (t *ConcurrentTree[V]) Update(fn func(txn *Txn[V])) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	txn := t.tree.Load().Txn()
+	fn(txn)
+	t.publish(txn)
+}
+
+// Insert is used to add a new entry or update an existing entry. Returns true if an existing record is updated.
+func (t *ConcurrentTree[V]) Insert(s string, v V) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	txn := t.tree.Load().Txn()
+	old, ok := txn.Insert(s, v)
+	t.publish(txn)
+	return old, ok
+}
+
+// Delete is used to delete a key, returning the previous value and if it was deleted
+func (t *ConcurrentTree[V]) Delete(s string) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	txn := t.tree.Load().Txn()
+	old, ok := txn.Delete(s)
+	if ok {
+		t.publish(txn)
+	}
+	return old, ok
+}
+
+// DeletePrefix is used to delete the subtree under a prefix. Returns how many keys were deleted.
+func (t *ConcurrentTree[V]) DeletePrefix(s string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	txn := t.tree.Load().Txn()
+	deleted := txn.DeletePrefix(s)
+	if deleted > 0 {
+		t.publish(txn)
+	}
+	return deleted
+}
+
+// publish stores the committed tree before waking up watchers, so they always observe the change
+func (t *ConcurrentTree[V]) publish(txn *Txn[V]) {
+	t.tree.Store(txn.CommitOnly())
+	txn.Notify()
+}
+
+// Len is used to return the number of elements in the tree
+func (t *ConcurrentTree[V]) Len() int {
+	return t.Snapshot().Len()
+}
+
+// ToMap is used to walk the tree and convert it into a map
+func (t *ConcurrentTree[V]) ToMap() map[string]V {
+	return t.Snapshot().ToMap()
+}
+
+// Get is used to lookup a specific key, returning the value and if it was found
+func (t *ConcurrentTree[V]) Get(s string) (V, bool) {
+	return t.Snapshot().Get(s)
+}
+
+// LongestPrefix is like Get, but instead of an exact match, it will return the longest prefix match.
+func (t *ConcurrentTree[V]) LongestPrefix(s string) (string, V, bool) {
+	return t.Snapshot().LongestPrefix(s)
+}
+
+// Minimum is used to return the minimum value in the tree
+func (t *ConcurrentTree[V]) Minimum() (string, V, bool) {
+	return t.Snapshot().Minimum()
+}
+
+// Maximum is used to return the maximum value in the tree
+func (t *ConcurrentTree[V]) Maximum() (string, V, bool) {
+	return t.Snapshot().Maximum()
+}
+
+// Walk is used to walk a snapshot of the tree. The callback may safely modify the tree.
+func (t *ConcurrentTree[V]) Walk(fn WalkFn[V]) {
+	t.Snapshot().Walk(fn)
+}
+
+// WalkPrefix is used to walk a snapshot of the tree under a prefix
+func (t *ConcurrentTree[V]) WalkPrefix(prefix string, fn WalkFn[V]) {
+	t.Snapshot().WalkPrefix(prefix, fn)
+}
+
+// WalkPath is used to walk a snapshot of the tree from the root down to a given leaf
+func (t *ConcurrentTree[V]) WalkPath(path string, fn WalkFn[V]) {
+	t.Snapshot().WalkPath(path, fn)
+}
+
+// Watch returns a channel that is closed once any key starting with prefix changes
+func (t *ConcurrentTree[V]) Watch(prefix string) <-chan struct{} {
+	return t.Snapshot().Watch(prefix)
+}
+
+// Iterator returns an iterator in ascending order over the current snapshot of the tree
+func (t *ConcurrentTree[V]) Iterator() *Iterator[V] {
+	return t.Snapshot().Iterator()
+}
+
+// ReverseIterator returns an iterator in descending order over the current snapshot of the tree
+func (t *ConcurrentTree[V]) ReverseIterator() *ReverseIterator[V] {
+	return t.Snapshot().ReverseIterator()
+}
+
+// All returns a sequence over every key and value of the current snapshot in ascending order
+func (t *ConcurrentTree[V]) All() iter.Seq2[string, V] {
+	return t.Snapshot().All()
+}
+
+// Backward returns a sequence over every key and value of the current snapshot in descending order
+func (t *ConcurrentTree[V]) Backward() iter.Seq2[string, V] {
+	return t.Snapshot().Backward()
+}
+
+// Prefix returns a sequence over the keys of the current snapshot starting with prefix in ascending order
+func (t *ConcurrentTree[V]) Prefix(prefix string) iter.Seq2[string, V] {
+	return t.Snapshot().Prefix(prefix)
+}
+
+// Range returns a sequence over the keys of the current snapshot in [start, end) in ascending order
+func (t *ConcurrentTree[V]) Range(start, end string) iter.Seq2[string, V] {
+	return t.Snapshot().Range(start, end)
+}
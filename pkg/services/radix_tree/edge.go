@@ -7,25 +7,25 @@ import "sort"
 //
 //	label: The character at the beginning of the edge. This character determines which child node to navigate to.
 //	node: The child node to which this edge is directed.
-type edge struct {
+type edge[V any] struct {
 	label byte
-	node  *node
+	node  *node[V]
 }
 
-type edges []edge
+type edges[V any] []edge[V]
 
-func (e edges) Len() int {
+func (e edges[V]) Len() int {
 	return len(e)
 }
 
-func (e edges) Less(i, j int) bool {
+func (e edges[V]) Less(i, j int) bool {
 	return e[i].label < e[j].label
 }
 
-func (e edges) Swap(i, j int) {
+func (e edges[V]) Swap(i, j int) {
 	e[i], e[j] = e[j], e[i]
 }
 
-func (e edges) Sort() {
+func (e edges[V]) Sort() {
 	sort.Sort(e)
 }
@@ -0,0 +1,291 @@
+package radix_tree
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxFieldSize bounds a single prefix or value read by the decoders, so a corrupt input cannot exhaust memory
+const maxFieldSize = 64 << 20
+
+var ErrInvalidEncoding = errors.New("invalid radix tree encoding")
+
+// Codec converts the values of a tree to bytes and back for the binary encoding
+type Codec[V any] struct {
+	Marshal   func(v V) ([]byte, error)
+	Unmarshal func(data []byte) (V, error)
+}
+
+// JSONCodec encodes values with encoding/json
+func JSONCodec[V any]() Codec[V] {
+	return Codec[V]{
+		Marshal: func(v V) ([]byte, error) {
+			return json.Marshal(v)
+		},
+		Unmarshal: func(data []byte) (V, error) {
+			var v V
+			err := json.Unmarshal(data, &v)
+			return v, err
+		},
+	}
+}
+
+// StringCodec stores string values as they are
+func StringCodec() Codec[string] {
+	return Codec[string]{
+		Marshal:   func(v string) ([]byte, error) { return []byte(v), nil },
+		Unmarshal: func(data []byte) (string, error) { return string(data), nil },
+	}
+}
+
+// SetCodec stores nothing for the values, for trees used as a set of keys
+func SetCodec() Codec[struct{}] {
+	return Codec[struct{}]{
+		Marshal:   func(struct{}) ([]byte, error) { return nil, nil },
+		Unmarshal: func([]byte) (struct{}, error) { return struct{}{}, nil },
+	}
+}
+
+// Encode writes the tree to w in a compact binary form. The nodes are written as they are laid out in memory,
+// so Decode rebuilds the same tree without inserting the keys one by one.
+//
+// Each node is written in pre-order as:
+//
+//	uvarint prefix length | prefix | leaf flag byte | [uvarint value length | value] | uvarint edge count | edges...
+//
+// Leaf keys are not stored, they are the concatenation of the prefixes from the root.
+func (t *Tree[V]) Encode(w io.Writer, codec Codec[V]) error {
+	bw := bufio.NewWriter(w)
+	if err := encodeNode(bw, t.encodedRoot(), codec); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// encodedRoot returns the root to encode, a zero-value Tree has none and is encoded as an empty tree
+func (t *Tree[V]) encodedRoot() *node[V] {
+	if t.root == nil {
+		return &node[V]{}
+	}
+	return t.root
+}
+
+// Decode reads a tree written by Encode. It may read past the end of the encoded tree.
+func Decode[V any](r io.Reader, codec Codec[V]) (*Tree[V], error) {
+	br, ok := r.(byteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	t := &Tree[V]{}
+	root, err := decodeNode(br, "", codec, &t.size)
+	if err != nil {
+		return nil, err
+	}
+	if root.prefix != "" {
+		return nil, fmt.Errorf("%w: root has a prefix", ErrInvalidEncoding)
+	}
+	t.root = root
+	return t, nil
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+func encodeNode[V any](w *bufio.Writer, n *node[V], codec Codec[V]) error {
+	writeBytes(w, []byte(n.prefix))
+
+	if n.leaf == nil {
+		w.WriteByte(0)
+	} else {
+		data, err := codec.Marshal(n.leaf.val)
+		if err != nil {
+			return fmt.Errorf("encode value of %q: %w", n.leaf.key, err)
+		}
+		w.WriteByte(1)
+		writeBytes(w, data)
+	}
+
+	writeUvarint(w, uint64(len(n.edges)))
+	for _, e := range n.edges {
+		if err := encodeNode(w, e.node, codec); err != nil {
+			return err
+		}
+	}
+
+	// bufio.Writer keeps the first write error, it is reported here and by Flush
+	_, err := w.Write(nil)
+	return err
+}
+
+func decodeNode[V any](r byteReader, parentKey string, codec Codec[V], size *int) (*node[V], error) {
+	prefix, err := readBytes(r)
+	if err != nil {
+		return nil, err
+	}
+	n := &node[V]{prefix: string(prefix)}
+	key := parentKey + n.prefix
+
+	flag, err := r.ReadByte()
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	switch flag {
+	case 0:
+	case 1:
+		data, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		val, err := codec.Unmarshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("decode value of %q: %w", key, err)
+		}
+		n.leaf = &leafNode[V]{key: key, val: val}
+		*size++
+	default:
+		return nil, fmt.Errorf("%w: bad leaf flag %d", ErrInvalidEncoding, flag)
+	}
+
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if count > 256 {
+		return nil, fmt.Errorf("%w: %d edges", ErrInvalidEncoding, count)
+	}
+	for i := uint64(0); i < count; i++ {
+		child, err := decodeNode(r, key, codec, size)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkChild(n, child); err != nil {
+			return nil, err
+		}
+		n.edges = append(n.edges, edge[V]{label: child.prefix[0], node: child})
+	}
+	return n, nil
+}
+
+// checkChild makes sure a decoded child keeps the tree valid: labels are unique and sorted
+func checkChild[V any](parent, child *node[V]) error {
+	if child.prefix == "" {
+		return fmt.Errorf("%w: empty edge prefix", ErrInvalidEncoding)
+	}
+	if l := len(parent.edges); l > 0 && parent.edges[l-1].label >= child.prefix[0] {
+		return fmt.Errorf("%w: edges out of order", ErrInvalidEncoding)
+	}
+	return nil
+}
+
+func writeUvarint(w *bufio.Writer, v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	w.Write(buf[:binary.PutUvarint(buf[:], v)])
+}
+
+func writeBytes(w *bufio.Writer, b []byte) {
+	writeUvarint(w, uint64(len(b)))
+	w.Write(b)
+}
+
+func readBytes(r byteReader) ([]byte, error) {
+	l, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if l > maxFieldSize {
+		return nil, fmt.Errorf("%w: field of %d bytes", ErrInvalidEncoding, l)
+	}
+	b := make([]byte, l)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return b, nil
+}
+
+// unexpectedEOF reports a truncated input, a clean EOF in the middle of a tree is an error too
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// jsonNode is the JSON form of a node. Leaf keys are rebuilt from the prefixes like in the binary encoding.
+type jsonNode[V any] struct {
+	Prefix string         `json:"prefix"`
+	Leaf   bool           `json:"leaf,omitempty"`
+	Value  *V             `json:"value,omitempty"`
+	Edges  []*jsonNode[V] `json:"edges,omitempty"`
+}
+
+// MarshalJSON encodes the structure of the tree, values are encoded with encoding/json
+func (t *Tree[V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONNode(t.encodedRoot()))
+}
+
+// UnmarshalJSON replaces the content of the tree with a tree encoded by MarshalJSON
+func (t *Tree[V]) UnmarshalJSON(data []byte) error {
+	var root jsonNode[V]
+	if err := json.Unmarshal(data, &root); err != nil {
+		return err
+	}
+	if root.Prefix != "" {
+		return fmt.Errorf("%w: root has a prefix", ErrInvalidEncoding)
+	}
+
+	size := 0
+	n, err := fromJSONNode(&root, "", &size)
+	if err != nil {
+		return err
+	}
+	t.root, t.size = n, size
+	return nil
+}
+
+func toJSONNode[V any](n *node[V]) *jsonNode[V] {
+	out := &jsonNode[V]{Prefix: n.prefix}
+	if n.leaf != nil {
+		val := n.leaf.val
+		out.Leaf, out.Value = true, &val
+	}
+	for _, e := range n.edges {
+		out.Edges = append(out.Edges, toJSONNode(e.node))
+	}
+	return out
+}
+
+func fromJSONNode[V any](in *jsonNode[V], parentKey string, size *int) (*node[V], error) {
+	n := &node[V]{prefix: in.Prefix}
+	key := parentKey + n.prefix
+
+	if in.Leaf {
+		var val V
+		if in.Value != nil {
+			val = *in.Value
+		}
+		n.leaf = &leafNode[V]{key: key, val: val}
+		*size++
+	}
+
+	for _, e := range in.Edges {
+		if e == nil {
+			return nil, fmt.Errorf("%w: null edge", ErrInvalidEncoding)
+		}
+		child, err := fromJSONNode(e, key, size)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkChild(n, child); err != nil {
+			return nil, err
+		}
+		n.edges = append(n.edges, edge[V]{label: child.prefix[0], node: child})
+	}
+	return n, nil
+}
@@ -0,0 +1,193 @@
+package radix_tree
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	inp := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		inp[generateUUID()] = i
+	}
+	inp[""] = -1
+	r := NewFromMap(inp)
+
+	var buf bytes.Buffer
+	if err := r.Encode(&buf, JSONCodec[int]()); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	out, err := Decode(&buf, JSONCodec[int]())
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if out.Len() != r.Len() || !reflect.DeepEqual(out.ToMap(), inp) {
+		t.Fatalf("mis-match after decode")
+	}
+	if !reflect.DeepEqual(out.root, r.root) {
+		t.Fatalf("structure was not preserved")
+	}
+
+	// The decoded tree must stay usable
+	out.Insert("foo", 1)
+	if _, ok := out.Delete(""); !ok {
+		t.Fatalf("missing empty key")
+	}
+	if out.Len() != r.Len() {
+		t.Fatalf("bad len: %v", out.Len())
+	}
+}
+
+func TestDecodeTruncated(t *testing.T) {
+	r := NewFromMap(map[string]string{"foo": "1", "foobar": "2", "zip": "3"})
+	var buf bytes.Buffer
+	if err := r.Encode(&buf, StringCodec()); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	data := buf.Bytes()
+	for i := 0; i < len(data); i++ {
+		if _, err := Decode(bytes.NewReader(data[:i]), StringCodec()); err == nil {
+			t.Fatalf("truncated input of %d bytes decoded", i)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	r := NewFromMap(map[string]*string{"foo": nil, "foobar": new(string), "zip": nil})
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	out := New[*string]()
+	if err := json.Unmarshal(data, out); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if out.Len() != 3 || !reflect.DeepEqual(out.ToMap(), r.ToMap()) {
+		t.Fatalf("mis-match: %v %v", out.ToMap(), r.ToMap())
+	}
+
+	bad := `{"prefix":"","edges":[{"prefix":"b","leaf":true},{"prefix":"a","leaf":true}]}`
+	if err := json.Unmarshal([]byte(bad), out); !errors.Is(err, ErrInvalidEncoding) {
+		t.Fatalf("expected invalid encoding, got %v", err)
+	}
+}
+
+func TestEncodeZeroValue(t *testing.T) {
+	var zero Tree[int]
+
+	// A zero-value Tree has no root, it is encoded like an empty tree
+	data, err := json.Marshal(&zero)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if string(data) != `{"prefix":""}` {
+		t.Fatalf("bad json: %s", data)
+	}
+	out := New[int]()
+	if err := json.Unmarshal(data, out); err != nil || out.Len() != 0 {
+		t.Fatalf("err: %v, len: %d", err, out.Len())
+	}
+
+	var buf bytes.Buffer
+	if err := zero.Encode(&buf, JSONCodec[int]()); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	decoded, err := Decode(&buf, JSONCodec[int]())
+	if err != nil || decoded.Len() != 0 {
+		t.Fatalf("err: %v, len: %d", err, decoded.Len())
+	}
+
+	buf.Reset()
+	if err := zero.WriteSnapshot(&buf, JSONCodec[int]()); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	decoded, err = ReadSnapshot(&buf, JSONCodec[int]())
+	if err != nil || decoded.Len() != 0 {
+		t.Fatalf("err: %v, len: %d", err, decoded.Len())
+	}
+
+	path := filepath.Join(t.TempDir(), "empty.snapshot")
+	if err := zero.SaveSnapshot(path, JSONCodec[int]()); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	loaded, err := LoadSnapshot(path, JSONCodec[int]())
+	if err != nil || loaded.Len() != 0 {
+		t.Fatalf("err: %v", err)
+	}
+
+	// The loaded tree is usable
+	loaded.Insert("foo", 1)
+	if v, ok := loaded.Get("foo"); !ok || v != 1 {
+		t.Fatalf("bad: %v %v", v, ok)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	input := "# disposable domains\nmailinator.com\n\n  yopmail.com  \nmailinator.com\n"
+	r, err := Load(strings.NewReader(input), KeyLine)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if r.Len() != 2 {
+		t.Fatalf("bad len: %v", r.Len())
+	}
+	if _, ok := r.Get("yopmail.com"); !ok {
+		t.Fatalf("missing key")
+	}
+
+	failing := func(line string) (string, int, error) { return "", 0, errors.New("boom") }
+	if _, err := Load(strings.NewReader("a\nb"), failing); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Fatalf("expected line error, got %v", err)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	r := New[struct{}]()
+	for i := 0; i < 500; i++ {
+		r.Insert(generateUUID(), struct{}{})
+	}
+
+	path := filepath.Join(t.TempDir(), "domains.snapshot")
+	if err := r.SaveSnapshot(path, SetCodec()); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	out, err := LoadSnapshot(path, SetCodec())
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !reflect.DeepEqual(out.ToMap(), r.ToMap()) {
+		t.Fatalf("mis-match after snapshot")
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteSnapshot(&buf, SetCodec()); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	data := buf.Bytes()
+
+	// Flip a byte of a key
+	corrupt := bytes.Clone(data)
+	corrupt[len(corrupt)/2] ^= 0x01
+	if _, err := ReadSnapshot(bytes.NewReader(corrupt), SetCodec()); err == nil {
+		t.Fatalf("corrupt snapshot loaded")
+	}
+
+	// Flip a byte of the checksum
+	corrupt = bytes.Clone(data)
+	corrupt[len(corrupt)-1] ^= 0x01
+	if _, err := ReadSnapshot(bytes.NewReader(corrupt), SetCodec()); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+
+	if _, err := ReadSnapshot(strings.NewReader("nope"), SetCodec()); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Fatalf("expected invalid snapshot, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package radix_tree
+
+import "iter"
+
+// ImmutableTree is a persistent radix tree. Modifications never touch an
+// existing tree, they produce a new one sharing every unchanged node with
+// its predecessor. A tree value can therefore be read by any number of
+// goroutines without locking, and readers keep a consistent snapshot
+// while writers commit new versions.
+type ImmutableTree[V any] struct {
+	root *node[V]
+	size int
+}
+
+// NewImmutable returns an empty ImmutableTree
+func NewImmutable[V any]() *ImmutableTree[V] {
+	return &ImmutableTree[V]{root: &node[V]{mutate: newWatchCh()}}
+}
+
+// NewImmutableFromMap returns a new immutable tree containing the keys from an existing map
+func NewImmutableFromMap[V any](m map[string]V) *ImmutableTree[V] {
+	txn := NewImmutable[V]().Txn()
+	for k, v := range m {
+		txn.Insert(k, v)
+	}
+	return txn.CommitOnly()
+}
+
+// Txn starts a new transaction that can be used to mutate the tree. Many changes can be applied in a single
+// transaction and only the nodes they touch are copied.
+func (t *ImmutableTree[V]) Txn() *Txn[V] {
+	return &Txn[V]{
+		root: t.root,
+		size: t.size,
+	}
+}
+
+// Len is used to return the number of elements in the tree
+func (t *ImmutableTree[V]) Len() int {
+	return t.size
+}
+
+// Insert is used to add or update a given key. Returns the new tree, the previous value and if it was updated.
+func (t *ImmutableTree[V]) Insert(s string, v V) (*ImmutableTree[V], V, bool) {
+	txn := t.Txn()
+	old, ok := txn.Insert(s, v)
+	return txn.Commit(), old, ok
+}
+
+// Delete is used to delete a given key. Returns the new tree, the previous value and if it was deleted.
+func (t *ImmutableTree[V]) Delete(s string) (*ImmutableTree[V], V, bool) {
+	txn := t.Txn()
+	old, ok := txn.Delete(s)
+	return txn.Commit(), old, ok
+}
+
+// DeletePrefix is used to delete the subtree under a prefix. Returns the new tree and how many keys were deleted.
+func (t *ImmutableTree[V]) DeletePrefix(s string) (*ImmutableTree[V], int) {
+	txn := t.Txn()
+	deleted := txn.DeletePrefix(s)
+	return txn.Commit(), deleted
+}
+
+// ToMap is used to walk the tree and convert it into a map
+func (t *ImmutableTree[V]) ToMap() map[string]V {
+	out := make(map[string]V, t.size)
+	t.Walk(func(k string, v V) bool {
+		out[k] = v
+		return false
+	})
+	return out
+}
+
+// Get is used to lookup a specific key, returning the value and if it was found
+func (t *ImmutableTree[V]) Get(s string) (V, bool) {
+	return t.root.get(s)
+}
+
+// LongestPrefix is like Get, but instead of an exact match, it will return the longest prefix match.
+func (t *ImmutableTree[V]) LongestPrefix(s string) (string, V, bool) {
+	return t.root.longestPrefixMatch(s)
+}
+
+// Minimum is used to return the minimum value in the tree
+func (t *ImmutableTree[V]) Minimum() (string, V, bool) {
+	return t.root.minimum()
+}
+
+// Maximum is used to return the maximum value in the tree
+func (t *ImmutableTree[V]) Maximum() (string, V, bool) {
+	return t.root.maximum()
+}
+
+// Walk is used to walk the tree
+func (t *ImmutableTree[V]) Walk(fn WalkFn[V]) {
+	recursiveWalk(t.root, fn)
+}
+
+// WalkPrefix is used to walk the tree under a prefix
+func (t *ImmutableTree[V]) WalkPrefix(prefix string, fn WalkFn[V]) {
+	t.root.walkPrefix(prefix, fn)
+}
+
+// WalkPath is used to walk the tree, but only visiting nodes from the root down to a given leaf.
+func (t *ImmutableTree[V]) WalkPath(path string, fn WalkFn[V]) {
+	t.root.walkPath(path, fn)
+}
+
+// Watch returns a channel that is closed once a later commit changes any key starting with prefix.
+// The channel may also fire for changes to neighbouring keys sharing the same node, so callers
+// should treat it as a hint to re-read the tree rather than as an exact change notification.
+func (t *ImmutableTree[V]) Watch(prefix string) <-chan struct{} {
+	return t.root.watchPrefix(prefix)
+}
+
+// Iterator returns an iterator over the keys in ascending order.
+func (t *ImmutableTree[V]) Iterator() *Iterator[V] {
+	return newIterator(t.root)
+}
+
+// ReverseIterator returns an iterator over the keys in descending order.
+func (t *ImmutableTree[V]) ReverseIterator() *ReverseIterator[V] {
+	return newReverseIterator(t.root)
+}
+
+// All returns a sequence over every key and value in ascending order
+func (t *ImmutableTree[V]) All() iter.Seq2[string, V] {
+	return allSeq(t.root)
+}
+
+// Backward returns a sequence over every key and value in descending order
+func (t *ImmutableTree[V]) Backward() iter.Seq2[string, V] {
+	return backwardSeq(t.root)
+}
+
+// Prefix returns a sequence over the keys starting with prefix in ascending order
+func (t *ImmutableTree[V]) Prefix(prefix string) iter.Seq2[string, V] {
+	return prefixSeq(t.root, prefix)
+}
+
+// Range returns a sequence over the keys in [start, end) in ascending order. An empty end means no upper bound,
+// which makes it suitable for cursor-based pagination: pass the last key of a page plus "\x00" as the next start.
+func (t *ImmutableTree[V]) Range(start, end string) iter.Seq2[string, V] {
+	return rangeSeq(t.root, start, end)
+}
@@ -0,0 +1,287 @@
+package radix_tree
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestImmutableRadix(t *testing.T) {
+	inp := make(map[string]interface{})
+	for i := 0; i < 1000; i++ {
+		inp[generateUUID()] = i
+	}
+
+	r := NewImmutableFromMap(inp)
+	if r.Len() != len(inp) {
+		t.Fatalf("bad length: %v %v", r.Len(), len(inp))
+	}
+
+	for k, v := range inp {
+		out, ok := r.Get(k)
+		if !ok {
+			t.Fatalf("missing key: %v", k)
+		}
+		if out != v {
+			t.Fatalf("value mis-match: %v %v", out, v)
+		}
+	}
+
+	txn := r.Txn()
+	for k, v := range inp {
+		out, ok := txn.Delete(k)
+		if !ok {
+			t.Fatalf("missing key: %v", k)
+		}
+		if out != v {
+			t.Fatalf("value mis-match: %v %v", out, v)
+		}
+	}
+	empty := txn.Commit()
+	if empty.Len() != 0 {
+		t.Fatalf("bad length: %v", empty.Len())
+	}
+
+	// The original tree must not have been affected
+	if r.Len() != len(inp) || !reflect.DeepEqual(r.ToMap(), inp) {
+		t.Fatalf("original tree was modified")
+	}
+}
+
+func TestImmutableSnapshotIsolation(t *testing.T) {
+	r1 := NewImmutable[any]()
+	r1, _, _ = r1.Insert("foo", 1)
+	r1, _, _ = r1.Insert("foobar", 2)
+
+	r2, old, updated := r1.Insert("foo", 3)
+	if !updated || old != 1 {
+		t.Fatalf("bad update: %v %v", old, updated)
+	}
+	r3, _, deleted := r2.Delete("foobar")
+	if !deleted {
+		t.Fatalf("bad delete")
+	}
+
+	if v, _ := r1.Get("foo"); v != 1 {
+		t.Fatalf("r1 changed: %v", v)
+	}
+	if _, ok := r1.Get("foobar"); !ok {
+		t.Fatalf("r1 lost foobar")
+	}
+	if v, _ := r2.Get("foo"); v != 3 {
+		t.Fatalf("bad r2 value: %v", v)
+	}
+	if _, ok := r3.Get("foobar"); ok {
+		t.Fatalf("r3 still has foobar")
+	}
+	if r1.Len() != 2 || r2.Len() != 2 || r3.Len() != 1 {
+		t.Fatalf("bad lengths: %v %v %v", r1.Len(), r2.Len(), r3.Len())
+	}
+}
+
+func TestImmutableDeletePrefix(t *testing.T) {
+	type exp struct {
+		inp        []string
+		prefix     string
+		out        []string
+		numDeleted int
+	}
+
+	cases := []exp{
+		{[]string{"", "A", "AB", "ABC", "R", "S"}, "A", []string{"", "R", "S"}, 3},
+		{[]string{"", "A", "AB", "ABC", "R", "S"}, "ABC", []string{"", "A", "AB", "R", "S"}, 1},
+		{[]string{"", "A", "AB", "ABC", "R", "S"}, "", []string{}, 6},
+		{[]string{"", "A", "AB", "ABC", "R", "S"}, "S", []string{"", "A", "AB", "ABC", "R"}, 1},
+		{[]string{"", "A", "AB", "ABC", "R", "S"}, "SS", []string{"", "A", "AB", "ABC", "R", "S"}, 0},
+	}
+
+	for _, test := range cases {
+		txn := NewImmutable[any]().Txn()
+		for _, ss := range test.inp {
+			txn.Insert(ss, true)
+		}
+		r := txn.Commit()
+
+		r2, deleted := r.DeletePrefix(test.prefix)
+		if deleted != test.numDeleted {
+			t.Fatalf("Bad delete, expected %v to be deleted but got %v", test.numDeleted, deleted)
+		}
+
+		out := []string{}
+		r2.Walk(func(s string, v interface{}) bool {
+			out = append(out, s)
+			return false
+		})
+		if !reflect.DeepEqual(out, test.out) {
+			t.Fatalf("mis-match: %v %v", out, test.out)
+		}
+		if r.Len() != len(test.inp) || r2.Len() != len(test.out) {
+			t.Fatalf("bad lengths: %v %v", r.Len(), r2.Len())
+		}
+	}
+}
+
+func TestImmutableLongestPrefix(t *testing.T) {
+	txn := NewImmutable[any]().Txn()
+	for _, k := range []string{"", "foo", "foobar", "foobarbaz", "foozip"} {
+		txn.Insert(k, nil)
+	}
+	r := txn.Commit()
+
+	cases := map[string]string{
+		"a":         "",
+		"foob":      "foo",
+		"foobarba":  "foobar",
+		"foobarbaz": "foobarbaz",
+		"foozipzap": "foozip",
+	}
+	for inp, out := range cases {
+		m, _, ok := r.LongestPrefix(inp)
+		if !ok || m != out {
+			t.Fatalf("mis-match for %q: %v %v", inp, m, out)
+		}
+	}
+}
+
+func TestImmutableWatch(t *testing.T) {
+	r := NewImmutable[any]()
+	r, _, _ = r.Insert("foo/bar", 1)
+	r, _, _ = r.Insert("foo/baz", 2)
+	r, _, _ = r.Insert("zip", 3)
+
+	fooCh := r.Watch("foo/")
+	zipCh := r.Watch("zip")
+	missingCh := r.Watch("foo/qux")
+
+	// Changing an unrelated key must not fire the foo watchers
+	r, _, _ = r.Insert("zip", 4)
+	select {
+	case <-zipCh:
+	default:
+		t.Fatalf("zip watch should have fired")
+	}
+	select {
+	case <-fooCh:
+		t.Fatalf("foo watch should not have fired")
+	default:
+	}
+
+	// Inserting a new key under the prefix fires the watchers covering it
+	r, _, _ = r.Insert("foo/qux", 5)
+	for name, ch := range map[string]<-chan struct{}{"foo/": fooCh, "foo/qux": missingCh} {
+		select {
+		case <-ch:
+		default:
+			t.Fatalf("%s watch should have fired", name)
+		}
+	}
+
+	// Deleting a prefix fires the watchers of the removed subtree
+	barCh := r.Watch("foo/bar")
+	r, _ = r.DeletePrefix("foo/")
+	select {
+	case <-barCh:
+	default:
+		t.Fatalf("foo/bar watch should have fired")
+	}
+	if r.Len() != 1 {
+		t.Fatalf("bad len: %v", r.Len())
+	}
+}
+
+func TestImmutableTxnCommitTwice(t *testing.T) {
+	txn := NewImmutable[any]().Txn()
+	txn.Insert("foo", 1)
+	r1 := txn.Commit()
+
+	// Writes after a commit must not leak into the committed tree
+	txn.Insert("foo", 2)
+	txn.Insert("bar", 3)
+	r2 := txn.Commit()
+
+	if v, _ := r1.Get("foo"); v != 1 {
+		t.Fatalf("r1 changed: %v", v)
+	}
+	if _, ok := r1.Get("bar"); ok {
+		t.Fatalf("r1 has bar")
+	}
+	if v, _ := r2.Get("foo"); v != 2 {
+		t.Fatalf("bad r2 value: %v", v)
+	}
+}
+
+func TestConcurrentTree(t *testing.T) {
+	r := NewConcurrent[any]()
+	ch := r.Watch("worker")
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				key := fmt.Sprintf("worker%d/%d", w, i)
+				r.Insert(key, i)
+				if _, ok := r.Get(key); !ok {
+					t.Errorf("missing key: %v", key)
+				}
+				r.WalkPrefix(fmt.Sprintf("worker%d/", w), func(s string, v interface{}) bool {
+					return false
+				})
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if r.Len() != 800 {
+		t.Fatalf("bad len: %v", r.Len())
+	}
+	select {
+	case <-ch:
+	default:
+		t.Fatalf("watch should have fired")
+	}
+
+	snapshot := r.Snapshot()
+	if deleted := r.DeletePrefix("worker0/"); deleted != 100 {
+		t.Fatalf("bad delete: %v", deleted)
+	}
+	if r.Len() != 700 || snapshot.Len() != 800 {
+		t.Fatalf("bad lengths: %v %v", r.Len(), snapshot.Len())
+	}
+
+	r.Update(func(txn *Txn[any]) {
+		txn.DeletePrefix("worker1/")
+		txn.Insert("done", true)
+	})
+	if r.Len() != 601 {
+		t.Fatalf("bad len: %v", r.Len())
+	}
+}
+
+func TestImmutableTxnConcurrentNotify(t *testing.T) {
+	r := NewImmutable[int]()
+	r, _, _ = r.Insert("foo/bar", 1)
+	watch := r.Watch("foo/")
+
+	// Transactions started from the same tree replace the same nodes and close the same channels
+	var wg sync.WaitGroup
+	for i := range 16 {
+		txn := r.Txn()
+		txn.Insert("foo/baz", i)
+		txn.CommitOnly()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			txn.Notify()
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case <-watch:
+	default:
+		t.Fatalf("foo/ watch should have fired")
+	}
+}
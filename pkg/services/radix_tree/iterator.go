@@ -0,0 +1,269 @@
+package radix_tree
+
+import (
+	"iter"
+	"strings"
+)
+
+// Iterator walks the keys of a tree in ascending order. It can be positioned with SeekPrefix or SeekLowerBound
+// before calling Next. An iterator over a mutable Tree must not outlive modifications of that tree, while
+// iterators over an ImmutableTree always see the snapshot they were created from.
+type Iterator[V any] struct {
+	root *node[V]
+
+	// stack holds the edges left to visit. The top slice is processed
+	// first and its first edge is the smallest pending subtree.
+	stack []edges[V]
+}
+
+func newIterator[V any](root *node[V]) *Iterator[V] {
+	return &Iterator[V]{root: root, stack: []edges[V]{{{node: root}}}}
+}
+
+// SeekPrefix restricts the iterator to the keys starting with prefix
+func (i *Iterator[V]) SeekPrefix(prefix string) {
+	n := i.root
+	i.stack = nil
+
+	search := prefix
+	for {
+		// Check for key exhaustion
+		if len(search) == 0 {
+			i.stack = []edges[V]{{{node: n}}}
+			return
+		}
+
+		// Look for an edge
+		n = n.getEdge(search[0])
+		if n == nil {
+			return
+		}
+
+		// Consume the search prefix
+		if strings.HasPrefix(search, n.prefix) {
+			search = search[len(n.prefix):]
+			continue
+		}
+		if strings.HasPrefix(n.prefix, search) {
+			// Child may be under our search prefix
+			i.stack = []edges[V]{{{node: n}}}
+		}
+		return
+	}
+}
+
+// SeekLowerBound positions the iterator so that Next returns the smallest key greater than or equal to key
+func (i *Iterator[V]) SeekLowerBound(key string) {
+	n := i.root
+	i.stack = nil
+
+	search := key
+	for {
+		// Compare the node prefix with the same amount of the search key
+		l := min(len(n.prefix), len(search))
+		switch cmp := strings.Compare(n.prefix[:l], search[:l]); {
+		case cmp < 0:
+			// The whole subtree is smaller than the key
+			return
+		case cmp > 0:
+			// The whole subtree is greater than the key
+			i.stack = append(i.stack, edges[V]{{node: n}})
+			return
+		}
+
+		// The key ends inside this node, so every key below it is greater or equal
+		if len(search) <= len(n.prefix) {
+			i.stack = append(i.stack, edges[V]{{node: n}})
+			return
+		}
+		search = search[len(n.prefix):]
+
+		// The leaf of this node is a strict prefix of the key and therefore smaller. Children with a greater label
+		// come after the key, the one with the same label has to be searched further.
+		idx, child := n.lowerBoundEdge(search[0])
+		if child == nil {
+			if idx < len(n.edges) {
+				i.stack = append(i.stack, n.edges[idx:])
+			}
+			return
+		}
+		if idx+1 < len(n.edges) {
+			i.stack = append(i.stack, n.edges[idx+1:])
+		}
+		n = child
+	}
+}
+
+// Next returns the next key and value in ascending order, or false once the iteration is done
+func (i *Iterator[V]) Next() (string, V, bool) {
+	for len(i.stack) > 0 {
+		// Pop the smallest pending edge
+		top := len(i.stack) - 1
+		pending := i.stack[top]
+		n := pending[0].node
+		if len(pending) > 1 {
+			i.stack[top] = pending[1:]
+		} else {
+			i.stack = i.stack[:top]
+		}
+
+		// Children come after the node itself
+		if len(n.edges) > 0 {
+			i.stack = append(i.stack, n.edges)
+		}
+		if n.leaf != nil {
+			return n.leaf.key, n.leaf.val, true
+		}
+	}
+	var zero V
+	return "", zero, false
+}
+
+// reverseFrame is a pending step of a ReverseIterator. The leaf of a node is emitted only after all its children.
+type reverseFrame[V any] struct {
+	node     *node[V]
+	leafOnly bool
+}
+
+// ReverseIterator walks the keys of a tree in descending order. It can be positioned with SeekPrefix or
+// SeekReverseLowerBound before calling Previous.
+type ReverseIterator[V any] struct {
+	root  *node[V]
+	stack []reverseFrame[V]
+}
+
+func newReverseIterator[V any](root *node[V]) *ReverseIterator[V] {
+	return &ReverseIterator[V]{root: root, stack: []reverseFrame[V]{{node: root}}}
+}
+
+// SeekPrefix restricts the iterator to the keys starting with prefix
+func (ri *ReverseIterator[V]) SeekPrefix(prefix string) {
+	it := newIterator(ri.root)
+	it.SeekPrefix(prefix)
+
+	ri.stack = nil
+	if len(it.stack) > 0 {
+		ri.stack = append(ri.stack, reverseFrame[V]{node: it.stack[0][0].node})
+	}
+}
+
+// SeekReverseLowerBound positions the iterator so that Previous returns the largest key less than or equal to key
+func (ri *ReverseIterator[V]) SeekReverseLowerBound(key string) {
+	n := ri.root
+	ri.stack = nil
+
+	search := key
+	for {
+		// Compare the node prefix with the same amount of the search key
+		l := min(len(n.prefix), len(search))
+		switch cmp := strings.Compare(n.prefix[:l], search[:l]); {
+		case cmp < 0:
+			// The whole subtree is smaller than the key
+			ri.stack = append(ri.stack, reverseFrame[V]{node: n})
+			return
+		case cmp > 0:
+			// The whole subtree is greater than the key
+			return
+		}
+
+		// The key ends inside this node: the keys below it are all greater, only an exact match is kept
+		if len(search) < len(n.prefix) {
+			return
+		}
+		if len(search) == len(n.prefix) {
+			if n.leaf != nil {
+				ri.stack = append(ri.stack, reverseFrame[V]{node: n, leafOnly: true})
+			}
+			return
+		}
+		search = search[len(n.prefix):]
+
+		// The leaf of this node is a strict prefix of the key and comes last. Children with a smaller label are
+		// entirely before the key, the one with the same label has to be searched further.
+		if n.leaf != nil {
+			ri.stack = append(ri.stack, reverseFrame[V]{node: n, leafOnly: true})
+		}
+		idx, child := n.lowerBoundEdge(search[0])
+		for _, e := range n.edges[:idx] {
+			ri.stack = append(ri.stack, reverseFrame[V]{node: e.node})
+		}
+		if child == nil {
+			return
+		}
+		n = child
+	}
+}
+
+// Previous returns the previous key and value in descending order, or false once the iteration is done
+func (ri *ReverseIterator[V]) Previous() (string, V, bool) {
+	for len(ri.stack) > 0 {
+		top := len(ri.stack) - 1
+		frame := ri.stack[top]
+		ri.stack = ri.stack[:top]
+
+		n := frame.node
+		if frame.leafOnly {
+			return n.leaf.key, n.leaf.val, true
+		}
+
+		// The node itself comes after all its children, the largest child is visited first
+		if n.leaf != nil {
+			ri.stack = append(ri.stack, reverseFrame[V]{node: n, leafOnly: true})
+		}
+		for _, e := range n.edges {
+			ri.stack = append(ri.stack, reverseFrame[V]{node: e.node})
+		}
+	}
+	var zero V
+	return "", zero, false
+}
+
+// ascending returns the keys below root in ascending order. seek positions the iterator and the sequence stops
+// before the first key rejected by keep.
+func ascending[V any](root *node[V], seek func(it *Iterator[V]), keep func(k string) bool) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		it := newIterator(root)
+		if seek != nil {
+			seek(it)
+		}
+		for k, v, ok := it.Next(); ok; k, v, ok = it.Next() {
+			if keep != nil && !keep(k) {
+				return
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// allSeq returns every key below root in ascending order
+func allSeq[V any](root *node[V]) iter.Seq2[string, V] {
+	return ascending(root, nil, nil)
+}
+
+// backwardSeq returns every key below root in descending order
+func backwardSeq[V any](root *node[V]) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		ri := newReverseIterator(root)
+		for k, v, ok := ri.Previous(); ok; k, v, ok = ri.Previous() {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// prefixSeq returns the keys below root starting with prefix in ascending order
+func prefixSeq[V any](root *node[V], prefix string) iter.Seq2[string, V] {
+	return ascending(root, func(it *Iterator[V]) { it.SeekPrefix(prefix) }, nil)
+}
+
+// rangeSeq returns the keys below root in [start, end) in ascending order. An empty end means no upper bound.
+func rangeSeq[V any](root *node[V], start, end string) iter.Seq2[string, V] {
+	var keep func(k string) bool
+	if end != "" {
+		keep = func(k string) bool { return k < end }
+	}
+	return ascending(root, func(it *Iterator[V]) { it.SeekLowerBound(start) }, keep)
+}
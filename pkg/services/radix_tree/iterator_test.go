@@ -0,0 +1,182 @@
+package radix_tree
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestIteratorOrder(t *testing.T) {
+	keys := []string{"", "a", "ab", "abc", "abd", "b", "ba", "foo/bar", "foo/baz", "zip"}
+	r := New[int]()
+	for i, k := range keys {
+		r.Insert(k, i)
+	}
+
+	var forward []string
+	for k, v := range r.All() {
+		if keys[v] != k {
+			t.Fatalf("value mis-match: %v %v", k, v)
+		}
+		forward = append(forward, k)
+	}
+	if !reflect.DeepEqual(forward, keys) {
+		t.Fatalf("mis-match: %v %v", forward, keys)
+	}
+
+	var backward []string
+	for k := range r.Backward() {
+		backward = append(backward, k)
+	}
+	reversed := slices.Clone(keys)
+	slices.Reverse(reversed)
+	if !reflect.DeepEqual(backward, reversed) {
+		t.Fatalf("mis-match: %v %v", backward, reversed)
+	}
+
+	var prefixed []string
+	for k := range r.Prefix("ab") {
+		prefixed = append(prefixed, k)
+	}
+	if !reflect.DeepEqual(prefixed, []string{"ab", "abc", "abd"}) {
+		t.Fatalf("bad prefix iteration: %v", prefixed)
+	}
+
+	// Breaking out of the loop must stop the iteration
+	count := 0
+	for range r.All() {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Fatalf("bad count: %v", count)
+	}
+}
+
+func TestIteratorSeekLowerBound(t *testing.T) {
+	keys := make([]string, 0, 500)
+	r := New[string]()
+	for i := 0; i < 500; i++ {
+		k := randomKey()
+		keys = append(keys, k)
+		r.Insert(k, k)
+	}
+	sort.Strings(keys)
+	keys = slices.Compact(keys)
+
+	searches := append(slices.Clone(keys[:50]), "", "\xff")
+	for i := 0; i < 200; i++ {
+		searches = append(searches, randomKey())
+	}
+
+	for _, search := range searches {
+		idx := sort.SearchStrings(keys, search)
+
+		it := r.Iterator()
+		it.SeekLowerBound(search)
+		var out []string
+		for k, _, ok := it.Next(); ok; k, _, ok = it.Next() {
+			out = append(out, k)
+		}
+		if !slices.Equal(out, keys[idx:]) {
+			t.Fatalf("lower bound %q: got %v want %v", search, out, keys[idx:])
+		}
+
+		// The reverse lower bound is the largest key <= search
+		end := idx
+		if end < len(keys) && keys[end] == search {
+			end++
+		}
+		ri := r.ReverseIterator()
+		ri.SeekReverseLowerBound(search)
+		out = out[:0]
+		for k, _, ok := ri.Previous(); ok; k, _, ok = ri.Previous() {
+			out = append(out, k)
+		}
+		want := slices.Clone(keys[:end])
+		slices.Reverse(want)
+		if !slices.Equal(out, want) {
+			t.Fatalf("reverse lower bound %q: got %v want %v", search, out, want)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	r := NewImmutable[int]()
+	for i, k := range []string{"user:1", "user:10", "user:2", "user:3", "users", "zone"} {
+		r, _, _ = r.Insert(k, i)
+	}
+
+	type exp struct {
+		start, end string
+		out        []string
+	}
+	cases := []exp{
+		{"user:", "user;", []string{"user:1", "user:10", "user:2", "user:3"}},
+		{"user:10", "user:3", []string{"user:10", "user:2"}},
+		{"user:2", "", []string{"user:2", "user:3", "users", "zone"}},
+		{"a", "b", nil},
+		{"zone\x00", "", nil},
+	}
+	for _, test := range cases {
+		var out []string
+		for k := range r.Range(test.start, test.end) {
+			out = append(out, k)
+		}
+		if !slices.Equal(out, test.out) {
+			t.Fatalf("range [%q, %q): got %v want %v", test.start, test.end, out, test.out)
+		}
+	}
+
+	// Paginate through the keys two at a time
+	var pages [][]string
+	cursor := ""
+	for {
+		var page []string
+		for k := range r.Range(cursor, "") {
+			page = append(page, k)
+			if len(page) == 2 {
+				break
+			}
+		}
+		if len(page) == 0 {
+			break
+		}
+		pages = append(pages, page)
+		cursor = page[len(page)-1] + "\x00"
+	}
+	if len(pages) != 3 || pages[2][1] != "zone" {
+		t.Fatalf("bad pages: %v", pages)
+	}
+}
+
+func TestReverseIteratorSeekPrefix(t *testing.T) {
+	r := New[bool]()
+	for _, k := range []string{"foo", "foo/bar", "foo/baz", "foobar", "zip"} {
+		r.Insert(k, true)
+	}
+
+	ri := r.ReverseIterator()
+	ri.SeekPrefix("foo/")
+	var out []string
+	for k, _, ok := ri.Previous(); ok; k, _, ok = ri.Previous() {
+		out = append(out, k)
+	}
+	if !slices.Equal(out, []string{"foo/baz", "foo/bar"}) {
+		t.Fatalf("bad reverse prefix iteration: %v", out)
+	}
+}
+
+// randomKey returns a short key from a small alphabet so generated keys share many prefixes
+func randomKey() string {
+	const alphabet = "abc/"
+	b := make([]byte, rand.Intn(6))
+	for i := range b {
+		b[i] = alphabet[rand.Intn(len(alphabet))]
+	}
+	return string(b)
+}
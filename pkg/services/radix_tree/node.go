@@ -1,6 +1,10 @@
 package radix_tree
 
-import "sort"
+import (
+	"sort"
+	"strings"
+	"sync"
+)
 
 // OBJECTIVE: The base node of a radix tree.
 // PROPERTIES:
@@ -8,38 +12,63 @@ import "sort"
 //	leaf: If this node is the endpoint of a key, there is a leafNode here.
 //	prefix: The common string of characters that the node represents (such as “user:”).
 //	edges: List of edges that exit this node. Edges are kept ordered (for sequential iteration and search).
-type node struct {
-	leaf   *leafNode
+//	mutate: Closed when an ImmutableTree transaction replaces this node. Always nil for the mutable Tree.
+type node[V any] struct {
+	leaf   *leafNode[V]
 	prefix string
-	edges  edges
+	edges  edges[V]
+	mutate *watchCh
+}
+
+// watchCh is the mutation channel of a node. Transactions started from the same tree can replace the node
+// concurrently and each one closes the channel, so it is closed once.
+type watchCh struct {
+	ch   chan struct{}
+	once sync.Once
+}
+
+func newWatchCh() *watchCh {
+	return &watchCh{ch: make(chan struct{})}
+}
+
+func (w *watchCh) close() {
+	w.once.Do(func() { close(w.ch) })
+}
+
+// channel returns the channel to wait on, nil for the nodes of the mutable Tree
+func (w *watchCh) channel() <-chan struct{} {
+	if w == nil {
+		return nil
+	}
+	return w.ch
 }
 
 // OBJECTIVE: Represents a leaf.
 // CONTENT:
 //
 //	key: The full key stored in the cache or dictionary.
-//	val: The value corresponding to this key (interface{} is used, so it can be any type).
-type leafNode struct {
+//	val: The value corresponding to this key, typed by the tree it belongs to.
+type leafNode[V any] struct {
 	key string
-	val interface{}
+	val V
 }
 
-func (n *node) isLeaf() bool {
+func (n *node[V]) isLeaf() bool {
 	return n.leaf != nil
 }
 
-func (n *node) addEdge(e edge) {
+func (n *node[V]) addEdge(e edge[V]) {
 	num := len(n.edges)
 	idx := sort.Search(num, func(i int) bool {
 		return n.edges[i].label >= e.label
 	})
 
-	n.edges = append(n.edges, edge{})
+	n.edges = append(n.edges, edge[V]{})
 	copy(n.edges[idx+1:], n.edges[idx:])
 	n.edges[idx] = e
 }
 
-func (n *node) updateEdge(label byte, node *node) {
+func (n *node[V]) updateEdge(label byte, node *node[V]) {
 	num := len(n.edges)
 	idx := sort.Search(num, func(i int) bool {
 		return n.edges[i].label >= label
@@ -51,7 +80,20 @@ func (n *node) updateEdge(label byte, node *node) {
 	panic("replacing missing edge")
 }
 
-func (n *node) getEdge(label byte) *node {
+// lowerBoundEdge returns the index of the first edge whose label is not less than label, and its node if the
+// label matches exactly
+func (n *node[V]) lowerBoundEdge(label byte) (int, *node[V]) {
+	num := len(n.edges)
+	idx := sort.Search(num, func(i int) bool {
+		return n.edges[i].label >= label
+	})
+	if idx < num && n.edges[idx].label == label {
+		return idx, n.edges[idx].node
+	}
+	return idx, nil
+}
+
+func (n *node[V]) getEdge(label byte) *node[V] {
 	num := len(n.edges)
 	idx := sort.Search(num, func(i int) bool {
 		return n.edges[i].label >= label
@@ -62,14 +104,200 @@ func (n *node) getEdge(label byte) *node {
 	return nil
 }
 
-func (n *node) delEdge(label byte) {
+func (n *node[V]) delEdge(label byte) {
 	num := len(n.edges)
 	idx := sort.Search(num, func(i int) bool {
 		return n.edges[i].label >= label
 	})
 	if idx < num && n.edges[idx].label == label {
 		copy(n.edges[idx:], n.edges[idx+1:])
-		n.edges[len(n.edges)-1] = edge{}
+		n.edges[len(n.edges)-1] = edge[V]{}
 		n.edges = n.edges[:len(n.edges)-1]
 	}
 }
+
+// get looks up a specific key below n, returning the value and if it was found
+func (n *node[V]) get(s string) (V, bool) {
+	search := s
+	for {
+		// Check for key exhaustion
+		if len(search) == 0 {
+			if n.isLeaf() {
+				return n.leaf.val, true
+			}
+			break
+		}
+
+		// Look for an edge
+		n = n.getEdge(search[0])
+		if n == nil {
+			break
+		}
+
+		// Consume the search prefix
+		if strings.HasPrefix(search, n.prefix) {
+			search = search[len(n.prefix):]
+		} else {
+			break
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// longestPrefixMatch returns the leaf below n whose key is the longest prefix of s
+func (n *node[V]) longestPrefixMatch(s string) (string, V, bool) {
+	var last *leafNode[V]
+	search := s
+	for {
+		// Look for a leaf node
+		if n.isLeaf() {
+			last = n.leaf
+		}
+
+		// Check for key exhaustion
+		if len(search) == 0 {
+			break
+		}
+
+		// Look for an edge
+		n = n.getEdge(search[0])
+		if n == nil {
+			break
+		}
+
+		// Consume the search prefix
+		if strings.HasPrefix(search, n.prefix) {
+			search = search[len(n.prefix):]
+		} else {
+			break
+		}
+	}
+	if last != nil {
+		return last.key, last.val, true
+	}
+	var zero V
+	return "", zero, false
+}
+
+// minimum returns the smallest key below n
+func (n *node[V]) minimum() (string, V, bool) {
+	for {
+		if n.isLeaf() {
+			return n.leaf.key, n.leaf.val, true
+		}
+		if len(n.edges) > 0 {
+			n = n.edges[0].node
+		} else {
+			break
+		}
+	}
+	var zero V
+	return "", zero, false
+}
+
+// maximum returns the largest key below n
+func (n *node[V]) maximum() (string, V, bool) {
+	for {
+		if num := len(n.edges); num > 0 {
+			n = n.edges[num-1].node
+			continue
+		}
+		if n.isLeaf() {
+			return n.leaf.key, n.leaf.val, true
+		}
+		break
+	}
+	var zero V
+	return "", zero, false
+}
+
+// walkPrefix walks every entry below n that starts with prefix
+func (n *node[V]) walkPrefix(prefix string, fn WalkFn[V]) {
+	search := prefix
+	for {
+		// Check for key exhaustion
+		if len(search) == 0 {
+			recursiveWalk(n, fn)
+			return
+		}
+
+		// Look for an edge
+		n = n.getEdge(search[0])
+		if n == nil {
+			return
+		}
+
+		// Consume the search prefix
+		if strings.HasPrefix(search, n.prefix) {
+			search = search[len(n.prefix):]
+			continue
+		}
+		if strings.HasPrefix(n.prefix, search) {
+			// Child may be under our search prefix
+			recursiveWalk(n, fn)
+		}
+		return
+	}
+}
+
+// walkPath visits the entries on the way from n down to path
+func (n *node[V]) walkPath(path string, fn WalkFn[V]) {
+	search := path
+	for {
+		// Visit the leaf values if any
+		if n.leaf != nil && fn(n.leaf.key, n.leaf.val) {
+			return
+		}
+
+		// Check for key exhaustion
+		if len(search) == 0 {
+			return
+		}
+
+		// Look for an edge
+		n = n.getEdge(search[0])
+		if n == nil {
+			return
+		}
+
+		// Consume the search prefix
+		if strings.HasPrefix(search, n.prefix) {
+			search = search[len(n.prefix):]
+		} else {
+			break
+		}
+	}
+}
+
+// watchPrefix returns the mutation channel of the deepest node that covers every key starting with prefix
+func (n *node[V]) watchPrefix(prefix string) <-chan struct{} {
+	watch := n.mutate
+	search := prefix
+	for {
+		// Check for key exhaustion
+		if len(search) == 0 {
+			return watch.channel()
+		}
+
+		// Look for an edge
+		n = n.getEdge(search[0])
+		if n == nil {
+			return watch.channel()
+		}
+
+		// Consume the search prefix
+		if strings.HasPrefix(search, n.prefix) {
+			watch = n.mutate
+			search = search[len(n.prefix):]
+			continue
+		}
+		if strings.HasPrefix(n.prefix, search) {
+			// Every key under the prefix lives below this child
+			return n.mutate.channel()
+		}
+
+		// The prefix splits this child, so an insert would rewrite the parent
+		return watch.channel()
+	}
+}
@@ -68,7 +68,7 @@ func TestRadix(t *testing.T) {
 }
 
 func TestRoot(t *testing.T) {
-	r := New()
+	r := New[any]()
 	_, ok := r.Delete("")
 	if ok {
 		t.Fatalf("bad")
@@ -89,7 +89,7 @@ func TestRoot(t *testing.T) {
 
 func TestDelete(t *testing.T) {
 
-	r := New()
+	r := New[any]()
 
 	s := []string{"", "A", "AB"}
 
@@ -122,7 +122,7 @@ func TestDeletePrefix(t *testing.T) {
 	}
 
 	for _, test := range cases {
-		r := New()
+		r := New[any]()
 		for _, ss := range test.inp {
 			r.Insert(ss, true)
 		}
@@ -146,7 +146,7 @@ func TestDeletePrefix(t *testing.T) {
 }
 
 func TestLongestPrefix(t *testing.T) {
-	r := New()
+	r := New[any]()
 
 	keys := []string{
 		"",
@@ -194,7 +194,7 @@ func TestLongestPrefix(t *testing.T) {
 }
 
 func TestWalkPrefix(t *testing.T) {
-	r := New()
+	r := New[any]()
 
 	keys := []string{
 		"foobar",
@@ -273,7 +273,7 @@ func TestWalkPrefix(t *testing.T) {
 }
 
 func TestWalkPath(t *testing.T) {
-	r := New()
+	r := New[any]()
 
 	keys := []string{
 		"foo",
@@ -345,7 +345,7 @@ func TestWalkPath(t *testing.T) {
 }
 
 func TestWalkDelete(t *testing.T) {
-	r := New()
+	r := New[any]()
 	r.Insert("init0/0", nil)
 	r.Insert("init0/1", nil)
 	r.Insert("init0/2", nil)
@@ -394,7 +394,7 @@ func generateUUID() string {
 }
 
 func BenchmarkInsert(b *testing.B) {
-	r := New()
+	r := New[any]()
 	for i := 0; i < 10000; i++ {
 		r.Insert(fmt.Sprintf("init%d", i), true)
 	}
@@ -0,0 +1,188 @@
+package radix_tree
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Snapshot files start with snapshotMagic and a version byte, followed by the number of keys as an uvarint and the
+// tree in the Encode format. They end with the big-endian CRC-32C of everything before it.
+const (
+	snapshotMagic   = "RDXS"
+	snapshotVersion = 1
+)
+
+var (
+	ErrInvalidSnapshot  = errors.New("invalid radix tree snapshot")
+	ErrChecksumMismatch = errors.New("radix tree snapshot checksum mismatch")
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// LineParser turns a line of a key set file into an entry. An empty key skips the line.
+type LineParser[V any] func(line string) (string, V, error)
+
+// KeyLine parses files with one key per line, such as a list of domains. Blank lines and lines starting with # are
+// skipped, surrounding spaces are trimmed.
+func KeyLine(line string) (string, struct{}, error) {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "#") {
+		return "", struct{}{}, nil
+	}
+	return line, struct{}{}, nil
+}
+
+// Load builds a tree from r line by line, so the input never has to be held in memory
+func Load[V any](r io.Reader, parse LineParser[V]) (*Tree[V], error) {
+	t := New[V]()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		key, val, err := parse(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if key == "" {
+			continue
+		}
+		t.Insert(key, val)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// LoadFile builds a tree from the lines of a file
+func LoadFile[V any](path string, parse LineParser[V]) (*Tree[V], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Load(f, parse)
+}
+
+// WriteSnapshot writes the tree to w in the snapshot format
+func (t *Tree[V]) WriteSnapshot(w io.Writer, codec Codec[V]) error {
+	crc := crc32.New(castagnoli)
+	bw := bufio.NewWriter(io.MultiWriter(w, crc))
+
+	bw.WriteString(snapshotMagic)
+	bw.WriteByte(snapshotVersion)
+	writeUvarint(bw, uint64(t.size))
+	if err := encodeNode(bw, t.encodedRoot(), codec); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := w.Write(crc.Sum(nil))
+	return err
+}
+
+// ReadSnapshot reads a tree written by WriteSnapshot and verifies its checksum
+func ReadSnapshot[V any](r io.Reader, codec Codec[V]) (*Tree[V], error) {
+	br := bufio.NewReader(r)
+	cr := &checksumReader{r: br, crc: crc32.New(castagnoli)}
+
+	header := make([]byte, len(snapshotMagic)+1)
+	if _, err := io.ReadFull(cr, header); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, unexpectedEOF(err))
+	}
+	if string(header[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, fmt.Errorf("%w: bad magic", ErrInvalidSnapshot)
+	}
+	if version := header[len(snapshotMagic)]; version != snapshotVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, version)
+	}
+
+	size, err := binary.ReadUvarint(cr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, unexpectedEOF(err))
+	}
+
+	t := &Tree[V]{}
+	t.root, err = decodeNode(cr, "", codec, &t.size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+
+	// The checksum is not part of itself, so it is read from the underlying reader
+	sum := make([]byte, crc32.Size)
+	if _, err := io.ReadFull(br, sum); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, unexpectedEOF(err))
+	}
+	if string(sum) != string(cr.crc.Sum(nil)) {
+		return nil, ErrChecksumMismatch
+	}
+	if t.root.prefix != "" || uint64(t.size) != size {
+		return nil, fmt.Errorf("%w: inconsistent tree", ErrInvalidSnapshot)
+	}
+	return t, nil
+}
+
+// SaveSnapshot writes the tree to a snapshot file. The file is replaced atomically, readers never see a partial
+// snapshot.
+func (t *Tree[V]) SaveSnapshot(path string, codec Codec[V]) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := t.WriteSnapshot(tmp, codec); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadSnapshot reads a snapshot file written by SaveSnapshot
+func LoadSnapshot[V any](path string, codec Codec[V]) (*Tree[V], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadSnapshot(f, codec)
+}
+
+// checksumReader hashes exactly the bytes consumed by the decoder, not the ones buffered ahead
+type checksumReader struct {
+	r   *bufio.Reader
+	crc hash.Hash32
+}
+
+func (c *checksumReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.crc.Write(p[:n])
+	return n, err
+}
+
+func (c *checksumReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.crc.Write([]byte{b})
+	}
+	return b, err
+}
@@ -1,27 +1,30 @@
 package radix_tree
 
-import "strings"
+import (
+	"iter"
+	"strings"
+)
 
 // WalkFn is used when walking the tree. Takes a key and value, returning if iteration should be terminated.
-type WalkFn func(s string, v interface{}) bool
+type WalkFn[V any] func(s string, v V) bool
 
 // Tree implements a radix tree. This can be treated as a
 // Dictionary abstract data type. The main advantage over
 // a standard hash map is prefix-based lookups and
-// ordered iteration,
-type Tree struct {
-	root *node
+// ordered iteration. V is the type of the stored values.
+type Tree[V any] struct {
+	root *node[V]
 	size int
 }
 
 // New returns an empty Tree
-func New() *Tree {
-	return NewFromMap(nil)
+func New[V any]() *Tree[V] {
+	return NewFromMap[V](nil)
 }
 
 // NewFromMap returns a new tree containing the keys from an existing map
-func NewFromMap(m map[string]interface{}) *Tree {
-	t := &Tree{root: &node{}}
+func NewFromMap[V any](m map[string]V) *Tree[V] {
+	t := &Tree[V]{root: &node[V]{}}
 	for k, v := range m {
 		t.Insert(k, v)
 	}
@@ -29,9 +32,9 @@ func NewFromMap(m map[string]interface{}) *Tree {
 }
 
 // ToMap is used to walk the tree and convert it into a map
-func (t *Tree) ToMap() map[string]interface{} {
-	out := make(map[string]interface{}, t.size)
-	t.Walk(func(k string, v interface{}) bool {
+func (t *Tree[V]) ToMap() map[string]V {
+	out := make(map[string]V, t.size)
+	t.Walk(func(k string, v V) bool {
 		out[k] = v
 		return false
 	})
@@ -39,7 +42,7 @@ func (t *Tree) ToMap() map[string]interface{} {
 }
 
 // Len is used to return the number of elements in the tree
-func (t *Tree) Len() int {
+func (t *Tree[V]) Len() int {
 	return t.size
 }
 
@@ -59,8 +62,9 @@ func longestPrefix(k1, k2 string) int {
 }
 
 // Insert is used to add a new entry or update an existing entry. Returns true if an existing record is updated.
-func (t *Tree) Insert(s string, v interface{}) (interface{}, bool) {
-	var parent *node
+func (t *Tree[V]) Insert(s string, v V) (V, bool) {
+	var zero V
+	var parent *node[V]
 	n := t.root
 	search := s
 	for {
@@ -72,12 +76,12 @@ func (t *Tree) Insert(s string, v interface{}) (interface{}, bool) {
 				return old, true
 			}
 
-			n.leaf = &leafNode{
+			n.leaf = &leafNode[V]{
 				key: s,
 				val: v,
 			}
 			t.size++
-			return nil, false
+			return zero, false
 		}
 
 		// Look for the edge
@@ -86,10 +90,10 @@ func (t *Tree) Insert(s string, v interface{}) (interface{}, bool) {
 
 		// No edge, create one
 		if n == nil {
-			e := edge{
+			e := edge[V]{
 				label: search[0],
-				node: &node{
-					leaf: &leafNode{
+				node: &node[V]{
+					leaf: &leafNode[V]{
 						key: s,
 						val: v,
 					},
@@ -98,7 +102,7 @@ func (t *Tree) Insert(s string, v interface{}) (interface{}, bool) {
 			}
 			parent.addEdge(e)
 			t.size++
-			return nil, false
+			return zero, false
 		}
 
 		// Determine longest prefix of the search key on match
@@ -110,20 +114,20 @@ func (t *Tree) Insert(s string, v interface{}) (interface{}, bool) {
 
 		// Split the node
 		t.size++
-		child := &node{
+		child := &node[V]{
 			prefix: search[:commonPrefix],
 		}
 		parent.updateEdge(search[0], child)
 
 		// Restore the existing node
-		child.addEdge(edge{
+		child.addEdge(edge[V]{
 			label: n.prefix[commonPrefix],
 			node:  n,
 		})
 		n.prefix = n.prefix[commonPrefix:]
 
 		// Create a new leaf node
-		leaf := &leafNode{
+		leaf := &leafNode[V]{
 			key: s,
 			val: v,
 		}
@@ -132,24 +136,25 @@ func (t *Tree) Insert(s string, v interface{}) (interface{}, bool) {
 		search = search[commonPrefix:]
 		if len(search) == 0 {
 			child.leaf = leaf
-			return nil, false
+			return zero, false
 		}
 
 		// Create a new edge for the node
-		child.addEdge(edge{
+		child.addEdge(edge[V]{
 			label: search[0],
-			node: &node{
+			node: &node[V]{
 				leaf:   leaf,
 				prefix: search,
 			},
 		})
-		return nil, false
+		return zero, false
 	}
 }
 
 // Delete is used to delete a key, returning the previous value and if it was deleted
-func (t *Tree) Delete(s string) (interface{}, bool) {
-	var parent *node
+func (t *Tree[V]) Delete(s string) (V, bool) {
+	var zero V
+	var parent *node[V]
 	var label byte
 	n := t.root
 	search := s
@@ -177,7 +182,7 @@ func (t *Tree) Delete(s string) (interface{}, bool) {
 			break
 		}
 	}
-	return nil, false
+	return zero, false
 
 DELETE:
 	// Delete the leaf
@@ -206,18 +211,18 @@ DELETE:
 // DeletePrefix is used to delete the subtree under a prefix
 // Returns how many nodes were deleted
 // Use this to delete large subtrees efficiently
-func (t *Tree) DeletePrefix(s string) int {
+func (t *Tree[V]) DeletePrefix(s string) int {
 	return t.deletePrefix(nil, t.root, s)
 }
 
 // delete does a recursive deletion
-func (t *Tree) deletePrefix(parent, n *node, prefix string) int {
+func (t *Tree[V]) deletePrefix(parent, n *node[V], prefix string) int {
 	// Check for key exhaustion
 	if len(prefix) == 0 {
 		// Remove the leaf node
 		subTreeSize := 0
 		//recursively walk from all edges of the node to be deleted
-		recursiveWalk(n, func(s string, v interface{}) bool {
+		recursiveWalk(n, func(s string, v V) bool {
 			subTreeSize++
 			return false
 		})
@@ -250,7 +255,7 @@ func (t *Tree) deletePrefix(parent, n *node, prefix string) int {
 	return t.deletePrefix(n, child, prefix)
 }
 
-func (n *node) mergeChild() {
+func (n *node[V]) mergeChild() {
 	e := n.edges[0]
 	child := e.node
 	n.prefix = n.prefix + child.prefix
@@ -259,169 +264,75 @@ func (n *node) mergeChild() {
 }
 
 // Get is used to lookup a specific key, returning the value and if it was found
-func (t *Tree) Get(s string) (interface{}, bool) {
-	n := t.root
-	search := s
-	for {
-		// Check for key exhaustion
-		if len(search) == 0 {
-			if n.isLeaf() {
-				return n.leaf.val, true
-			}
-			break
-		}
-
-		// Look for an edge
-		n = n.getEdge(search[0])
-		if n == nil {
-			break
-		}
-
-		// Consume the search prefix
-		if strings.HasPrefix(search, n.prefix) {
-			search = search[len(n.prefix):]
-		} else {
-			break
-		}
-	}
-	return nil, false
+func (t *Tree[V]) Get(s string) (V, bool) {
+	return t.root.get(s)
 }
 
 // LongestPrefix is like Get, but instead of an exact match, it will return the longest prefix match.
-func (t *Tree) LongestPrefix(s string) (string, interface{}, bool) {
-	var last *leafNode
-	n := t.root
-	search := s
-	for {
-		// Look for a leaf node
-		if n.isLeaf() {
-			last = n.leaf
-		}
-
-		// Check for key exhaustion
-		if len(search) == 0 {
-			break
-		}
-
-		// Look for an edge
-		n = n.getEdge(search[0])
-		if n == nil {
-			break
-		}
-
-		// Consume the search prefix
-		if strings.HasPrefix(search, n.prefix) {
-			search = search[len(n.prefix):]
-		} else {
-			break
-		}
-	}
-	if last != nil {
-		return last.key, last.val, true
-	}
-	return "", nil, false
+func (t *Tree[V]) LongestPrefix(s string) (string, V, bool) {
+	return t.root.longestPrefixMatch(s)
 }
 
 // Minimum is used to return the minimum value in the tree
-func (t *Tree) Minimum() (string, interface{}, bool) {
-	n := t.root
-	for {
-		if n.isLeaf() {
-			return n.leaf.key, n.leaf.val, true
-		}
-		if len(n.edges) > 0 {
-			n = n.edges[0].node
-		} else {
-			break
-		}
-	}
-	return "", nil, false
+func (t *Tree[V]) Minimum() (string, V, bool) {
+	return t.root.minimum()
 }
 
 // Maximum is used to return the maximum value in the tree
-func (t *Tree) Maximum() (string, interface{}, bool) {
-	n := t.root
-	for {
-		if num := len(n.edges); num > 0 {
-			n = n.edges[num-1].node
-			continue
-		}
-		if n.isLeaf() {
-			return n.leaf.key, n.leaf.val, true
-		}
-		break
-	}
-	return "", nil, false
+func (t *Tree[V]) Maximum() (string, V, bool) {
+	return t.root.maximum()
 }
 
 // Walk is used to walk the tree
-func (t *Tree) Walk(fn WalkFn) {
+func (t *Tree[V]) Walk(fn WalkFn[V]) {
 	recursiveWalk(t.root, fn)
 }
 
 // WalkPrefix is used to walk the tree under a prefix
-func (t *Tree) WalkPrefix(prefix string, fn WalkFn) {
-	n := t.root
-	search := prefix
-	for {
-		// Check for key exhaustion
-		if len(search) == 0 {
-			recursiveWalk(n, fn)
-			return
-		}
-
-		// Look for an edge
-		n = n.getEdge(search[0])
-		if n == nil {
-			return
-		}
-
-		// Consume the search prefix
-		if strings.HasPrefix(search, n.prefix) {
-			search = search[len(n.prefix):]
-			continue
-		}
-		if strings.HasPrefix(n.prefix, search) {
-			// Child may be under our search prefix
-			recursiveWalk(n, fn)
-		}
-		return
-	}
+func (t *Tree[V]) WalkPrefix(prefix string, fn WalkFn[V]) {
+	t.root.walkPrefix(prefix, fn)
 }
 
 // WalkPath is used to walk the tree, but only visiting nodes from the root down to a given leaf. Where WalkPrefix walks
 // all the entries *under* the given prefix, this walks the entries *above* the given prefix.
-func (t *Tree) WalkPath(path string, fn WalkFn) {
-	n := t.root
-	search := path
-	for {
-		// Visit the leaf values if any
-		if n.leaf != nil && fn(n.leaf.key, n.leaf.val) {
-			return
-		}
+func (t *Tree[V]) WalkPath(path string, fn WalkFn[V]) {
+	t.root.walkPath(path, fn)
+}
 
-		// Check for key exhaustion
-		if len(search) == 0 {
-			return
-		}
+// Iterator returns an iterator over the keys in ascending order. The tree must not be modified while it is in use.
+func (t *Tree[V]) Iterator() *Iterator[V] {
+	return newIterator(t.root)
+}
 
-		// Look for an edge
-		n = n.getEdge(search[0])
-		if n == nil {
-			return
-		}
+// ReverseIterator returns an iterator over the keys in descending order. The tree must not be modified while it is
+// in use.
+func (t *Tree[V]) ReverseIterator() *ReverseIterator[V] {
+	return newReverseIterator(t.root)
+}
 
-		// Consume the search prefix
-		if strings.HasPrefix(search, n.prefix) {
-			search = search[len(n.prefix):]
-		} else {
-			break
-		}
-	}
+// All returns a sequence over every key and value in ascending order
+func (t *Tree[V]) All() iter.Seq2[string, V] {
+	return allSeq(t.root)
+}
+
+// Backward returns a sequence over every key and value in descending order
+func (t *Tree[V]) Backward() iter.Seq2[string, V] {
+	return backwardSeq(t.root)
+}
+
+// Prefix returns a sequence over the keys starting with prefix in ascending order
+func (t *Tree[V]) Prefix(prefix string) iter.Seq2[string, V] {
+	return prefixSeq(t.root, prefix)
+}
+
+// Range returns a sequence over the keys in [start, end) in ascending order. An empty end means no upper bound,
+// which makes it suitable for cursor-based pagination: pass the last key of a page plus "\x00" as the next start.
+func (t *Tree[V]) Range(start, end string) iter.Seq2[string, V] {
+	return rangeSeq(t.root, start, end)
 }
 
 // recursiveWalk is used to do a pre-order walk of a node recursively. Returns true if the walk should be aborted
-func recursiveWalk(n *node, fn WalkFn) bool {
+func recursiveWalk[V any](n *node[V], fn WalkFn[V]) bool {
 	// Visit the leaf values if any
 	if n.leaf != nil && fn(n.leaf.key, n.leaf.val) {
 		return true
@@ -0,0 +1,317 @@
+package radix_tree
+
+import "strings"
+
+// Txn is a transaction on an ImmutableTree. It batches many modifications and copies each touched node at
+// most once. A Txn is not safe for concurrent use, but the tree it was started from stays readable.
+type Txn[V any] struct {
+	root *node[V]
+	size int
+
+	// writable holds the nodes created by this transaction. They are not
+	// visible to any reader yet, so they can be modified in place.
+	writable map[*node[V]]struct{}
+
+	// trackChannels holds the mutation channels of the replaced nodes.
+	// They are closed on Commit to wake up watchers.
+	trackChannels map[*watchCh]struct{}
+}
+
+// Len is used to return the number of elements in the tree including the pending changes
+func (t *Txn[V]) Len() int {
+	return t.size
+}
+
+// Get is used to lookup a specific key, including the pending changes of the transaction
+func (t *Txn[V]) Get(s string) (V, bool) {
+	return t.root.get(s)
+}
+
+// Insert is used to add or update a given key. Returns the previous value and if it was updated.
+func (t *Txn[V]) Insert(s string, v V) (V, bool) {
+	newRoot, old, didUpdate := t.insert(t.root, s, s, v)
+	t.root = newRoot
+	if !didUpdate {
+		t.size++
+	}
+	return old, didUpdate
+}
+
+// Delete is used to delete a given key. Returns the previous value and if it was deleted.
+func (t *Txn[V]) Delete(s string) (V, bool) {
+	newRoot, leaf := t.delete(t.root, s)
+	if newRoot != nil {
+		t.root = newRoot
+	}
+	if leaf == nil {
+		var zero V
+		return zero, false
+	}
+	t.size--
+	return leaf.val, true
+}
+
+// DeletePrefix is used to delete the subtree under a prefix. Returns how many keys were deleted.
+func (t *Txn[V]) DeletePrefix(s string) int {
+	newRoot, deleted := t.deletePrefix(t.root, s)
+	if newRoot != nil {
+		t.root = newRoot
+	}
+	t.size -= deleted
+	return deleted
+}
+
+// Commit returns the new tree and closes the watch channels of every changed prefix
+func (t *Txn[V]) Commit() *ImmutableTree[V] {
+	tree := t.CommitOnly()
+	t.Notify()
+	return tree
+}
+
+// CommitOnly returns the new tree without notifying watchers. Notify must be called afterward, usually once
+// the new tree has been published to readers.
+func (t *Txn[V]) CommitOnly() *ImmutableTree[V] {
+	// Committed nodes become visible to readers, so further writes on this
+	// transaction must copy them again.
+	t.writable = nil
+	return &ImmutableTree[V]{root: t.root, size: t.size}
+}
+
+// Notify closes the watch channels collected since the last notification
+func (t *Txn[V]) Notify() {
+	for ch := range t.trackChannels {
+		ch.close()
+	}
+	t.trackChannels = nil
+}
+
+// newNode creates a node owned by the transaction
+func (t *Txn[V]) newNode(prefix string, leaf *leafNode[V]) *node[V] {
+	n := &node[V]{
+		leaf:   leaf,
+		prefix: prefix,
+		mutate: newWatchCh(),
+	}
+	t.markWritable(n)
+	return n
+}
+
+// writeNode returns a node that can be modified in place, copying n if it is shared with a committed tree
+func (t *Txn[V]) writeNode(n *node[V]) *node[V] {
+	if _, ok := t.writable[n]; ok {
+		return n
+	}
+
+	nc := t.newNode(n.prefix, n.leaf)
+	if len(n.edges) != 0 {
+		nc.edges = make(edges[V], len(n.edges))
+		copy(nc.edges, n.edges)
+	}
+
+	t.trackChannel(n.mutate)
+	return nc
+}
+
+func (t *Txn[V]) markWritable(n *node[V]) {
+	if t.writable == nil {
+		t.writable = make(map[*node[V]]struct{})
+	}
+	t.writable[n] = struct{}{}
+}
+
+func (t *Txn[V]) trackChannel(ch *watchCh) {
+	if ch == nil {
+		return
+	}
+	if t.trackChannels == nil {
+		t.trackChannels = make(map[*watchCh]struct{})
+	}
+	t.trackChannels[ch] = struct{}{}
+}
+
+// trackSubtree tracks the channels of every node below n and returns the number of leaves found
+func (t *Txn[V]) trackSubtree(n *node[V]) int {
+	t.trackChannel(n.mutate)
+	count := 0
+	if n.isLeaf() {
+		count++
+	}
+	for _, e := range n.edges {
+		count += t.trackSubtree(e.node)
+	}
+	return count
+}
+
+// mergeChild collapses the single child of n into n
+func (t *Txn[V]) mergeChild(n *node[V]) {
+	child := n.edges[0].node
+	t.trackChannel(child.mutate)
+
+	n.prefix = n.prefix + child.prefix
+	n.leaf = child.leaf
+	if len(child.edges) != 0 {
+		n.edges = make(edges[V], len(child.edges))
+		copy(n.edges, child.edges)
+	} else {
+		n.edges = nil
+	}
+}
+
+// insert does a recursive copy-on-write insertion and returns the replacement of n
+func (t *Txn[V]) insert(n *node[V], k, search string, v V) (*node[V], V, bool) {
+	var zero V
+
+	// Handle key exhaustion
+	if len(search) == 0 {
+		old := zero
+		didUpdate := false
+		if n.isLeaf() {
+			old = n.leaf.val
+			didUpdate = true
+		}
+
+		nc := t.writeNode(n)
+		nc.leaf = &leafNode[V]{key: k, val: v}
+		return nc, old, didUpdate
+	}
+
+	// Look for the edge
+	label := search[0]
+	child := n.getEdge(label)
+
+	// No edge, create one
+	if child == nil {
+		nc := t.writeNode(n)
+		nc.addEdge(edge[V]{
+			label: label,
+			node:  t.newNode(search, &leafNode[V]{key: k, val: v}),
+		})
+		return nc, zero, false
+	}
+
+	// Determine longest prefix of the search key on match
+	commonPrefix := longestPrefix(search, child.prefix)
+	if commonPrefix == len(child.prefix) {
+		newChild, old, didUpdate := t.insert(child, k, search[commonPrefix:], v)
+		nc := t.writeNode(n)
+		nc.updateEdge(label, newChild)
+		return nc, old, didUpdate
+	}
+
+	// Split the node
+	nc := t.writeNode(n)
+	splitNode := t.newNode(search[:commonPrefix], nil)
+	nc.updateEdge(label, splitNode)
+
+	// Restore the existing node
+	modChild := t.writeNode(child)
+	splitNode.addEdge(edge[V]{
+		label: modChild.prefix[commonPrefix],
+		node:  modChild,
+	})
+	modChild.prefix = modChild.prefix[commonPrefix:]
+
+	// If the new key is a subset, add to this node
+	leaf := &leafNode[V]{key: k, val: v}
+	search = search[commonPrefix:]
+	if len(search) == 0 {
+		splitNode.leaf = leaf
+		return nc, zero, false
+	}
+
+	// Create a new edge for the node
+	splitNode.addEdge(edge[V]{
+		label: search[0],
+		node:  t.newNode(search, leaf),
+	})
+	return nc, zero, false
+}
+
+// delete does a recursive copy-on-write deletion. Returns nil if nothing changed.
+func (t *Txn[V]) delete(n *node[V], search string) (*node[V], *leafNode[V]) {
+	// Check for key exhaustion
+	if len(search) == 0 {
+		if !n.isLeaf() {
+			return nil, nil
+		}
+		leaf := n.leaf
+
+		nc := t.writeNode(n)
+		nc.leaf = nil
+
+		// Check if we should merge this node
+		if n != t.root && len(nc.edges) == 1 {
+			t.mergeChild(nc)
+		}
+		return nc, leaf
+	}
+
+	// Look for an edge
+	label := search[0]
+	child := n.getEdge(label)
+	if child == nil || !strings.HasPrefix(search, child.prefix) {
+		return nil, nil
+	}
+
+	// Consume the search prefix
+	newChild, leaf := t.delete(child, search[len(child.prefix):])
+	if newChild == nil {
+		return nil, nil
+	}
+
+	nc := t.writeNode(n)
+	t.replaceChild(n, nc, label, newChild)
+	return nc, leaf
+}
+
+// deletePrefix does a recursive copy-on-write deletion of a subtree. Returns nil if nothing changed.
+func (t *Txn[V]) deletePrefix(n *node[V], search string) (*node[V], int) {
+	// Check for key exhaustion
+	if len(search) == 0 {
+		if !n.isLeaf() && len(n.edges) == 0 {
+			return nil, 0
+		}
+
+		deleted := t.trackSubtree(n)
+		nc := t.writeNode(n)
+		nc.leaf = nil
+		nc.edges = nil // deletes the entire subtree
+		return nc, deleted
+	}
+
+	// Look for an edge
+	label := search[0]
+	child := n.getEdge(label)
+	if child == nil || (!strings.HasPrefix(child.prefix, search) && !strings.HasPrefix(search, child.prefix)) {
+		return nil, 0
+	}
+
+	// Consume the search prefix
+	if len(child.prefix) > len(search) {
+		search = ""
+	} else {
+		search = search[len(child.prefix):]
+	}
+	newChild, deleted := t.deletePrefix(child, search)
+	if newChild == nil {
+		return nil, 0
+	}
+
+	nc := t.writeNode(n)
+	t.replaceChild(n, nc, label, newChild)
+	return nc, deleted
+}
+
+// replaceChild points the edge of nc (the writable copy of n) to newChild, removing it if it became empty
+func (t *Txn[V]) replaceChild(n, nc *node[V], label byte, newChild *node[V]) {
+	if newChild.leaf != nil || len(newChild.edges) != 0 {
+		nc.updateEdge(label, newChild)
+		return
+	}
+
+	// Delete the empty child and check if we should merge the remaining one
+	nc.delEdge(label)
+	if n != t.root && len(nc.edges) == 1 && !nc.isLeaf() {
+		t.mergeChild(nc)
+	}
+}
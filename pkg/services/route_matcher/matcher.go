@@ -0,0 +1,219 @@
+// Package route_matcher resolves request paths against route patterns such as
+// "/v1/notification/email-accounts/:email" or "/v1/iam/*rest". Patterns are indexed
+// in a radix tree by their static prefix, so only the routes whose prefix matches
+// the request path are ever compared, and the most specific match wins.
+package route_matcher
+
+import (
+	"errors"
+	"fmt"
+	"platform/pkg/services/radix_tree"
+	"strings"
+)
+
+var (
+	ErrInvalidPattern   = errors.New("invalid route pattern")
+	ErrDuplicatePattern = errors.New("route pattern already registered")
+)
+
+// segmentKind orders segments by specificity: a static segment beats a
+// parameter, which beats a wildcard.
+type segmentKind int
+
+const (
+	staticSegment segmentKind = iota
+	paramSegment
+	wildcardSegment
+)
+
+type segment struct {
+	kind  segmentKind
+	value string // literal text for static segments, parameter name otherwise
+}
+
+type route[V any] struct {
+	pattern  string
+	segments []segment
+	value    V
+}
+
+// Match is the result of a successful lookup.
+type Match[V any] struct {
+	Pattern string
+	Value   V
+	Params  map[string]string
+}
+
+// Matcher maps route patterns to values. Patterns are made of "/" separated
+// segments; a segment starting with ":" matches exactly one path segment and a
+// final segment starting with "*" matches the rest of the path, including nothing.
+// A Matcher must be fully built with Add before it is used concurrently by Match.
+type Matcher[V any] struct {
+	tree *radix_tree.Tree[[]*route[V]]
+	size int
+}
+
+// New returns an empty Matcher
+func New[V any]() *Matcher[V] {
+	return &Matcher[V]{tree: radix_tree.New[[]*route[V]]()}
+}
+
+// Len returns the number of registered patterns
+func (m *Matcher[V]) Len() int {
+	return m.size
+}
+
+// Add registers a pattern. It fails if the pattern is malformed or already registered.
+func (m *Matcher[V]) Add(pattern string, value V) error {
+	segments, err := parsePattern(pattern)
+	if err != nil {
+		return err
+	}
+
+	// Routes are indexed by the static text before their first dynamic segment
+	prefix := staticPrefix(pattern)
+	routes, _ := m.tree.Get(prefix)
+	for _, r := range routes {
+		if sameShape(r.segments, segments) {
+			return fmt.Errorf("%w: %s conflicts with %s", ErrDuplicatePattern, pattern, r.pattern)
+		}
+	}
+
+	m.tree.Insert(prefix, append(routes, &route[V]{
+		pattern:  pattern,
+		segments: segments,
+		value:    value,
+	}))
+	m.size++
+	return nil
+}
+
+// Match returns the most specific route matching path
+func (m *Matcher[V]) Match(path string) (Match[V], bool) {
+	pathSegments := splitPath(path)
+
+	var best *route[V]
+	var bestParams map[string]string
+
+	// Every route whose static prefix is a prefix of the path is a candidate. They are visited from the shortest
+	// prefix to the longest one, like LongestPrefix does for a single key.
+	m.tree.WalkPath(path, func(_ string, routes []*route[V]) bool {
+		for _, r := range routes {
+			params, ok := r.match(pathSegments)
+			if !ok {
+				continue
+			}
+			if best == nil || moreSpecific(r.segments, best.segments) {
+				best = r
+				bestParams = params
+			}
+		}
+		return false
+	})
+
+	if best == nil {
+		return Match[V]{}, false
+	}
+	return Match[V]{Pattern: best.pattern, Value: best.value, Params: bestParams}, true
+}
+
+// match checks the path segments against the route and extracts its parameters
+func (r *route[V]) match(pathSegments []string) (map[string]string, bool) {
+	var params map[string]string
+	setParam := func(name, value string) {
+		if name == "" {
+			return
+		}
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[name] = value
+	}
+
+	for i, seg := range r.segments {
+		if seg.kind == wildcardSegment {
+			setParam(seg.value, strings.Join(pathSegments[i:], "/"))
+			return params, true
+		}
+		if i >= len(pathSegments) {
+			return nil, false
+		}
+		switch seg.kind {
+		case staticSegment:
+			if seg.value != pathSegments[i] {
+				return nil, false
+			}
+		case paramSegment:
+			if pathSegments[i] == "" {
+				return nil, false
+			}
+			setParam(seg.value, pathSegments[i])
+		}
+	}
+	return params, len(pathSegments) == len(r.segments)
+}
+
+// moreSpecific reports whether a is more specific than b. Both are assumed to match the same path.
+func moreSpecific(a, b []segment) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i].kind != b[i].kind {
+			return a[i].kind < b[i].kind
+		}
+	}
+	return len(a) > len(b)
+}
+
+// sameShape reports whether two patterns would match exactly the same paths
+func sameShape(a, b []segment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].kind != b[i].kind || (a[i].kind == staticSegment && a[i].value != b[i].value) {
+			return false
+		}
+	}
+	return true
+}
+
+func parsePattern(pattern string) ([]segment, error) {
+	if !strings.HasPrefix(pattern, "/") {
+		return nil, fmt.Errorf("%w: %q must start with /", ErrInvalidPattern, pattern)
+	}
+
+	parts := splitPath(pattern)
+	segments := make([]segment, 0, len(parts))
+	for i, part := range parts {
+		switch {
+		case strings.HasPrefix(part, ":"):
+			if len(part) == 1 {
+				return nil, fmt.Errorf("%w: %q has an unnamed parameter", ErrInvalidPattern, pattern)
+			}
+			segments = append(segments, segment{kind: paramSegment, value: part[1:]})
+		case strings.HasPrefix(part, "*"):
+			if i != len(parts)-1 {
+				return nil, fmt.Errorf("%w: %q has a wildcard before the last segment", ErrInvalidPattern, pattern)
+			}
+			segments = append(segments, segment{kind: wildcardSegment, value: part[1:]})
+		default:
+			if strings.ContainsAny(part, ":*") {
+				return nil, fmt.Errorf("%w: %q mixes static text and a dynamic segment", ErrInvalidPattern, pattern)
+			}
+			segments = append(segments, segment{kind: staticSegment, value: part})
+		}
+	}
+	return segments, nil
+}
+
+// staticPrefix returns the pattern up to its first dynamic segment
+func staticPrefix(pattern string) string {
+	if idx := strings.IndexAny(pattern, ":*"); idx >= 0 {
+		return pattern[:idx]
+	}
+	return pattern
+}
+
+// splitPath returns the segments of an absolute path. The root path has a single empty segment.
+func splitPath(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "/"), "/")
+}
@@ -0,0 +1,82 @@
+package route_matcher
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMatcherMostSpecific(t *testing.T) {
+	m := New[string]()
+	patterns := []string{
+		"/",
+		"/v1/*",
+		"/v1/notification/*rest",
+		"/v1/notification/email-accounts",
+		"/v1/notification/email-accounts/:email",
+		"/v1/notification/email-accounts/oauth2-callback",
+		"/v1/iam/users/:id/roles/:role",
+	}
+	for _, p := range patterns {
+		if err := m.Add(p, p); err != nil {
+			t.Fatalf("add %q: %v", p, err)
+		}
+	}
+	if m.Len() != len(patterns) {
+		t.Fatalf("bad len: %v", m.Len())
+	}
+
+	type exp struct {
+		path    string
+		pattern string
+		params  map[string]string
+	}
+	cases := []exp{
+		{"/", "/", nil},
+		{"/v1", "", nil},
+		{"/v1/", "/v1/*", nil},
+		{"/v1/iam/register", "/v1/*", nil},
+		{"/v1/notification/email-accounts", "/v1/notification/email-accounts", nil},
+		{"/v1/notification/email-accounts/oauth2-callback", "/v1/notification/email-accounts/oauth2-callback", nil},
+		{"/v1/notification/email-accounts/a@b.com", "/v1/notification/email-accounts/:email", map[string]string{"email": "a@b.com"}},
+		{"/v1/notification/email-accounts/a@b.com/test", "/v1/notification/*rest", map[string]string{"rest": "email-accounts/a@b.com/test"}},
+		{"/v1/notification/", "/v1/notification/*rest", map[string]string{"rest": ""}},
+		{"/v1/iam/users/7/roles/admin", "/v1/iam/users/:id/roles/:role", map[string]string{"id": "7", "role": "admin"}},
+		{"/v1/iam/users//roles/admin", "/v1/*", nil},
+		{"/v2/anything", "", nil},
+	}
+	for _, test := range cases {
+		out, ok := m.Match(test.path)
+		if test.pattern == "" {
+			if ok {
+				t.Fatalf("%q should not match, got %q", test.path, out.Pattern)
+			}
+			continue
+		}
+		if !ok || out.Pattern != test.pattern || out.Value != test.pattern {
+			t.Fatalf("%q: got %q want %q", test.path, out.Pattern, test.pattern)
+		}
+		if !reflect.DeepEqual(out.Params, test.params) {
+			t.Fatalf("%q: bad params %v want %v", test.path, out.Params, test.params)
+		}
+	}
+}
+
+func TestMatcherAddErrors(t *testing.T) {
+	m := New[int]()
+	if err := m.Add("/users/:id", 1); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if err := m.Add("/users/:name", 2); !errors.Is(err, ErrDuplicatePattern) {
+		t.Fatalf("expected duplicate error, got %v", err)
+	}
+
+	for _, p := range []string{"users", "/users/:", "/files/*/meta", "/a:b"} {
+		if err := m.Add(p, 0); !errors.Is(err, ErrInvalidPattern) {
+			t.Fatalf("%q: expected invalid pattern error, got %v", p, err)
+		}
+	}
+	if m.Len() != 1 {
+		t.Fatalf("bad len: %v", m.Len())
+	}
+}